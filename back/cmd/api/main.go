@@ -12,7 +12,10 @@ import (
 
 	"github.com/lauratech/fin/back/internal/config"
 	"github.com/lauratech/fin/back/internal/server"
+	"github.com/lauratech/fin/back/internal/shared/calendar"
 	"github.com/lauratech/fin/back/internal/shared/database"
+	"github.com/lauratech/fin/back/internal/shared/jobs"
+	"github.com/lauratech/fin/back/internal/shared/payees"
 )
 
 func main() {
@@ -22,6 +25,26 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	// Load encryption keyring
+	keyring, err := cfg.LoadKeyring()
+	if err != nil {
+		log.Fatalf("Failed to load encryption keyring: %v", err)
+	}
+
+	// Load regional holidays
+	if cfg.HolidayCalendarFile != "" {
+		if err := calendar.LoadFile(cfg.HolidayCalendarFile); err != nil {
+			log.Fatalf("Failed to load holiday calendar: %v", err)
+		}
+	}
+
+	// Load banks and collectors beyond the embedded payee registry
+	if cfg.PayeeRegistryFile != "" {
+		if err := payees.LoadFile(cfg.PayeeRegistryFile); err != nil {
+			log.Fatalf("Failed to load payee registry: %v", err)
+		}
+	}
+
 	// Initialize database connection
 	db, err := database.NewPostgresConnection(cfg.DatabaseURL)
 	if err != nil {
@@ -32,7 +55,15 @@ func main() {
 	log.Println("✅ Database connection established")
 
 	// Create server with dependencies
-	srv := server.New(cfg, db)
+	srv := server.New(cfg, db, keyring)
+
+	// Start background jobs (stopped on shutdown)
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	jobsDone := make(chan struct{})
+	go func() {
+		defer close(jobsDone)
+		jobs.NewScheduler(db, srv.Jobs()...).Start(jobsCtx)
+	}()
 
 	// HTTP server configuration
 	httpServer := &http.Server{
@@ -66,5 +97,13 @@ func main() {
 		log.Printf("Server forced to shutdown: %v", err)
 	}
 
+	// Wait for running jobs to stop
+	stopJobs()
+	select {
+	case <-jobsDone:
+	case <-ctx.Done():
+		log.Printf("Background jobs did not stop in time")
+	}
+
 	log.Println("✅ Server stopped")
 }
@@ -0,0 +1,96 @@
+// Command embosser exports requested physical cards to a fixed-width embosser file.
+//
+// It stands in for the card production processor: every run writes one batch
+// file (see cards.WriteEmbosserFile for the layout) and marks the exported
+// shipments as produced. The file contains full card data, so it is created
+// with owner-only permissions and must be moved to the processor and deleted.
+//
+// Usage:
+//
+//	go run ./cmd/embosser [-out-dir embosser] [-limit 500]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/lauratech/fin/back/internal/config"
+	"github.com/lauratech/fin/back/internal/modules/cards"
+	"github.com/lauratech/fin/back/internal/shared/database"
+)
+
+func main() {
+	outDir := flag.String("out-dir", "embosser", "directory for embosser files")
+	limit := flag.Int("limit", 500, "maximum cards per batch")
+	flag.Parse()
+
+	if *limit <= 0 {
+		log.Fatalf("limit must be positive")
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	keyring, err := cfg.LoadKeyring()
+	if err != nil {
+		log.Fatalf("Failed to load encryption keyring: %v", err)
+	}
+
+	// Initialize database connection
+	db, err := database.NewPostgresConnection(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	service := cards.NewService(cards.NewRepository(db, keyring), db, cards.Config{
+		CVVKey:            []byte(cfg.CardCVVKey),
+		PANFingerprintKey: []byte(cfg.PANFingerprintKey),
+	}, nil)
+
+	if err := os.MkdirAll(*outDir, 0o700); err != nil {
+		log.Fatalf("Failed to create output directory: %v", err)
+	}
+
+	// Write to a temporary file; it only gets its final name once the batch is committed
+	tmp, err := os.CreateTemp(*outDir, ".embosser-*.tmp")
+	if err != nil {
+		log.Fatalf("Failed to create batch file: %v", err)
+	}
+	tmpPath := tmp.Name()
+
+	batch, err := service.ExportEmbosserBatch(ctx, tmp, *limit)
+	closeErr := tmp.Close()
+	if err != nil {
+		os.Remove(tmpPath)
+		log.Fatalf("Export failed (no cards were marked as produced): %v", err)
+	}
+	if batch.Records == 0 {
+		os.Remove(tmpPath)
+		log.Printf("No cards waiting for production")
+		return
+	}
+	if closeErr != nil {
+		log.Fatalf("Batch %s was marked as produced but %s could not be written: %v", batch.BatchID, tmpPath, closeErr)
+	}
+
+	name := filepath.Join(*outDir, fmt.Sprintf("EMB_%s_%s.txt", batch.CreatedAt.UTC().Format("20060102T150405"), batch.BatchID[:8]))
+	if err := os.Rename(tmpPath, name); err != nil {
+		log.Fatalf("Batch %s was marked as produced but could not be renamed from %s: %v", batch.BatchID, tmpPath, err)
+	}
+
+	log.Printf("Exported %d cards in batch %s to %s (%s)", batch.Records, batch.BatchID, name, time.Since(batch.CreatedAt).Round(time.Millisecond))
+}
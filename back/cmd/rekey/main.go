@@ -0,0 +1,202 @@
+// Command rekey re-encrypts all cards to the current encryption key version
+// and backfills the PAN fingerprints of cards issued before fingerprints existed.
+//
+// Cards are processed in ID order in batches. The last processed card ID is
+// checkpointed to a state file after every batch, so an interrupted run
+// resumes where it stopped. Cards that fail to re-encrypt are logged and
+// skipped; run again (with -restart) to retry them.
+//
+// Usage:
+//
+//	go run ./cmd/rekey [-batch-size 100] [-state-file .rekey-state] [-restart]
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/google/uuid"
+	"github.com/lauratech/fin/back/internal/config"
+	"github.com/lauratech/fin/back/internal/modules/cards"
+	"github.com/lauratech/fin/back/internal/shared/database"
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+)
+
+func main() {
+	batchSize := flag.Int("batch-size", 100, "cards per batch")
+	stateFile := flag.String("state-file", ".rekey-state", "checkpoint file used to resume interrupted runs")
+	restart := flag.Bool("restart", false, "ignore the checkpoint and start from the first card")
+	flag.Parse()
+
+	if *batchSize <= 0 {
+		log.Fatalf("batch-size must be positive")
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	keyring, err := cfg.LoadKeyring()
+	if err != nil {
+		log.Fatalf("Failed to load encryption keyring: %v", err)
+	}
+
+	// Initialize database connection
+	conn, err := database.NewPostgresConnection(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer conn.Close()
+
+	// Stop after the current batch on SIGINT/SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	repo := cards.NewRepository(conn, keyring)
+
+	// Resume from checkpoint
+	cursor := uuid.Nil
+	if !*restart {
+		cursor, err = readCheckpoint(*stateFile)
+		if err != nil {
+			log.Fatalf("Failed to read checkpoint: %v", err)
+		}
+		if cursor != uuid.Nil {
+			log.Printf("Resuming after card %s", cursor)
+		}
+	}
+
+	total, err := repo.CountCardsForRekey(ctx)
+	if err != nil {
+		log.Fatalf("Failed to count cards: %v", err)
+	}
+	log.Printf("Processing %d cards (key version %d)", total, keyring.CurrentVersion())
+
+	var processed, rekeyed, failed, fingerprinted int
+	for {
+		if ctx.Err() != nil {
+			log.Printf("Interrupted - run again to resume after card %s", cursor)
+			os.Exit(1)
+		}
+
+		batch, err := repo.ListCardsForRekey(ctx, cursor, *batchSize)
+		if err != nil {
+			log.Fatalf("Failed to list cards: %v", err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		for i := range batch {
+			card := &batch[i]
+			processed++
+
+			result := processCard(ctx, repo, card, []byte(cfg.PANFingerprintKey))
+			if result.rekeyErr != nil {
+				failed++
+				log.Printf("Card %s: re-encryption failed: %v", card.ID, result.rekeyErr)
+				continue
+			}
+			if result.rekeyed {
+				rekeyed++
+			}
+			if result.fingerprintErr != nil {
+				log.Printf("Card %s: fingerprint backfill failed: %v", card.ID, result.fingerprintErr)
+			} else if result.fingerprinted {
+				fingerprinted++
+			}
+		}
+
+		cursor = batch[len(batch)-1].ID
+		if err := writeCheckpoint(*stateFile, cursor); err != nil {
+			log.Fatalf("Failed to write checkpoint: %v", err)
+		}
+
+		log.Printf("Progress: %d/%d processed (%d re-encrypted, %d failed)", processed, total, rekeyed, failed)
+	}
+
+	// Done - a finished run must not be resumed
+	if err := os.Remove(*stateFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("Failed to remove checkpoint: %v", err)
+	}
+
+	log.Printf("Done: %d processed, %d re-encrypted, %d failed, %d fingerprints backfilled", processed, rekeyed, failed, fingerprinted)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// cardStore is the part of the card repository used by the command
+type cardStore interface {
+	NeedsRekey(card *db.Card) bool
+	RekeyCard(ctx context.Context, card *db.Card) (bool, error)
+	DecryptCardNumber(card *db.Card) (string, error)
+	SetPANFingerprint(ctx context.Context, cardID uuid.UUID, fingerprint string) error
+}
+
+// cardResult is the outcome of processing one card
+type cardResult struct {
+	rekeyed        bool
+	rekeyErr       error
+	fingerprinted  bool
+	fingerprintErr error
+}
+
+// processCard re-encrypts a card not under the current key and backfills its
+// PAN fingerprint if missing. Cards already re-encrypted on read by the API are
+// only listed for the fingerprint.
+func processCard(ctx context.Context, store cardStore, card *db.Card, fingerprintKey []byte) cardResult {
+	var result cardResult
+
+	if store.NeedsRekey(card) {
+		result.rekeyed, result.rekeyErr = store.RekeyCard(ctx, card)
+		if result.rekeyErr != nil {
+			return result
+		}
+	}
+
+	if !card.PanFingerprint.Valid {
+		pan, err := store.DecryptCardNumber(card)
+		if err == nil {
+			err = store.SetPANFingerprint(ctx, card.ID, cards.PANFingerprint(fingerprintKey, pan))
+		}
+		result.fingerprinted, result.fingerprintErr = err == nil, err
+	}
+
+	return result
+}
+
+// readCheckpoint returns the last processed card ID (uuid.Nil if there is no checkpoint)
+func readCheckpoint(path string) (uuid.UUID, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return uuid.Nil, nil
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	id, err := uuid.Parse(strings.TrimSpace(string(data)))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid checkpoint %q: %w", path, err)
+	}
+	return id, nil
+}
+
+// writeCheckpoint atomically stores the last processed card ID
+func writeCheckpoint(path string, id uuid.UUID) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(id.String()+"\n"), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS card_reveal_tokens CASCADE;
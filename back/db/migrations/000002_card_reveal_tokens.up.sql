@@ -0,0 +1,19 @@
+-- ========================================
+-- CARD REVEAL TOKENS TABLE
+-- ========================================
+-- Short-lived, single-use tokens issued after step-up verification.
+-- Only the SHA-256 hash of the token is stored.
+CREATE TABLE card_reveal_tokens (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    card_id UUID NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    consumed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_card_reveal_tokens_card_id ON card_reveal_tokens(card_id);
+CREATE INDEX idx_card_reveal_tokens_expires_at ON card_reveal_tokens(expires_at);
@@ -0,0 +1,5 @@
+ALTER TABLE cards DROP CONSTRAINT IF EXISTS cards_dynamic_cvv_secret;
+
+ALTER TABLE cards
+    DROP COLUMN IF EXISTS dcvv_secret_encrypted,
+    DROP COLUMN IF EXISTS cvv_mode;
@@ -0,0 +1,11 @@
+-- ========================================
+-- DYNAMIC CVV (virtual cards)
+-- ========================================
+-- cvv_mode = 'dynamic' derives a time-based CVV from a per-card secret
+-- (HMAC over the current time window). The secret is AES-256-GCM encrypted.
+ALTER TABLE cards
+    ADD COLUMN cvv_mode VARCHAR(10) NOT NULL DEFAULT 'static' CHECK (cvv_mode IN ('static', 'dynamic')),
+    ADD COLUMN dcvv_secret_encrypted BYTEA;
+
+ALTER TABLE cards
+    ADD CONSTRAINT cards_dynamic_cvv_secret CHECK (cvv_mode = 'static' OR dcvv_secret_encrypted IS NOT NULL);
@@ -0,0 +1,8 @@
+ALTER TABLE cards DROP CONSTRAINT IF EXISTS cards_amount_cap_required;
+
+ALTER TABLE cards
+    DROP COLUMN IF EXISTS total_spent_cents,
+    DROP COLUMN IF EXISTS amount_cap_cents,
+    DROP COLUMN IF EXISTS locked_merchant_category,
+    DROP COLUMN IF EXISTS locked_merchant_name,
+    DROP COLUMN IF EXISTS profile;
@@ -0,0 +1,16 @@
+-- ========================================
+-- CARD PROFILES (virtual cards)
+-- ========================================
+-- standard:        regular card
+-- single_use:      cancelled automatically after the first successful authorization
+-- merchant_locked: bound to the first merchant it is used at
+-- amount_capped:   lifetime spending capped at amount_cap_cents
+ALTER TABLE cards
+    ADD COLUMN profile VARCHAR(20) NOT NULL DEFAULT 'standard' CHECK (profile IN ('standard', 'single_use', 'merchant_locked', 'amount_capped')),
+    ADD COLUMN locked_merchant_name VARCHAR(255),
+    ADD COLUMN locked_merchant_category VARCHAR(50),
+    ADD COLUMN amount_cap_cents BIGINT CHECK (amount_cap_cents > 0),
+    ADD COLUMN total_spent_cents BIGINT NOT NULL DEFAULT 0 CHECK (total_spent_cents >= 0);
+
+ALTER TABLE cards
+    ADD CONSTRAINT cards_amount_cap_required CHECK (profile <> 'amount_capped' OR amount_cap_cents IS NOT NULL);
@@ -0,0 +1,4 @@
+ALTER TABLE card_transactions
+    DROP COLUMN IF EXISTS mcc;
+
+DROP TABLE IF EXISTS card_category_controls CASCADE;
@@ -0,0 +1,24 @@
+-- ========================================
+-- CARD CATEGORY CONTROLS
+-- ========================================
+-- Per-card merchant category blocking and monthly limits.
+-- Categories come from the MCC mapping shipped with the service (internal/shared/mcc).
+CREATE TABLE card_category_controls (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    card_id UUID NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+
+    category VARCHAR(50) NOT NULL,
+    is_blocked BOOLEAN NOT NULL DEFAULT FALSE,
+    monthly_limit_cents BIGINT CHECK (monthly_limit_cents >= 0),
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    UNIQUE (card_id, category)
+);
+
+CREATE INDEX idx_card_category_controls_card_id ON card_category_controls(card_id);
+
+-- Raw MCC reported by the acquirer (merchant_category holds the mapped category)
+ALTER TABLE card_transactions
+    ADD COLUMN mcc VARCHAR(4);
@@ -0,0 +1,3 @@
+ALTER TABLE card_transactions
+    DROP COLUMN IF EXISTS merchant_country,
+    DROP COLUMN IF EXISTS channel;
@@ -0,0 +1,7 @@
+-- ========================================
+-- AUTHORIZATION CONTEXT (card transactions)
+-- ========================================
+-- Entry channel and merchant country captured at authorization time
+ALTER TABLE card_transactions
+    ADD COLUMN channel VARCHAR(20) CHECK (channel IN ('ecommerce', 'pos_chip', 'contactless', 'magstripe', 'atm')),
+    ADD COLUMN merchant_country VARCHAR(2);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_cards_pan_fingerprint;
+
+ALTER TABLE cards
+    DROP COLUMN IF EXISTS pan_fingerprint;
+
+DROP TABLE IF EXISTS card_bin_ranges CASCADE;
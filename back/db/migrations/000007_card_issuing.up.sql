@@ -0,0 +1,38 @@
+-- ========================================
+-- CARD BIN RANGES (issuer configuration)
+-- ========================================
+-- Issuer-assigned BIN/IIN prefixes per brand and product.
+-- PANs are generated as prefix + random digits + Luhn check digit.
+CREATE TABLE card_bin_ranges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+
+    brand VARCHAR(20) NOT NULL CHECK (brand IN ('visa', 'mastercard', 'elo')),
+    product VARCHAR(20) NOT NULL CHECK (product IN ('physical', 'virtual')),
+    prefix VARCHAR(8) NOT NULL CHECK (prefix ~ '^[0-9]{1,8}$'),
+    pan_length SMALLINT NOT NULL DEFAULT 16 CHECK (pan_length BETWEEN 13 AND 19),
+
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    UNIQUE (brand, product, prefix)
+);
+
+CREATE INDEX idx_card_bin_ranges_brand_product ON card_bin_ranges(brand, product) WHERE is_active;
+
+-- Example ranges - replace with the BINs assigned to the issuer
+INSERT INTO card_bin_ranges (brand, product, prefix) VALUES
+    ('visa', 'physical', '498401'),
+    ('visa', 'virtual', '498402'),
+    ('mastercard', 'physical', '522840'),
+    ('mastercard', 'virtual', '522841'),
+    ('elo', 'physical', '636368'),
+    ('elo', 'virtual', '636369');
+
+-- ========================================
+-- PAN FINGERPRINT
+-- ========================================
+-- Keyed HMAC-SHA256 of the PAN (hex). Enforces PAN uniqueness without decrypting.
+ALTER TABLE cards
+    ADD COLUMN pan_fingerprint VARCHAR(64);
+
+CREATE UNIQUE INDEX idx_cards_pan_fingerprint ON cards(pan_fingerprint);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_cards_pan_token;
+
+ALTER TABLE cards
+    DROP COLUMN IF EXISTS pan_token;
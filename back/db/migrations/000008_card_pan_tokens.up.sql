@@ -0,0 +1,10 @@
+-- ========================================
+-- PAN TOKENS
+-- ========================================
+-- Format-preserving surrogate for the PAN handed to downstream systems.
+-- Same length as the PAN, keeps the last four digits and always fails the
+-- Luhn check so it can never be mistaken for a real card number.
+ALTER TABLE cards
+    ADD COLUMN pan_token VARCHAR(19);
+
+CREATE UNIQUE INDEX idx_cards_pan_token ON cards(pan_token);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_cards_encryption_key_version;
+
+ALTER TABLE cards
+    DROP COLUMN IF EXISTS encryption_key_version;
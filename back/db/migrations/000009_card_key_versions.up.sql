@@ -0,0 +1,10 @@
+-- ========================================
+-- ENCRYPTION KEY VERSIONS
+-- ========================================
+-- Key version of the envelope ciphertexts of a card (card number, CVV and
+-- dynamic CVV secret are always re-encrypted together).
+-- 0 = legacy ciphertexts without a key header.
+ALTER TABLE cards
+    ADD COLUMN encryption_key_version INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_cards_encryption_key_version ON cards(encryption_key_version);
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS card_account_updates CASCADE;
+
+DROP INDEX IF EXISTS idx_cards_replaced_by_card_id;
+
+ALTER TABLE cards
+    DROP COLUMN IF EXISTS replaced_by_card_id,
+    DROP COLUMN IF EXISTS cancelled_at,
+    DROP COLUMN IF EXISTS cancellation_reason;
@@ -0,0 +1,33 @@
+-- ========================================
+-- CARD CANCELLATION & REPLACEMENT
+-- ========================================
+-- Reason given when a card is cancelled; lost/stolen also set the card status.
+-- replaced_by_card_id links a cancelled card to the card issued in its place.
+ALTER TABLE cards
+    ADD COLUMN cancellation_reason VARCHAR(20) CHECK (cancellation_reason IN ('lost', 'stolen', 'damaged', 'user_request')),
+    ADD COLUMN cancelled_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN replaced_by_card_id UUID REFERENCES cards(id) ON DELETE RESTRICT;
+
+CREATE UNIQUE INDEX idx_cards_replaced_by_card_id ON cards(replaced_by_card_id);
+
+-- ========================================
+-- CARD ACCOUNT UPDATES
+-- ========================================
+-- Account-updater stand-in: recurring merchants to be told about a replacement
+-- card (card-on-file credentials), consumed by the network integration.
+CREATE TABLE card_account_updates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    old_card_id UUID NOT NULL REFERENCES cards(id) ON DELETE RESTRICT,
+    new_card_id UUID NOT NULL REFERENCES cards(id) ON DELETE RESTRICT,
+
+    merchant_name VARCHAR(255) NOT NULL,
+    merchant_category VARCHAR(50),
+
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    UNIQUE (new_card_id, merchant_name)
+);
+
+CREATE INDEX idx_card_account_updates_status ON card_account_updates(status);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_cards_expires_at;
+
+ALTER TABLE cards
+    DROP COLUMN IF EXISTS renewed_at;
+
+DROP TABLE IF EXISTS notifications CASCADE;
@@ -0,0 +1,31 @@
+-- ========================================
+-- NOTIFICATIONS
+-- ========================================
+-- User-facing notifications raised by background jobs (card expiry, renewals...).
+-- dedup_key makes raising the same notification idempotent across job runs.
+CREATE TABLE notifications (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    type VARCHAR(50) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL,
+
+    resource_type VARCHAR(50),
+    resource_id UUID,
+
+    dedup_key VARCHAR(255) NOT NULL UNIQUE,
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_notifications_user_id ON notifications(user_id, created_at DESC);
+
+-- ========================================
+-- CARD RENEWAL
+-- ========================================
+-- Set when a card is renewed in place (same PAN, new expiry and CVV)
+ALTER TABLE cards
+    ADD COLUMN renewed_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_cards_expires_at ON cards(expires_at);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS card_shipments CASCADE;
+
+ALTER TABLE cards
+    DROP COLUMN IF EXISTS activated_at;
@@ -0,0 +1,47 @@
+-- ========================================
+-- PHYSICAL CARD ACTIVATION
+-- ========================================
+-- Physical cards only authorize after the holder activates the plastic.
+-- Existing cards were usable before activation existed.
+ALTER TABLE cards
+    ADD COLUMN activated_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE cards SET activated_at = COALESCE(created_at, NOW());
+
+-- ========================================
+-- CARD SHIPMENTS
+-- ========================================
+-- Lifecycle of a plastic: requested -> produced -> shipped -> delivered -> activated.
+-- A card may have several shipments (renewals re-ship the card); the latest is current.
+CREATE TABLE card_shipments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    card_id UUID NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'requested' CHECK (status IN ('requested', 'produced', 'shipped', 'delivered', 'activated')),
+
+    -- Delivery address
+    recipient_name VARCHAR(255) NOT NULL,
+    street VARCHAR(255) NOT NULL,
+    number VARCHAR(20) NOT NULL,
+    complement VARCHAR(100),
+    neighborhood VARCHAR(100) NOT NULL,
+    city VARCHAR(100) NOT NULL,
+    state CHAR(2) NOT NULL,
+    postal_code CHAR(8) NOT NULL,
+
+    -- Production and delivery tracking
+    embosser_batch_id UUID,
+    carrier VARCHAR(50),
+    tracking_code VARCHAR(100),
+
+    requested_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    produced_at TIMESTAMP WITH TIME ZONE,
+    shipped_at TIMESTAMP WITH TIME ZONE,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    activated_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_card_shipments_card_id ON card_shipments(card_id, created_at DESC);
+CREATE INDEX idx_card_shipments_requested ON card_shipments(created_at) WHERE status = 'requested';
+CREATE INDEX idx_card_shipments_batch ON card_shipments(embosser_batch_id);
@@ -0,0 +1,38 @@
+-- ========================================
+-- CARD DISPUTES
+-- ========================================
+-- A holder contests a card transaction. Lifecycle:
+--   opened -> provisional_credit -> won | lost
+-- (a dispute may also be resolved straight from opened).
+-- The provisional credit is paid to users.balance_cents and reversed if the dispute is lost.
+CREATE TABLE card_disputes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    card_id UUID NOT NULL REFERENCES cards(id) ON DELETE RESTRICT,
+    card_transaction_id UUID NOT NULL REFERENCES card_transactions(id) ON DELETE RESTRICT,
+    support_ticket_id UUID REFERENCES support_tickets(id) ON DELETE SET NULL,
+
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('fraud', 'not_received', 'duplicate', 'wrong_amount')),
+    evidence TEXT NOT NULL,
+    amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'opened' CHECK (status IN ('opened', 'provisional_credit', 'won', 'lost')),
+    provisional_credit_cents BIGINT NOT NULL DEFAULT 0 CHECK (provisional_credit_cents >= 0),
+    resolution_note TEXT,
+
+    -- Deadlines
+    provisional_credit_due_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    resolution_due_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    provisional_credited_at TIMESTAMP WITH TIME ZONE,
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- At most one dispute in progress per transaction
+CREATE UNIQUE INDEX idx_card_disputes_active_transaction ON card_disputes(card_transaction_id)
+    WHERE status IN ('opened', 'provisional_credit');
+
+CREATE INDEX idx_card_disputes_user_id ON card_disputes(user_id, created_at DESC);
+CREATE INDEX idx_card_disputes_provisional_due ON card_disputes(provisional_credit_due_at) WHERE status = 'opened';
+CREATE INDEX idx_card_disputes_resolution_due ON card_disputes(resolution_due_at) WHERE status IN ('opened', 'provisional_credit');
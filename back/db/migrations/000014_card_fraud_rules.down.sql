@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_card_transactions_user_mcc;
+DROP TABLE IF EXISTS card_fraud_decisions CASCADE;
+DROP TABLE IF EXISTS fraud_settings CASCADE;
+DROP TABLE IF EXISTS fraud_rules CASCADE;
@@ -0,0 +1,63 @@
+-- ========================================
+-- FRAUD RULES
+-- ========================================
+-- Rules scored on every card authorization. A triggered rule adds its score;
+-- the total is compared to the thresholds in fraud_settings.
+-- params holds the rule-specific knobs (windows, counts, multipliers).
+CREATE TABLE fraud_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(50) UNIQUE NOT NULL CHECK (code IN ('velocity', 'amount_above_median', 'first_international', 'new_mcc', 'card_testing')),
+    description VARCHAR(255) NOT NULL,
+    is_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    score INTEGER NOT NULL CHECK (score BETWEEN 0 AND 100),
+    params JSONB NOT NULL DEFAULT '{}',
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+INSERT INTO fraud_rules (code, description, score, params) VALUES
+    ('velocity', 'Many authorizations on the card in a short window', 40, '{"max_count": 5, "window_minutes": 10}'),
+    ('amount_above_median', 'Amount far above the user''s median purchase', 35, '{"multiplier": 5, "lookback_days": 90, "min_transactions": 5}'),
+    ('first_international', 'First purchase abroad', 30, '{}'),
+    ('new_mcc', 'Merchant category never used before', 15, '{"min_transactions": 10}'),
+    ('card_testing', 'Several small authorizations (card testing)', 50, '{"small_amount_cents": 200, "max_count": 3, "window_minutes": 60}');
+
+-- Decision thresholds (single row)
+CREATE TABLE fraud_settings (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    step_up_score INTEGER NOT NULL CHECK (step_up_score > 0),
+    decline_score INTEGER NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CHECK (decline_score >= step_up_score)
+);
+
+INSERT INTO fraud_settings (step_up_score, decline_score) VALUES (40, 80);
+
+-- ========================================
+-- CARD FRAUD DECISIONS
+-- ========================================
+-- One row per scored authorization with the rules that fired (explanation).
+-- Approved authorizations link the persisted card transaction.
+CREATE TABLE card_fraud_decisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    card_id UUID NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    card_transaction_id UUID REFERENCES card_transactions(id) ON DELETE SET NULL,
+
+    amount_cents BIGINT NOT NULL,
+    merchant_name VARCHAR(255) NOT NULL,
+    mcc VARCHAR(4),
+    channel VARCHAR(20) NOT NULL,
+    merchant_country VARCHAR(2),
+
+    decision VARCHAR(10) NOT NULL CHECK (decision IN ('approve', 'decline', 'step_up')),
+    score INTEGER NOT NULL,
+    explanation JSONB NOT NULL DEFAULT '[]',
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_card_fraud_decisions_card ON card_fraud_decisions(card_id, created_at DESC);
+CREATE INDEX idx_card_transactions_user_mcc ON card_transactions(user_id, mcc);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_card_txn_card_date_id;
+DROP INDEX IF EXISTS idx_card_txn_user_date_id;
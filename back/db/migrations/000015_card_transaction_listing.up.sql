@@ -0,0 +1,6 @@
+-- ========================================
+-- CARD TRANSACTION LISTING
+-- ========================================
+-- Keyset pagination walks (transaction_date, id) in descending order
+CREATE INDEX idx_card_txn_user_date_id ON card_transactions(user_id, transaction_date DESC, id DESC);
+CREATE INDEX idx_card_txn_card_date_id ON card_transactions(card_id, transaction_date DESC, id DESC);
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS merchant_category_overrides CASCADE;
+
+DROP INDEX IF EXISTS idx_card_txn_unenriched;
+DROP INDEX IF EXISTS idx_card_txn_user_merchant_key;
+
+ALTER TABLE card_transactions
+    DROP COLUMN IF EXISTS merchant_city,
+    DROP COLUMN IF EXISTS merchant_logo_key,
+    DROP COLUMN IF EXISTS merchant_display_name,
+    DROP COLUMN IF EXISTS merchant_key;
@@ -0,0 +1,28 @@
+-- ========================================
+-- MERCHANT ENRICHMENT (card transactions)
+-- ========================================
+-- Canonical merchant derived from the raw descriptor (merchant_name) at authorization
+ALTER TABLE card_transactions
+    ADD COLUMN merchant_key VARCHAR(100),
+    ADD COLUMN merchant_display_name VARCHAR(255),
+    ADD COLUMN merchant_logo_key VARCHAR(100),
+    ADD COLUMN merchant_city VARCHAR(100);
+
+CREATE INDEX idx_card_txn_user_merchant_key ON card_transactions(user_id, merchant_key);
+CREATE INDEX idx_card_txn_unenriched ON card_transactions(created_at) WHERE merchant_key IS NULL;
+
+-- ========================================
+-- MERCHANT CATEGORY OVERRIDES
+-- ========================================
+-- "Always categorize this merchant as ..." - applied by budgets and spending analytics
+CREATE TABLE merchant_category_overrides (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    merchant_key VARCHAR(100) NOT NULL,
+    merchant_name VARCHAR(255) NOT NULL,
+    category VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    UNIQUE (user_id, merchant_key)
+);
@@ -0,0 +1,3 @@
+UPDATE bills SET due_date = created_at::DATE WHERE due_date IS NULL;
+
+ALTER TABLE bills ALTER COLUMN due_date SET NOT NULL;
@@ -0,0 +1,6 @@
+-- ========================================
+-- BILLS WITHOUT DUE DATE
+-- ========================================
+-- Boletos with due date factor 0000 and concessionária bills that do not
+-- embed a date have no due date: they are never overdue.
+ALTER TABLE bills ALTER COLUMN due_date DROP NOT NULL;
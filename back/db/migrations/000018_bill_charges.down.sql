@@ -0,0 +1,10 @@
+ALTER TABLE bills
+    DROP COLUMN IF EXISTS charges_calculated_at,
+    DROP COLUMN IF EXISTS charges_source,
+    DROP COLUMN IF EXISTS discount_cents,
+    DROP COLUMN IF EXISTS interest_cents,
+    DROP COLUMN IF EXISTS fine_cents,
+    DROP COLUMN IF EXISTS effective_due_date;
+
+DROP TABLE IF EXISTS bill_registry_entries;
+DROP TABLE IF EXISTS bill_charge_rules;
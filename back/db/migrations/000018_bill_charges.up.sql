@@ -0,0 +1,49 @@
+-- ========================================
+-- BILL CHARGE RULES (per payee)
+-- ========================================
+-- Late payment fine, daily interest and early payment discount of a payee:
+-- a bank (payee_code = bank code) or a concessionária (segment + company ID).
+-- Rates are in basis points (200 = 2%).
+CREATE TABLE bill_charge_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    payee_type VARCHAR(20) NOT NULL CHECK (payee_type IN ('bank', 'concessionaria')),
+    payee_code VARCHAR(20) NOT NULL,
+    fine_bps INTEGER NOT NULL DEFAULT 0 CHECK (fine_bps BETWEEN 0 AND 10000),
+    interest_monthly_bps INTEGER NOT NULL DEFAULT 0 CHECK (interest_monthly_bps BETWEEN 0 AND 10000),
+    discount_bps INTEGER NOT NULL DEFAULT 0 CHECK (discount_bps BETWEEN 0 AND 10000),
+    discount_days INTEGER NOT NULL DEFAULT 0 CHECK (discount_days BETWEEN 0 AND 365),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    UNIQUE (payee_type, payee_code)
+);
+
+-- ========================================
+-- BOLETO REGISTRY (CIP/NPC stand-in)
+-- ========================================
+-- Charges registered by the beneficiary bank for a single boleto; they take
+-- precedence over the payee rules
+CREATE TABLE bill_registry_entries (
+    barcode VARCHAR(50) PRIMARY KEY,
+    fine_bps INTEGER NOT NULL DEFAULT 0 CHECK (fine_bps BETWEEN 0 AND 10000),
+    interest_monthly_bps INTEGER NOT NULL DEFAULT 0 CHECK (interest_monthly_bps BETWEEN 0 AND 10000),
+    discount_bps INTEGER NOT NULL DEFAULT 0 CHECK (discount_bps BETWEEN 0 AND 10000),
+    discount_until DATE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- ========================================
+-- BILL CHARGES BREAKDOWN
+-- ========================================
+-- final_amount_cents = amount_cents + fine_cents + interest_cents - discount_cents + fee_cents,
+-- as of charges_calculated_at (recalculated when the bill is paid)
+ALTER TABLE bills
+    ADD COLUMN effective_due_date DATE,
+    ADD COLUMN fine_cents BIGINT NOT NULL DEFAULT 0 CHECK (fine_cents >= 0),
+    ADD COLUMN interest_cents BIGINT NOT NULL DEFAULT 0 CHECK (interest_cents >= 0),
+    ADD COLUMN discount_cents BIGINT NOT NULL DEFAULT 0 CHECK (discount_cents >= 0),
+    ADD COLUMN charges_source VARCHAR(20) NOT NULL DEFAULT 'none' CHECK (charges_source IN ('registry', 'payee_rule', 'none')),
+    ADD COLUMN charges_calculated_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE bills SET effective_due_date = due_date, charges_calculated_at = created_at;
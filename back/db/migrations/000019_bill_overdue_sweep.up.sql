@@ -0,0 +1,6 @@
+-- ========================================
+-- OVERDUE BILL SWEEP
+-- ========================================
+-- The overdue sweeper walks unpaid bills with a due date in (due_date, id) order.
+CREATE INDEX idx_bills_unpaid_due_date ON bills(due_date, id)
+    WHERE status IN ('pending', 'overdue') AND due_date IS NOT NULL;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_bills_scheduled_for;
+
+ALTER TABLE bills
+    DROP COLUMN IF EXISTS failure_reason,
+    DROP COLUMN IF EXISTS last_payment_attempt_at,
+    DROP COLUMN IF EXISTS payment_attempts,
+    DROP COLUMN IF EXISTS schedule_status,
+    DROP COLUMN IF EXISTS scheduled_for;
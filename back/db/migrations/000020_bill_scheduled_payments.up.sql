@@ -0,0 +1,15 @@
+-- ========================================
+-- SCHEDULED BILL PAYMENTS (agendamento)
+-- ========================================
+-- A bill scheduled for a date is paid by the scheduled payments worker on that
+-- date. Payments without balance are retried until the retry deadline; the
+-- schedule then fails with the reason of the last attempt.
+ALTER TABLE bills
+    ADD COLUMN scheduled_for DATE,
+    ADD COLUMN schedule_status VARCHAR(20) CHECK (schedule_status IN ('scheduled', 'paid', 'failed')),
+    ADD COLUMN payment_attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN last_payment_attempt_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN failure_reason TEXT;
+
+CREATE INDEX idx_bills_scheduled_for ON bills(scheduled_for, id)
+    WHERE schedule_status = 'scheduled';
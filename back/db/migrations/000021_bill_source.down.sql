@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_users_active_cpf;
+
+ALTER TABLE bills
+    DROP COLUMN IF EXISTS source;
@@ -0,0 +1,11 @@
+-- ========================================
+-- BILL SOURCE
+-- ========================================
+-- Bills are registered by the user (manual) or discovered through DDA (Débito
+-- Direto Autorizado), which lists the boletos registered against the user's CPF.
+ALTER TABLE bills
+    ADD COLUMN source VARCHAR(20) NOT NULL DEFAULT 'manual' CHECK (source IN ('manual', 'dda'));
+
+-- Active users with a CPF, walked by the DDA sync
+CREATE INDEX idx_users_active_cpf ON users(id)
+    WHERE status = 'active' AND cpf IS NOT NULL;
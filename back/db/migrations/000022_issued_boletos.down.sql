@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS issued_boletos;
+DROP SEQUENCE IF EXISTS issued_boletos_nosso_numero_seq;
@@ -0,0 +1,35 @@
+-- ========================================
+-- ISSUED BOLETOS (emissão)
+-- ========================================
+-- Boletos issued by users to charge their customers. The campo livre of the
+-- barcode carries the nosso número. The charges are also registered in
+-- bill_registry_entries, so payers are charged them. A payment by another user
+-- of the platform credits the issuer and marks the boleto as paid.
+CREATE SEQUENCE issued_boletos_nosso_numero_seq;
+
+CREATE TABLE issued_boletos (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    issuer_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    issuer_name VARCHAR(255) NOT NULL,
+    nosso_numero BIGINT NOT NULL UNIQUE,
+    barcode VARCHAR(44) NOT NULL UNIQUE,
+    linha_digitavel VARCHAR(47) NOT NULL,
+    amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+    due_date DATE NOT NULL,
+    payer_name VARCHAR(255) NOT NULL,
+    payer_document VARCHAR(14) NOT NULL,
+    description TEXT,
+    fine_bps INTEGER NOT NULL DEFAULT 0 CHECK (fine_bps BETWEEN 0 AND 10000),
+    interest_monthly_bps INTEGER NOT NULL DEFAULT 0 CHECK (interest_monthly_bps BETWEEN 0 AND 10000),
+    discount_bps INTEGER NOT NULL DEFAULT 0 CHECK (discount_bps BETWEEN 0 AND 10000),
+    discount_until DATE,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'paid', 'cancelled')),
+    paid_amount_cents BIGINT,
+    paid_at TIMESTAMP WITH TIME ZONE,
+    payer_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    bill_id UUID REFERENCES bills(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_issued_boletos_issuer ON issued_boletos(issuer_user_id, created_at DESC);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_card_disputes_reversal_pending;
+
+ALTER TABLE card_disputes
+    DROP COLUMN IF EXISTS reversal_pending_cents;
@@ -0,0 +1,10 @@
+-- ========================================
+-- CARD DISPUTE PENDING REVERSAL
+-- ========================================
+-- A lost dispute takes the provisional credit back from the balance. The part
+-- the balance cannot cover stays pending on the dispute and is taken by the
+-- deadlines job as the balance allows.
+ALTER TABLE card_disputes
+    ADD COLUMN reversal_pending_cents BIGINT NOT NULL DEFAULT 0 CHECK (reversal_pending_cents >= 0);
+
+CREATE INDEX idx_card_disputes_reversal_pending ON card_disputes(resolved_at) WHERE reversal_pending_cents > 0;
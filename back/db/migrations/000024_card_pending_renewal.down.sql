@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_cards_renewal_pending;
+
+ALTER TABLE cards
+    DROP COLUMN IF EXISTS renewal_expires_at,
+    DROP COLUMN IF EXISTS renewal_expiry_year,
+    DROP COLUMN IF EXISTS renewal_expiry_month,
+    DROP COLUMN IF EXISTS renewal_cvv_encrypted;
@@ -0,0 +1,14 @@
+-- ========================================
+-- CARD PENDING RENEWAL
+-- ========================================
+-- A same-PAN renewal prints a new expiry and CVV on a new plastic. Until the
+-- holder activates it (or the current expiry passes) the card keeps its current
+-- expiry and CVV, so the plastic in hand keeps working; the renewed ones wait here.
+ALTER TABLE cards
+    ADD COLUMN renewal_cvv_encrypted BYTEA,
+    ADD COLUMN renewal_expiry_month SMALLINT,
+    ADD COLUMN renewal_expiry_year SMALLINT,
+    ADD COLUMN renewal_expires_at TIMESTAMP WITH TIME ZONE;
+
+-- Pending renewals applied when the current expiry passes
+CREATE INDEX idx_cards_renewal_pending ON cards(expires_at) WHERE renewal_expires_at IS NOT NULL;
@@ -0,0 +1,2 @@
+-- Data-only migration: the previous per-card settings are not recorded, so the
+-- enabled flags are kept on rollback.
@@ -0,0 +1,10 @@
+-- ========================================
+-- CARD INTERNATIONAL USAGE
+-- ========================================
+-- Authorizations now require international usage to be enabled. Cards issued
+-- before that check keep working abroad unless the holder blocked it; new cards
+-- still start with international usage disabled.
+UPDATE cards
+SET is_international = TRUE, updated_at = NOW()
+WHERE is_international IS NOT TRUE
+  AND block_international IS NOT TRUE;
@@ -0,0 +1,54 @@
+-- name: GetBillChargeRule :one
+SELECT * FROM bill_charge_rules
+WHERE payee_type = $1 AND payee_code = $2
+LIMIT 1;
+
+-- name: ListBillChargeRules :many
+SELECT * FROM bill_charge_rules
+ORDER BY payee_type, payee_code;
+
+-- UpsertBillChargeRule creates or replaces the charge rule of a payee
+-- name: UpsertBillChargeRule :one
+INSERT INTO bill_charge_rules (
+    payee_type,
+    payee_code,
+    fine_bps,
+    interest_monthly_bps,
+    discount_bps,
+    discount_days
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (payee_type, payee_code) DO UPDATE
+SET
+    fine_bps = EXCLUDED.fine_bps,
+    interest_monthly_bps = EXCLUDED.interest_monthly_bps,
+    discount_bps = EXCLUDED.discount_bps,
+    discount_days = EXCLUDED.discount_days,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: GetBillRegistryEntry :one
+SELECT * FROM bill_registry_entries
+WHERE barcode = $1
+LIMIT 1;
+
+-- UpsertBillRegistryEntry registers the charges of a boleto (CIP/NPC stand-in)
+-- name: UpsertBillRegistryEntry :one
+INSERT INTO bill_registry_entries (
+    barcode,
+    fine_bps,
+    interest_monthly_bps,
+    discount_bps,
+    discount_until
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (barcode) DO UPDATE
+SET
+    fine_bps = EXCLUDED.fine_bps,
+    interest_monthly_bps = EXCLUDED.interest_monthly_bps,
+    discount_bps = EXCLUDED.discount_bps,
+    discount_until = EXCLUDED.discount_until,
+    updated_at = NOW()
+RETURNING *;
@@ -8,9 +8,18 @@ INSERT INTO bills (
     fee_cents,
     final_amount_cents,
     recipient_name,
-    due_date
+    due_date,
+    effective_due_date,
+    fine_cents,
+    interest_cents,
+    discount_cents,
+    charges_source,
+    scheduled_for,
+    schedule_status,
+    source,
+    charges_calculated_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, NOW()
 )
 RETURNING *;
 
@@ -51,32 +60,100 @@ SET status = $2
 WHERE id = $1
 RETURNING *;
 
+-- UpdateBillCharges stores the charges breakdown recalculated for a payment date
+-- name: UpdateBillCharges :one
+UPDATE bills
+SET
+    effective_due_date = sqlc.narg(effective_due_date)::DATE,
+    fine_cents = sqlc.arg(fine_cents),
+    interest_cents = sqlc.arg(interest_cents),
+    discount_cents = sqlc.arg(discount_cents),
+    fee_cents = sqlc.arg(fee_cents),
+    final_amount_cents = sqlc.arg(final_amount_cents),
+    charges_source = sqlc.arg(charges_source),
+    charges_calculated_at = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- MarkBillAsPaid pays a bill; a pending schedule is closed as paid
 -- name: MarkBillAsPaid :one
 UPDATE bills
 SET
     status = 'paid',
-    payment_date = NOW()
+    payment_date = NOW(),
+    schedule_status = CASE WHEN schedule_status = 'scheduled' THEN 'paid' ELSE schedule_status END,
+    failure_reason = NULL
 WHERE id = $1
 RETURNING *;
 
+-- ScheduleBillPayment schedules the payment of a bill, resetting previous attempts
+-- name: ScheduleBillPayment :one
+UPDATE bills
+SET
+    scheduled_for = sqlc.arg(scheduled_for)::DATE,
+    schedule_status = 'scheduled',
+    payment_attempts = 0,
+    last_payment_attempt_at = NULL,
+    failure_reason = NULL
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: UnscheduleBillPayment :one
+UPDATE bills
+SET
+    scheduled_for = NULL,
+    schedule_status = NULL
+WHERE id = $1 AND schedule_status = 'scheduled'
+RETURNING *;
+
+-- ListDueScheduledBills lists unpaid bills scheduled up to a date, after a (scheduled_for, id) cursor
+-- name: ListDueScheduledBills :many
+SELECT * FROM bills
+WHERE schedule_status = 'scheduled'
+  AND status IN ('pending', 'overdue')
+  AND scheduled_for <= sqlc.arg(scheduled_on_or_before)::DATE
+  AND (scheduled_for, id) > (sqlc.arg(after_scheduled_for)::DATE, sqlc.arg(after_id)::UUID)
+ORDER BY scheduled_for, id
+LIMIT sqlc.arg(batch_size);
+
+-- RecordBillPaymentFailure records a failed scheduled payment attempt; the
+-- schedule fails when no retry is left
+-- name: RecordBillPaymentFailure :one
+UPDATE bills
+SET
+    payment_attempts = payment_attempts + 1,
+    last_payment_attempt_at = NOW(),
+    failure_reason = sqlc.arg(failure_reason),
+    schedule_status = CASE WHEN sqlc.arg(give_up)::BOOLEAN THEN 'failed' ELSE schedule_status END
+WHERE id = sqlc.arg(id) AND schedule_status = 'scheduled'
+RETURNING *;
+
 -- name: DeleteBill :exec
 UPDATE bills
 SET status = 'cancelled'
 WHERE id = $1;
 
+-- ListOverdueBills lists bills past their due date that are still pending, or
+-- overdue with charges last calculated before calculated_before, after a (due_date, id) cursor
 -- name: ListOverdueBills :many
 SELECT * FROM bills
-WHERE status = 'pending'
-  AND due_date < CURRENT_DATE
-ORDER BY due_date ASC
-LIMIT $1 OFFSET $2;
+WHERE due_date <= sqlc.arg(due_on_or_before)::DATE
+  AND (
+    status = 'pending'
+    OR (status = 'overdue' AND charges_calculated_at < sqlc.arg(calculated_before)::TIMESTAMPTZ)
+  )
+  AND (due_date, id) > (sqlc.arg(after_due_date)::DATE, sqlc.arg(after_id)::UUID)
+ORDER BY due_date, id
+LIMIT sqlc.arg(batch_size);
 
 -- name: GetUserBillsStats :one
 SELECT
     COUNT(*) as total_bills,
     COUNT(*) FILTER (WHERE status = 'pending') as pending_bills,
+    COUNT(*) FILTER (WHERE status = 'overdue') as overdue_bills,
     COUNT(*) FILTER (WHERE status = 'paid') as paid_bills,
     COALESCE(SUM(final_amount_cents) FILTER (WHERE status = 'pending'), 0)::bigint as pending_amount_cents,
+    COALESCE(SUM(final_amount_cents) FILTER (WHERE status = 'overdue'), 0)::bigint as overdue_amount_cents,
     COALESCE(SUM(final_amount_cents) FILTER (WHERE status = 'paid'), 0)::bigint as paid_amount_cents
 FROM bills
 WHERE user_id = $1;
@@ -0,0 +1,34 @@
+-- ========================================
+-- CARD ACCOUNT UPDATES QUERIES
+-- ========================================
+
+-- ListCardRecurringMerchants returns merchants with completed charges in at
+-- least two distinct months since the given date (card-on-file subscriptions).
+-- name: ListCardRecurringMerchants :many
+SELECT
+    merchant_name,
+    COALESCE(MAX(merchant_category), '')::text AS merchant_category,
+    COUNT(DISTINCT date_trunc('month', transaction_date))::bigint AS months_charged
+FROM card_transactions
+WHERE card_id = $1
+  AND status = 'completed'
+  AND transaction_date >= $2
+GROUP BY merchant_name
+HAVING COUNT(DISTINCT date_trunc('month', transaction_date)) >= 2
+ORDER BY merchant_name;
+
+-- name: CreateCardAccountUpdate :one
+INSERT INTO card_account_updates (
+    old_card_id,
+    new_card_id,
+    merchant_name,
+    merchant_category
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: ListCardAccountUpdates :many
+SELECT * FROM card_account_updates
+WHERE new_card_id = $1
+ORDER BY merchant_name;
@@ -0,0 +1,8 @@
+-- ========================================
+-- CARD BIN RANGES QUERIES
+-- ========================================
+
+-- name: ListActiveCardBINRanges :many
+SELECT * FROM card_bin_ranges
+WHERE brand = $1 AND product = $2 AND is_active = TRUE
+ORDER BY prefix;
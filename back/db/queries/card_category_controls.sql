@@ -0,0 +1,36 @@
+-- ========================================
+-- CARD CATEGORY CONTROLS QUERIES
+-- ========================================
+
+-- name: CreateCardCategoryControl :one
+INSERT INTO card_category_controls (
+    card_id,
+    category,
+    is_blocked,
+    monthly_limit_cents
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: ListCardCategoryControls :many
+SELECT * FROM card_category_controls
+WHERE card_id = $1
+ORDER BY category;
+
+-- name: GetCardCategoryControl :one
+SELECT * FROM card_category_controls
+WHERE card_id = $1 AND category = $2
+LIMIT 1;
+
+-- name: DeleteCardCategoryControls :exec
+DELETE FROM card_category_controls
+WHERE card_id = $1;
+
+-- name: SumCardCategorySpent :one
+SELECT COALESCE(SUM(amount_cents), 0)::bigint AS total_cents
+FROM card_transactions
+WHERE card_id = $1
+  AND merchant_category = $2
+  AND status = 'completed'
+  AND transaction_date >= sqlc.arg(since);
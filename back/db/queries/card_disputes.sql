@@ -0,0 +1,101 @@
+-- ========================================
+-- CARD DISPUTES QUERIES
+-- ========================================
+
+-- name: CreateCardDispute :one
+INSERT INTO card_disputes (
+    user_id,
+    card_id,
+    card_transaction_id,
+    reason,
+    evidence,
+    amount_cents,
+    provisional_credit_due_at,
+    resolution_due_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: GetCardDisputeByID :one
+SELECT * FROM card_disputes
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetCardDisputeForUpdate :one
+SELECT * FROM card_disputes
+WHERE id = $1
+FOR UPDATE;
+
+-- name: CountActiveCardDisputesForTransaction :one
+SELECT COUNT(*) FROM card_disputes
+WHERE card_transaction_id = $1
+  AND status IN ('opened', 'provisional_credit');
+
+-- name: ListUserCardDisputes :many
+SELECT * FROM card_disputes
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountUserCardDisputes :one
+SELECT COUNT(*) FROM card_disputes
+WHERE user_id = $1;
+
+-- name: SetCardDisputeTicket :exec
+UPDATE card_disputes
+SET support_ticket_id = $2, updated_at = NOW()
+WHERE id = $1;
+
+-- name: MarkCardDisputeProvisionalCredit :exec
+UPDATE card_disputes
+SET status = 'provisional_credit',
+    provisional_credit_cents = $2,
+    provisional_credited_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: ResolveCardDispute :exec
+UPDATE card_disputes
+SET status = $2,
+    resolution_note = $3,
+    reversal_pending_cents = $4,
+    resolved_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: SetCardDisputeReversalPending :exec
+UPDATE card_disputes
+SET reversal_pending_cents = $2, updated_at = NOW()
+WHERE id = $1;
+
+-- ListCardDisputesPastProvisionalDeadline returns opened disputes whose provisional credit is overdue
+-- name: ListCardDisputesPastProvisionalDeadline :many
+SELECT id FROM card_disputes
+WHERE status = 'opened'
+  AND provisional_credit_due_at <= $1
+ORDER BY provisional_credit_due_at
+LIMIT $2;
+
+-- ListCardDisputesPastResolutionDeadline returns unresolved disputes whose resolution is overdue
+-- name: ListCardDisputesPastResolutionDeadline :many
+SELECT id FROM card_disputes
+WHERE status IN ('opened', 'provisional_credit')
+  AND resolution_due_at <= $1
+ORDER BY resolution_due_at
+LIMIT $2;
+
+-- ListCardDisputesWithoutTicket returns disputes whose support ticket could not be opened
+-- name: ListCardDisputesWithoutTicket :many
+SELECT * FROM card_disputes
+WHERE support_ticket_id IS NULL
+  AND status IN ('opened', 'provisional_credit')
+ORDER BY created_at
+LIMIT $1;
+
+-- ListCardDisputesWithReversalPending returns lost disputes whose provisional credit is not fully reversed
+-- name: ListCardDisputesWithReversalPending :many
+SELECT id FROM card_disputes
+WHERE reversal_pending_cents > 0
+ORDER BY resolved_at
+LIMIT $1;
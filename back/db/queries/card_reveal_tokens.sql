@@ -0,0 +1,29 @@
+-- ========================================
+-- CARD REVEAL TOKENS QUERIES
+-- ========================================
+
+-- name: CreateCardRevealToken :one
+INSERT INTO card_reveal_tokens (
+    card_id,
+    user_id,
+    token_hash,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- ConsumeCardRevealToken atomically marks a token as used.
+-- Returns no rows if the token is unknown, expired or already consumed.
+-- name: ConsumeCardRevealToken :one
+UPDATE card_reveal_tokens
+SET
+    consumed_at = NOW()
+WHERE token_hash = $1
+  AND consumed_at IS NULL
+  AND expires_at > NOW()
+RETURNING *;
+
+-- name: DeleteExpiredCardRevealTokens :exec
+DELETE FROM card_reveal_tokens
+WHERE expires_at < NOW() - INTERVAL '1 day';
@@ -0,0 +1,75 @@
+-- ========================================
+-- CARD SHIPMENTS QUERIES
+-- ========================================
+
+-- name: CreateCardShipment :one
+INSERT INTO card_shipments (
+    card_id,
+    recipient_name,
+    street,
+    number,
+    complement,
+    neighborhood,
+    city,
+    state,
+    postal_code
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING *;
+
+-- name: GetLatestCardShipment :one
+SELECT * FROM card_shipments
+WHERE card_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: GetCardShipmentForUpdate :one
+SELECT * FROM card_shipments
+WHERE id = $1
+FOR UPDATE;
+
+-- ListShipmentsForEmbossing locks requested shipments of usable cards.
+-- Rows locked by a concurrent export are skipped.
+-- name: ListShipmentsForEmbossing :many
+SELECT * FROM card_shipments
+WHERE status = 'requested'
+  AND card_id IN (SELECT id FROM cards WHERE cards.status IN ('active', 'blocked'))
+ORDER BY created_at
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: MarkShipmentProduced :exec
+UPDATE card_shipments
+SET
+    status = 'produced',
+    embosser_batch_id = $2,
+    produced_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND status = 'requested';
+
+-- name: MarkShipmentShipped :exec
+UPDATE card_shipments
+SET
+    status = 'shipped',
+    carrier = $2,
+    tracking_code = $3,
+    shipped_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND status = 'produced';
+
+-- name: MarkShipmentDelivered :exec
+UPDATE card_shipments
+SET
+    status = 'delivered',
+    delivered_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND status = 'shipped';
+
+-- name: MarkShipmentActivated :exec
+UPDATE card_shipments
+SET
+    status = 'activated',
+    activated_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND status IN ('shipped', 'delivered');
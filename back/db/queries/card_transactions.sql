@@ -7,9 +7,16 @@ INSERT INTO card_transactions (
     merchant_category,
     status,
     is_international,
-    transaction_date
+    transaction_date,
+    mcc,
+    channel,
+    merchant_country,
+    merchant_key,
+    merchant_display_name,
+    merchant_logo_key,
+    merchant_city
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
 )
 RETURNING *;
 
@@ -34,21 +41,88 @@ LIMIT $2 OFFSET $3;
 SELECT COUNT(*) FROM card_transactions
 WHERE card_id = $1;
 
+-- Spending by category with the user's merchant category overrides applied
 -- name: GetCardTransactionsByCategory :many
 SELECT
-    merchant_category,
+    COALESCE(o.category, t.merchant_category, '')::VARCHAR AS merchant_category,
     COUNT(*) as transaction_count,
-    SUM(amount_cents) as total_amount_cents
-FROM card_transactions
-WHERE user_id = $1
-  AND transaction_date >= sqlc.arg(start_date)
-  AND transaction_date <= sqlc.arg(end_date)
-GROUP BY merchant_category
+    SUM(t.amount_cents) as total_amount_cents
+FROM card_transactions t
+LEFT JOIN merchant_category_overrides o
+    ON o.user_id = t.user_id AND o.merchant_key = t.merchant_key
+WHERE t.user_id = $1
+  AND t.transaction_date >= sqlc.arg(start_date)
+  AND t.transaction_date <= sqlc.arg(end_date)
+GROUP BY 1
 ORDER BY total_amount_cents DESC;
 
--- name: GetCardTransactionsByDateRange :many
+-- Filters are optional (NULL = any). end_date is exclusive, search is an ILIKE
+-- pattern fragment and category honors the user's merchant category overrides. Keyset pagination: pass the last row's (transaction_date, id) as cursor.
+-- name: ListFilteredCardTransactions :many
 SELECT * FROM card_transactions
-WHERE user_id = $1
-  AND transaction_date >= sqlc.arg(start_date)
-  AND transaction_date <= sqlc.arg(end_date)
-ORDER BY transaction_date DESC;
+WHERE user_id = sqlc.arg(user_id)
+  AND (sqlc.narg(card_id)::UUID IS NULL OR card_id = sqlc.narg(card_id)::UUID)
+  AND (sqlc.narg(start_date)::TIMESTAMPTZ IS NULL OR transaction_date >= sqlc.narg(start_date)::TIMESTAMPTZ)
+  AND (sqlc.narg(end_date)::TIMESTAMPTZ IS NULL OR transaction_date < sqlc.narg(end_date)::TIMESTAMPTZ)
+  AND (sqlc.narg(status)::VARCHAR IS NULL OR status = sqlc.narg(status)::VARCHAR)
+  AND (sqlc.narg(category)::VARCHAR IS NULL OR COALESCE(
+        (SELECT o.category FROM merchant_category_overrides o
+         WHERE o.user_id = card_transactions.user_id AND o.merchant_key = card_transactions.merchant_key),
+        merchant_category) = sqlc.narg(category)::VARCHAR)
+  AND (sqlc.narg(search)::TEXT IS NULL
+       OR merchant_name ILIKE '%' || sqlc.narg(search)::TEXT || '%'
+       OR merchant_display_name ILIKE '%' || sqlc.narg(search)::TEXT || '%')
+  AND (sqlc.narg(min_amount_cents)::BIGINT IS NULL OR amount_cents >= sqlc.narg(min_amount_cents)::BIGINT)
+  AND (sqlc.narg(max_amount_cents)::BIGINT IS NULL OR amount_cents <= sqlc.narg(max_amount_cents)::BIGINT)
+  AND (sqlc.narg(is_international)::BOOLEAN IS NULL OR COALESCE(is_international, FALSE) = sqlc.narg(is_international)::BOOLEAN)
+  AND (sqlc.narg(cursor_date)::TIMESTAMPTZ IS NULL OR (transaction_date, id) < (sqlc.narg(cursor_date)::TIMESTAMPTZ, sqlc.narg(cursor_id)::UUID))
+ORDER BY transaction_date DESC, id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- Totals of ListFilteredCardTransactions over every page (same filters, no cursor)
+-- name: SummarizeFilteredCardTransactions :one
+SELECT
+    COUNT(*) AS transaction_count,
+    COALESCE(SUM(amount_cents), 0)::BIGINT AS total_amount_cents
+FROM card_transactions
+WHERE user_id = sqlc.arg(user_id)
+  AND (sqlc.narg(card_id)::UUID IS NULL OR card_id = sqlc.narg(card_id)::UUID)
+  AND (sqlc.narg(start_date)::TIMESTAMPTZ IS NULL OR transaction_date >= sqlc.narg(start_date)::TIMESTAMPTZ)
+  AND (sqlc.narg(end_date)::TIMESTAMPTZ IS NULL OR transaction_date < sqlc.narg(end_date)::TIMESTAMPTZ)
+  AND (sqlc.narg(status)::VARCHAR IS NULL OR status = sqlc.narg(status)::VARCHAR)
+  AND (sqlc.narg(category)::VARCHAR IS NULL OR COALESCE(
+        (SELECT o.category FROM merchant_category_overrides o
+         WHERE o.user_id = card_transactions.user_id AND o.merchant_key = card_transactions.merchant_key),
+        merchant_category) = sqlc.narg(category)::VARCHAR)
+  AND (sqlc.narg(search)::TEXT IS NULL
+       OR merchant_name ILIKE '%' || sqlc.narg(search)::TEXT || '%'
+       OR merchant_display_name ILIKE '%' || sqlc.narg(search)::TEXT || '%')
+  AND (sqlc.narg(min_amount_cents)::BIGINT IS NULL OR amount_cents >= sqlc.narg(min_amount_cents)::BIGINT)
+  AND (sqlc.narg(max_amount_cents)::BIGINT IS NULL OR amount_cents <= sqlc.narg(max_amount_cents)::BIGINT)
+  AND (sqlc.narg(is_international)::BOOLEAN IS NULL OR COALESCE(is_international, FALSE) = sqlc.narg(is_international)::BOOLEAN);
+
+-- name: GetCardTransactionForUpdate :one
+SELECT * FROM card_transactions
+WHERE id = $1
+FOR UPDATE;
+
+-- name: UpdateCardTransactionStatus :exec
+UPDATE card_transactions
+SET status = $2
+WHERE id = $1;
+
+-- name: ListUnenrichedCardTransactions :many
+SELECT * FROM card_transactions
+WHERE merchant_key IS NULL
+ORDER BY created_at
+LIMIT $1;
+
+-- name: SetCardTransactionMerchant :exec
+UPDATE card_transactions
+SET
+    merchant_key = $2,
+    merchant_display_name = $3,
+    merchant_logo_key = $4,
+    merchant_city = $5,
+    merchant_category = $6
+WHERE id = $1;
@@ -23,10 +23,19 @@ INSERT INTO cards (
     is_international,
     block_international,
     block_online,
-    expires_at
+    expires_at,
+    cvv_mode,
+    dcvv_secret_encrypted,
+    profile,
+    amount_cap_cents,
+    pan_fingerprint,
+    pan_token,
+    encryption_key_version,
+    activated_at
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
-    $11, $12, $13, $14, $15, $16, $17, $18, $19, $20
+    $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+    $21, $22, $23, $24, $25, $26, $27, $28
 )
 RETURNING *;
 
@@ -95,6 +104,21 @@ SET
     updated_at = NOW()
 WHERE id = $1;
 
+-- name: UpdateCardTotalSpent :exec
+UPDATE cards
+SET
+    total_spent_cents = $2,
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: LockCardMerchant :exec
+UPDATE cards
+SET
+    locked_merchant_name = $2,
+    locked_merchant_category = $3,
+    updated_at = NOW()
+WHERE id = $1;
+
 -- name: ResetDailySpent :exec
 UPDATE cards
 SET
@@ -130,6 +154,54 @@ SET
     updated_at = NOW()
 WHERE id = $1;
 
+-- name: CancelCardWithReason :exec
+UPDATE cards
+SET
+    status = $2,
+    cancellation_reason = $3,
+    cancelled_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: SetCardReplacement :exec
+UPDATE cards
+SET
+    replaced_by_card_id = $2,
+    updated_at = NOW()
+WHERE id = $1;
+
+-- name: GetReplacedCard :one
+SELECT * FROM cards
+WHERE replaced_by_card_id = $1
+LIMIT 1;
+
+-- name: CountCardsByPANFingerprint :one
+SELECT COUNT(*) FROM cards
+WHERE pan_fingerprint = $1;
+
+-- name: GetCardByPANFingerprint :one
+SELECT * FROM cards
+WHERE pan_fingerprint = $1
+LIMIT 1;
+
+-- name: GetCardByPANToken :one
+SELECT * FROM cards
+WHERE pan_token = $1
+LIMIT 1;
+
+-- name: CountCardsByPANToken :one
+SELECT COUNT(*) FROM cards
+WHERE pan_token = $1;
+
+-- SetCardPANToken assigns a token only once.
+-- Returns no rows if the card already has one.
+-- name: SetCardPANToken :one
+UPDATE cards
+SET pan_token = $2,
+    updated_at = NOW()
+WHERE id = $1 AND pan_token IS NULL
+RETURNING *;
+
 -- name: CountUserCards :one
 SELECT COUNT(*) FROM cards
 WHERE user_id = $1;
@@ -142,3 +214,142 @@ WHERE user_id = $1 AND status = 'active';
 SELECT * FROM cards
 WHERE user_id = $1 AND status = $2
 ORDER BY created_at DESC;
+
+-- ========================================
+-- KEY ROTATION
+-- ========================================
+
+-- Cards not under the current key, or still missing their PAN fingerprint
+-- (including cards already re-encrypted on read by the API).
+-- name: ListCardsForRekey :many
+SELECT * FROM cards
+WHERE id > $1 AND (encryption_key_version <> $2 OR pan_fingerprint IS NULL)
+ORDER BY id
+LIMIT $3;
+
+-- name: CountCardsForRekey :one
+SELECT COUNT(*) FROM cards
+WHERE encryption_key_version <> $1 OR pan_fingerprint IS NULL;
+
+-- UpdateCardEncryption stores re-encrypted ciphertexts.
+-- Affects no rows if the card was re-encrypted or its CVVs changed concurrently
+-- (renewal stored or applied), so stale ciphertexts are never written back.
+-- name: UpdateCardEncryption :execrows
+UPDATE cards
+SET card_number_encrypted = $2,
+    cvv_encrypted = $3,
+    dcvv_secret_encrypted = $4,
+    encryption_key_version = $5,
+    renewal_cvv_encrypted = $6,
+    updated_at = NOW()
+WHERE id = $1
+  AND encryption_key_version = sqlc.arg(previous_key_version)
+  AND cvv_encrypted = sqlc.arg(previous_cvv_encrypted)
+  AND dcvv_secret_encrypted IS NOT DISTINCT FROM sqlc.arg(previous_dcvv_secret_encrypted)::BYTEA
+  AND renewal_cvv_encrypted IS NOT DISTINCT FROM sqlc.arg(previous_renewal_cvv_encrypted)::BYTEA;
+
+-- name: SetCardPANFingerprint :exec
+UPDATE cards
+SET pan_fingerprint = $2,
+    updated_at = NOW()
+WHERE id = $1 AND pan_fingerprint IS NULL;
+
+-- ========================================
+-- EXPIRY LIFECYCLE
+-- ========================================
+
+-- ApplyOverdueCardRenewals moves the pending same-PAN renewal of cards past
+-- their current expiry into effect: the old plastic no longer works anyway.
+-- name: ApplyOverdueCardRenewals :many
+UPDATE cards
+SET
+    cvv_encrypted = renewal_cvv_encrypted,
+    expiry_month = renewal_expiry_month,
+    expiry_year = renewal_expiry_year,
+    expires_at = renewal_expires_at,
+    renewal_cvv_encrypted = NULL,
+    renewal_expiry_month = NULL,
+    renewal_expiry_year = NULL,
+    renewal_expires_at = NULL,
+    updated_at = NOW()
+WHERE renewal_expires_at IS NOT NULL
+  AND status IN ('active', 'blocked')
+  AND expires_at <= $1
+RETURNING *;
+
+-- name: ExpireCards :many
+UPDATE cards
+SET
+    status = 'expired',
+    updated_at = NOW()
+WHERE status IN ('active', 'blocked')
+  AND expires_at <= $1
+RETURNING *;
+
+-- ListCardsDueForRenewal returns active physical cards expiring inside the
+-- renewal window that were not renewed or replaced yet.
+-- name: ListCardsDueForRenewal :many
+SELECT * FROM cards
+WHERE type = 'physical'
+  AND profile = 'standard'
+  AND status = 'active'
+  AND replaced_by_card_id IS NULL
+  AND renewal_expires_at IS NULL
+  AND expires_at > sqlc.arg(now)
+  AND expires_at <= sqlc.arg(renew_before)
+ORDER BY expires_at;
+
+-- name: ListCardsExpiringBetween :many
+SELECT * FROM cards
+WHERE status IN ('active', 'blocked')
+  AND replaced_by_card_id IS NULL
+  AND renewal_expires_at IS NULL
+  AND expires_at > sqlc.arg(from_time)
+  AND expires_at <= sqlc.arg(to_time)
+ORDER BY expires_at;
+
+-- RenewCardInPlace keeps the PAN and stores the new expiry and CVV as pending
+-- until the new plastic is activated (see ApplyCardRenewal).
+-- Affects no rows if the card expiry changed or it was renewed concurrently.
+-- name: RenewCardInPlace :execrows
+UPDATE cards
+SET
+    card_number_encrypted = $2,
+    cvv_encrypted = $3,
+    dcvv_secret_encrypted = $4,
+    encryption_key_version = $5,
+    renewal_cvv_encrypted = $6,
+    renewal_expiry_month = $7,
+    renewal_expiry_year = $8,
+    renewal_expires_at = $9,
+    renewed_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1
+  AND expires_at = sqlc.arg(previous_expires_at)
+  AND renewal_expires_at IS NULL;
+
+-- ApplyCardRenewal moves a pending same-PAN renewal into effect
+-- name: ApplyCardRenewal :exec
+UPDATE cards
+SET
+    cvv_encrypted = renewal_cvv_encrypted,
+    expiry_month = renewal_expiry_month,
+    expiry_year = renewal_expiry_year,
+    expires_at = renewal_expires_at,
+    renewal_cvv_encrypted = NULL,
+    renewal_expiry_month = NULL,
+    renewal_expiry_year = NULL,
+    renewal_expires_at = NULL,
+    updated_at = NOW()
+WHERE id = $1 AND renewal_expires_at IS NOT NULL;
+
+-- ========================================
+-- PHYSICAL CARD ACTIVATION
+-- ========================================
+
+-- name: ActivateCard :exec
+UPDATE cards
+SET
+    activated_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND activated_at IS NULL;
@@ -0,0 +1,108 @@
+-- ========================================
+-- FRAUD RULES QUERIES
+-- ========================================
+
+-- name: ListFraudRules :many
+SELECT * FROM fraud_rules
+ORDER BY code;
+
+-- name: ListEnabledFraudRules :many
+SELECT * FROM fraud_rules
+WHERE is_enabled = TRUE
+ORDER BY code;
+
+-- name: GetFraudRuleByCode :one
+SELECT * FROM fraud_rules
+WHERE code = $1
+LIMIT 1;
+
+-- name: UpdateFraudRule :one
+UPDATE fraud_rules
+SET
+    is_enabled = $2,
+    score = $3,
+    params = $4,
+    updated_at = NOW()
+WHERE code = $1
+RETURNING *;
+
+-- name: GetFraudSettings :one
+SELECT * FROM fraud_settings
+LIMIT 1;
+
+-- name: UpdateFraudSettings :one
+UPDATE fraud_settings
+SET
+    step_up_score = $1,
+    decline_score = $2,
+    updated_at = NOW()
+RETURNING *;
+
+-- CountCardFraudDecisionsSince counts scored authorizations on a card since a time
+-- (declined and stepped-up attempts included)
+-- name: CountCardFraudDecisionsSince :one
+SELECT COUNT(*) FROM card_fraud_decisions
+WHERE card_id = $1
+  AND created_at >= sqlc.arg(since);
+
+-- CountCardSmallFraudDecisionsSince counts scored authorizations up to a small amount on a card since a time
+-- name: CountCardSmallFraudDecisionsSince :one
+SELECT COUNT(*) FROM card_fraud_decisions
+WHERE card_id = $1
+  AND created_at >= sqlc.arg(since)
+  AND amount_cents <= sqlc.arg(max_amount_cents);
+
+-- GetUserCardSpendingProfile returns the median amount and count of the user's completed card transactions since a time
+-- name: GetUserCardSpendingProfile :one
+SELECT
+    COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY amount_cents), 0)::BIGINT AS median_amount_cents,
+    COUNT(*) AS transaction_count
+FROM card_transactions
+WHERE user_id = $1
+  AND status = 'completed'
+  AND transaction_date >= sqlc.arg(since);
+
+-- name: CountUserCompletedCardTransactions :one
+SELECT COUNT(*) FROM card_transactions
+WHERE user_id = $1
+  AND status = 'completed';
+
+-- name: CountUserInternationalCardTransactions :one
+SELECT COUNT(*) FROM card_transactions
+WHERE user_id = $1
+  AND status = 'completed'
+  AND is_international = TRUE;
+
+-- name: CountUserCardTransactionsByMCC :one
+SELECT COUNT(*) FROM card_transactions
+WHERE user_id = $1
+  AND status = 'completed'
+  AND mcc = $2;
+
+-- name: CreateCardFraudDecision :one
+INSERT INTO card_fraud_decisions (
+    card_id,
+    user_id,
+    card_transaction_id,
+    amount_cents,
+    merchant_name,
+    mcc,
+    channel,
+    merchant_country,
+    decision,
+    score,
+    explanation
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+)
+RETURNING *;
+
+-- name: ListCardFraudDecisions :many
+SELECT * FROM card_fraud_decisions
+WHERE card_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountCardFraudDecisions :one
+SELECT COUNT(*) FROM card_fraud_decisions
+WHERE card_id = $1;
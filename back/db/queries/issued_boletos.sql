@@ -0,0 +1,71 @@
+-- NextIssuedBoletoNossoNumero reserves the nosso número of a new boleto
+-- name: NextIssuedBoletoNossoNumero :one
+SELECT nextval('issued_boletos_nosso_numero_seq')::BIGINT AS nosso_numero;
+
+-- name: CreateIssuedBoleto :one
+INSERT INTO issued_boletos (
+    issuer_user_id,
+    issuer_name,
+    nosso_numero,
+    barcode,
+    linha_digitavel,
+    amount_cents,
+    due_date,
+    payer_name,
+    payer_document,
+    description,
+    fine_bps,
+    interest_monthly_bps,
+    discount_bps,
+    discount_until
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+)
+RETURNING *;
+
+-- name: GetIssuedBoletoByID :one
+SELECT * FROM issued_boletos
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetIssuedBoletoByBarcode :one
+SELECT * FROM issued_boletos
+WHERE barcode = $1
+LIMIT 1;
+
+-- name: GetIssuedBoletoByBarcodeForUpdate :one
+SELECT * FROM issued_boletos
+WHERE barcode = $1
+FOR UPDATE;
+
+-- ListUserIssuedBoletos lists the boletos issued by a user, optionally by status
+-- name: ListUserIssuedBoletos :many
+SELECT * FROM issued_boletos
+WHERE issuer_user_id = sqlc.arg(issuer_user_id)::UUID
+  AND (sqlc.narg(status)::VARCHAR IS NULL OR status = sqlc.narg(status)::VARCHAR)
+ORDER BY created_at DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountUserIssuedBoletos :one
+SELECT COUNT(*) FROM issued_boletos
+WHERE issuer_user_id = sqlc.arg(issuer_user_id)::UUID
+  AND (sqlc.narg(status)::VARCHAR IS NULL OR status = sqlc.narg(status)::VARCHAR);
+
+-- name: CancelIssuedBoleto :one
+UPDATE issued_boletos
+SET status = 'cancelled', updated_at = NOW()
+WHERE id = $1 AND status = 'open'
+RETURNING *;
+
+-- MarkIssuedBoletoPaid records the payment of an open boleto by a user of the platform
+-- name: MarkIssuedBoletoPaid :one
+UPDATE issued_boletos
+SET
+    status = 'paid',
+    paid_amount_cents = $2,
+    paid_at = NOW(),
+    payer_user_id = $3,
+    bill_id = $4,
+    updated_at = NOW()
+WHERE id = $1 AND status = 'open'
+RETURNING *;
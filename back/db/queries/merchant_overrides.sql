@@ -0,0 +1,30 @@
+-- name: UpsertMerchantCategoryOverride :one
+INSERT INTO merchant_category_overrides (
+    user_id,
+    merchant_key,
+    merchant_name,
+    category
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (user_id, merchant_key) DO UPDATE
+SET
+    merchant_name = EXCLUDED.merchant_name,
+    category = EXCLUDED.category,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: ListUserMerchantCategoryOverrides :many
+SELECT * FROM merchant_category_overrides
+WHERE user_id = $1
+ORDER BY merchant_name;
+
+-- name: DeleteMerchantCategoryOverride :execrows
+DELETE FROM merchant_category_overrides
+WHERE user_id = $1 AND merchant_key = $2;
+
+-- name: GetUserCardTransactionByMerchantKey :one
+SELECT * FROM card_transactions
+WHERE user_id = $1 AND merchant_key = $2
+ORDER BY transaction_date DESC
+LIMIT 1;
@@ -0,0 +1,39 @@
+-- ========================================
+-- NOTIFICATIONS QUERIES
+-- ========================================
+
+-- CreateNotification inserts a notification once per dedup key.
+-- Returns no rows if a notification with the same key already exists.
+-- name: CreateNotification :one
+INSERT INTO notifications (
+    user_id,
+    type,
+    title,
+    message,
+    resource_type,
+    resource_id,
+    dedup_key
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (dedup_key) DO NOTHING
+RETURNING *;
+
+-- name: ListUserNotifications :many
+SELECT * FROM notifications
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountUserNotifications :one
+SELECT COUNT(*) FROM notifications
+WHERE user_id = $1;
+
+-- name: CountUnreadNotifications :one
+SELECT COUNT(*) FROM notifications
+WHERE user_id = $1 AND read_at IS NULL;
+
+-- name: MarkNotificationRead :execrows
+UPDATE notifications
+SET read_at = COALESCE(read_at, NOW())
+WHERE id = $1 AND user_id = $2;
@@ -88,3 +88,12 @@ SELECT * FROM transfers
 WHERE user_id = $1 AND status = $2
 ORDER BY created_at DESC;
 
+
+-- ListDueScheduledTEDs lists TEDs waiting for their settlement date (requested outside the TED window)
+-- name: ListDueScheduledTEDs :many
+SELECT * FROM transfers
+WHERE type = 'ted'
+  AND status = 'processing'
+  AND scheduled_for <= $1
+ORDER BY scheduled_for, id
+LIMIT $2;
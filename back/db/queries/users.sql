@@ -36,3 +36,12 @@ SELECT * FROM users WHERE id = $1 FOR UPDATE;
 SELECT * FROM users
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2;
+
+-- ListActiveUsersWithCPF lists active users with a CPF after an id cursor
+-- name: ListActiveUsersWithCPF :many
+SELECT * FROM users
+WHERE status = 'active'
+  AND cpf IS NOT NULL
+  AND id > sqlc.arg(after_id)::UUID
+ORDER BY id
+LIMIT sqlc.arg(batch_size);
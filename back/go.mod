@@ -8,12 +8,12 @@ require (
 	github.com/go-chi/chi/v5 v5.2.4
 	github.com/jackc/pgx/v5 v5.8.0
 	github.com/lib/pq v1.10.9
+	github.com/sqlc-dev/pqtype v0.3.0
+	golang.org/x/crypto v0.47.0
 )
 
 require (
 	github.com/jackc/puddle/v2 v2.2.2 // indirect
-	github.com/sqlc-dev/pqtype v0.3.0 // indirect
-	golang.org/x/crypto v0.47.0 // indirect
 	golang.org/x/sync v0.19.0 // indirect
 	golang.org/x/sys v0.40.0 // indirect
 )
@@ -3,6 +3,11 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lauratech/fin/back/internal/shared/crypto"
 )
 
 // Config holds application configuration
@@ -15,7 +20,55 @@ type Config struct {
 	TrustedProxyIP string
 
 	// Encryption
-	EncryptionKey string // 32 bytes for AES-256
+	EncryptionKey         string // 32 bytes for AES-256 (key version 1, also decrypts legacy ciphertexts)
+	EncryptionKeys        string // Additional key versions: "2:<32 bytes>,3:<32 bytes>"
+	EncryptionKeyVersion  uint32 // Version used for new encryptions (default: highest)
+	EncryptionKeyringFile string // Local KMS stand-in; replaces the keys above when set
+
+	// Dynamic CVV (virtual cards)
+	DynamicCVVWindow       time.Duration // Lifetime of each dynamic CVV
+	DynamicCVVDriftWindows int           // Adjacent windows accepted on authorization
+
+	// Card issuing
+	CardCVVKey        string // Derives server-side CVVs (min 32 bytes)
+	PANFingerprintKey string // Keys PAN fingerprints for uniqueness and lookup (min 32 bytes)
+
+	// Internal service-to-service API (empty disables /internal routes)
+	InternalAPIToken string
+
+	// Card expiry lifecycle job
+	CardLifecycleInterval time.Duration // How often the job runs (0 disables it)
+	CardRenewalLeadDays   int           // Renew physical cards this many days before expiry
+	CardExpiryNoticeDays  int           // Notify users this many days before expiry
+	CardRenewalSamePAN    bool          // Renew keeping the PAN (new expiry and CVV only)
+
+	// Card dispute deadlines job
+	DisputeDeadlinesInterval time.Duration // How often the job runs (0 disables it)
+
+	// Merchant enrichment job (backfills card transactions stored before enrichment)
+	MerchantEnrichmentInterval time.Duration // How often the job runs (0 disables it)
+
+	// Banking calendar
+	HolidayCalendarFile string // Extra state and municipal holidays (same format as calendar/holidays.csv)
+
+	// Payee registry
+	PayeeRegistryFile string // Extra or updated banks and collectors (same format as payees/payees.csv)
+
+	// Overdue bill sweeper job (marks overdue bills, recalculates charges and notifies users)
+	BillOverdueInterval time.Duration // How often the job runs (0 disables it)
+
+	// Scheduled bill payments worker (pays bills on their scheduled date)
+	BillScheduledPaymentsInterval time.Duration // How often the job runs (0 disables it)
+
+	// DDA sync job (creates bills from the boletos registered against each user's CPF)
+	DDAProviderDir  string        // File-based DDA stand-in: <dir>/<cpf>.json (empty disables DDA)
+	DDASyncInterval time.Duration // How often the job runs (0 disables it)
+
+	// Boleto issuance (users charging others with boletos)
+	BoletoBankCode string // COMPE code of the issuing bank in the barcode (empty disables issuance)
+
+	// Scheduled TED settlement job (TEDs requested outside the TED window)
+	TEDSettlementInterval time.Duration // How often the job runs (0 disables it)
 }
 
 // Load reads configuration from environment variables
@@ -26,24 +79,160 @@ func Load() (*Config, error) {
 		DatabaseURL:    getEnv("DATABASE_URL", ""),
 		TrustedProxyIP: getEnv("TRUSTED_PROXY_IP", "127.0.0.1"),
 		EncryptionKey:  getEnv("ENCRYPTION_KEY", ""),
+
+		EncryptionKeys:        getEnv("ENCRYPTION_KEYS", ""),
+		EncryptionKeyringFile: getEnv("ENCRYPTION_KEYRING_FILE", ""),
+
+		CardCVVKey:        getEnv("CARD_CVV_KEY", ""),
+		PANFingerprintKey: getEnv("PAN_FINGERPRINT_KEY", ""),
+
+		InternalAPIToken: getEnv("INTERNAL_API_TOKEN", ""),
+
+		HolidayCalendarFile: getEnv("HOLIDAY_CALENDAR_FILE", ""),
+
+		PayeeRegistryFile: getEnv("PAYEE_REGISTRY_FILE", ""),
+	}
+
+	dcvvWindowSeconds, err := strconv.Atoi(getEnv("DYNAMIC_CVV_WINDOW_SECONDS", "300"))
+	if err != nil || dcvvWindowSeconds <= 0 {
+		return nil, fmt.Errorf("DYNAMIC_CVV_WINDOW_SECONDS must be a positive integer")
+	}
+	keyVersion, err := strconv.ParseUint(getEnv("ENCRYPTION_KEY_VERSION", "0"), 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("ENCRYPTION_KEY_VERSION must be a non-negative integer")
+	}
+	cfg.EncryptionKeyVersion = uint32(keyVersion)
+
+	cfg.DynamicCVVWindow = time.Duration(dcvvWindowSeconds) * time.Second
+
+	cfg.DynamicCVVDriftWindows, err = strconv.Atoi(getEnv("DYNAMIC_CVV_DRIFT_WINDOWS", "1"))
+	if err != nil || cfg.DynamicCVVDriftWindows < 0 {
+		return nil, fmt.Errorf("DYNAMIC_CVV_DRIFT_WINDOWS must be a non-negative integer")
+	}
+
+	lifecycleMinutes, err := strconv.Atoi(getEnv("CARD_LIFECYCLE_INTERVAL_MINUTES", "60"))
+	if err != nil || lifecycleMinutes < 0 {
+		return nil, fmt.Errorf("CARD_LIFECYCLE_INTERVAL_MINUTES must be a non-negative integer")
+	}
+	cfg.CardLifecycleInterval = time.Duration(lifecycleMinutes) * time.Minute
+
+	cfg.CardRenewalLeadDays, err = strconv.Atoi(getEnv("CARD_RENEWAL_LEAD_DAYS", "45"))
+	if err != nil || cfg.CardRenewalLeadDays <= 0 {
+		return nil, fmt.Errorf("CARD_RENEWAL_LEAD_DAYS must be a positive integer")
+	}
+
+	cfg.CardExpiryNoticeDays, err = strconv.Atoi(getEnv("CARD_EXPIRY_NOTICE_DAYS", "30"))
+	if err != nil || cfg.CardExpiryNoticeDays <= 0 {
+		return nil, fmt.Errorf("CARD_EXPIRY_NOTICE_DAYS must be a positive integer")
 	}
 
+	cfg.CardRenewalSamePAN, err = strconv.ParseBool(getEnv("CARD_RENEWAL_SAME_PAN", "false"))
+	if err != nil {
+		return nil, fmt.Errorf("CARD_RENEWAL_SAME_PAN must be true or false")
+	}
+
+	disputeMinutes, err := strconv.Atoi(getEnv("DISPUTE_DEADLINES_INTERVAL_MINUTES", "60"))
+	if err != nil || disputeMinutes < 0 {
+		return nil, fmt.Errorf("DISPUTE_DEADLINES_INTERVAL_MINUTES must be a non-negative integer")
+	}
+	cfg.DisputeDeadlinesInterval = time.Duration(disputeMinutes) * time.Minute
+
+	enrichmentMinutes, err := strconv.Atoi(getEnv("MERCHANT_ENRICHMENT_INTERVAL_MINUTES", "60"))
+	if err != nil || enrichmentMinutes < 0 {
+		return nil, fmt.Errorf("MERCHANT_ENRICHMENT_INTERVAL_MINUTES must be a non-negative integer")
+	}
+	cfg.MerchantEnrichmentInterval = time.Duration(enrichmentMinutes) * time.Minute
+
+	overdueMinutes, err := strconv.Atoi(getEnv("BILL_OVERDUE_INTERVAL_MINUTES", "60"))
+	if err != nil || overdueMinutes < 0 {
+		return nil, fmt.Errorf("BILL_OVERDUE_INTERVAL_MINUTES must be a non-negative integer")
+	}
+	cfg.BillOverdueInterval = time.Duration(overdueMinutes) * time.Minute
+
+	scheduledPaymentsMinutes, err := strconv.Atoi(getEnv("BILL_SCHEDULED_PAYMENTS_INTERVAL_MINUTES", "30"))
+	if err != nil || scheduledPaymentsMinutes < 0 {
+		return nil, fmt.Errorf("BILL_SCHEDULED_PAYMENTS_INTERVAL_MINUTES must be a non-negative integer")
+	}
+	cfg.BillScheduledPaymentsInterval = time.Duration(scheduledPaymentsMinutes) * time.Minute
+
+	cfg.DDAProviderDir = getEnv("DDA_PROVIDER_DIR", "")
+	ddaMinutes, err := strconv.Atoi(getEnv("DDA_SYNC_INTERVAL_MINUTES", "360"))
+	if err != nil || ddaMinutes < 0 {
+		return nil, fmt.Errorf("DDA_SYNC_INTERVAL_MINUTES must be a non-negative integer")
+	}
+	cfg.DDASyncInterval = time.Duration(ddaMinutes) * time.Minute
+
+	cfg.BoletoBankCode = getEnv("BOLETO_BANK_CODE", "")
+	if cfg.BoletoBankCode != "" {
+		if len(cfg.BoletoBankCode) != 3 || strings.Trim(cfg.BoletoBankCode, "0123456789") != "" {
+			return nil, fmt.Errorf("BOLETO_BANK_CODE must be a 3-digit bank code")
+		}
+	}
+
+	settlementMinutes, err := strconv.Atoi(getEnv("TED_SETTLEMENT_INTERVAL_MINUTES", "15"))
+	if err != nil || settlementMinutes < 0 {
+		return nil, fmt.Errorf("TED_SETTLEMENT_INTERVAL_MINUTES must be a non-negative integer")
+	}
+	cfg.TEDSettlementInterval = time.Duration(settlementMinutes) * time.Minute
+
 	// Validate required fields
 	if cfg.DatabaseURL == "" {
 		return nil, fmt.Errorf("DATABASE_URL environment variable is required")
 	}
 
-	if cfg.EncryptionKey == "" {
-		return nil, fmt.Errorf("ENCRYPTION_KEY environment variable is required (32 bytes)")
+	if cfg.EncryptionKeyringFile == "" {
+		if cfg.EncryptionKey == "" {
+			return nil, fmt.Errorf("ENCRYPTION_KEY environment variable is required (32 bytes)")
+		}
+
+		if len(cfg.EncryptionKey) != 32 {
+			return nil, fmt.Errorf("ENCRYPTION_KEY must be exactly 32 bytes, got %d", len(cfg.EncryptionKey))
+		}
+	}
+
+	if len(cfg.CardCVVKey) < 32 {
+		return nil, fmt.Errorf("CARD_CVV_KEY environment variable is required (at least 32 bytes)")
 	}
 
-	if len(cfg.EncryptionKey) != 32 {
-		return nil, fmt.Errorf("ENCRYPTION_KEY must be exactly 32 bytes, got %d", len(cfg.EncryptionKey))
+	if len(cfg.PANFingerprintKey) < 32 {
+		return nil, fmt.Errorf("PAN_FINGERPRINT_KEY environment variable is required (at least 32 bytes)")
+	}
+
+	if cfg.InternalAPIToken != "" && len(cfg.InternalAPIToken) < 32 {
+		return nil, fmt.Errorf("INTERNAL_API_TOKEN must be at least 32 bytes when set")
 	}
 
 	return cfg, nil
 }
 
+// LoadKeyring builds the encryption keyring from the keyring file or from
+// ENCRYPTION_KEY (version 1) plus ENCRYPTION_KEYS
+func (c *Config) LoadKeyring() (*crypto.Keyring, error) {
+	if c.EncryptionKeyringFile != "" {
+		return crypto.LoadKeyringFile(c.EncryptionKeyringFile)
+	}
+
+	keys, err := crypto.ParseKeys(c.EncryptionKeys)
+	if err != nil {
+		return nil, err
+	}
+	if _, exists := keys[1]; exists {
+		return nil, fmt.Errorf("key version 1 is reserved for ENCRYPTION_KEY")
+	}
+	keys[1] = []byte(c.EncryptionKey)
+
+	current := c.EncryptionKeyVersion
+	if current == 0 {
+		for version := range keys {
+			if version > current {
+				current = version
+			}
+		}
+	}
+
+	return crypto.NewKeyring(keys, current, 1)
+}
+
 // getEnv retrieves environment variable with fallback
 func getEnv(key, fallback string) string {
 	if value := os.Getenv(key); value != "" {
@@ -1,23 +1,23 @@
 package bills
 
 import (
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/lauratech/fin/back/internal/shared/payees"
 )
 
 // Brazilian barcode formats:
 // - Boleto Bancário: 47 digits (line) or 44 digits (barcode)
-// - Concessionárias (utilities): 48 digits
+// - Concessionárias (utilities): 48 digits (line) or 44 digits (barcode), starting with "8"
 
 var (
 	// Boleto bancário pattern (47 digits with spaces/dots or 44 digits)
 	boletoLineRegex    = regexp.MustCompile(`^\d{5}\.\d{5}\s\d{5}\.\d{6}\s\d{5}\.\d{6}\s\d\s\d{14}$`)
 	boletoBarcodeRegex = regexp.MustCompile(`^\d{44}$`)
-
-	// Concessionária pattern (48 digits)
-	concessionariaRegex = regexp.MustCompile(`^\d{48}$`)
 )
 
 // BarcodeType represents the type of barcode
@@ -33,9 +33,16 @@ type BarcodeInfo struct {
 	Type          BarcodeType
 	Barcode       string // Normalized barcode (digits only)
 	AmountCents   int64
-	DueDate       time.Time
+	DueDate       *time.Time // nil when the bill has no due date
 	RecipientName string
 	BillType      string
+
+	// Concessionária only
+	Segment        string // Segment code (position 2)
+	ValueType      string // ValueTypeEffective or ValueTypeReference
+	ReferenceValue int64  // Value field of reference value barcodes (AmountCents is 0)
+	CompanyID      string // Company/agency ID, or CNPJ root for segment 6
+	FreeField      string // Collector-defined digits after the company ID
 }
 
 // ValidateBarcode validates a Brazilian barcode and returns parsed information
@@ -46,7 +53,9 @@ func ValidateBarcode(input string) (*BarcodeInfo, error) {
 	normalized = strings.ReplaceAll(normalized, "-", "")
 
 	// Determine barcode type
-	if boletoBarcodeRegex.MatchString(normalized) {
+	if isConcessionaria(normalized) {
+		return validateConcessionaria(normalized)
+	} else if boletoBarcodeRegex.MatchString(normalized) {
 		return validateBoletoBancario(normalized)
 	} else if len(normalized) == 47 {
 		// Convert linha digitável (47) to código de barras (44)
@@ -55,8 +64,6 @@ func ValidateBarcode(input string) (*BarcodeInfo, error) {
 			return nil, ErrInvalidBarcode
 		}
 		return validateBoletoBancario(barcode)
-	} else if concessionariaRegex.MatchString(normalized) {
-		return validateConcessionaria(normalized)
 	}
 
 	return nil, ErrInvalidBarcode
@@ -80,13 +87,15 @@ func validateBoletoBancario(barcode string) (*BarcodeInfo, error) {
 		return nil, ErrInvalidAmount
 	}
 
-	// Parse due date (positions 5-8, days since 07/10/1997)
-	dueDateFactor := barcode[5:9]
-	dueDate := parseDueDateFactor(dueDateFactor)
+	// Parse due date (positions 5-8, due date factor)
+	dueDate, err := parseDueDateFactor(barcode[5:9], time.Now())
+	if err != nil {
+		return nil, err
+	}
 
 	// Determine recipient based on bank code (first 3 digits)
 	bankCode := barcode[0:3]
-	recipientName := getBankName(bankCode)
+	recipientName := bankName(bankCode)
 
 	return &BarcodeInfo{
 		Type:          BarcodeTypeBoleto,
@@ -98,43 +107,6 @@ func validateBoletoBancario(barcode string) (*BarcodeInfo, error) {
 	}, nil
 }
 
-// validateConcessionaria validates a concessionária barcode (48 digits)
-func validateConcessionaria(barcode string) (*BarcodeInfo, error) {
-	if len(barcode) != 48 {
-		return nil, ErrInvalidBarcode
-	}
-
-	// Validate check digits (positions 11, 22, 33, 44 using modulo 10/11)
-	if !validateConcessionariaCheckDigits(barcode) {
-		return nil, ErrInvalidBarcode
-	}
-
-	// Parse amount (positions 4-15, in centavos)
-	amountStr := barcode[4:15]
-	amountCents, err := strconv.ParseInt(amountStr, 10, 64)
-	if err != nil || amountCents <= 0 {
-		return nil, ErrInvalidAmount
-	}
-
-	// Concessionária bills typically don't have due date in barcode
-	// Use a default (e.g., 30 days from now)
-	dueDate := time.Now().AddDate(0, 0, 30)
-
-	// Determine type based on segment code (position 2)
-	segmentCode := barcode[1:2]
-	billType := getConcessionariaType(segmentCode)
-	recipientName := getConcessionariaName(segmentCode)
-
-	return &BarcodeInfo{
-		Type:          BarcodeTypeConcessionaria,
-		Barcode:       barcode,
-		AmountCents:   amountCents,
-		DueDate:       dueDate,
-		RecipientName: recipientName,
-		BillType:      billType,
-	}, nil
-}
-
 // convertLinhaDigitavelToBarcode converts a 47-digit linha digitável to 44-digit barcode
 func convertLinhaDigitavelToBarcode(linha string) (string, error) {
 	// Remove formatting
@@ -165,8 +137,12 @@ func validateBoletoCheckDigit(barcode string) bool {
 	// Extract check digit (position 4)
 	checkDigit, _ := strconv.Atoi(string(barcode[4]))
 
-	// Calculate expected check digit using modulo 11
-	sequence := barcode[0:4] + barcode[5:]
+	return checkDigit == boletoCheckDigit(barcode[0:4]+barcode[5:])
+}
+
+// boletoCheckDigit computes the check digit of a boleto barcode from its other
+// 43 digits, using modulo 11
+func boletoCheckDigit(sequence string) int {
 	sum := 0
 	multiplier := 2
 
@@ -187,98 +163,59 @@ func validateBoletoCheckDigit(barcode string) bool {
 		expected = 1
 	}
 
-	return checkDigit == expected
+	return expected
 }
 
-// validateConcessionariaCheckDigits validates check digits using modulo 10
-func validateConcessionariaCheckDigits(barcode string) bool {
-	// Simplified validation - in production, implement full modulo 10/11 algorithm
-	// for positions 11, 22, 33, 44
-	positions := []int{11, 22, 33, 44}
-	for _, pos := range positions {
-		if pos >= len(barcode) {
-			return false
-		}
-		// Basic digit check
-		if _, err := strconv.Atoi(string(barcode[pos])); err != nil {
-			return false
-		}
+// BuildBoletoBarcode builds the 44-digit barcode of a boleto, the inverse of
+// validateBoletoBancario: bank code, currency (9, real), check digit, due date
+// factor (0000 without due date), amount in centavos and the 25-digit free field
+func BuildBoletoBarcode(bankCode string, dueDate *time.Time, amountCents int64, freeField string) (string, error) {
+	if len(bankCode) != 3 || !isDigits(bankCode) || len(freeField) != 25 || !isDigits(freeField) || amountCents <= 0 || amountCents > 9999999999 {
+		return "", ErrInvalidBarcode
 	}
-	return true
-}
 
-// parseDueDateFactor converts the due date factor to a time.Time
-func parseDueDateFactor(factor string) time.Time {
-	factorInt, err := strconv.Atoi(factor)
-	if err != nil || factorInt == 0 {
-		// If no date or invalid, return 30 days from now
-		return time.Now().AddDate(0, 0, 30)
+	factor := "0000"
+	if dueDate != nil {
+		var err error
+		if factor, err = dueDateFactor(*dueDate); err != nil {
+			return "", err
+		}
 	}
 
-	// Base date: October 7, 1997
-	baseDate := time.Date(1997, 10, 7, 0, 0, 0, 0, time.UTC)
-	dueDate := baseDate.AddDate(0, 0, factorInt)
-
-	return dueDate
+	sequence := fmt.Sprintf("%s9%s%010d%s", bankCode, factor, amountCents, freeField)
+	return fmt.Sprintf("%s%d%s", sequence[0:4], boletoCheckDigit(sequence), sequence[4:]), nil
 }
 
-// getBankName returns bank name based on bank code
-func getBankName(code string) string {
-	banks := map[string]string{
-		"001": "Banco do Brasil",
-		"033": "Santander",
-		"104": "Caixa Econômica Federal",
-		"237": "Bradesco",
-		"341": "Itaú",
-		"356": "Banco Real",
-		"389": "Banco Mercantil do Brasil",
-		"399": "HSBC",
-		"422": "Banco Safra",
-		"453": "Banco Rural",
-		"633": "Banco Rendimento",
-		"652": "Itaú Unibanco",
-		"745": "Citibank",
+// BoletoLinhaDigitavel returns the 47-digit linha digitável of a boleto barcode,
+// the inverse of convertLinhaDigitavelToBarcode: three fields of the bank code,
+// currency and free field with modulo 10 check digits, the barcode check digit,
+// then the due date factor and amount
+func BoletoLinhaDigitavel(barcode string) (string, error) {
+	if len(barcode) != 44 || !isDigits(barcode) {
+		return "", ErrInvalidBarcode
 	}
 
-	if name, ok := banks[code]; ok {
-		return name
-	}
-	return "Instituição Financeira"
+	field1 := barcode[0:4] + barcode[19:24]
+	field2 := barcode[24:34]
+	field3 := barcode[34:44]
+	return fmt.Sprintf("%s%d%s%d%s%d%s%s",
+		field1, modulo10(field1), field2, modulo10(field2), field3, modulo10(field3), barcode[4:5], barcode[5:19]), nil
 }
 
-// getConcessionariaType returns bill type based on segment code
-func getConcessionariaType(code string) string {
-	types := map[string]string{
-		"1": "utility", // Energia elétrica
-		"2": "utility", // Telecomunicações
-		"3": "utility", // Água e saneamento
-		"4": "utility", // Gás
-		"5": "tax",     // Tributos
-		"6": "other",   // Outros
-		"7": "other",   // Outros
-		"8": "other",   // Outros
-		"9": "tax",     // Uso próprio
+// FormatLinhaDigitavel formats a 47-digit linha digitável as printed on boletos
+// (AAAAA.AAAAA BBBBB.BBBBBB CCCCC.CCCCCC D EEEEEEEEEEEEEE)
+func FormatLinhaDigitavel(linha string) string {
+	if len(linha) != 47 {
+		return linha
 	}
-
-	if t, ok := types[code]; ok {
-		return t
-	}
-	return "other"
+	return fmt.Sprintf("%s.%s %s.%s %s.%s %s %s",
+		linha[0:5], linha[5:10], linha[10:15], linha[15:21], linha[21:26], linha[26:32], linha[32:33], linha[33:47])
 }
 
-// getConcessionariaName returns recipient name based on segment code
-func getConcessionariaName(code string) string {
-	names := map[string]string{
-		"1": "Companhia de Energia",
-		"2": "Operadora de Telefonia",
-		"3": "Companhia de Água e Saneamento",
-		"4": "Companhia de Gás",
-		"5": "Receita Federal",
-		"9": "Órgão Público",
+// bankName returns the name of the bank with a COMPE code in the payee registry
+func bankName(code string) string {
+	if bank, ok := payees.LookupBank(code); ok {
+		return bank.Name
 	}
-
-	if name, ok := names[code]; ok {
-		return name
-	}
-	return "Concessionária de Serviço Público"
+	return "Instituição Financeira"
 }
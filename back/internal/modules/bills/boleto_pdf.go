@@ -0,0 +1,248 @@
+package bills
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lauratech/fin/back/internal/shared/barcode"
+	"github.com/lauratech/fin/back/internal/shared/calendar"
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+	"github.com/lauratech/fin/back/internal/shared/pdf"
+)
+
+// Layout of the printed boleto (points, A4): the payer's receipt on top and the
+// ficha de compensação with the barcode at the bottom, split by a cut line
+const (
+	boletoLeft      = 30.0
+	boletoRight     = pdf.A4Width - boletoLeft
+	boletoSideX     = 420.0 // Right column: due date and amounts
+	boletoRowHeight = 22.0
+
+	// Interleaved 2 of 5 barcode: 0.254 mm narrow bars, wide bars 3 times as
+	// wide, 13 mm high
+	boletoBarNarrow = 0.72
+	boletoBarWide   = 3
+	boletoBarHeight = 36.85
+
+	// Text that fits the fields and the instructions box
+	boletoMaxFieldLength       = 70
+	boletoMaxInstructionLength = 85
+	boletoMaxInstructionLines  = 5
+)
+
+// boletoCell is a labeled field of a boleto row, from x to the next field
+type boletoCell struct {
+	x     float64
+	label string
+	value string
+}
+
+// renderBoletoPDF renders the printable boleto of an issued boleto
+func renderBoletoPDF(boleto *db.IssuedBoleto) ([]byte, error) {
+	runs, err := barcode.Encode(boleto.Barcode, boletoBarWide)
+	if err != nil {
+		return nil, err
+	}
+
+	bankCode := boleto.Barcode[0:3]
+	bank := fmt.Sprintf("%s-%d", bankCode, bankCodeCheckDigit(bankCode))
+	linha := FormatLinhaDigitavel(boleto.LinhaDigitavel)
+	dueDate := boleto.DueDate.Format("02/01/2006")
+	issuedOn := ""
+	if boleto.CreatedAt.Valid {
+		issuedOn = calendar.DateOf(boleto.CreatedAt.Time).Format("02/01/2006")
+	}
+	amount := formatBRL(boleto.AmountCents)
+	nossoNumero := strconv.FormatInt(boleto.NossoNumero, 10)
+	issuer := truncateText(boleto.IssuerName, boletoMaxFieldLength)
+	payer := truncateText(boleto.PayerName, boletoMaxFieldLength) + " - CPF/CNPJ " + formatDocument(boleto.PayerDocument)
+
+	w := pdf.NewWriter(pdf.A4Width, pdf.A4Height)
+
+	// 1. Recibo do pagador
+	w.Text(boletoLeft, 812, 8, true, "Recibo do Pagador")
+	top := boletoHeader(w, 790, bankCode, bank, linha)
+	top = boletoRow(w, top,
+		boletoCell{boletoLeft, "Beneficiário", issuer},
+		boletoCell{boletoSideX, "Vencimento", dueDate})
+	top = boletoRow(w, top,
+		boletoCell{boletoLeft, "Pagador", payer},
+		boletoCell{boletoSideX, "Valor do documento", amount})
+	top = boletoRow(w, top,
+		boletoCell{boletoLeft, "Nosso número", nossoNumero},
+		boletoCell{225, "Data do documento", issuedOn},
+		boletoCell{boletoSideX, "(=) Valor cobrado", ""})
+	if boleto.Description.Valid {
+		top = boletoRow(w, top, boletoCell{boletoLeft, "Descrição", truncateText(boleto.Description.String, boletoMaxInstructionLength)})
+	}
+	w.Text(boletoSideX, top-10, 6, false, "Autenticação mecânica")
+
+	// 2. Cut line
+	const cutY = 560.0
+	for x := boletoLeft; x < boletoRight; x += 6 {
+		w.Line(x, cutY, x+3, cutY, 0.5)
+	}
+	w.Text(boletoSideX, cutY+4, 6, false, "Corte na linha pontilhada")
+
+	// 3. Ficha de compensação
+	top = boletoHeader(w, 530, bankCode, bank, linha)
+	top = boletoRow(w, top,
+		boletoCell{boletoLeft, "Local de pagamento", "Pagável em qualquer banco até o vencimento"},
+		boletoCell{boletoSideX, "Vencimento", dueDate})
+	top = boletoRow(w, top,
+		boletoCell{boletoLeft, "Beneficiário", issuer},
+		boletoCell{boletoSideX, "Nosso número", nossoNumero})
+	top = boletoRow(w, top,
+		boletoCell{boletoLeft, "Data do documento", issuedOn},
+		boletoCell{130, "Espécie doc.", "DM"},
+		boletoCell{200, "Aceite", "N"},
+		boletoCell{250, "Espécie", "R$"},
+		boletoCell{320, "Data processamento", issuedOn},
+		boletoCell{boletoSideX, "(=) Valor do documento", amount})
+
+	// Instructions (three rows high) beside the amount adjustments
+	instructionsTop := top
+	for _, label := range []string{"(-) Desconto / Abatimento", "(+) Mora / Multa", "(=) Valor cobrado"} {
+		top = boletoRow(w, top, boletoCell{boletoSideX, label, ""})
+	}
+	w.Line(boletoLeft, instructionsTop, boletoLeft, top, 0.5)
+	w.Line(boletoLeft, top, boletoSideX, top, 0.5)
+	w.Text(boletoLeft+2, instructionsTop-7, 6, false, "Instruções (texto de responsabilidade do beneficiário)")
+	for i, line := range boletoInstructions(boleto) {
+		w.Text(boletoLeft+2, instructionsTop-18-float64(i)*10, 8, false, line)
+	}
+
+	top = boletoRow(w, top, boletoCell{boletoLeft, "Pagador", payer})
+	w.Text(boletoSideX, top-10, 6, false, "Autenticação mecânica - Ficha de Compensação")
+
+	// 4. Barcode, bars at the even runs
+	x, y := boletoLeft, top-20-boletoBarHeight
+	for i, run := range runs {
+		width := float64(run) * boletoBarNarrow
+		if i%2 == 0 {
+			w.Rect(x, y, width, boletoBarHeight)
+		}
+		x += width
+	}
+
+	return w.Bytes(), nil
+}
+
+// boletoHeader draws the bank, its code and the linha digitável above a thick
+// line at y, and returns the top of the first row
+func boletoHeader(w *pdf.Writer, y float64, bankCode, bank, linha string) float64 {
+	w.Text(boletoLeft, y+4, 10, true, truncateText(bankName(bankCode), 25))
+	w.Line(185, y, 185, y+20, 1)
+	w.Text(192, y+4, 14, true, bank)
+	w.Line(245, y, 245, y+20, 1)
+	w.Text(252, y+4, 11, true, linha)
+	w.Line(boletoLeft, y, boletoRight, y, 1.5)
+	return y
+}
+
+// boletoRow draws a row of fields below top, from the first field to the right
+// edge, and returns the top of the next row
+func boletoRow(w *pdf.Writer, top float64, cells ...boletoCell) float64 {
+	bottom := top - boletoRowHeight
+	for _, cell := range cells {
+		w.Line(cell.x, top, cell.x, bottom, 0.5)
+		w.Text(cell.x+2, top-7, 6, false, cell.label)
+		w.Text(cell.x+2, top-18, 9, cell.x == boletoSideX, cell.value)
+	}
+	w.Line(boletoRight, top, boletoRight, bottom, 0.5)
+	w.Line(cells[0].x, bottom, boletoRight, bottom, 0.5)
+	return bottom
+}
+
+// boletoInstructions returns the lines of the instructions box: the charges
+// after the due date, the discount and the description
+func boletoInstructions(boleto *db.IssuedBoleto) []string {
+	var lines []string
+	if boleto.FineBps > 0 {
+		lines = append(lines, "Após o vencimento, cobrar multa de "+formatBps(boleto.FineBps))
+	}
+	if boleto.InterestMonthlyBps > 0 {
+		lines = append(lines, "Após o vencimento, cobrar juros de "+formatBps(boleto.InterestMonthlyBps)+" ao mês")
+	}
+	if boleto.DiscountBps > 0 && boleto.DiscountUntil.Valid {
+		lines = append(lines, fmt.Sprintf("Conceder desconto de %s até %s",
+			formatBps(boleto.DiscountBps), boleto.DiscountUntil.Time.Format("02/01/2006")))
+	}
+	if boleto.Description.Valid {
+		lines = append(lines, wrapText(boleto.Description.String, boletoMaxInstructionLength)...)
+	}
+	if len(lines) > boletoMaxInstructionLines {
+		lines = lines[:boletoMaxInstructionLines]
+	}
+	return lines
+}
+
+// bankCodeCheckDigit computes the check digit printed after the bank code
+// (modulo 11, weights 2 to 4 from the right, 10 and 11 map to 0)
+func bankCodeCheckDigit(bankCode string) int {
+	sum := 0
+	for i := 0; i < len(bankCode); i++ {
+		sum += int(bankCode[len(bankCode)-1-i]-'0') * (i + 2)
+	}
+	digit := 11 - sum%11
+	if digit >= 10 {
+		return 0
+	}
+	return digit
+}
+
+// formatBRL formats an amount in centavos as printed in reais (R$ 1.234,56)
+func formatBRL(cents int64) string {
+	units := strconv.FormatInt(cents/100, 10)
+	for i := len(units) - 3; i > 0; i -= 3 {
+		units = units[:i] + "." + units[i:]
+	}
+	return fmt.Sprintf("R$ %s,%02d", units, cents%100)
+}
+
+// formatBps formats a rate in basis points as a percentage (2,50%)
+func formatBps(bps int32) string {
+	return fmt.Sprintf("%d,%02d%%", bps/100, bps%100)
+}
+
+// formatDocument formats a CPF (000.000.000-00) or CNPJ (00.000.000/0000-00)
+func formatDocument(document string) string {
+	switch len(document) {
+	case 11:
+		return document[0:3] + "." + document[3:6] + "." + document[6:9] + "-" + document[9:11]
+	case 14:
+		return document[0:2] + "." + document[2:5] + "." + document[5:8] + "/" + document[8:12] + "-" + document[12:14]
+	}
+	return document
+}
+
+// truncateText shortens text to at most n characters
+func truncateText(text string, n int) string {
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+	return string(runes[:n-3]) + "..."
+}
+
+// wrapText splits text into lines of at most n characters at spaces
+func wrapText(text string, n int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		word = truncateText(word, n)
+		if line != "" && len([]rune(line))+1+len([]rune(word)) > n {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,109 @@
+package bills
+
+import (
+	"time"
+
+	"github.com/lauratech/fin/back/internal/shared/calendar"
+)
+
+// Charge sources: where the fine, interest and discount rates of a bill come from
+const (
+	ChargeSourceRegistry  = "registry"   // Boleto registry (CIP/NPC)
+	ChargeSourcePayeeRule = "payee_rule" // Charge rule of the payee
+	ChargeSourceNone      = "none"       // No charges registered: the printed amount applies
+)
+
+// Payee types of charge rules
+const (
+	PayeeTypeBank           = "bank"           // payee_code: bank code
+	PayeeTypeConcessionaria = "concessionaria" // payee_code: segment + company ID
+)
+
+// maxRateBps is the highest rate accepted for fines, interest and discounts (100%)
+const maxRateBps = 10000
+
+// ChargeRule holds the late payment and discount rates applied to a bill.
+// Rates are in basis points (200 = 2%).
+type ChargeRule struct {
+	Source             string
+	FineBps            int64      // Fine charged once the bill is late (multa)
+	InterestMonthlyBps int64      // Interest per month late, charged pro rata per day (juros de mora)
+	DiscountBps        int64      // Discount for payments up to DiscountUntil
+	DiscountUntil      *time.Time // Last day of the discount (nil: no discount)
+}
+
+// ChargeBreakdown is the amount due for a bill on a payment date
+type ChargeBreakdown struct {
+	AmountCents      int64      `json:"amount_cents"`   // Printed amount
+	FineCents        int64      `json:"fine_cents"`     // Late payment fine
+	InterestCents    int64      `json:"interest_cents"` // Late payment interest
+	DiscountCents    int64      `json:"discount_cents"` // Early payment discount
+	FeeCents         int64      `json:"fee_cents"`
+	TotalCents       int64      `json:"total_cents"`
+	DueDate          *time.Time `json:"due_date"`           // Printed due date
+	EffectiveDueDate *time.Time `json:"effective_due_date"` // Due date moved to the next business day
+	DaysOverdue      int        `json:"days_overdue"`
+	Source           string     `json:"source"`
+	CalculatedOn     string     `json:"calculated_on"` // Payment date of the breakdown (YYYY-MM-DD)
+}
+
+// ComputeCharges returns the amount due for a bill paid on payDate.
+//
+// A bill is late when paid after its effective due date (the printed due date,
+// or the next business day when it falls on a weekend or national holiday).
+// Late bills pay the fine and simple interest for every calendar day since the
+// printed due date. Bills without due date are never late.
+func ComputeCharges(amountCents int64, dueDate *time.Time, rule *ChargeRule, feeCents int64, payDate time.Time) ChargeBreakdown {
+	breakdown := ChargeBreakdown{
+		AmountCents:  amountCents,
+		FeeCents:     feeCents,
+		DueDate:      dueDate,
+		Source:       ChargeSourceNone,
+		CalculatedOn: calendar.DateOf(payDate).Format("2006-01-02"),
+	}
+	if dueDate != nil {
+		effective := calendar.NextBusinessDay(*dueDate)
+		breakdown.EffectiveDueDate = &effective
+	}
+	breakdown.DaysOverdue = DaysOverdue(dueDate, payDate)
+
+	if rule != nil {
+		breakdown.Source = rule.Source
+
+		// 1. Fine and interest for late payments
+		if breakdown.DaysOverdue > 0 {
+			breakdown.FineCents = amountCents * rule.FineBps / 10000
+			breakdown.InterestCents = amountCents * rule.InterestMonthlyBps * int64(breakdown.DaysOverdue) / (10000 * 30)
+		}
+
+		// 2. Discount for early payments
+		if rule.DiscountBps > 0 && rule.DiscountUntil != nil && !calendar.DateOf(payDate).After(*rule.DiscountUntil) {
+			breakdown.DiscountCents = amountCents * rule.DiscountBps / 10000
+		}
+	}
+
+	breakdown.TotalCents = amountCents + breakdown.FineCents + breakdown.InterestCents - breakdown.DiscountCents + feeCents
+	return breakdown
+}
+
+// payeeOf returns the charge rule payee of a stored barcode: the bank of a
+// boleto, or the segment and company ID of a concessionária
+func payeeOf(barcode string) (payeeType, payeeCode string, ok bool) {
+	if len(barcode) == 48 {
+		var err error
+		if barcode, err = concessionariaLineToBarcode(barcode); err != nil {
+			return "", "", false
+		}
+	}
+	if len(barcode) != 44 {
+		return "", "", false
+	}
+
+	if barcode[0] != concessionariaProductID {
+		return PayeeTypeBank, barcode[:3], true
+	}
+	if barcode[1] == '6' {
+		return PayeeTypeConcessionaria, barcode[1:2] + barcode[15:23], true
+	}
+	return PayeeTypeConcessionaria, barcode[1:2] + barcode[15:19], true
+}
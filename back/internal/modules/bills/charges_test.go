@@ -0,0 +1,155 @@
+package bills
+
+import (
+	"testing"
+	"time"
+)
+
+// date returns a UTC midnight date like stored due dates
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// TestComputeCharges tests fines, interest, discounts and the business-day due date
+func TestComputeCharges(t *testing.T) {
+	tuesday := date(2026, 3, 10)
+	saturday := date(2026, 3, 14)
+	goodFriday := date(2026, 4, 3)
+	discountUntil := date(2026, 3, 5)
+
+	rule := &ChargeRule{
+		Source:             ChargeSourcePayeeRule,
+		FineBps:            200, // 2%
+		InterestMonthlyBps: 100, // 1% a month
+		DiscountBps:        500, // 5%
+		DiscountUntil:      &discountUntil,
+	}
+
+	tests := []struct {
+		name          string
+		dueDate       *time.Time
+		rule          *ChargeRule
+		payDate       time.Time
+		wantEffective string
+		wantDays      int
+		wantFine      int64
+		wantInterest  int64
+		wantDiscount  int64
+		wantTotal     int64
+	}{
+		{"On due date", &tuesday, rule, date(2026, 3, 10).Add(15 * time.Hour), "2026-03-10", 0, 0, 0, 0, 100200},
+		{"Night of due date in Brasília", &tuesday, rule, date(2026, 3, 11).Add(2 * time.Hour), "2026-03-10", 0, 0, 0, 0, 100200},
+		{"One day late", &tuesday, rule, date(2026, 3, 11).Add(15 * time.Hour), "2026-03-10", 1, 2000, 33, 0, 102233},
+		{"Thirty days late", &tuesday, rule, date(2026, 4, 9).Add(15 * time.Hour), "2026-03-10", 30, 2000, 1000, 0, 103200},
+		{"Due on Saturday paid Monday", &saturday, rule, date(2026, 3, 16).Add(15 * time.Hour), "2026-03-16", 0, 0, 0, 0, 100200},
+		{"Due on Saturday paid Tuesday", &saturday, rule, date(2026, 3, 17).Add(15 * time.Hour), "2026-03-16", 3, 2000, 100, 0, 102300},
+		{"Due on Good Friday paid Monday", &goodFriday, rule, date(2026, 4, 6).Add(15 * time.Hour), "2026-04-06", 0, 0, 0, 0, 100200},
+		{"Discount on last day", &tuesday, rule, date(2026, 3, 5).Add(15 * time.Hour), "2026-03-10", 0, 0, 0, 5000, 95200},
+		{"Discount expired", &tuesday, rule, date(2026, 3, 6).Add(15 * time.Hour), "2026-03-10", 0, 0, 0, 0, 100200},
+		{"No due date", nil, rule, date(2030, 1, 2).Add(15 * time.Hour), "", 0, 0, 0, 0, 100200},
+		{"No rule", &tuesday, nil, date(2026, 5, 10).Add(15 * time.Hour), "2026-03-10", 61, 0, 0, 0, 100200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeCharges(100000, tt.dueDate, tt.rule, BillPaymentFeeCents, tt.payDate)
+
+			effective := ""
+			if got.EffectiveDueDate != nil {
+				effective = got.EffectiveDueDate.Format("2006-01-02")
+			}
+			if effective != tt.wantEffective || got.DaysOverdue != tt.wantDays {
+				t.Errorf("EffectiveDueDate = %q, DaysOverdue = %d, want %q and %d", effective, got.DaysOverdue, tt.wantEffective, tt.wantDays)
+			}
+			if got.FineCents != tt.wantFine || got.InterestCents != tt.wantInterest || got.DiscountCents != tt.wantDiscount {
+				t.Errorf("fine = %d, interest = %d, discount = %d, want %d, %d and %d",
+					got.FineCents, got.InterestCents, got.DiscountCents, tt.wantFine, tt.wantInterest, tt.wantDiscount)
+			}
+			if got.TotalCents != tt.wantTotal {
+				t.Errorf("TotalCents = %d, want %d", got.TotalCents, tt.wantTotal)
+			}
+
+			wantSource := ChargeSourcePayeeRule
+			if tt.rule == nil {
+				wantSource = ChargeSourceNone
+			}
+			if got.Source != wantSource {
+				t.Errorf("Source = %q, want %q", got.Source, wantSource)
+			}
+		})
+	}
+}
+
+// TestPayeeOf tests the charge rule payee of stored barcodes
+func TestPayeeOf(t *testing.T) {
+	tests := []struct {
+		barcode  string
+		wantType string
+		wantCode string
+	}{
+		{"34191000000000123451090000000000000000000000", PayeeTypeBank, "341"},
+		{"83620000000667800481001809756573100158963608", PayeeTypeConcessionaria, "30048"},
+		{"836200000005667800481000180975657313001589636081", PayeeTypeConcessionaria, "30048"},
+		{"8660" + "00000150000" + "12345678" + "202604300000000000001", PayeeTypeConcessionaria, "612345678"},
+	}
+
+	for _, tt := range tests {
+		payeeType, payeeCode, ok := payeeOf(tt.barcode)
+		if !ok || payeeType != tt.wantType || payeeCode != tt.wantCode {
+			t.Errorf("payeeOf(%q) = %q, %q, %v, want %q and %q", tt.barcode, payeeType, payeeCode, ok, tt.wantType, tt.wantCode)
+		}
+	}
+
+	if _, _, ok := payeeOf("123"); ok {
+		t.Error("payeeOf() accepted a short barcode")
+	}
+}
+
+// TestValidatePayee tests charge rule payees
+func TestValidatePayee(t *testing.T) {
+	tests := []struct {
+		name      string
+		payeeType string
+		payeeCode string
+		expected  error
+	}{
+		{"Bank", PayeeTypeBank, "341", nil},
+		{"Concessionária", PayeeTypeConcessionaria, "30048", nil},
+		{"Concessionária by CNPJ", PayeeTypeConcessionaria, "612345678", nil},
+		{"Short bank code", PayeeTypeBank, "41", ErrInvalidChargeRule},
+		{"Unknown segment", PayeeTypeConcessionaria, "80048", ErrInvalidChargeRule},
+		{"CNPJ root too short", PayeeTypeConcessionaria, "61234", ErrInvalidChargeRule},
+		{"Letters", PayeeTypeBank, "ABC", ErrInvalidChargeRule},
+		{"Unknown type", "merchant", "341", ErrInvalidChargeRule},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidatePayee(tt.payeeType, tt.payeeCode); err != tt.expected {
+				t.Errorf("ValidatePayee() error = %v, expected %v", err, tt.expected)
+			}
+		})
+	}
+}
+
+// TestValidateChargeRates tests rule and registry rate validation
+func TestValidateChargeRates(t *testing.T) {
+	if err := ValidateChargeRule(UpsertChargeRuleRequest{FineBps: 200, InterestMonthlyBps: 100, DiscountBps: 500, DiscountDays: 5}); err != nil {
+		t.Errorf("valid rule: error = %v", err)
+	}
+	for _, req := range []UpsertChargeRuleRequest{{FineBps: -1}, {InterestMonthlyBps: 10001}, {DiscountDays: 366}} {
+		if err := ValidateChargeRule(req); err != ErrInvalidChargeRule {
+			t.Errorf("ValidateChargeRule(%+v) error = %v, expected %v", req, err, ErrInvalidChargeRule)
+		}
+	}
+
+	until, err := ValidateRegisterBoletoCharges(RegisterBoletoChargesRequest{DiscountBps: 300, DiscountUntil: "2026-03-05"})
+	if err != nil || until == nil || !until.Equal(date(2026, 3, 5)) {
+		t.Errorf("registry discount: until = %v, error = %v", until, err)
+	}
+	for _, req := range []RegisterBoletoChargesRequest{{DiscountBps: 300}, {DiscountUntil: "05/03/2026"}, {FineBps: 20000}} {
+		if _, err := ValidateRegisterBoletoCharges(req); err != ErrInvalidChargeRule {
+			t.Errorf("ValidateRegisterBoletoCharges(%+v) error = %v, expected %v", req, err, ErrInvalidChargeRule)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package bills
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/lauratech/fin/back/internal/shared/payees"
+)
+
+// Concessionária (arrecadação) barcodes follow the FEBRABAN collection layout.
+//
+// Barcode (44 digits):
+//
+//	1      product ID ("8")
+//	2      segment (see concessionariaSegments)
+//	3      value identifier (see valueIdentifiers)
+//	4      general check digit
+//	5-15   value (11 digits)
+//	16-44  company ID and free field: 4-digit company ID + 25 digits, or
+//	       for segment 6, the 8-digit CNPJ root + 21 digits
+//
+// Line (48 digits): the barcode in 4 blocks of 11 digits, each followed by its
+// check digit. Every check digit uses modulo 10 or modulo 11 as the value
+// identifier says.
+
+const (
+	concessionariaProductID = '8'
+
+	// ValueTypeEffective means the value field is the amount to pay, in cents
+	ValueTypeEffective = "effective"
+	// ValueTypeReference means the value field is a reference (e.g. a quantity of an index)
+	// and the amount to pay must be informed by the payer
+	ValueTypeReference = "reference"
+)
+
+// valueIdentifier is the meaning of barcode position 3
+type valueIdentifier struct {
+	valueType string
+	modulo11  bool // check digits use modulo 11 instead of modulo 10
+}
+
+var valueIdentifiers = map[byte]valueIdentifier{
+	'6': {valueType: ValueTypeEffective},
+	'7': {valueType: ValueTypeReference},
+	'8': {valueType: ValueTypeEffective, modulo11: true},
+	'9': {valueType: ValueTypeReference, modulo11: true},
+}
+
+// concessionariaSegment is the kind of collector of a concessionária barcode
+// (position 2). Its name is the recipient of collectors missing from the payee registry.
+type concessionariaSegment struct {
+	name     string
+	billType string
+}
+
+var concessionariaSegments = map[byte]concessionariaSegment{
+	'1': {name: "Prefeitura", billType: "tax"},
+	'2': {name: "Companhia de Água e Saneamento", billType: "utility"},
+	'3': {name: "Companhia de Energia Elétrica e Gás", billType: "utility"},
+	'4': {name: "Operadora de Telecomunicações", billType: "utility"},
+	'5': {name: "Órgão Governamental", billType: "tax"},
+	'6': {name: "Concessionária de Serviço Público", billType: "other"}, // identified by CNPJ
+	'7': {name: "Órgão de Trânsito", billType: "tax"},                   // traffic fines
+	'9': {name: "Concessionária de Serviço Público", billType: "other"}, // reserved to the bank
+}
+
+// isConcessionaria reports whether a normalized barcode or line is a concessionária one
+func isConcessionaria(digits string) bool {
+	return (len(digits) == 44 || len(digits) == 48) && digits[0] == concessionariaProductID
+}
+
+// validateConcessionaria validates a concessionária barcode (44 digits) or line (48 digits)
+func validateConcessionaria(digits string) (*BarcodeInfo, error) {
+	// 1. Convert the line to the barcode, checking every block
+	barcode := digits
+	if len(digits) == 48 {
+		var err error
+		if barcode, err = concessionariaLineToBarcode(digits); err != nil {
+			return nil, err
+		}
+	}
+	if len(barcode) != 44 || !isDigits(barcode) || barcode[0] != concessionariaProductID {
+		return nil, ErrInvalidBarcode
+	}
+
+	// 2. Segment and value identifier
+	segment, ok := concessionariaSegments[barcode[1]]
+	if !ok {
+		return nil, ErrInvalidBarcode
+	}
+	identifier, ok := valueIdentifiers[barcode[2]]
+	if !ok {
+		return nil, ErrInvalidBarcode
+	}
+
+	// 3. General check digit (position 4, computed over the other 43 digits)
+	if concessionariaCheckDigit(barcode[:3]+barcode[4:], identifier.modulo11) != int(barcode[3]-'0') {
+		return nil, ErrInvalidBarcode
+	}
+
+	// 4. Value (positions 5-15)
+	value, _ := strconv.ParseInt(barcode[4:15], 10, 64)
+	info := &BarcodeInfo{
+		Type:          BarcodeTypeConcessionaria,
+		Barcode:       barcode,
+		ValueType:     identifier.valueType,
+		Segment:       string(barcode[1]),
+		RecipientName: segment.name,
+		BillType:      segment.billType,
+	}
+	if identifier.valueType == ValueTypeEffective {
+		info.AmountCents = value
+	} else {
+		info.ReferenceValue = value
+	}
+
+	// 5. Company ID and free field
+	if barcode[1] == '6' {
+		info.CompanyID = barcode[15:23]
+		info.FreeField = barcode[23:]
+	} else {
+		info.CompanyID = barcode[15:19]
+		info.FreeField = barcode[19:]
+	}
+	if collector, ok := payees.LookupCollector(info.Segment, info.CompanyID); ok {
+		info.RecipientName = collector.Name
+	}
+
+	// 6. Due date: collectors commonly start the free field with YYYYMMDD (otherwise there is none)
+	if dueDate, ok := embeddedDueDate(info.FreeField); ok {
+		info.DueDate = &dueDate
+	}
+
+	return info, nil
+}
+
+// concessionariaLineToBarcode converts a 48-digit line to the 44-digit barcode,
+// validating the check digit of each block
+func concessionariaLineToBarcode(line string) (string, error) {
+	if len(line) != 48 || !isDigits(line) {
+		return "", ErrInvalidBarcode
+	}
+	identifier, ok := valueIdentifiers[line[2]]
+	if !ok {
+		return "", ErrInvalidBarcode
+	}
+
+	barcode := make([]byte, 0, 44)
+	for block := 0; block < 4; block++ {
+		digits := line[block*12 : block*12+11]
+		if concessionariaCheckDigit(digits, identifier.modulo11) != int(line[block*12+11]-'0') {
+			return "", ErrInvalidBarcode
+		}
+		barcode = append(barcode, digits...)
+	}
+	return string(barcode), nil
+}
+
+// concessionariaBarcodeToLine converts a 44-digit barcode to the 48-digit line
+func concessionariaBarcodeToLine(barcode string) string {
+	modulo11 := valueIdentifiers[barcode[2]].modulo11
+	line := make([]byte, 0, 48)
+	for block := 0; block < 4; block++ {
+		digits := barcode[block*11 : block*11+11]
+		line = append(line, digits...)
+		line = append(line, byte('0'+concessionariaCheckDigit(digits, modulo11)))
+	}
+	return string(line)
+}
+
+// concessionariaCheckDigit computes a concessionária check digit
+func concessionariaCheckDigit(digits string, modulo11 bool) int {
+	if modulo11 {
+		return modulo11Arrecadacao(digits)
+	}
+	return modulo10(digits)
+}
+
+// modulo10 computes a check digit with weights 2, 1, 2, 1... from the right,
+// adding the digits of each product
+func modulo10(digits string) int {
+	sum := 0
+	weight := 2
+	for i := len(digits) - 1; i >= 0; i-- {
+		product := int(digits[i]-'0') * weight
+		sum += product/10 + product%10
+		weight = 3 - weight
+	}
+	return (10 - sum%10) % 10
+}
+
+// modulo11Arrecadacao computes a check digit with weights 2 to 9 from the right.
+// Remainders 0 and 1 give 0 (bank boletos use 1 instead).
+func modulo11Arrecadacao(digits string) int {
+	sum := 0
+	weight := 2
+	for i := len(digits) - 1; i >= 0; i-- {
+		sum += int(digits[i]-'0') * weight
+		weight++
+		if weight > 9 {
+			weight = 2
+		}
+	}
+
+	remainder := sum % 11
+	if remainder == 0 || remainder == 1 {
+		return 0
+	}
+	return 11 - remainder
+}
+
+// embeddedDueDate reads a YYYYMMDD due date from the start of a free field
+func embeddedDueDate(freeField string) (time.Time, bool) {
+	if len(freeField) < 8 {
+		return time.Time{}, false
+	}
+	date, err := time.Parse("20060102", freeField[:8])
+	if err != nil || date.Year() < 2000 || date.Year() > 2099 {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// isDigits reports whether a value only holds ASCII digits
+func isDigits(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
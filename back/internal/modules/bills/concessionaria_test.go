@@ -0,0 +1,245 @@
+package bills
+
+import (
+	"strconv"
+	"testing"
+)
+
+// realConcessionariaLines are lines of actual utility and tax bills
+var realConcessionariaLines = []struct {
+	line      string
+	barcode   string
+	segment   string
+	amount    int64
+	companyID string
+}{
+	// Energy, effective value, modulo 10
+	{"836200000005667800481000180975657313001589636081", "83620000000667800481001809756573100158963608", "3", 6678, "0048"},
+	// Telecom, effective value, modulo 10
+	{"846700000017435900240209024050002435842210108119", "84670000001435900240200240500024384221010811", "4", 14359, "0024"},
+	// Government, effective value, modulo 11
+	{"858900004609524601791605607593050865831483000010", "85890000460524601791606075930508683148300001", "5", 4605246, "0179"},
+}
+
+// buildConcessionaria inserts the general check digit into a 43-digit barcode body
+func buildConcessionaria(body string) string {
+	modulo11 := valueIdentifiers[body[2]].modulo11
+	return body[:3] + strconv.Itoa(concessionariaCheckDigit(body, modulo11)) + body[3:]
+}
+
+// TestValidateConcessionariaRealBills tests both forms of real bills
+func TestValidateConcessionariaRealBills(t *testing.T) {
+	for _, tt := range realConcessionariaLines {
+		formatted := tt.line[:11] + "-" + tt.line[11:12] + " " + tt.line[12:23] + "-" + tt.line[23:24] + " " +
+			tt.line[24:35] + "-" + tt.line[35:36] + " " + tt.line[36:47] + "-" + tt.line[47:]
+
+		for _, input := range []string{tt.line, formatted, tt.barcode} {
+			info, err := ValidateBarcode(input)
+			if err != nil {
+				t.Errorf("ValidateBarcode(%q) error = %v", input, err)
+				continue
+			}
+			if info.Type != BarcodeTypeConcessionaria || info.Barcode != tt.barcode {
+				t.Errorf("ValidateBarcode(%q) = %s %q, want concessionária %q", input, info.Type, info.Barcode, tt.barcode)
+			}
+			if info.Segment != tt.segment || info.ValueType != ValueTypeEffective || info.AmountCents != tt.amount || info.CompanyID != tt.companyID {
+				t.Errorf("ValidateBarcode(%q) = segment %q, %s value %d, company %q", input, info.Segment, info.ValueType, info.AmountCents, info.CompanyID)
+			}
+		}
+
+		if got := concessionariaBarcodeToLine(tt.barcode); got != tt.line {
+			t.Errorf("concessionariaBarcodeToLine(%q) = %q, want %q", tt.barcode, got, tt.line)
+		}
+	}
+}
+
+// TestValidateConcessionariaRecipient tests that the collector name comes from the payee registry
+func TestValidateConcessionariaRecipient(t *testing.T) {
+	tests := []struct {
+		name      string
+		line      string
+		recipient string
+	}{
+		{"Registered collector", realConcessionariaLines[0].line, "Light Serviços de Eletricidade"},
+		{"Unregistered collector", realConcessionariaLines[1].line, concessionariaSegments['4'].name},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := ValidateBarcode(tt.line)
+			if err != nil {
+				t.Fatalf("ValidateBarcode() error = %v", err)
+			}
+			if info.RecipientName != tt.recipient {
+				t.Errorf("RecipientName = %q, want %q", info.RecipientName, tt.recipient)
+			}
+		})
+	}
+}
+
+// TestValidateConcessionariaSingleDigitErrors tests that changing any single digit is rejected
+func TestValidateConcessionariaSingleDigitErrors(t *testing.T) {
+	for _, tt := range realConcessionariaLines {
+		for _, valid := range []string{tt.line, tt.barcode} {
+			for i := 0; i < len(valid); i++ {
+				for d := byte('0'); d <= '9'; d++ {
+					if valid[i] == d {
+						continue
+					}
+					mutated := valid[:i] + string(d) + valid[i+1:]
+					if info, err := ValidateBarcode(mutated); err == nil && info.Type == BarcodeTypeConcessionaria {
+						t.Errorf("ValidateBarcode(%q) accepted digit %c at position %d", mutated, d, i+1)
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestValidateConcessionariaLayout tests value identifiers, segments and field extraction
+func TestValidateConcessionariaLayout(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string // barcode without the general check digit
+		wantValueType string
+		wantAmount    int64
+		wantReference int64
+		wantCompanyID string
+		wantFreeField string
+		wantDueDate   string
+	}{
+		{
+			name:          "Effective value, modulo 10",
+			body:          "826" + "00000012345" + "0123" + "2026031500000000000000001",
+			wantValueType: ValueTypeEffective,
+			wantAmount:    12345,
+			wantCompanyID: "0123",
+			wantFreeField: "2026031500000000000000001",
+			wantDueDate:   "2026-03-15",
+		},
+		{
+			name:          "Reference value, modulo 10",
+			body:          "817" + "00000000450" + "9876" + "0000000000000000000004242",
+			wantValueType: ValueTypeReference,
+			wantReference: 450,
+			wantCompanyID: "9876",
+			wantFreeField: "0000000000000000000004242",
+		},
+		{
+			name:          "Effective value, modulo 11",
+			body:          "858" + "00000098765" + "0001" + "2026123100000000000009999",
+			wantValueType: ValueTypeEffective,
+			wantAmount:    98765,
+			wantCompanyID: "0001",
+			wantFreeField: "2026123100000000000009999",
+			wantDueDate:   "2026-12-31",
+		},
+		{
+			name:          "Reference value, modulo 11",
+			body:          "879" + "00000000003" + "5555" + "1234567890123456789012345",
+			wantValueType: ValueTypeReference,
+			wantReference: 3,
+			wantCompanyID: "5555",
+			wantFreeField: "1234567890123456789012345",
+		},
+		{
+			name:          "Segment 6 identified by CNPJ root",
+			body:          "866" + "00000150000" + "12345678" + "202604300000000000001",
+			wantValueType: ValueTypeEffective,
+			wantAmount:    150000,
+			wantCompanyID: "12345678",
+			wantFreeField: "202604300000000000001",
+			wantDueDate:   "2026-04-30",
+		},
+		{
+			name:          "Invalid embedded date means no due date",
+			body:          "836" + "00000001000" + "0042" + "2026023000000000000000000",
+			wantValueType: ValueTypeEffective,
+			wantAmount:    1000,
+			wantCompanyID: "0042",
+			wantFreeField: "2026023000000000000000000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			barcode := buildConcessionaria(tt.body)
+			for _, input := range []string{barcode, concessionariaBarcodeToLine(barcode)} {
+				info, err := ValidateBarcode(input)
+				if err != nil {
+					t.Fatalf("ValidateBarcode(%q) error = %v", input, err)
+				}
+				if info.Barcode != barcode || info.ValueType != tt.wantValueType {
+					t.Errorf("Barcode = %q, ValueType = %q", info.Barcode, info.ValueType)
+				}
+				if info.AmountCents != tt.wantAmount || info.ReferenceValue != tt.wantReference {
+					t.Errorf("AmountCents = %d, ReferenceValue = %d, want %d and %d", info.AmountCents, info.ReferenceValue, tt.wantAmount, tt.wantReference)
+				}
+				if info.CompanyID != tt.wantCompanyID || info.FreeField != tt.wantFreeField {
+					t.Errorf("CompanyID = %q, FreeField = %q", info.CompanyID, info.FreeField)
+				}
+				if tt.wantDueDate == "" && info.DueDate != nil {
+					t.Errorf("DueDate = %v, want no due date", info.DueDate)
+				}
+				if tt.wantDueDate != "" && (info.DueDate == nil || info.DueDate.Format("2006-01-02") != tt.wantDueDate) {
+					t.Errorf("DueDate = %v, want %s", info.DueDate, tt.wantDueDate)
+				}
+			}
+		})
+	}
+}
+
+// TestValidateConcessionariaInvalid tests rejected barcodes and lines
+func TestValidateConcessionariaInvalid(t *testing.T) {
+	valid := buildConcessionaria("826" + "00000012345" + "0123" + "2026031500000000000000001")
+	line := concessionariaBarcodeToLine(valid)
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"Unknown segment 8", buildConcessionaria("886" + valid[4:])},
+		{"Unknown segment 0", buildConcessionaria("806" + valid[4:])},
+		{"Unknown value identifier", buildConcessionaria("825" + valid[4:])},
+		{"Wrong general check digit", valid[:3] + strconv.Itoa((int(valid[3]-'0')+1)%10) + valid[4:]},
+		{"Wrong block check digit", line[:11] + strconv.Itoa((int(line[11]-'0')+1)%10) + line[12:]},
+		{"Swapped blocks", line[12:24] + line[:12] + line[24:]},
+		{"Line with 47 digits", line[:47]},
+		{"Line with 49 digits", line + "0"},
+		{"Letters", valid[:10] + "A" + valid[11:]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ValidateBarcode(tt.input); err != ErrInvalidBarcode {
+				t.Errorf("ValidateBarcode(%q) error = %v, expected %v", tt.input, err, ErrInvalidBarcode)
+			}
+		})
+	}
+}
+
+// TestConcessionariaCheckDigits tests modulo 10 and modulo 11 edge cases
+func TestConcessionariaCheckDigits(t *testing.T) {
+	tests := []struct {
+		name     string
+		digits   string
+		modulo11 bool
+		want     int
+	}{
+		{"Modulo 10", "83620000000", false, 5},
+		{"Modulo 10 product above 9", "00000000009", false, 1},
+		{"Modulo 10 sum multiple of 10", "00000000019", false, 0},
+		{"Modulo 11", "85890000460", true, 9},
+		{"Modulo 11 remainder 0", "00000000000", true, 0},
+		{"Modulo 11 remainder 1", "00000000006", true, 0},
+		{"Modulo 11 remainder 10", "00000000005", true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := concessionariaCheckDigit(tt.digits, tt.modulo11); got != tt.want {
+				t.Errorf("concessionariaCheckDigit(%q) = %d, want %d", tt.digits, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package bills
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+	"github.com/lauratech/fin/back/internal/shared/dda"
+)
+
+// Bill sources
+const (
+	SourceManual = "manual" // Registered by the user
+	SourceDDA    = "dda"    // Discovered through DDA
+)
+
+// DDASyncBatchSize is how many users the DDA sync loads at a time
+const DDASyncBatchSize = 500
+
+// RunDDASync pulls the boletos registered against the CPF of each active user
+// and creates the new ones as pending bills with source "dda". Boletos whose
+// barcode is already registered (by the user or by an earlier sync) are skipped,
+// so the sync is idempotent. Without a DDA provider it does nothing.
+func (s *Service) RunDDASync(ctx context.Context, now time.Time) (*DDASyncResult, error) {
+	result := &DDASyncResult{}
+	if s.dda == nil {
+		return result, nil
+	}
+	var errs []error
+
+	params := db.ListActiveUsersWithCPFParams{BatchSize: DDASyncBatchSize}
+
+	for {
+		// 1. Load the next batch of users after the cursor
+		users, err := s.repo.ListActiveUsersWithCPF(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		// 2. Fetch and store the boletos of each user
+		for i := range users {
+			if err := s.syncUserBoletos(ctx, result, &users[i], now); err != nil {
+				result.Failed++
+				errs = append(errs, fmt.Errorf("user %s: %w", users[i].ID, err))
+			}
+		}
+
+		if len(users) < DDASyncBatchSize {
+			break
+		}
+		params.AfterID = users[len(users)-1].ID
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// syncUserBoletos stores the new DDA boletos of a user
+func (s *Service) syncUserBoletos(ctx context.Context, result *DDASyncResult, user *db.User, now time.Time) error {
+	// 1. Fetch boletos registered against the CPF
+	boletos, err := s.dda.FetchBoletos(ctx, user.Cpf.String)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, boleto := range boletos {
+		// 2. Validate boleto (a provider may list malformed or foreign entries)
+		info, err := ValidateDDABoleto(boleto, user.Cpf.String)
+		if err != nil {
+			result.Invalid++
+			continue
+		}
+
+		// 3. Skip barcodes already registered
+		_, err = s.repo.GetByBarcode(ctx, info.Barcode)
+		if err == nil {
+			result.Duplicates++
+			continue
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			errs = append(errs, fmt.Errorf("boleto %s: %w", boleto.ID, err))
+			continue
+		}
+
+		// 4. Create pending bill
+		if err := s.createDDABill(ctx, user.ID, info, now); err != nil {
+			errs = append(errs, fmt.Errorf("boleto %s: %w", boleto.ID, err))
+			continue
+		}
+		result.Created++
+	}
+
+	return errors.Join(errs...)
+}
+
+// createDDABill creates a pending bill from a validated DDA boleto
+func (s *Service) createDDABill(ctx context.Context, userID uuid.UUID, info *BarcodeInfo, now time.Time) error {
+	rule, err := s.chargeRule(ctx, info.Barcode, info.DueDate)
+	if err != nil {
+		return err
+	}
+	charges := ComputeCharges(info.AmountCents, info.DueDate, rule, BillPaymentFeeCents, now)
+
+	_, err = s.repo.Create(ctx, db.CreateBillParams{
+		UserID:           userID,
+		Type:             info.BillType,
+		Status:           "pending",
+		Barcode:          info.Barcode,
+		AmountCents:      info.AmountCents,
+		FeeCents:         sql.NullInt64{Int64: BillPaymentFeeCents, Valid: true},
+		FinalAmountCents: charges.TotalCents,
+		RecipientName:    info.RecipientName,
+		DueDate:          sqlNullTime(info.DueDate),
+		EffectiveDueDate: sqlNullTime(charges.EffectiveDueDate),
+		FineCents:        charges.FineCents,
+		InterestCents:    charges.InterestCents,
+		DiscountCents:    charges.DiscountCents,
+		ChargesSource:    charges.Source,
+		Source:           SourceDDA,
+	})
+	return err
+}
+
+// ValidateDDABoleto validates a boleto listed by DDA for the payer with the given
+// CPF and returns its parsed barcode, with the beneficiary of the boleto as the recipient
+func ValidateDDABoleto(boleto dda.Boleto, cpf string) (*BarcodeInfo, error) {
+	if boleto.PayerDocument != cpf {
+		return nil, ErrUnauthorized
+	}
+
+	info, err := ValidateBarcode(boleto.Barcode)
+	if err != nil {
+		return nil, err
+	}
+	if info.Type != BarcodeTypeBoleto {
+		return nil, ErrInvalidBarcode
+	}
+	if err := ValidateAmount(info.AmountCents); err != nil {
+		return nil, err
+	}
+
+	if boleto.RecipientName != "" {
+		info.RecipientName = boleto.RecipientName
+	}
+	return info, nil
+}
@@ -0,0 +1,53 @@
+package bills
+
+import (
+	"testing"
+
+	"github.com/lauratech/fin/back/internal/shared/dda"
+)
+
+// TestValidateDDABoleto tests which DDA boletos become bills of the payer
+func TestValidateDDABoleto(t *testing.T) {
+	const cpf = "12345678909"
+	boleto := dda.Boleto{
+		ID:            "dda-1",
+		Barcode:       "34191000000000150001090000000000000000000001", // Itaú, R$ 150.00, no due date
+		PayerDocument: cpf,
+		RecipientName: "Escola Exemplo",
+	}
+
+	info, err := ValidateDDABoleto(boleto, cpf)
+	if err != nil {
+		t.Fatalf("ValidateDDABoleto() error = %v", err)
+	}
+	if info.AmountCents != 15000 || info.RecipientName != "Escola Exemplo" || info.BillType != "bank" || info.DueDate != nil {
+		t.Errorf("ValidateDDABoleto() = %+v, want R$ 150.00 bank bill from Escola Exemplo without due date", info)
+	}
+
+	withoutName := boleto
+	withoutName.RecipientName = ""
+	if info, err := ValidateDDABoleto(withoutName, cpf); err != nil || info.RecipientName != bankName("341") {
+		t.Errorf("without recipient: ValidateDDABoleto() = %+v, %v, want the bank name", info, err)
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*dda.Boleto)
+		wantErr error
+	}{
+		{"Other payer", func(b *dda.Boleto) { b.PayerDocument = "98765432100" }, ErrUnauthorized},
+		{"Bad check digit", func(b *dda.Boleto) { b.Barcode = "34192000000000150001090000000000000000000001" }, ErrInvalidBarcode},
+		{"Concessionária", func(b *dda.Boleto) { b.Barcode = "83620000000667800481001809756573100158963608" }, ErrInvalidBarcode},
+		{"Zero amount", func(b *dda.Boleto) { b.Barcode = "00196000000000000001090000000000000000000001" }, ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boleto
+			tt.modify(&b)
+			if _, err := ValidateDDABoleto(b, cpf); err != tt.wantErr {
+				t.Errorf("ValidateDDABoleto() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
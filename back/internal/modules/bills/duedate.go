@@ -0,0 +1,96 @@
+package bills
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lauratech/fin/back/internal/shared/calendar"
+)
+
+// Boleto due dates are encoded as a 4-digit factor: days since 1997-10-07.
+// The factor reached 9999 on 2025-02-21 and restarted at 1000 on 2025-02-22,
+// so each factor maps to a date every 9000 days. As FEBRABAN prescribes, the
+// date picked is the one in a window from 3000 days before to 5500 days after
+// the current date. Factor 0000 means the boleto has no due date.
+
+var dueDateFactorBase = time.Date(1997, 10, 7, 0, 0, 0, 0, time.UTC)
+
+const (
+	minDueDateFactor        = 1000
+	maxDueDateFactor        = 9999
+	dueDateFactorCycleDays  = maxDueDateFactor - minDueDateFactor + 1
+	dueDateWindowPastDays   = 3000
+	dueDateWindowFutureDays = 5500
+)
+
+// parseDueDateFactor converts a due date factor to the due date closest to now
+// within the FEBRABAN window. Returns nil (and no error) for factor 0000.
+func parseDueDateFactor(factor string, now time.Time) (*time.Time, error) {
+	value, err := strconv.Atoi(factor)
+	if err != nil || len(factor) != 4 {
+		return nil, ErrInvalidBarcode
+	}
+	if value == 0 {
+		return nil, nil
+	}
+	// Factors below 1000 were only used before the first cycle reached 1000 (July 2000)
+	if value < minDueDateFactor {
+		return nil, ErrInvalidBarcode
+	}
+
+	// Move the date of the first cycle forward, one cycle at a time, into the window
+	today := calendar.DateOf(now)
+	earliest := today.AddDate(0, 0, -dueDateWindowPastDays)
+	dueDate := dueDateFactorBase.AddDate(0, 0, value)
+	for dueDate.Before(earliest) {
+		dueDate = dueDate.AddDate(0, 0, dueDateFactorCycleDays)
+	}
+
+	// The window is shorter than a cycle: some factors have no date in it
+	if dueDate.After(today.AddDate(0, 0, dueDateWindowFutureDays)) {
+		return nil, ErrInvalidBarcode
+	}
+
+	return &dueDate, nil
+}
+
+// dueDateFactor converts a due date to its factor, the inverse of
+// parseDueDateFactor. Dates before the first factor 1000 (2000-07-03) have none.
+func dueDateFactor(dueDate time.Time) (string, error) {
+	due := time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(due.Sub(dueDateFactorBase).Hours() / 24)
+	if days < minDueDateFactor {
+		return "", ErrInvalidBarcode
+	}
+	factor := (days-minDueDateFactor)%dueDateFactorCycleDays + minDueDateFactor
+	return fmt.Sprintf("%04d", factor), nil
+}
+
+// DaysOverdue returns how many days a bill is past its due date, on the current
+// date in Brasília. A due date on a weekend or national holiday moves to the next
+// business day; once that day is past, the days count from the printed due date.
+// Bills without due date are never overdue.
+func DaysOverdue(dueDate *time.Time, now time.Time) int {
+	if dueDate == nil {
+		return 0
+	}
+
+	due := time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), 0, 0, 0, 0, time.UTC)
+	today := calendar.DateOf(now)
+	if !today.After(calendar.NextBusinessDay(due)) {
+		return 0
+	}
+	return int(today.Sub(due).Hours() / 24)
+}
+
+// overdueCutoff returns the latest due date that is overdue on the current date
+// in Brasília: bills due on or before it are past their effective due date.
+func overdueCutoff(now time.Time) time.Time {
+	today := calendar.DateOf(now)
+	due := today.AddDate(0, 0, -1)
+	for !today.After(calendar.NextBusinessDay(due)) {
+		due = due.AddDate(0, 0, -1)
+	}
+	return due
+}
@@ -0,0 +1,178 @@
+package bills
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+// TestParseDueDateFactor tests the factor rollover of 2025-02-22 and the FEBRABAN window
+func TestParseDueDateFactor(t *testing.T) {
+	tests := []struct {
+		name   string
+		factor string
+		today  string
+		want   string // empty for no due date
+	}{
+		{"Last factor before rollover", "9999", "2025-02-10", "2025-02-21"},
+		{"First factor after rollover", "1000", "2025-03-01", "2025-02-22"},
+		{"Rollover seen ahead of time", "1000", "2024-01-01", "2025-02-22"},
+		{"Day after rollover", "1001", "2025-02-22", "2025-02-23"},
+		{"Current cycle", "1500", "2026-10-18", "2026-07-07"},
+		{"Previous cycle within the window", "8000", "2026-10-18", "2019-09-02"},
+		{"Previous cycle before rollover", "9000", "2023-06-01", "2022-05-29"},
+		{"Next cycle far ahead", "9000", "2041-01-01", "2047-01-18"},
+		{"No due date", "0000", "2026-10-18", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			today, _ := time.Parse("2006-01-02", tt.today)
+			got, err := parseDueDateFactor(tt.factor, today.Add(12*time.Hour))
+			if err != nil {
+				t.Fatalf("parseDueDateFactor(%q) error = %v", tt.factor, err)
+			}
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("parseDueDateFactor(%q) = %v, want no due date", tt.factor, got)
+				}
+				return
+			}
+			if got == nil || got.Format("2006-01-02") != tt.want {
+				t.Errorf("parseDueDateFactor(%q) on %s = %v, want %s", tt.factor, tt.today, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParseDueDateFactorInvalid tests factors without a valid due date
+func TestParseDueDateFactorInvalid(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	for _, factor := range []string{"0999", "0001", "12a4", "123", ""} {
+		if _, err := parseDueDateFactor(factor, now); err != ErrInvalidBarcode {
+			t.Errorf("parseDueDateFactor(%q) error = %v, expected %v", factor, err, ErrInvalidBarcode)
+		}
+	}
+
+	// 2025-02-21 is more than 5500 days after 2010-01-01 and 2049-10-13 is a cycle later
+	if _, err := parseDueDateFactor("9999", time.Date(2010, 1, 1, 12, 0, 0, 0, time.UTC)); err != ErrInvalidBarcode {
+		t.Errorf("factor outside the window: error = %v, expected %v", err, ErrInvalidBarcode)
+	}
+}
+
+// TestValidateBoletoDueDate tests due dates read from boleto barcodes
+func TestValidateBoletoDueDate(t *testing.T) {
+	// Barcode without check digit: bank, currency, factor, amount and free field
+	build := func(factor string) string {
+		body := "341" + "9" + factor + "0000012345" + "1090000000000000000000000"
+		for digit := 1; digit <= 9; digit++ {
+			barcode := body[:4] + strconv.Itoa(digit) + body[4:]
+			if validateBoletoCheckDigit(barcode) {
+				return barcode
+			}
+		}
+		t.Fatalf("no check digit for %q", body)
+		return ""
+	}
+
+	info, err := ValidateBarcode(build("0000"))
+	if err != nil {
+		t.Fatalf("ValidateBarcode() error = %v", err)
+	}
+	if info.DueDate != nil {
+		t.Errorf("factor 0000: DueDate = %v, want no due date", info.DueDate)
+	}
+
+	if _, err := ValidateBarcode(build("0500")); err != ErrInvalidBarcode {
+		t.Errorf("factor 0500: error = %v, expected %v", err, ErrInvalidBarcode)
+	}
+}
+
+// TestDaysOverdue tests overdue days on the Brasília date
+func TestDaysOverdue(t *testing.T) {
+	due := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		dueDate *time.Time
+		now     time.Time
+		want    int
+	}{
+		{"No due date", nil, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), 0},
+		{"Before due date", &due, time.Date(2026, 3, 9, 15, 0, 0, 0, time.UTC), 0},
+		{"On due date", &due, time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC), 0},
+		{"Due date night in Brasília", &due, time.Date(2026, 3, 11, 2, 30, 0, 0, time.UTC), 0},
+		{"Day after", &due, time.Date(2026, 3, 11, 3, 0, 0, 0, time.UTC), 1},
+		{"Month after", &due, time.Date(2026, 4, 10, 12, 0, 0, 0, time.UTC), 31},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DaysOverdue(tt.dueDate, tt.now); got != tt.want {
+				t.Errorf("DaysOverdue() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestOverdueCutoff tests that the sweep cutoff agrees with DaysOverdue around weekends and holidays
+func TestOverdueCutoff(t *testing.T) {
+	tests := []struct {
+		today string
+		want  string
+	}{
+		{"2026-03-11", "2026-03-10"}, // Wednesday: Tuesday's bills are overdue
+		{"2026-03-16", "2026-03-13"}, // Monday: weekend bills roll over to today
+		{"2026-03-17", "2026-03-16"}, // Tuesday: weekend bills were due on Monday
+		{"2026-02-18", "2026-02-13"}, // Ash Wednesday: weekend and Carnaval bills roll over to today
+		{"2026-02-19", "2026-02-18"}, // Thursday after Carnaval
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.today, func(t *testing.T) {
+			today, _ := time.Parse("2006-01-02", tt.today)
+			now := today.Add(15 * time.Hour)
+			cutoff := overdueCutoff(now)
+			if got := cutoff.Format("2006-01-02"); got != tt.want {
+				t.Fatalf("overdueCutoff(%s) = %s, want %s", tt.today, got, tt.want)
+			}
+
+			for day := cutoff.AddDate(0, 0, -10); !day.After(today); day = day.AddDate(0, 0, 1) {
+				due := day
+				if overdue := DaysOverdue(&due, now) > 0; overdue != !day.After(cutoff) {
+					t.Errorf("due %s: DaysOverdue() > 0 is %v, cutoff %s", day.Format("2006-01-02"), overdue, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// TestDueDateFactor tests that dueDateFactor inverts parseDueDateFactor across the rollover
+func TestDueDateFactor(t *testing.T) {
+	tests := []struct {
+		dueDate string
+		want    string
+	}{
+		{"2000-07-03", "1000"},
+		{"2025-02-21", "9999"},
+		{"2025-02-22", "1000"},
+		{"2026-07-07", "1500"},
+	}
+
+	for _, tt := range tests {
+		dueDate, _ := time.Parse("2006-01-02", tt.dueDate)
+		got, err := dueDateFactor(dueDate)
+		if err != nil || got != tt.want {
+			t.Errorf("dueDateFactor(%s) = %q, %v, want %q", tt.dueDate, got, err, tt.want)
+			continue
+		}
+		parsed, err := parseDueDateFactor(got, dueDate)
+		if err != nil || parsed == nil || !parsed.Equal(dueDate) {
+			t.Errorf("parseDueDateFactor(%q) = %v, %v, want %s", got, parsed, err, tt.dueDate)
+		}
+	}
+
+	if _, err := dueDateFactor(time.Date(2000, 7, 2, 0, 0, 0, 0, time.UTC)); err != ErrInvalidBarcode {
+		t.Errorf("dueDateFactor before factor 1000: error = %v, expected %v", err, ErrInvalidBarcode)
+	}
+}
@@ -32,4 +32,34 @@ var (
 
 	// ErrUnauthorized is returned when user doesn't own the bill
 	ErrUnauthorized = errors.New("unauthorized to access this bill")
+
+	// ErrInvalidChargeRule is returned when charge rates or the payee are invalid
+	ErrInvalidChargeRule = errors.New("invalid bill charge rule")
+
+	// ErrInvalidSchedule is returned when a scheduled payment date is invalid
+	ErrInvalidSchedule = errors.New("invalid bill payment schedule")
+
+	// ErrBillNotScheduled is returned when unscheduling a bill without a pending schedule
+	ErrBillNotScheduled = errors.New("bill payment is not scheduled")
+
+	// ErrInvalidDocument is returned when an uploaded bill is not a readable PDF or image
+	ErrInvalidDocument = errors.New("invalid bill document")
+
+	// ErrBarcodeNotFound is returned when no valid barcode is found in an uploaded bill
+	ErrBarcodeNotFound = errors.New("no barcode found in bill document")
+
+	// ErrIssuanceDisabled is returned when boleto issuance is not configured
+	ErrIssuanceDisabled = errors.New("boleto issuance is disabled")
+
+	// ErrInvalidIssuance is returned when the payer, due date or description of a boleto to issue is invalid
+	ErrInvalidIssuance = errors.New("invalid boleto issuance")
+
+	// ErrIssuedBoletoNotFound is returned when an issued boleto is not found
+	ErrIssuedBoletoNotFound = errors.New("issued boleto not found")
+
+	// ErrBoletoCancelled is returned when paying a boleto its issuer cancelled
+	ErrBoletoCancelled = errors.New("boleto cancelled by its issuer")
+
+	// ErrOwnBoleto is returned when a user registers or pays a boleto they issued
+	ErrOwnBoleto = errors.New("cannot pay own boleto")
 )
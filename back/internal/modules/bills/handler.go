@@ -3,6 +3,8 @@ package bills
 import (
 	"encoding/json"
 	"errors"
+	"io"
+	"mime"
 	"net/http"
 	"strconv"
 
@@ -38,6 +40,41 @@ func (h *Handler) ValidateBarcode(w http.ResponseWriter, r *http.Request) {
 	response.Success(w, http.StatusOK, result, r.Context())
 }
 
+// ParseBill extracts and validates the barcode of a bill PDF or image, sent as
+// the "file" field of a multipart form or as the request body
+// POST /api/bills/parse
+func (h *Handler) ParseBill(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxBillDocumentSize)
+	data, err := readBillDocument(r)
+	if err != nil {
+		h.handleBillError(w, ErrInvalidDocument)
+		return
+	}
+
+	result, err := h.service.ParseBillDocument(r.Context(), data)
+	if err != nil {
+		h.handleBillError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, result, r.Context())
+}
+
+// readBillDocument reads an uploaded bill document
+func readBillDocument(r *http.Request) ([]byte, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		return io.ReadAll(r.Body)
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
 // CreateBill creates a new bill from a barcode
 // POST /api/bills
 func (h *Handler) CreateBill(w http.ResponseWriter, r *http.Request) {
@@ -137,6 +174,24 @@ func (h *Handler) ListBills(w http.ResponseWriter, r *http.Request) {
 	response.Paginated(w, http.StatusOK, bills, pagination, r.Context())
 }
 
+// GetStats returns the user's bill counts and amounts by status
+// GET /api/bills/stats
+func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	stats, err := h.service.GetStats(r.Context(), userID)
+	if err != nil {
+		h.handleBillError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, stats, r.Context())
+}
+
 // PayBill processes a bill payment
 // POST /api/bills/{id}/pay
 func (h *Handler) PayBill(w http.ResponseWriter, r *http.Request) {
@@ -161,6 +216,60 @@ func (h *Handler) PayBill(w http.ResponseWriter, r *http.Request) {
 	response.Success(w, http.StatusOK, bill, r.Context())
 }
 
+// ScheduleBill schedules the payment of a bill on a date
+// PUT /api/bills/{id}/schedule
+func (h *Handler) ScheduleBill(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	billID := chi.URLParam(r, "id")
+	if billID == "" {
+		response.Error(w, http.StatusBadRequest, "VAL_001", "Bill ID is required", nil)
+		return
+	}
+
+	var req ScheduleBillRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "VAL_001", "Invalid request body", nil)
+		return
+	}
+
+	bill, err := h.service.ScheduleBill(r.Context(), userID, billID, req)
+	if err != nil {
+		h.handleBillError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, bill, r.Context())
+}
+
+// UnscheduleBill cancels the scheduled payment of a bill
+// DELETE /api/bills/{id}/schedule
+func (h *Handler) UnscheduleBill(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	billID := chi.URLParam(r, "id")
+	if billID == "" {
+		response.Error(w, http.StatusBadRequest, "VAL_001", "Bill ID is required", nil)
+		return
+	}
+
+	bill, err := h.service.UnscheduleBill(r.Context(), userID, billID)
+	if err != nil {
+		h.handleBillError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, bill, r.Context())
+}
+
 // CancelBill cancels a bill
 // DELETE /api/bills/{id}
 func (h *Handler) CancelBill(w http.ResponseWriter, r *http.Request) {
@@ -185,6 +294,199 @@ func (h *Handler) CancelBill(w http.ResponseWriter, r *http.Request) {
 	response.Success(w, http.StatusNoContent, nil, r.Context())
 }
 
+// IssueBoleto issues a boleto charging a payer
+// POST /api/bills/issued
+func (h *Handler) IssueBoleto(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	var req IssueBoletoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "VAL_001", "Invalid request body", nil)
+		return
+	}
+
+	boleto, err := h.service.IssueBoleto(r.Context(), userID, req)
+	if err != nil {
+		h.handleBillError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusCreated, boleto, r.Context())
+}
+
+// ListIssuedBoletos lists the boletos issued by the user
+// GET /api/bills/issued
+func (h *Handler) ListIssuedBoletos(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	// Parse query parameters
+	params := BillListParams{
+		Page:   1,
+		Limit:  20,
+		Status: r.URL.Query().Get("status"),
+	}
+
+	if page, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && page > 0 {
+		params.Page = page
+	}
+
+	if limit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && limit > 0 && limit <= 100 {
+		params.Limit = limit
+	}
+
+	boletos, total, err := h.service.ListIssuedBoletos(r.Context(), userID, params)
+	if err != nil {
+		h.handleBillError(w, err)
+		return
+	}
+
+	// Calculate pagination
+	totalPages := int(total) / params.Limit
+	if int(total)%params.Limit != 0 {
+		totalPages++
+	}
+
+	pagination := response.Pagination{
+		Page:       params.Page,
+		Limit:      params.Limit,
+		Total:      int(total),
+		TotalPages: totalPages,
+		HasMore:    params.Page < totalPages,
+	}
+
+	response.Paginated(w, http.StatusOK, boletos, pagination, r.Context())
+}
+
+// GetIssuedBoleto retrieves a boleto issued by the user
+// GET /api/bills/issued/{id}
+func (h *Handler) GetIssuedBoleto(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	boleto, err := h.service.GetIssuedBoleto(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		h.handleBillError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, boleto, r.Context())
+}
+
+// GetIssuedBoletoPDF returns the printable PDF of a boleto issued by the user
+// GET /api/bills/issued/{id}/pdf
+func (h *Handler) GetIssuedBoletoPDF(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	boletoID := chi.URLParam(r, "id")
+	data, err := h.service.RenderIssuedBoletoPDF(r.Context(), userID, boletoID)
+	if err != nil {
+		h.handleBillError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", `inline; filename="boleto-`+boletoID+`.pdf"`)
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// CancelIssuedBoleto cancels an open boleto issued by the user
+// DELETE /api/bills/issued/{id}
+func (h *Handler) CancelIssuedBoleto(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	boleto, err := h.service.CancelIssuedBoleto(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		h.handleBillError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, boleto, r.Context())
+}
+
+// ListChargeRules lists the payee charge rules
+// GET /internal/bills/charge-rules
+func (h *Handler) ListChargeRules(w http.ResponseWriter, r *http.Request) {
+	if _, ok := r.Context().Value("internal_service").(string); !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	rules, err := h.service.ListChargeRules(r.Context())
+	if err != nil {
+		h.handleBillError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, rules, r.Context())
+}
+
+// UpsertChargeRule sets the fine, interest and discount rates of a payee
+// PUT /internal/bills/charge-rules/{payee_type}/{payee_code}
+func (h *Handler) UpsertChargeRule(w http.ResponseWriter, r *http.Request) {
+	if _, ok := r.Context().Value("internal_service").(string); !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	var req UpsertChargeRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "VAL_001", "Invalid request body", nil)
+		return
+	}
+
+	rule, err := h.service.UpsertChargeRule(r.Context(), chi.URLParam(r, "payee_type"), chi.URLParam(r, "payee_code"), req)
+	if err != nil {
+		h.handleBillError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, rule, r.Context())
+}
+
+// RegisterBoletoCharges registers the charges of a boleto (CIP/NPC registry stand-in)
+// PUT /internal/bills/registry/{barcode}
+func (h *Handler) RegisterBoletoCharges(w http.ResponseWriter, r *http.Request) {
+	if _, ok := r.Context().Value("internal_service").(string); !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	var req RegisterBoletoChargesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "VAL_001", "Invalid request body", nil)
+		return
+	}
+
+	entry, err := h.service.RegisterBoletoCharges(r.Context(), chi.URLParam(r, "barcode"), req)
+	if err != nil {
+		h.handleBillError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, entry, r.Context())
+}
+
 // handleBillError maps domain errors to HTTP responses
 func (h *Handler) handleBillError(w http.ResponseWriter, err error) {
 	switch {
@@ -206,6 +508,26 @@ func (h *Handler) handleBillError(w http.ResponseWriter, err error) {
 		response.Error(w, http.StatusBadRequest, "BILL_008", "Invalid bill type", nil)
 	case errors.Is(err, ErrUnauthorized):
 		response.Error(w, http.StatusForbidden, "BILL_009", "Unauthorized to access this bill", nil)
+	case errors.Is(err, ErrInvalidChargeRule):
+		response.Error(w, http.StatusBadRequest, "BILL_010", "Invalid charge rule (rates 0-10000 bps, discount days 0-365)", nil)
+	case errors.Is(err, ErrInvalidSchedule):
+		response.Error(w, http.StatusBadRequest, "BILL_011", "Invalid schedule date (from today up to the due date, YYYY-MM-DD)", nil)
+	case errors.Is(err, ErrBillNotScheduled):
+		response.Error(w, http.StatusConflict, "BILL_012", "Bill payment is not scheduled", nil)
+	case errors.Is(err, ErrInvalidDocument):
+		response.Error(w, http.StatusBadRequest, "BILL_013", "Invalid document (PDF, PNG, JPEG or GIF up to 10 MB)", nil)
+	case errors.Is(err, ErrBarcodeNotFound):
+		response.Error(w, http.StatusUnprocessableEntity, "BILL_014", "No valid barcode found in the document", nil)
+	case errors.Is(err, ErrIssuanceDisabled):
+		response.Error(w, http.StatusServiceUnavailable, "BILL_015", "Boleto issuance is not available", nil)
+	case errors.Is(err, ErrInvalidIssuance):
+		response.Error(w, http.StatusBadRequest, "BILL_016", "Invalid boleto (payer name and CPF/CNPJ, due date up to 365 days ahead, description up to 200 characters)", nil)
+	case errors.Is(err, ErrIssuedBoletoNotFound):
+		response.Error(w, http.StatusNotFound, "BILL_017", "Issued boleto not found", nil)
+	case errors.Is(err, ErrOwnBoleto):
+		response.Error(w, http.StatusBadRequest, "BILL_018", "Cannot pay a boleto you issued", nil)
+	case errors.Is(err, ErrBoletoCancelled):
+		response.Error(w, http.StatusConflict, "BILL_019", "Boleto was cancelled by its issuer", nil)
 	default:
 		response.Error(w, http.StatusInternalServerError, "SYS_001", "Internal server error", nil)
 	}
@@ -0,0 +1,309 @@
+package bills
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lauratech/fin/back/internal/modules/notifications"
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+)
+
+// Statuses of an issued boleto
+const (
+	IssuedBoletoStatusOpen      = "open"
+	IssuedBoletoStatusPaid      = "paid"
+	IssuedBoletoStatusCancelled = "cancelled"
+)
+
+const (
+	// MaxIssuanceDays is how far ahead the due date of an issued boleto can be
+	MaxIssuanceDays = 365
+
+	// MaxPayerNameLength is the longest payer name of an issued boleto
+	MaxPayerNameLength = 255
+
+	// MaxBoletoDescriptionLength is the longest description of an issued boleto
+	MaxBoletoDescriptionLength = 200
+)
+
+// IssueBoleto issues a boleto charging a payer. The barcode carries the
+// configured bank code and the nosso número in its free field, and the fine,
+// interest and discount are registered like RegisterBoletoCharges, so bills
+// paying it are charged them. Anyone can pay it; payments by other users of
+// the platform credit the issuer.
+func (s *Service) IssueBoleto(ctx context.Context, userID string, req IssueBoletoRequest) (*IssuedBoleto, error) {
+	// 1. Issuance needs the bank code of the barcode
+	if s.cfg.BoletoBankCode == "" {
+		return nil, ErrIssuanceDisabled
+	}
+
+	// 2. Validate request
+	req.PayerName = strings.TrimSpace(req.PayerName)
+	req.PayerDocument = digitsOnly(req.PayerDocument)
+	req.Description = strings.TrimSpace(req.Description)
+	dueDate, discountUntil, err := ValidateIssueBoleto(req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	userUUID, _ := uuid.Parse(userID)
+	var issued db.IssuedBoleto
+
+	err = s.executeInTransaction(ctx, func(tx *sql.Tx) error {
+		qtx := db.New(tx)
+
+		// 3. Beneficiário: the issuer's name
+		issuer, err := qtx.GetUserByID(ctx, userUUID)
+		if err != nil {
+			return err
+		}
+		issuerName := issuer.FullName.String
+		if issuerName == "" {
+			issuerName = issuer.Email
+		}
+
+		// 4. Reserve the nosso número and build the barcode and linha digitável
+		nossoNumero, err := qtx.NextIssuedBoletoNossoNumero(ctx)
+		if err != nil {
+			return err
+		}
+		barcode, err := BuildBoletoBarcode(s.cfg.BoletoBankCode, &dueDate, req.AmountCents, fmt.Sprintf("%025d", nossoNumero))
+		if err != nil {
+			return err
+		}
+		linha, err := BoletoLinhaDigitavel(barcode)
+		if err != nil {
+			return err
+		}
+
+		// 5. Store the boleto and register its charges
+		var description sql.NullString
+		if req.Description != "" {
+			description = sql.NullString{String: req.Description, Valid: true}
+		}
+		issued, err = qtx.CreateIssuedBoleto(ctx, db.CreateIssuedBoletoParams{
+			IssuerUserID:       userUUID,
+			IssuerName:         issuerName,
+			NossoNumero:        nossoNumero,
+			Barcode:            barcode,
+			LinhaDigitavel:     linha,
+			AmountCents:        req.AmountCents,
+			DueDate:            dueDate,
+			PayerName:          req.PayerName,
+			PayerDocument:      req.PayerDocument,
+			Description:        description,
+			FineBps:            int32(req.FineBps),
+			InterestMonthlyBps: int32(req.InterestMonthlyBps),
+			DiscountBps:        int32(req.DiscountBps),
+			DiscountUntil:      sqlNullTime(discountUntil),
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = qtx.UpsertBillRegistryEntry(ctx, db.UpsertBillRegistryEntryParams{
+			Barcode:            barcode,
+			FineBps:            int32(req.FineBps),
+			InterestMonthlyBps: int32(req.InterestMonthlyBps),
+			DiscountBps:        int32(req.DiscountBps),
+			DiscountUntil:      sqlNullTime(discountUntil),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbIssuedBoletoToIssuedBoleto(&issued), nil
+}
+
+// GetIssuedBoleto retrieves a boleto issued by the user
+func (s *Service) GetIssuedBoleto(ctx context.Context, userID, boletoID string) (*IssuedBoleto, error) {
+	dbBoleto, err := s.getIssuedBoleto(ctx, userID, boletoID)
+	if err != nil {
+		return nil, err
+	}
+	return dbIssuedBoletoToIssuedBoleto(dbBoleto), nil
+}
+
+// ListIssuedBoletos retrieves the boletos issued by a user with pagination
+func (s *Service) ListIssuedBoletos(ctx context.Context, userID string, params BillListParams) ([]*IssuedBoleto, int64, error) {
+	// Set defaults
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	if params.Limit <= 0 || params.Limit > 100 {
+		params.Limit = 20
+	}
+
+	userUUID, _ := uuid.Parse(userID)
+	status := sql.NullString{String: params.Status, Valid: params.Status != ""}
+
+	dbBoletos, err := s.repo.ListIssuedBoletos(ctx, db.ListUserIssuedBoletosParams{
+		IssuerUserID: userUUID,
+		Status:       status,
+		PageLimit:    int32(params.Limit),
+		PageOffset:   int32((params.Page - 1) * params.Limit),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.CountIssuedBoletos(ctx, db.CountUserIssuedBoletosParams{
+		IssuerUserID: userUUID,
+		Status:       status,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	boletos := make([]*IssuedBoleto, len(dbBoletos))
+	for i := range dbBoletos {
+		boletos[i] = dbIssuedBoletoToIssuedBoleto(&dbBoletos[i])
+	}
+	return boletos, total, nil
+}
+
+// CancelIssuedBoleto cancels an open boleto issued by the user. Payments of it
+// are refused from then on, and scheduled ones fail.
+func (s *Service) CancelIssuedBoleto(ctx context.Context, userID, boletoID string) (*IssuedBoleto, error) {
+	// 1. Get boleto and verify ownership and status
+	dbBoleto, err := s.getIssuedBoleto(ctx, userID, boletoID)
+	if err != nil {
+		return nil, err
+	}
+	if err := issuedBoletoStatusError(dbBoleto.Status); err != nil {
+		return nil, err
+	}
+
+	// 2. Cancel (the boleto may have been paid meanwhile)
+	cancelled, err := s.repo.CancelIssuedBoleto(ctx, dbBoleto.ID)
+	if err != nil {
+		return nil, err
+	}
+	if cancelled == nil {
+		return nil, ErrBillAlreadyPaid
+	}
+
+	return dbIssuedBoletoToIssuedBoleto(cancelled), nil
+}
+
+// RenderIssuedBoletoPDF renders the printable PDF of a boleto issued by the user
+func (s *Service) RenderIssuedBoletoPDF(ctx context.Context, userID, boletoID string) ([]byte, error) {
+	dbBoleto, err := s.getIssuedBoleto(ctx, userID, boletoID)
+	if err != nil {
+		return nil, err
+	}
+	return renderBoletoPDF(dbBoleto)
+}
+
+// getIssuedBoleto retrieves an issued boleto and verifies the user issued it
+func (s *Service) getIssuedBoleto(ctx context.Context, userID, boletoID string) (*db.IssuedBoleto, error) {
+	dbBoleto, err := s.repo.GetIssuedBoleto(ctx, boletoID)
+	if err != nil {
+		return nil, err
+	}
+	if dbBoleto.IssuerUserID.String() != userID {
+		return nil, ErrUnauthorized
+	}
+	return dbBoleto, nil
+}
+
+// issuedBoletoStatusError returns the error of paying or cancelling a boleto
+// that is no longer open, or nil
+func issuedBoletoStatusError(status string) error {
+	switch status {
+	case IssuedBoletoStatusPaid:
+		return ErrBillAlreadyPaid
+	case IssuedBoletoStatusCancelled:
+		return ErrBoletoCancelled
+	}
+	return nil
+}
+
+// settleIssuedBoleto credits the issuer of a boleto issued on the platform when
+// a bill paying it is paid, inside the payment transaction. The fee of the
+// payment is not credited. Bills of other boletos are left alone.
+func settleIssuedBoleto(ctx context.Context, qtx *db.Queries, issued *db.IssuedBoleto, paidBill *db.Bill, charges ChargeBreakdown) error {
+	if issued == nil {
+		return nil
+	}
+
+	// 1. Credit the issuer
+	credited := charges.TotalCents - charges.FeeCents
+	err := qtx.UpdateUserBalance(ctx, db.UpdateUserBalanceParams{
+		ID:           issued.IssuerUserID,
+		BalanceCents: sql.NullInt64{Int64: credited, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+
+	// 2. Mark the boleto as paid by the bill
+	_, err = qtx.MarkIssuedBoletoPaid(ctx, db.MarkIssuedBoletoPaidParams{
+		ID:              issued.ID,
+		PaidAmountCents: sql.NullInt64{Int64: credited, Valid: true},
+		PayerUserID:     uuid.NullUUID{UUID: paidBill.UserID, Valid: true},
+		BillID:          uuid.NullUUID{UUID: paidBill.ID, Valid: true},
+	})
+	return err
+}
+
+// lockIssuedBoleto locks the boleto issued on the platform that a bill pays and
+// checks the payer can pay it. Returns nil (and no error) for other boletos.
+func lockIssuedBoleto(ctx context.Context, qtx *db.Queries, dbBill *db.Bill) (*db.IssuedBoleto, error) {
+	issued, err := qtx.GetIssuedBoletoByBarcodeForUpdate(ctx, dbBill.Barcode)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if issued.IssuerUserID == dbBill.UserID {
+		return nil, ErrOwnBoleto
+	}
+	if err := issuedBoletoStatusError(issued.Status); err != nil {
+		return nil, err
+	}
+	return &issued, nil
+}
+
+// notifyBoletoPaid notifies the issuer of a boleto issued on the platform that a bill paid it, once per boleto
+func (s *Service) notifyBoletoPaid(ctx context.Context, paidBill *db.Bill) error {
+	if s.notifications == nil {
+		return nil
+	}
+
+	issued, err := s.repo.GetIssuedBoletoByBarcode(ctx, paidBill.Barcode)
+	if err != nil || issued == nil || issued.BillID.UUID != paidBill.ID {
+		return err
+	}
+
+	_, err = s.notifications.Notify(ctx, notifications.NotifyRequest{
+		UserID: issued.IssuerUserID.String(),
+		Type:   notifications.TypeBoletoPaid,
+		Title:  "Boleto paid",
+		Message: fmt.Sprintf("%s paid your boleto due %s. R$ %d.%02d was credited to your balance.",
+			issued.PayerName, issued.DueDate.Format("02/01/2006"),
+			issued.PaidAmountCents.Int64/100, issued.PaidAmountCents.Int64%100),
+		ResourceType: "issued_boleto",
+		ResourceID:   issued.ID.String(),
+		DedupKey:     fmt.Sprintf("%s:%s", notifications.TypeBoletoPaid, issued.ID),
+	})
+	return err
+}
+
+// digitsOnly removes everything but digits from a value (formatted CPF or CNPJ)
+func digitsOnly(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, value)
+}
@@ -0,0 +1,154 @@
+package bills
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+	"github.com/lauratech/fin/back/internal/shared/pdf"
+)
+
+// TestBuildBoletoBarcode tests that issued barcodes and lines validate back to the issued boleto
+func TestBuildBoletoBarcode(t *testing.T) {
+	dueDate := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 30)
+	freeField := "0000000000000000000012345"
+
+	code, err := BuildBoletoBarcode("341", &dueDate, 150075, freeField)
+	if err != nil {
+		t.Fatalf("BuildBoletoBarcode() error = %v", err)
+	}
+	linha, err := BoletoLinhaDigitavel(code)
+	if err != nil {
+		t.Fatalf("BoletoLinhaDigitavel() error = %v", err)
+	}
+	if len(code) != 44 || len(linha) != 47 {
+		t.Fatalf("barcode %q and line %q, want 44 and 47 digits", code, linha)
+	}
+
+	for _, input := range []string{code, linha, FormatLinhaDigitavel(linha)} {
+		info, err := ValidateBarcode(input)
+		if err != nil {
+			t.Fatalf("ValidateBarcode(%q) error = %v", input, err)
+		}
+		if info.Barcode != code || info.AmountCents != 150075 || info.Type != BarcodeTypeBoleto ||
+			info.DueDate == nil || !info.DueDate.Equal(dueDate) {
+			t.Errorf("ValidateBarcode(%q) = %+v, want the issued boleto", input, info)
+		}
+	}
+	if got, err := convertLinhaDigitavelToBarcode(linha); err != nil || got != code {
+		t.Errorf("convertLinhaDigitavelToBarcode(%q) = %q, %v, want %q", linha, got, err, code)
+	}
+
+	// Without due date
+	code, err = BuildBoletoBarcode("001", nil, 100, freeField)
+	if err != nil || code[5:9] != "0000" {
+		t.Errorf("BuildBoletoBarcode(no due date) = %q, %v, want factor 0000", code, err)
+	}
+
+	for _, tt := range []struct {
+		name      string
+		bankCode  string
+		amount    int64
+		freeField string
+	}{
+		{"Short bank code", "34", 100, freeField},
+		{"Zero amount", "341", 0, freeField},
+		{"Amount too large", "341", 10000000000, freeField},
+		{"Short free field", "341", 100, "123"},
+		{"Non-digit free field", "341", 100, "000000000000000000000000A"},
+	} {
+		if _, err := BuildBoletoBarcode(tt.bankCode, &dueDate, tt.amount, tt.freeField); err != ErrInvalidBarcode {
+			t.Errorf("%s: error = %v, want %v", tt.name, err, ErrInvalidBarcode)
+		}
+	}
+}
+
+// TestFormatLinhaDigitavel tests the printed layout of the line
+func TestFormatLinhaDigitavel(t *testing.T) {
+	got := FormatLinhaDigitavel("34191090080000000000000000000018100000000015000")
+	if want := "34191.09008 00000.000000 00000.000018 1 00000000015000"; got != want {
+		t.Errorf("FormatLinhaDigitavel() = %q, want %q", got, want)
+	}
+}
+
+// TestValidateIssueBoleto tests the payer, due date and charges of boletos to issue
+func TestValidateIssueBoleto(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	valid := IssueBoletoRequest{
+		AmountCents:   15000,
+		DueDate:       "2026-11-18",
+		PayerName:     "Maria Silva",
+		PayerDocument: "52998224725",
+		DiscountBps:   500,
+		DiscountUntil: "2026-11-10",
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*IssueBoletoRequest)
+		wantErr error
+	}{
+		{"Valid CPF payer", func(*IssueBoletoRequest) {}, nil},
+		{"Valid CNPJ payer", func(r *IssueBoletoRequest) { r.PayerDocument = "11222333000181" }, nil},
+		{"Due today", func(r *IssueBoletoRequest) { r.DueDate = "2026-10-18"; r.DiscountUntil = ""; r.DiscountBps = 0 }, nil},
+		{"Invalid CPF", func(r *IssueBoletoRequest) { r.PayerDocument = "52998224724" }, ErrInvalidIssuance},
+		{"Missing payer name", func(r *IssueBoletoRequest) { r.PayerName = " " }, ErrInvalidIssuance},
+		{"Description too long", func(r *IssueBoletoRequest) { r.Description = strings.Repeat("a", 201) }, ErrInvalidIssuance},
+		{"Due date in the past", func(r *IssueBoletoRequest) { r.DueDate = "2026-10-17" }, ErrInvalidIssuance},
+		{"Due date too far", func(r *IssueBoletoRequest) { r.DueDate = "2027-10-19" }, ErrInvalidIssuance},
+		{"Invalid amount", func(r *IssueBoletoRequest) { r.AmountCents = 0 }, ErrInvalidAmount},
+		{"Discount after due date", func(r *IssueBoletoRequest) { r.DiscountUntil = "2026-11-19" }, ErrInvalidChargeRule},
+		{"Fine too high", func(r *IssueBoletoRequest) { r.FineBps = 10001 }, ErrInvalidChargeRule},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid
+			tt.modify(&req)
+			_, _, err := ValidateIssueBoleto(req, now)
+			if err != tt.wantErr {
+				t.Errorf("ValidateIssueBoleto() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestRenderBoletoPDF tests that the printed line of the boleto PDF is read back by ExtractBarcode
+func TestRenderBoletoPDF(t *testing.T) {
+	dueDate := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 10)
+	code, _ := BuildBoletoBarcode("341", &dueDate, 123456, "0000000000000000000000042")
+	linha, _ := BoletoLinhaDigitavel(code)
+
+	data, err := renderBoletoPDF(&db.IssuedBoleto{
+		IssuerName:     "Padaria Pão Quente",
+		NossoNumero:    42,
+		Barcode:        code,
+		LinhaDigitavel: linha,
+		AmountCents:    123456,
+		DueDate:        dueDate,
+		PayerName:      "Maria Silva",
+		PayerDocument:  "52998224725",
+		Description:    sql.NullString{String: "Encomenda de festa", Valid: true},
+		FineBps:        200,
+	})
+	if err != nil {
+		t.Fatalf("renderBoletoPDF() error = %v", err)
+	}
+
+	if got, err := ExtractBarcode(data); err != nil || got != linha {
+		t.Errorf("ExtractBarcode() = %q, %v, want %q", got, err, linha)
+	}
+
+	doc, err := pdf.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	text := doc.Text()
+	for _, want := range []string{"341-7", "R$ 1.234,56", "529.982.247-25", "multa de 2,00%", "Encomenda de festa", "P\xe3o Quente"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("PDF text = %q, expected to contain %q", text, want)
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/lauratech/fin/back/internal/shared/calendar"
 	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
 )
 
@@ -14,12 +16,14 @@ func dbBillToBill(dbBill *db.Bill) *Bill {
 		UserID:           dbBill.UserID.String(),
 		Type:             dbBill.Type,
 		Status:           dbBill.Status,
+		Source:           dbBill.Source,
 		Barcode:          dbBill.Barcode,
 		AmountCents:      dbBill.AmountCents,
 		RecipientName:    dbBill.RecipientName,
-		DueDate:          dbBill.DueDate,
+		DueDate:          nullTimePtr(dbBill.DueDate),
 		FinalAmountCents: dbBill.FinalAmountCents,
 	}
+	bill.DaysOverdue = billDaysOverdue(dbBill.Status, bill.DueDate)
 
 	if dbBill.CreatedAt.Valid {
 		bill.CreatedAt = dbBill.CreatedAt.Time
@@ -29,11 +33,35 @@ func dbBillToBill(dbBill *db.Bill) *Bill {
 		bill.FeeCents = dbBill.FeeCents.Int64
 	}
 
+	bill.Charges = ChargeBreakdown{
+		AmountCents:      dbBill.AmountCents,
+		FineCents:        dbBill.FineCents,
+		InterestCents:    dbBill.InterestCents,
+		DiscountCents:    dbBill.DiscountCents,
+		FeeCents:         bill.FeeCents,
+		TotalCents:       dbBill.FinalAmountCents,
+		DueDate:          bill.DueDate,
+		EffectiveDueDate: nullTimePtr(dbBill.EffectiveDueDate),
+		Source:           dbBill.ChargesSource,
+	}
+	if dbBill.ChargesCalculatedAt.Valid {
+		bill.Charges.DaysOverdue = DaysOverdue(bill.DueDate, dbBill.ChargesCalculatedAt.Time)
+		bill.Charges.CalculatedOn = calendar.DateOf(dbBill.ChargesCalculatedAt.Time).Format("2006-01-02")
+	}
+
 	if dbBill.PaymentDate.Valid {
 		paymentDate := dbBill.PaymentDate.Time
 		bill.PaymentDate = &paymentDate
 	}
 
+	bill.ScheduledFor = nullTimePtr(dbBill.ScheduledFor)
+	bill.ScheduleStatus = dbBill.ScheduleStatus.String
+	bill.PaymentAttempts = int(dbBill.PaymentAttempts)
+	if dbBill.FailureReason.Valid {
+		failureReason := dbBill.FailureReason.String
+		bill.FailureReason = &failureReason
+	}
+
 	return bill
 }
 
@@ -43,11 +71,15 @@ func dbBillToBillSummary(dbBill *db.Bill) *BillSummary {
 		ID:               dbBill.ID.String(),
 		Type:             dbBill.Type,
 		Status:           dbBill.Status,
+		Source:           dbBill.Source,
 		RecipientName:    dbBill.RecipientName,
 		AmountCents:      dbBill.AmountCents,
 		FinalAmountCents: dbBill.FinalAmountCents,
-		DueDate:          dbBill.DueDate,
+		DueDate:          nullTimePtr(dbBill.DueDate),
+		ScheduledFor:     nullTimePtr(dbBill.ScheduledFor),
+		ScheduleStatus:   dbBill.ScheduleStatus.String,
 	}
+	summary.DaysOverdue = billDaysOverdue(dbBill.Status, summary.DueDate)
 
 	if dbBill.CreatedAt.Valid {
 		summary.CreatedAt = dbBill.CreatedAt.Time
@@ -65,6 +97,75 @@ func billsToBillSummaries(dbBills []db.Bill) []*BillSummary {
 	return summaries
 }
 
+// dbChargeRuleToPayeeChargeRule converts a database charge rule to domain charge rule
+func dbChargeRuleToPayeeChargeRule(dbRule *db.BillChargeRule) *PayeeChargeRule {
+	rule := &PayeeChargeRule{
+		PayeeType:          dbRule.PayeeType,
+		PayeeCode:          dbRule.PayeeCode,
+		FineBps:            int64(dbRule.FineBps),
+		InterestMonthlyBps: int64(dbRule.InterestMonthlyBps),
+		DiscountBps:        int64(dbRule.DiscountBps),
+		DiscountDays:       int(dbRule.DiscountDays),
+	}
+	if dbRule.UpdatedAt.Valid {
+		rule.UpdatedAt = dbRule.UpdatedAt.Time
+	}
+	return rule
+}
+
+// dbRegistryEntryToRegisteredBoletoCharges converts a database registry entry to domain registered charges
+func dbRegistryEntryToRegisteredBoletoCharges(entry *db.BillRegistryEntry) *RegisteredBoletoCharges {
+	return &RegisteredBoletoCharges{
+		Barcode:            entry.Barcode,
+		FineBps:            int64(entry.FineBps),
+		InterestMonthlyBps: int64(entry.InterestMonthlyBps),
+		DiscountBps:        int64(entry.DiscountBps),
+		DiscountUntil:      nullTimePtr(entry.DiscountUntil),
+	}
+}
+
+// registryEntryToChargeRule converts registered boleto charges to the rule applied to the bill
+func registryEntryToChargeRule(entry *db.BillRegistryEntry) *ChargeRule {
+	return &ChargeRule{
+		Source:             ChargeSourceRegistry,
+		FineBps:            int64(entry.FineBps),
+		InterestMonthlyBps: int64(entry.InterestMonthlyBps),
+		DiscountBps:        int64(entry.DiscountBps),
+		DiscountUntil:      nullTimePtr(entry.DiscountUntil),
+	}
+}
+
+// payeeRuleToChargeRule converts a payee charge rule to the rule applied to a bill due on dueDate
+func payeeRuleToChargeRule(dbRule *db.BillChargeRule, dueDate *time.Time) *ChargeRule {
+	rule := &ChargeRule{
+		Source:             ChargeSourcePayeeRule,
+		FineBps:            int64(dbRule.FineBps),
+		InterestMonthlyBps: int64(dbRule.InterestMonthlyBps),
+		DiscountBps:        int64(dbRule.DiscountBps),
+	}
+	if dueDate != nil {
+		discountUntil := dueDate.AddDate(0, 0, -int(dbRule.DiscountDays))
+		rule.DiscountUntil = &discountUntil
+	}
+	return rule
+}
+
+// billDaysOverdue returns the days overdue of a bill that is still to be paid
+func billDaysOverdue(status string, dueDate *time.Time) int {
+	if status != "pending" && status != "overdue" {
+		return 0
+	}
+	return DaysOverdue(dueDate, time.Now())
+}
+
+// nullTimePtr converts sql.NullTime to a time pointer
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
 // sqlNullTime converts a time pointer to sql.NullTime
 func sqlNullTime(t *time.Time) sql.NullTime {
 	if t == nil {
@@ -80,3 +181,65 @@ func sqlNullString(s *string) sql.NullString {
 	}
 	return sql.NullString{String: *s, Valid: true}
 }
+
+// updateChargesParams maps a charges breakdown to the bill charges update
+func updateChargesParams(billID uuid.UUID, charges ChargeBreakdown) db.UpdateBillChargesParams {
+	return db.UpdateBillChargesParams{
+		ID:               billID,
+		EffectiveDueDate: sqlNullTime(charges.EffectiveDueDate),
+		FineCents:        charges.FineCents,
+		InterestCents:    charges.InterestCents,
+		DiscountCents:    charges.DiscountCents,
+		FeeCents:         sql.NullInt64{Int64: charges.FeeCents, Valid: true},
+		FinalAmountCents: charges.TotalCents,
+		ChargesSource:    charges.Source,
+	}
+}
+
+// dbStatsToBillStats converts the bill stats of a user
+func dbStatsToBillStats(stats *db.GetUserBillsStatsRow) *BillStats {
+	return &BillStats{
+		TotalBills:         stats.TotalBills,
+		PendingBills:       stats.PendingBills,
+		OverdueBills:       stats.OverdueBills,
+		PaidBills:          stats.PaidBills,
+		PendingAmountCents: stats.PendingAmountCents,
+		OverdueAmountCents: stats.OverdueAmountCents,
+		PaidAmountCents:    stats.PaidAmountCents,
+	}
+}
+
+// dbIssuedBoletoToIssuedBoleto converts a database issued boleto to domain issued boleto
+func dbIssuedBoletoToIssuedBoleto(dbBoleto *db.IssuedBoleto) *IssuedBoleto {
+	boleto := &IssuedBoleto{
+		ID:                 dbBoleto.ID.String(),
+		Status:             dbBoleto.Status,
+		IssuerName:         dbBoleto.IssuerName,
+		NossoNumero:        dbBoleto.NossoNumero,
+		Barcode:            dbBoleto.Barcode,
+		LinhaDigitavel:     FormatLinhaDigitavel(dbBoleto.LinhaDigitavel),
+		AmountCents:        dbBoleto.AmountCents,
+		DueDate:            dbBoleto.DueDate,
+		PayerName:          dbBoleto.PayerName,
+		PayerDocument:      dbBoleto.PayerDocument,
+		FineBps:            int64(dbBoleto.FineBps),
+		InterestMonthlyBps: int64(dbBoleto.InterestMonthlyBps),
+		DiscountBps:        int64(dbBoleto.DiscountBps),
+		DiscountUntil:      nullTimePtr(dbBoleto.DiscountUntil),
+		PaidAt:             nullTimePtr(dbBoleto.PaidAt),
+	}
+
+	if dbBoleto.Description.Valid {
+		description := dbBoleto.Description.String
+		boleto.Description = &description
+	}
+	if dbBoleto.PaidAmountCents.Valid {
+		paidAmount := dbBoleto.PaidAmountCents.Int64
+		boleto.PaidAmountCents = &paidAmount
+	}
+	if dbBoleto.CreatedAt.Valid {
+		boleto.CreatedAt = dbBoleto.CreatedAt.Time
+	}
+
+	return boleto
+}
@@ -0,0 +1,135 @@
+package bills
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lauratech/fin/back/internal/modules/notifications"
+	"github.com/lauratech/fin/back/internal/shared/calendar"
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+)
+
+// OverdueSweepBatchSize is how many bills the sweeper loads at a time
+const OverdueSweepBatchSize = 500
+
+// RunOverdueSweep marks pending bills past their effective due date as overdue
+// and notifies their owners, and recalculates the fine and interest of overdue
+// bills once a day (on the Brasília date).
+//
+// The sweep is idempotent: a bill is notified once, and recalculated bills are
+// skipped until the next day.
+func (s *Service) RunOverdueSweep(ctx context.Context, now time.Time) (*OverdueSweepResult, error) {
+	result := &OverdueSweepResult{}
+	var errs []error
+
+	today := calendar.DateOf(now)
+	params := db.ListOverdueBillsParams{
+		DueOnOrBefore:    overdueCutoff(now),
+		CalculatedBefore: time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, calendar.Location),
+		AfterDueDate:     time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC),
+		BatchSize:        OverdueSweepBatchSize,
+	}
+
+	for {
+		// 1. Load the next batch after the cursor
+		batch, err := s.repo.ListOverdueBills(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		// 2. Mark and recalculate each bill, then notify bills that just became overdue
+		for i := range batch {
+			dbBill := &batch[i]
+			becameOverdue, err := s.markOverdue(ctx, dbBill.ID, now)
+			if err != nil {
+				result.Failed++
+				errs = append(errs, fmt.Errorf("bill %s: %w", dbBill.ID, err))
+				continue
+			}
+			if !becameOverdue {
+				result.Recalculated++
+				continue
+			}
+			result.MarkedOverdue++
+
+			created, err := s.notifyOverdue(ctx, dbBill)
+			if err != nil {
+				result.Failed++
+				errs = append(errs, fmt.Errorf("notify bill %s: %w", dbBill.ID, err))
+			} else if created {
+				result.Notified++
+			}
+		}
+
+		if len(batch) < OverdueSweepBatchSize {
+			break
+		}
+		last := batch[len(batch)-1]
+		params.AfterDueDate = last.DueDate.Time
+		params.AfterID = last.ID
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// markOverdue recalculates the charges of an unpaid bill for now and moves it to
+// overdue. Reports whether the bill was pending (it just became overdue).
+func (s *Service) markOverdue(ctx context.Context, billID uuid.UUID, now time.Time) (bool, error) {
+	becameOverdue := false
+
+	err := s.executeInTransaction(ctx, func(tx *sql.Tx) error {
+		qtx := db.New(tx)
+
+		// 1. Lock bill (it may have been paid or cancelled since it was listed)
+		dbBill, err := qtx.GetBillForUpdate(ctx, billID)
+		if err != nil {
+			return err
+		}
+		if dbBill.Status != "pending" && dbBill.Status != "overdue" {
+			return nil
+		}
+
+		// 2. Recalculate charges (fine and interest grow with the days overdue)
+		dueDate := nullTimePtr(dbBill.DueDate)
+		rule, err := s.chargeRule(ctx, dbBill.Barcode, dueDate)
+		if err != nil {
+			return err
+		}
+		charges := ComputeCharges(dbBill.AmountCents, dueDate, rule, BillPaymentFeeCents, now)
+		if _, err := qtx.UpdateBillCharges(ctx, updateChargesParams(billID, charges)); err != nil {
+			return err
+		}
+
+		// 3. Move to overdue
+		if dbBill.Status == "pending" {
+			if _, err := qtx.UpdateBillStatus(ctx, db.UpdateBillStatusParams{ID: billID, Status: "overdue"}); err != nil {
+				return err
+			}
+			becameOverdue = true
+		}
+		return nil
+	})
+	return becameOverdue, err
+}
+
+// notifyOverdue notifies the owner of a bill that became overdue, once per bill
+func (s *Service) notifyOverdue(ctx context.Context, dbBill *db.Bill) (bool, error) {
+	if s.notifications == nil {
+		return false, nil
+	}
+
+	return s.notifications.Notify(ctx, notifications.NotifyRequest{
+		UserID: dbBill.UserID.String(),
+		Type:   notifications.TypeBillOverdue,
+		Title:  "Bill overdue",
+		Message: fmt.Sprintf("Your bill from %s due on %s is overdue. Late fees may apply.",
+			dbBill.RecipientName, dbBill.DueDate.Time.Format("02/01/2006")),
+		ResourceType: "bill",
+		ResourceID:   dbBill.ID.String(),
+		DedupKey:     fmt.Sprintf("%s:%s", notifications.TypeBillOverdue, dbBill.ID),
+	})
+}
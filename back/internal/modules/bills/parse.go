@@ -0,0 +1,108 @@
+package bills
+
+import (
+	"bytes"
+	"context"
+	"image"
+	_ "image/gif" // Registers image formats for image.Decode
+	_ "image/jpeg"
+	_ "image/png"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/lauratech/fin/back/internal/shared/barcode"
+	"github.com/lauratech/fin/back/internal/shared/pdf"
+)
+
+// MaxBillDocumentSize is the largest PDF or image accepted by the bill parser (10 MB)
+const MaxBillDocumentSize = 10 << 20
+
+// Digit lengths of the codes printed on bills, most specific first
+var printedCodeLengths = []int{47, 48, 44} // Linha digitável, concessionária line, barcode
+
+// digitSpanRegex matches digits with the separators used to print codes
+var digitSpanRegex = regexp.MustCompile(`\d[\d.\-\s]*\d`)
+
+// ParseBillDocument extracts the barcode of a bill from a PDF or image and
+// validates it like ValidateBarcodeInfo
+func (s *Service) ParseBillDocument(ctx context.Context, data []byte) (*ValidateBarcodeResponse, error) {
+	code, err := ExtractBarcode(data)
+	if err != nil {
+		return nil, err
+	}
+	return s.ValidateBarcodeInfo(ctx, code)
+}
+
+// ExtractBarcode finds the barcode of a bill in a PDF or an image (PNG, JPEG,
+// GIF): the linha digitável of the PDF text layer first, then the ITF barcodes
+// of the images. Returns the first code that ValidateBarcode accepts.
+func ExtractBarcode(data []byte) (string, error) {
+	if len(data) == 0 || len(data) > MaxBillDocumentSize {
+		return "", ErrInvalidDocument
+	}
+
+	// 1. Images are scanned directly, once their header shows a size worth decoding
+	if strings.HasPrefix(http.DetectContentType(data), "image/") {
+		config, _, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil || config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > pdf.MaxImagePixels {
+			return "", ErrInvalidDocument
+		}
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return "", ErrInvalidDocument
+		}
+		if code, ok := firstValidCode(barcode.ScanImage(img)); ok {
+			return code, nil
+		}
+		return "", ErrBarcodeNotFound
+	}
+
+	// 2. PDF: text layer, then embedded images
+	doc, err := pdf.Parse(data)
+	if err != nil {
+		return "", ErrInvalidDocument
+	}
+	if code, ok := firstValidCode(printedCodes(doc.Text())); ok {
+		return code, nil
+	}
+	for _, img := range doc.Images() {
+		if code, ok := firstValidCode(barcode.ScanImage(img)); ok {
+			return code, nil
+		}
+	}
+	return "", ErrBarcodeNotFound
+}
+
+// printedCodes returns the digit sequences of text that may be a printed code:
+// every window of a code length within spans of digits and separators
+func printedCodes(text string) []string {
+	var codes []string
+	for _, span := range digitSpanRegex.FindAllString(text, -1) {
+		digits := strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' {
+				return r
+			}
+			return -1
+		}, span)
+
+		for offset := 0; offset+44 <= len(digits); offset++ {
+			for _, length := range printedCodeLengths {
+				if offset+length <= len(digits) {
+					codes = append(codes, digits[offset:offset+length])
+				}
+			}
+		}
+	}
+	return codes
+}
+
+// firstValidCode returns the first code that is a valid barcode or line
+func firstValidCode(codes []string) (string, bool) {
+	for _, code := range codes {
+		if _, err := ValidateBarcode(code); err == nil {
+			return code, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,79 @@
+package bills
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"strings"
+	"testing"
+)
+
+// textPDF builds a one-page PDF showing each line of text
+func textPDF(lines ...string) []byte {
+	var content strings.Builder
+	content.WriteString("BT /F1 10 Tf 50 750 Td\n")
+	for _, line := range lines {
+		fmt.Fprintf(&content, "(%s) Tj 0 -14 Td\n", line)
+	}
+	content.WriteString("ET")
+
+	return []byte(fmt.Sprintf(`%%PDF-1.4
+1 0 obj <</Type /Catalog /Pages 2 0 R>> endobj
+2 0 obj <</Type /Pages /Kids [3 0 R] /Count 1>> endobj
+3 0 obj <</Type /Page /Parent 2 0 R /Contents 4 0 R>> endobj
+4 0 obj <</Length %d>>
+stream
+%s
+endstream
+endobj
+trailer <</Root 1 0 R>>
+%%%%EOF
+`, content.Len(), content.String()))
+}
+
+// pngHeader builds the signature and header chunk of a PNG claiming the given size
+func pngHeader(width, height uint32) []byte {
+	chunk := []byte("IHDR")
+	chunk = binary.BigEndian.AppendUint32(chunk, width)
+	chunk = binary.BigEndian.AppendUint32(chunk, height)
+	chunk = append(chunk, 8, 0, 0, 0, 0) // 8-bit grayscale
+
+	data := []byte("\x89PNG\r\n\x1a\n")
+	data = binary.BigEndian.AppendUint32(data, uint32(len(chunk)-4))
+	data = append(data, chunk...)
+	return binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(chunk))
+}
+
+// TestExtractBarcode tests finding the code of a bill in the text layer of a PDF
+func TestExtractBarcode(t *testing.T) {
+	const linha = "34191090080000000000000000000018100000000015000"
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    string
+		wantErr error
+	}{
+		{"Linha digitável", textPDF("Recibo do pagador", "34191.09008 00000.000000 00000.000018 1 00000000015000"), linha, nil},
+		{"After other numbers", textPDF("Nosso numero 123 34191.09008 00000.000000 00000.000018 1 00000000015000"), linha, nil},
+		{"Split across lines", textPDF("34191.09008 00000.000000", "00000.000018 1 00000000015000"), linha, nil},
+		{"Concessionária line", textPDF("83620000000-5 66780048100-0 18097565731-3 00158963608-1"),
+			"836200000005667800481000180975657313001589636081", nil},
+		{"Barcode digits", textPDF("34191000000000150001090000000000000000000001"), "34191000000000150001090000000000000000000001", nil},
+		{"Wrong check digit", textPDF("34191.09008 00000.000000 00000.000018 2 00000000015000"), "", ErrBarcodeNotFound},
+		{"No code", textPDF("Fatura de cartao", "Total R$ 150,00"), "", ErrBarcodeNotFound},
+		{"Not a document", []byte("hello"), "", ErrInvalidDocument},
+		{"Broken image", []byte("\x89PNG\r\n\x1a\n\x00\x00"), "", ErrInvalidDocument},
+		{"Oversized image", pngHeader(60000, 60000), "", ErrInvalidDocument},
+		{"Empty", nil, "", ErrInvalidDocument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExtractBarcode(tt.data)
+			if got != tt.want || err != tt.wantErr {
+				t.Errorf("ExtractBarcode() = %q, %v, want %q, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package bills
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
@@ -152,3 +154,177 @@ func (r *Repository) Delete(ctx context.Context, id string) error {
 
 	return r.queries.DeleteBill(ctx, billID)
 }
+
+// GetChargeRule retrieves the charge rule of a payee.
+// Returns nil (and no error) if the payee has no rule.
+func (r *Repository) GetChargeRule(ctx context.Context, payeeType, payeeCode string) (*db.BillChargeRule, error) {
+	rule, err := r.queries.GetBillChargeRule(ctx, db.GetBillChargeRuleParams{
+		PayeeType: payeeType,
+		PayeeCode: payeeCode,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// ListChargeRules retrieves every payee charge rule
+func (r *Repository) ListChargeRules(ctx context.Context) ([]db.BillChargeRule, error) {
+	return r.queries.ListBillChargeRules(ctx)
+}
+
+// UpsertChargeRule creates or replaces the charge rule of a payee
+func (r *Repository) UpsertChargeRule(ctx context.Context, params db.UpsertBillChargeRuleParams) (*db.BillChargeRule, error) {
+	rule, err := r.queries.UpsertBillChargeRule(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// GetRegistryEntry retrieves the registered charges of a boleto.
+// Returns nil (and no error) if the boleto is not registered.
+func (r *Repository) GetRegistryEntry(ctx context.Context, barcode string) (*db.BillRegistryEntry, error) {
+	entry, err := r.queries.GetBillRegistryEntry(ctx, barcode)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// UpsertRegistryEntry registers the charges of a boleto
+func (r *Repository) UpsertRegistryEntry(ctx context.Context, params db.UpsertBillRegistryEntryParams) (*db.BillRegistryEntry, error) {
+	entry, err := r.queries.UpsertBillRegistryEntry(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// ListOverdueBills retrieves a batch of bills to mark as overdue or to recalculate
+func (r *Repository) ListOverdueBills(ctx context.Context, params db.ListOverdueBillsParams) ([]db.Bill, error) {
+	return r.queries.ListOverdueBills(ctx, params)
+}
+
+// GetStats retrieves the bill counts and amounts of a user by status
+func (r *Repository) GetStats(ctx context.Context, userID string) (*db.GetUserBillsStatsRow, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := r.queries.GetUserBillsStats(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// SchedulePayment schedules the payment of a bill on a date
+func (r *Repository) SchedulePayment(ctx context.Context, id uuid.UUID, scheduledFor time.Time) (*db.Bill, error) {
+	bill, err := r.queries.ScheduleBillPayment(ctx, db.ScheduleBillPaymentParams{
+		ID:           id,
+		ScheduledFor: scheduledFor,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &bill, nil
+}
+
+// UnschedulePayment cancels the pending scheduled payment of a bill
+func (r *Repository) UnschedulePayment(ctx context.Context, id uuid.UUID) (*db.Bill, error) {
+	bill, err := r.queries.UnscheduleBillPayment(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrBillNotScheduled
+		}
+		return nil, err
+	}
+	return &bill, nil
+}
+
+// ListDueScheduledBills retrieves a batch of bills whose scheduled payment is due
+func (r *Repository) ListDueScheduledBills(ctx context.Context, params db.ListDueScheduledBillsParams) ([]db.Bill, error) {
+	return r.queries.ListDueScheduledBills(ctx, params)
+}
+
+// ListActiveUsersWithCPF lists active users with a CPF after an id cursor, for the DDA sync
+func (r *Repository) ListActiveUsersWithCPF(ctx context.Context, params db.ListActiveUsersWithCPFParams) ([]db.User, error) {
+	return r.queries.ListActiveUsersWithCPF(ctx, params)
+}
+
+// RecordPaymentFailure records a failed scheduled payment attempt, failing the schedule when giveUp
+func (r *Repository) RecordPaymentFailure(ctx context.Context, id uuid.UUID, reason string, giveUp bool) (*db.Bill, error) {
+	bill, err := r.queries.RecordBillPaymentFailure(ctx, db.RecordBillPaymentFailureParams{
+		ID:            id,
+		FailureReason: sql.NullString{String: reason, Valid: true},
+		GiveUp:        giveUp,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrBillNotScheduled
+		}
+		return nil, err
+	}
+	return &bill, nil
+}
+
+// GetIssuedBoleto retrieves an issued boleto by ID
+func (r *Repository) GetIssuedBoleto(ctx context.Context, id string) (*db.IssuedBoleto, error) {
+	boletoID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrIssuedBoletoNotFound
+	}
+
+	boleto, err := r.queries.GetIssuedBoletoByID(ctx, boletoID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrIssuedBoletoNotFound
+		}
+		return nil, err
+	}
+	return &boleto, nil
+}
+
+// GetIssuedBoletoByBarcode retrieves the boleto issued on the platform with a barcode.
+// Returns nil (and no error) if the barcode was not issued here.
+func (r *Repository) GetIssuedBoletoByBarcode(ctx context.Context, barcode string) (*db.IssuedBoleto, error) {
+	boleto, err := r.queries.GetIssuedBoletoByBarcode(ctx, barcode)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &boleto, nil
+}
+
+// ListIssuedBoletos retrieves the boletos issued by a user, optionally by status
+func (r *Repository) ListIssuedBoletos(ctx context.Context, params db.ListUserIssuedBoletosParams) ([]db.IssuedBoleto, error) {
+	return r.queries.ListUserIssuedBoletos(ctx, params)
+}
+
+// CountIssuedBoletos counts the boletos issued by a user, optionally by status
+func (r *Repository) CountIssuedBoletos(ctx context.Context, params db.CountUserIssuedBoletosParams) (int64, error) {
+	return r.queries.CountUserIssuedBoletos(ctx, params)
+}
+
+// CancelIssuedBoleto cancels an open issued boleto.
+// Returns nil (and no error) if the boleto is no longer open.
+func (r *Repository) CancelIssuedBoleto(ctx context.Context, id uuid.UUID) (*db.IssuedBoleto, error) {
+	boleto, err := r.queries.CancelIssuedBoleto(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &boleto, nil
+}
@@ -0,0 +1,224 @@
+package bills
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lauratech/fin/back/internal/modules/notifications"
+	"github.com/lauratech/fin/back/internal/shared/calendar"
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+)
+
+// Schedule statuses of a bill payment
+const (
+	ScheduleStatusScheduled = "scheduled"
+	ScheduleStatusPaid      = "paid"
+	ScheduleStatusFailed    = "failed"
+)
+
+// Failure reasons recorded on bills whose scheduled payment failed
+const (
+	FailureReasonInsufficientBalance = "insufficient_balance" // Retried until the retry deadline
+	FailureReasonBoletoCancelled     = "boleto_cancelled"     // Boleto issued on the platform, cancelled by its issuer
+)
+
+const (
+	// MaxScheduleDays is how far ahead a payment can be scheduled
+	MaxScheduleDays = 365
+
+	// ScheduledPaymentCutoff is the time (Brasília) of the retry deadline day after
+	// which payments without balance are no longer retried
+	ScheduledPaymentCutoff = 20 * time.Hour
+
+	// ScheduledPaymentsBatchSize is how many scheduled bills the worker loads at a time
+	ScheduledPaymentsBatchSize = 500
+)
+
+// scheduleRetryDeadline returns when a scheduled payment without balance stops
+// being retried: the cutoff on the scheduled date, or on the effective due date
+// when it is later (the bill can still be paid without charges until then)
+func scheduleRetryDeadline(scheduledFor time.Time, dueDate *time.Time) time.Time {
+	last := scheduledFor
+	if dueDate != nil {
+		if effective := calendar.NextBusinessDay(*dueDate); effective.After(last) {
+			last = effective
+		}
+	}
+	return time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, calendar.Location).Add(ScheduledPaymentCutoff)
+}
+
+// ScheduleBill schedules the payment of an unpaid bill on a date
+func (s *Service) ScheduleBill(ctx context.Context, userID, billID string, req ScheduleBillRequest) (*Bill, error) {
+	// 1. Get bill and verify ownership and status
+	dbBill, err := s.getUnpaidBill(ctx, userID, billID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Validate date against the due date
+	scheduledFor, err := ValidateSchedule(req.ScheduledFor, nullTimePtr(dbBill.DueDate), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Schedule (a failed schedule starts over)
+	scheduled, err := s.repo.SchedulePayment(ctx, dbBill.ID, scheduledFor)
+	if err != nil {
+		return nil, err
+	}
+
+	return dbBillToBill(scheduled), nil
+}
+
+// UnscheduleBill cancels the pending scheduled payment of a bill
+func (s *Service) UnscheduleBill(ctx context.Context, userID, billID string) (*Bill, error) {
+	dbBill, err := s.getUnpaidBill(ctx, userID, billID)
+	if err != nil {
+		return nil, err
+	}
+
+	unscheduled, err := s.repo.UnschedulePayment(ctx, dbBill.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dbBillToBill(unscheduled), nil
+}
+
+// getUnpaidBill retrieves a bill of the user that can still be paid
+func (s *Service) getUnpaidBill(ctx context.Context, userID, billID string) (*db.Bill, error) {
+	dbBill, err := s.repo.GetByID(ctx, billID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrBillNotFound
+		}
+		return nil, err
+	}
+	if dbBill.UserID.String() != userID {
+		return nil, ErrUnauthorized
+	}
+	if dbBill.Status == "paid" {
+		return nil, ErrBillAlreadyPaid
+	}
+	if dbBill.Status == "cancelled" {
+		return nil, ErrBillCancelled
+	}
+	return dbBill, nil
+}
+
+// RunScheduledPayments pays the bills scheduled up to today (Brasília) with the
+// same checks as PayBill. Payments without balance are retried on every run
+// until the retry deadline, after which the schedule fails with the reason
+// recorded on the bill. Owners are notified of paid and failed schedules.
+func (s *Service) RunScheduledPayments(ctx context.Context, now time.Time) (*ScheduledPaymentsResult, error) {
+	result := &ScheduledPaymentsResult{}
+	var errs []error
+
+	params := db.ListDueScheduledBillsParams{
+		ScheduledOnOrBefore: calendar.DateOf(now),
+		AfterScheduledFor:   time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC),
+		BatchSize:           ScheduledPaymentsBatchSize,
+	}
+
+	for {
+		// 1. Load the next batch after the cursor
+		batch, err := s.repo.ListDueScheduledBills(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		// 2. Pay each bill
+		for i := range batch {
+			if err := s.payScheduledBill(ctx, result, &batch[i], now); err != nil {
+				result.Failed++
+				errs = append(errs, fmt.Errorf("bill %s: %w", batch[i].ID, err))
+			}
+		}
+
+		if len(batch) < ScheduledPaymentsBatchSize {
+			break
+		}
+		last := batch[len(batch)-1]
+		params.AfterScheduledFor = last.ScheduledFor.Time
+		params.AfterID = last.ID
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// payScheduledBill attempts the scheduled payment of a bill and records the outcome
+func (s *Service) payScheduledBill(ctx context.Context, result *ScheduledPaymentsResult, dbBill *db.Bill, now time.Time) error {
+	// 1. Pay (the bill may have been paid or cancelled since it was listed)
+	var paid *db.Bill
+	err := s.executeInTransaction(ctx, func(tx *sql.Tx) error {
+		var err error
+		paid, err = s.payBill(ctx, db.New(tx), dbBill.UserID, dbBill.ID, now)
+		return err
+	})
+	switch {
+	case err == nil:
+		result.Paid++
+		return errors.Join(
+			s.notifySchedule(ctx, paid, notifications.TypeBillScheduledPaid, "Scheduled bill paid",
+				fmt.Sprintf("Your bill from %s was paid as scheduled (R$ %d.%02d).",
+					paid.RecipientName, paid.FinalAmountCents/100, paid.FinalAmountCents%100)),
+			s.notifyBoletoPaid(ctx, paid),
+		)
+	case errors.Is(err, ErrBillAlreadyPaid), errors.Is(err, ErrBillCancelled):
+		return nil
+	case !errors.Is(err, ErrInsufficientBalance) && !errors.Is(err, ErrBoletoCancelled):
+		return err
+	}
+
+	// 2. Record the attempt: without balance the schedule fails past the retry
+	// deadline, and a cancelled boleto can't be paid anymore
+	reason := FailureReasonInsufficientBalance
+	giveUp := !now.Before(scheduleRetryDeadline(dbBill.ScheduledFor.Time, nullTimePtr(dbBill.DueDate)))
+	message := "insufficient balance. Pay it manually to avoid late fees."
+	if errors.Is(err, ErrBoletoCancelled) {
+		reason, giveUp, message = FailureReasonBoletoCancelled, true, "the boleto was cancelled by its issuer."
+	}
+	failed, err := s.repo.RecordPaymentFailure(ctx, dbBill.ID, reason, giveUp)
+	if errors.Is(err, ErrBillNotScheduled) {
+		return nil // Unscheduled meanwhile
+	}
+	if err != nil {
+		return err
+	}
+	if !giveUp {
+		result.Retried++
+		return nil
+	}
+	result.GaveUp++
+	return s.notifySchedule(ctx, failed, notifications.TypeBillScheduledFailed, "Scheduled payment failed",
+		fmt.Sprintf("Your scheduled payment of the bill from %s failed: %s", failed.RecipientName, message))
+}
+
+// notifySchedule notifies the owner of a bill about its scheduled payment, once per schedule date and outcome
+func (s *Service) notifySchedule(ctx context.Context, dbBill *db.Bill, notificationType, title, message string) error {
+	if s.notifications == nil {
+		return nil
+	}
+
+	_, err := s.notifications.Notify(ctx, notifications.NotifyRequest{
+		UserID:       dbBill.UserID.String(),
+		Type:         notificationType,
+		Title:        title,
+		Message:      message,
+		ResourceType: "bill",
+		ResourceID:   dbBill.ID.String(),
+		DedupKey:     fmt.Sprintf("%s:%s:%s", notificationType, dbBill.ID, dbBill.ScheduledFor.Time.Format("2006-01-02")),
+	})
+	return err
+}
+
+// scheduleParams returns the schedule columns of a new bill
+func scheduleParams(scheduledFor *time.Time) (sql.NullTime, sql.NullString) {
+	if scheduledFor == nil {
+		return sql.NullTime{}, sql.NullString{}
+	}
+	return sql.NullTime{Time: *scheduledFor, Valid: true}, sql.NullString{String: ScheduleStatusScheduled, Valid: true}
+}
@@ -0,0 +1,75 @@
+package bills
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lauratech/fin/back/internal/shared/calendar"
+)
+
+// TestValidateSchedule tests scheduled payment dates against today and the due date
+func TestValidateSchedule(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC) // Tuesday
+	dueFriday := date(2026, 3, 20)
+	dueSaturday := date(2026, 3, 21)
+
+	tests := []struct {
+		name    string
+		value   string
+		dueDate *time.Time
+		want    time.Time
+		wantErr error
+	}{
+		{"Today", "2026-03-10", &dueFriday, date(2026, 3, 10), nil},
+		{"On the due date", "2026-03-20", &dueFriday, date(2026, 3, 20), nil},
+		{"Weekend moves to Monday", "2026-03-14", &dueFriday, date(2026, 3, 16), nil},
+		{"Up to the effective due date", "2026-03-23", &dueSaturday, date(2026, 3, 23), nil},
+		{"No due date", "2026-12-01", nil, date(2026, 12, 1), nil},
+		{"Christmas moves to the next business day", "2026-12-25", nil, date(2026, 12, 28), nil},
+		{"Yesterday", "2026-03-09", &dueFriday, time.Time{}, ErrInvalidSchedule},
+		{"After the due date", "2026-03-23", &dueFriday, time.Time{}, ErrInvalidSchedule},
+		{"Weekend after the due date", "2026-03-21", &dueFriday, time.Time{}, ErrInvalidSchedule},
+		{"More than a year ahead", "2027-03-12", nil, time.Time{}, ErrInvalidSchedule},
+		{"Invalid format", "20/03/2026", &dueFriday, time.Time{}, ErrInvalidSchedule},
+		{"Empty", "", nil, time.Time{}, ErrInvalidSchedule},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateSchedule(tt.value, tt.dueDate, now)
+			if err != tt.wantErr || !got.Equal(tt.want) {
+				t.Errorf("ValidateSchedule(%q) = %s, %v, want %s, %v",
+					tt.value, got.Format("2006-01-02"), err, tt.want.Format("2006-01-02"), tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestScheduleRetryDeadline tests until when payments without balance are retried
+func TestScheduleRetryDeadline(t *testing.T) {
+	cutoff := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 20, 0, 0, 0, calendar.Location)
+	}
+	dueFriday := date(2026, 3, 20)
+	dueSaturday := date(2026, 3, 21)
+
+	tests := []struct {
+		name         string
+		scheduledFor time.Time
+		dueDate      *time.Time
+		want         time.Time
+	}{
+		{"Scheduled on the due date", date(2026, 3, 20), &dueFriday, cutoff(2026, 3, 20)},
+		{"Scheduled early retries until the due date", date(2026, 3, 12), &dueFriday, cutoff(2026, 3, 20)},
+		{"Due on a weekend retries until Monday", date(2026, 3, 16), &dueSaturday, cutoff(2026, 3, 23)},
+		{"No due date", date(2026, 3, 12), nil, cutoff(2026, 3, 12)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scheduleRetryDeadline(tt.scheduledFor, tt.dueDate); !got.Equal(tt.want) {
+				t.Errorf("scheduleRetryDeadline() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
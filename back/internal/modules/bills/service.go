@@ -4,26 +4,42 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lauratech/fin/back/internal/modules/notifications"
 	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+	"github.com/lauratech/fin/back/internal/shared/dda"
 )
 
 const (
 	BillPaymentFeeCents = 200 // R$ 2.00 fee per bill payment
 )
 
+// Config holds bill service settings
+type Config struct {
+	// Boleto issuance: COMPE code of the bank in the barcode of issued
+	// boletos (empty disables issuance)
+	BoletoBankCode string
+}
+
 // Service handles business logic for bills
 type Service struct {
-	repo *Repository
-	db   *sql.DB
+	repo          *Repository
+	db            *sql.DB
+	cfg           Config
+	notifications *notifications.Service
+	dda           dda.Provider // nil disables the DDA sync
 }
 
 // NewService creates a new bill service
-func NewService(repo *Repository, database *sql.DB) *Service {
+func NewService(repo *Repository, database *sql.DB, cfg Config, notificationsService *notifications.Service, ddaProvider dda.Provider) *Service {
 	return &Service{
-		repo: repo,
-		db:   database,
+		repo:          repo,
+		db:            database,
+		cfg:           cfg,
+		notifications: notificationsService,
+		dda:           ddaProvider,
 	}
 }
 
@@ -35,13 +51,30 @@ func (s *Service) ValidateBarcodeInfo(ctx context.Context, barcode string) (*Val
 		return nil, err
 	}
 
-	return &ValidateBarcodeResponse{
-		Valid:         true,
-		RecipientName: barcodeInfo.RecipientName,
-		AmountCents:   barcodeInfo.AmountCents,
-		DueDate:       barcodeInfo.DueDate.Format("2006-01-02"),
-		Type:          barcodeInfo.BillType,
-	}, nil
+	result := &ValidateBarcodeResponse{
+		Valid:          true,
+		Barcode:        barcodeInfo.Barcode,
+		RecipientName:  barcodeInfo.RecipientName,
+		AmountCents:    barcodeInfo.AmountCents,
+		Type:           barcodeInfo.BillType,
+		Segment:        barcodeInfo.Segment,
+		ValueType:      barcodeInfo.ValueType,
+		ReferenceValue: barcodeInfo.ReferenceValue,
+		CompanyID:      barcodeInfo.CompanyID,
+	}
+	if barcodeInfo.DueDate != nil {
+		result.DueDate = barcodeInfo.DueDate.Format("2006-01-02")
+	}
+
+	// Amount due if paid today
+	rule, err := s.chargeRule(ctx, barcodeInfo.Barcode, barcodeInfo.DueDate)
+	if err != nil {
+		return nil, err
+	}
+	charges := ComputeCharges(barcodeInfo.AmountCents, barcodeInfo.DueDate, rule, BillPaymentFeeCents, time.Now())
+	result.Charges = &charges
+
+	return result, nil
 }
 
 // CreateBill creates a new bill from a barcode
@@ -57,6 +90,30 @@ func (s *Service) CreateBill(ctx context.Context, userID string, req CreateBillR
 		return nil, err
 	}
 
+	// Reference value barcodes carry no amount: the payer informs it
+	amountCents := barcodeInfo.AmountCents
+	if amountCents == 0 {
+		amountCents = req.AmountCents
+	}
+	if err := ValidateAmount(amountCents); err != nil {
+		return nil, err
+	}
+
+	// Boletos issued on the platform: payable by others while open, to the issuer
+	issued, err := s.repo.GetIssuedBoletoByBarcode(ctx, barcodeInfo.Barcode)
+	if err != nil {
+		return nil, err
+	}
+	if issued != nil {
+		if issued.IssuerUserID.String() == userID {
+			return nil, ErrOwnBoleto
+		}
+		if err := issuedBoletoStatusError(issued.Status); err != nil {
+			return nil, err
+		}
+		barcodeInfo.RecipientName = issued.IssuerName
+	}
+
 	// Check if barcode already exists
 	_, err = s.repo.GetByBarcode(ctx, barcodeInfo.Barcode)
 	if err == nil {
@@ -65,8 +122,23 @@ func (s *Service) CreateBill(ctx context.Context, userID string, req CreateBillR
 		return nil, err
 	}
 
-	// Calculate final amount (amount + fee)
-	finalAmountCents := barcodeInfo.AmountCents + BillPaymentFeeCents
+	// Calculate final amount (amount + charges + fee)
+	rule, err := s.chargeRule(ctx, barcodeInfo.Barcode, barcodeInfo.DueDate)
+	if err != nil {
+		return nil, err
+	}
+	charges := ComputeCharges(amountCents, barcodeInfo.DueDate, rule, BillPaymentFeeCents, time.Now())
+
+	// Optional scheduled payment
+	var scheduledFor *time.Time
+	if req.ScheduledFor != "" {
+		date, err := ValidateSchedule(req.ScheduledFor, barcodeInfo.DueDate, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		scheduledFor = &date
+	}
+	scheduledForParam, scheduleStatus := scheduleParams(scheduledFor)
 
 	// Create bill
 	userUUID, _ := uuid.Parse(userID)
@@ -75,11 +147,19 @@ func (s *Service) CreateBill(ctx context.Context, userID string, req CreateBillR
 		Type:             req.Type,
 		Status:           "pending",
 		Barcode:          barcodeInfo.Barcode,
-		AmountCents:      barcodeInfo.AmountCents,
+		AmountCents:      amountCents,
 		FeeCents:         sql.NullInt64{Int64: BillPaymentFeeCents, Valid: true},
-		FinalAmountCents: finalAmountCents,
+		FinalAmountCents: charges.TotalCents,
 		RecipientName:    barcodeInfo.RecipientName,
-		DueDate:          barcodeInfo.DueDate,
+		DueDate:          sqlNullTime(barcodeInfo.DueDate),
+		EffectiveDueDate: sqlNullTime(charges.EffectiveDueDate),
+		FineCents:        charges.FineCents,
+		InterestCents:    charges.InterestCents,
+		DiscountCents:    charges.DiscountCents,
+		ChargesSource:    charges.Source,
+		ScheduledFor:     scheduledForParam,
+		ScheduleStatus:   scheduleStatus,
+		Source:           SourceManual,
 	})
 	if err != nil {
 		return nil, err
@@ -147,71 +227,103 @@ func (s *Service) PayBill(ctx context.Context, userID, billID string) (*Bill, er
 	var bill *db.Bill
 
 	err := s.executeInTransaction(ctx, func(tx *sql.Tx) error {
-		qtx := db.New(tx)
 		userUUID, _ := uuid.Parse(userID)
 		billUUID, _ := uuid.Parse(billID)
 
-		// 1. Lock and get bill
-		dbBill, err := qtx.GetBillForUpdate(ctx, billUUID)
-		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				return ErrBillNotFound
-			}
-			return err
-		}
+		var err error
+		bill, err = s.payBill(ctx, db.New(tx), userUUID, billUUID, time.Now())
+		return err
+	})
 
-		// 2. Verify ownership
-		if dbBill.UserID != userUUID {
-			return ErrUnauthorized
-		}
+	if err != nil {
+		return nil, err
+	}
 
-		// 3. Check bill status
-		if dbBill.Status == "paid" {
-			return ErrBillAlreadyPaid
-		}
-		if dbBill.Status == "cancelled" {
-			return ErrBillCancelled
-		}
+	// Let the issuer of a boleto issued on the platform know (the payment is
+	// committed: a failed notification does not fail it)
+	_ = s.notifyBoletoPaid(ctx, bill)
 
-		// 4. Lock user record
-		user, err := qtx.GetUserForUpdate(ctx, userUUID)
-		if err != nil {
-			return err
-		}
+	return dbBillToBill(bill), nil
+}
 
-		// 5. Check balance
-		userBalance := int64(0)
-		if user.BalanceCents.Valid {
-			userBalance = user.BalanceCents.Int64
-		}
-		if userBalance < dbBill.FinalAmountCents {
-			return ErrInsufficientBalance
+// payBill pays a bill of a user inside a transaction, for immediate and scheduled payments
+func (s *Service) payBill(ctx context.Context, qtx *db.Queries, userUUID, billUUID uuid.UUID, now time.Time) (*db.Bill, error) {
+	// 1. Lock and get bill
+	dbBill, err := qtx.GetBillForUpdate(ctx, billUUID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrBillNotFound
 		}
+		return nil, err
+	}
 
-		// 6. Debit user balance
-		err = qtx.UpdateUserBalance(ctx, db.UpdateUserBalanceParams{
-			ID:           userUUID,
-			BalanceCents: sql.NullInt64{Int64: -dbBill.FinalAmountCents, Valid: true},
-		})
-		if err != nil {
-			return err
-		}
+	// 2. Verify ownership
+	if dbBill.UserID != userUUID {
+		return nil, ErrUnauthorized
+	}
 
-		// 7. Mark bill as paid
-		paidBill, err := qtx.MarkBillAsPaid(ctx, billUUID)
-		if err != nil {
-			return err
-		}
+	// 3. Check bill status
+	if dbBill.Status == "paid" {
+		return nil, ErrBillAlreadyPaid
+	}
+	if dbBill.Status == "cancelled" {
+		return nil, ErrBillCancelled
+	}
+
+	// 4. Lock the boleto if it was issued on the platform (issuers can't pay their own)
+	issued, err := lockIssuedBoleto(ctx, qtx, &dbBill)
+	if err != nil {
+		return nil, err
+	}
 
-		bill = &paidBill
-		return nil
+	// 5. Recalculate charges for today (fine, interest and discount change with the payment date)
+	dueDate := nullTimePtr(dbBill.DueDate)
+	rule, err := s.chargeRule(ctx, dbBill.Barcode, dueDate)
+	if err != nil {
+		return nil, err
+	}
+	charges := ComputeCharges(dbBill.AmountCents, dueDate, rule, BillPaymentFeeCents, now)
+	dbBill, err = qtx.UpdateBillCharges(ctx, updateChargesParams(billUUID, charges))
+	if err != nil {
+		return nil, err
+	}
+
+	// 6. Lock user record
+	user, err := qtx.GetUserForUpdate(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 7. Check balance
+	userBalance := int64(0)
+	if user.BalanceCents.Valid {
+		userBalance = user.BalanceCents.Int64
+	}
+	if userBalance < dbBill.FinalAmountCents {
+		return nil, ErrInsufficientBalance
+	}
+
+	// 8. Debit user balance
+	err = qtx.UpdateUserBalance(ctx, db.UpdateUserBalanceParams{
+		ID:           userUUID,
+		BalanceCents: sql.NullInt64{Int64: -dbBill.FinalAmountCents, Valid: true},
 	})
+	if err != nil {
+		return nil, err
+	}
 
+	// 9. Mark bill as paid
+	paidBill, err := qtx.MarkBillAsPaid(ctx, billUUID)
 	if err != nil {
 		return nil, err
 	}
 
-	return dbBillToBill(bill), nil
+	// 10. Credit the issuer of a boleto issued on the platform
+	if err := settleIssuedBoleto(ctx, qtx, issued, &paidBill, charges); err != nil {
+		return nil, err
+	}
+
+	return &paidBill, nil
 }
 
 // CancelBill cancels a bill
@@ -239,6 +351,107 @@ func (s *Service) CancelBill(ctx context.Context, userID, billID string) error {
 	return s.repo.Delete(ctx, billID)
 }
 
+// GetStats returns the bill counts and amounts of a user by status
+func (s *Service) GetStats(ctx context.Context, userID string) (*BillStats, error) {
+	stats, err := s.repo.GetStats(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return dbStatsToBillStats(stats), nil
+}
+
+// chargeRule resolves the charge rates of a bill: the boleto registry first, then
+// the rule of the payee. Returns nil (and no error) when neither has the bill.
+func (s *Service) chargeRule(ctx context.Context, barcode string, dueDate *time.Time) (*ChargeRule, error) {
+	entry, err := s.repo.GetRegistryEntry(ctx, barcode)
+	if err != nil {
+		return nil, err
+	}
+	if entry != nil {
+		return registryEntryToChargeRule(entry), nil
+	}
+
+	payeeType, payeeCode, ok := payeeOf(barcode)
+	if !ok {
+		return nil, nil
+	}
+	dbRule, err := s.repo.GetChargeRule(ctx, payeeType, payeeCode)
+	if err != nil || dbRule == nil {
+		return nil, err
+	}
+	return payeeRuleToChargeRule(dbRule, dueDate), nil
+}
+
+// ListChargeRules returns every payee charge rule
+func (s *Service) ListChargeRules(ctx context.Context) ([]*PayeeChargeRule, error) {
+	dbRules, err := s.repo.ListChargeRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]*PayeeChargeRule, len(dbRules))
+	for i := range dbRules {
+		rules[i] = dbChargeRuleToPayeeChargeRule(&dbRules[i])
+	}
+	return rules, nil
+}
+
+// UpsertChargeRule sets the late payment and discount rates of a payee
+func (s *Service) UpsertChargeRule(ctx context.Context, payeeType, payeeCode string, req UpsertChargeRuleRequest) (*PayeeChargeRule, error) {
+	// 1. Validate request
+	if err := ValidatePayee(payeeType, payeeCode); err != nil {
+		return nil, err
+	}
+	if err := ValidateChargeRule(req); err != nil {
+		return nil, err
+	}
+
+	// 2. Store rule (applies to bills paid from now on)
+	dbRule, err := s.repo.UpsertChargeRule(ctx, db.UpsertBillChargeRuleParams{
+		PayeeType:          payeeType,
+		PayeeCode:          payeeCode,
+		FineBps:            int32(req.FineBps),
+		InterestMonthlyBps: int32(req.InterestMonthlyBps),
+		DiscountBps:        int32(req.DiscountBps),
+		DiscountDays:       int32(req.DiscountDays),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbChargeRuleToPayeeChargeRule(dbRule), nil
+}
+
+// RegisterBoletoCharges stores the charges registered for a boleto (CIP/NPC registry stand-in)
+func (s *Service) RegisterBoletoCharges(ctx context.Context, barcode string, req RegisterBoletoChargesRequest) (*RegisteredBoletoCharges, error) {
+	// 1. Validate barcode (the registry only holds boletos) and charges
+	barcodeInfo, err := ValidateBarcode(barcode)
+	if err != nil {
+		return nil, err
+	}
+	if barcodeInfo.Type != BarcodeTypeBoleto {
+		return nil, ErrInvalidBarcode
+	}
+	discountUntil, err := ValidateRegisterBoletoCharges(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Store entry under the normalized barcode
+	entry, err := s.repo.UpsertRegistryEntry(ctx, db.UpsertBillRegistryEntryParams{
+		Barcode:            barcodeInfo.Barcode,
+		FineBps:            int32(req.FineBps),
+		InterestMonthlyBps: int32(req.InterestMonthlyBps),
+		DiscountBps:        int32(req.DiscountBps),
+		DiscountUntil:      sqlNullTime(discountUntil),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbRegistryEntryToRegisteredBoletoCharges(entry), nil
+}
+
 // executeInTransaction executes a function within a database transaction
 func (s *Service) executeInTransaction(ctx context.Context, fn func(*sql.Tx) error) error {
 	tx, err := s.db.BeginTx(ctx, nil)
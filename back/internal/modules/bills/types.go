@@ -4,30 +4,41 @@ import "time"
 
 // Bill represents a bill in the system
 type Bill struct {
-	ID               string     `json:"id"`
-	UserID           string     `json:"user_id"`
-	Type             string     `json:"type"`   // "bank", "utility", "tax", "other"
-	Status           string     `json:"status"` // "pending", "paid", "overdue", "cancelled"
-	Barcode          string     `json:"barcode"`
-	AmountCents      int64      `json:"amount_cents"`
-	FeeCents         int64      `json:"fee_cents"`
-	FinalAmountCents int64      `json:"final_amount_cents"`
-	RecipientName    string     `json:"recipient_name"`
-	DueDate          time.Time  `json:"due_date"`
-	PaymentDate      *time.Time `json:"payment_date,omitempty"`
-	CreatedAt        time.Time  `json:"created_at"`
+	ID               string          `json:"id"`
+	UserID           string          `json:"user_id"`
+	Type             string          `json:"type"`   // "bank", "utility", "tax", "other"
+	Status           string          `json:"status"` // "pending", "paid", "overdue", "cancelled"
+	Source           string          `json:"source"` // "manual" or "dda" (discovered through DDA)
+	Barcode          string          `json:"barcode"`
+	AmountCents      int64           `json:"amount_cents"`
+	FeeCents         int64           `json:"fee_cents"`
+	FinalAmountCents int64           `json:"final_amount_cents"`
+	RecipientName    string          `json:"recipient_name"`
+	DueDate          *time.Time      `json:"due_date"` // null when the bill has no due date
+	DaysOverdue      int             `json:"days_overdue,omitempty"`
+	Charges          ChargeBreakdown `json:"charges"` // final_amount_cents breakdown, recalculated on payment
+	PaymentDate      *time.Time      `json:"payment_date,omitempty"`
+	ScheduledFor     *time.Time      `json:"scheduled_for,omitempty"`    // Date of the scheduled payment
+	ScheduleStatus   string          `json:"schedule_status,omitempty"`  // "scheduled", "paid", "failed"
+	PaymentAttempts  int             `json:"payment_attempts,omitempty"` // Failed scheduled payment attempts
+	FailureReason    *string         `json:"failure_reason,omitempty"`   // Reason of the last failed attempt
+	CreatedAt        time.Time       `json:"created_at"`
 }
 
 // BillSummary represents a bill summary for list responses
 type BillSummary struct {
-	ID               string    `json:"id"`
-	Type             string    `json:"type"`
-	Status           string    `json:"status"`
-	RecipientName    string    `json:"recipient_name"`
-	AmountCents      int64     `json:"amount_cents"`
-	FinalAmountCents int64     `json:"final_amount_cents"`
-	DueDate          time.Time `json:"due_date"`
-	CreatedAt        time.Time `json:"created_at"`
+	ID               string     `json:"id"`
+	Type             string     `json:"type"`
+	Status           string     `json:"status"`
+	Source           string     `json:"source"`
+	RecipientName    string     `json:"recipient_name"`
+	AmountCents      int64      `json:"amount_cents"`
+	FinalAmountCents int64      `json:"final_amount_cents"`
+	DueDate          *time.Time `json:"due_date"`
+	DaysOverdue      int        `json:"days_overdue,omitempty"`
+	ScheduledFor     *time.Time `json:"scheduled_for,omitempty"`
+	ScheduleStatus   string     `json:"schedule_status,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
 }
 
 // ValidateBarcodeRequest represents a barcode validation request
@@ -38,16 +49,33 @@ type ValidateBarcodeRequest struct {
 // ValidateBarcodeResponse represents a barcode validation response
 type ValidateBarcodeResponse struct {
 	Valid         bool   `json:"valid"`
+	Barcode       string `json:"barcode,omitempty"` // Normalized barcode (44 digits)
 	RecipientName string `json:"recipient_name,omitempty"`
 	AmountCents   int64  `json:"amount_cents,omitempty"`
-	DueDate       string `json:"due_date,omitempty"`
+	DueDate       string `json:"due_date,omitempty"` // Empty when the bill has no due date
 	Type          string `json:"type,omitempty"`
+
+	// Concessionária only
+	Segment        string `json:"segment,omitempty"`
+	ValueType      string `json:"value_type,omitempty"`      // "effective" or "reference"
+	ReferenceValue int64  `json:"reference_value,omitempty"` // Set instead of amount_cents for reference values
+	CompanyID      string `json:"company_id,omitempty"`
+
+	// Amount due if paid today
+	Charges *ChargeBreakdown `json:"charges,omitempty"`
 }
 
 // CreateBillRequest represents a request to create/register a bill
 type CreateBillRequest struct {
-	Barcode string `json:"barcode"`
-	Type    string `json:"type"` // "bank", "utility", "tax", "other"
+	Barcode      string `json:"barcode"`
+	Type         string `json:"type"`                    // "bank", "utility", "tax", "other"
+	AmountCents  int64  `json:"amount_cents,omitempty"`  // Required when the barcode has no amount (reference value)
+	ScheduledFor string `json:"scheduled_for,omitempty"` // YYYY-MM-DD: pay automatically on this date
+}
+
+// ScheduleBillRequest represents a request to schedule the payment of a bill
+type ScheduleBillRequest struct {
+	ScheduledFor string `json:"scheduled_for"` // YYYY-MM-DD
 }
 
 // PayBillRequest represents a request to pay a bill
@@ -61,3 +89,110 @@ type BillListParams struct {
 	Limit  int    `json:"limit"`
 	Status string `json:"status,omitempty"` // Filter by status
 }
+
+// BillStats represents the bill counts and amounts of a user by status
+// (amounts are final amounts, as last calculated)
+type BillStats struct {
+	TotalBills         int64 `json:"total_bills"`
+	PendingBills       int64 `json:"pending_bills"`
+	OverdueBills       int64 `json:"overdue_bills"`
+	PaidBills          int64 `json:"paid_bills"`
+	PendingAmountCents int64 `json:"pending_amount_cents"`
+	OverdueAmountCents int64 `json:"overdue_amount_cents"`
+	PaidAmountCents    int64 `json:"paid_amount_cents"`
+}
+
+// OverdueSweepResult summarizes one run of the overdue bill sweeper
+type OverdueSweepResult struct {
+	MarkedOverdue int `json:"marked_overdue"`
+	Recalculated  int `json:"recalculated"`
+	Notified      int `json:"notified"`
+	Failed        int `json:"failed"`
+}
+
+// ScheduledPaymentsResult summarizes one run of the scheduled payments worker
+type ScheduledPaymentsResult struct {
+	Paid    int `json:"paid"`
+	Retried int `json:"retried"` // Insufficient balance, retried on the next run
+	GaveUp  int `json:"gave_up"` // Insufficient balance past the retry deadline: the schedule failed
+	Failed  int `json:"failed"`
+}
+
+// DDASyncResult summarizes one run of the DDA sync
+type DDASyncResult struct {
+	Created    int `json:"created"`
+	Duplicates int `json:"duplicates"` // Barcode already registered
+	Invalid    int `json:"invalid"`    // Not a valid boleto of the user
+	Failed     int `json:"failed"`
+}
+
+// PayeeChargeRule represents the late payment and discount rates of a payee
+type PayeeChargeRule struct {
+	PayeeType          string    `json:"payee_type"` // "bank" or "concessionaria"
+	PayeeCode          string    `json:"payee_code"` // Bank code, or segment + company ID
+	FineBps            int64     `json:"fine_bps"`
+	InterestMonthlyBps int64     `json:"interest_monthly_bps"`
+	DiscountBps        int64     `json:"discount_bps"`
+	DiscountDays       int       `json:"discount_days"` // Discount for payments this many days before the due date (0: up to it)
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// UpsertChargeRuleRequest represents a request to set the charge rule of a payee
+type UpsertChargeRuleRequest struct {
+	FineBps            int64 `json:"fine_bps"`
+	InterestMonthlyBps int64 `json:"interest_monthly_bps"`
+	DiscountBps        int64 `json:"discount_bps"`
+	DiscountDays       int   `json:"discount_days"`
+}
+
+// RegisterBoletoChargesRequest represents the charges of a boleto in the registry
+type RegisterBoletoChargesRequest struct {
+	FineBps            int64  `json:"fine_bps"`
+	InterestMonthlyBps int64  `json:"interest_monthly_bps"`
+	DiscountBps        int64  `json:"discount_bps"`
+	DiscountUntil      string `json:"discount_until,omitempty"` // YYYY-MM-DD
+}
+
+// RegisteredBoletoCharges represents the charges of a boleto in the registry
+type RegisteredBoletoCharges struct {
+	Barcode            string     `json:"barcode"`
+	FineBps            int64      `json:"fine_bps"`
+	InterestMonthlyBps int64      `json:"interest_monthly_bps"`
+	DiscountBps        int64      `json:"discount_bps"`
+	DiscountUntil      *time.Time `json:"discount_until,omitempty"`
+}
+
+// IssueBoletoRequest represents a request to issue a boleto charging a payer
+type IssueBoletoRequest struct {
+	AmountCents        int64  `json:"amount_cents"`
+	DueDate            string `json:"due_date"` // YYYY-MM-DD
+	PayerName          string `json:"payer_name"`
+	PayerDocument      string `json:"payer_document"` // CPF or CNPJ (digits or formatted)
+	Description        string `json:"description,omitempty"`
+	FineBps            int64  `json:"fine_bps,omitempty"`
+	InterestMonthlyBps int64  `json:"interest_monthly_bps,omitempty"`
+	DiscountBps        int64  `json:"discount_bps,omitempty"`
+	DiscountUntil      string `json:"discount_until,omitempty"` // YYYY-MM-DD, up to the due date
+}
+
+// IssuedBoleto represents a boleto issued by a user
+type IssuedBoleto struct {
+	ID                 string     `json:"id"`
+	Status             string     `json:"status"` // "open", "paid", "cancelled"
+	IssuerName         string     `json:"issuer_name"`
+	NossoNumero        int64      `json:"nosso_numero"`
+	Barcode            string     `json:"barcode"`
+	LinhaDigitavel     string     `json:"linha_digitavel"` // Formatted as printed
+	AmountCents        int64      `json:"amount_cents"`
+	DueDate            time.Time  `json:"due_date"`
+	PayerName          string     `json:"payer_name"`
+	PayerDocument      string     `json:"payer_document"`
+	Description        *string    `json:"description,omitempty"`
+	FineBps            int64      `json:"fine_bps"`
+	InterestMonthlyBps int64      `json:"interest_monthly_bps"`
+	DiscountBps        int64      `json:"discount_bps"`
+	DiscountUntil      *time.Time `json:"discount_until,omitempty"`
+	PaidAmountCents    *int64     `json:"paid_amount_cents,omitempty"` // Credited to the issuer
+	PaidAt             *time.Time `json:"paid_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
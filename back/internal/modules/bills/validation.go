@@ -1,6 +1,13 @@
 package bills
 
-import "strings"
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lauratech/fin/back/internal/modules/transfers"
+	"github.com/lauratech/fin/back/internal/shared/calendar"
+)
 
 // ValidateBillType validates bill type
 func ValidateBillType(billType string) error {
@@ -38,3 +45,148 @@ func NormalizeBarcode(barcode string) string {
 	barcode = strings.ReplaceAll(barcode, ".", "")
 	return barcode
 }
+
+// ValidatePayee validates the payee of a charge rule: a 3-digit bank code, or
+// a concessionária segment followed by its company ID (4 digits, or the 8-digit
+// CNPJ root for segment 6)
+func ValidatePayee(payeeType, payeeCode string) error {
+	if !isDigits(payeeCode) {
+		return ErrInvalidChargeRule
+	}
+
+	switch payeeType {
+	case PayeeTypeBank:
+		if len(payeeCode) != 3 {
+			return ErrInvalidChargeRule
+		}
+	case PayeeTypeConcessionaria:
+		if _, ok := concessionariaSegments[payeeCode[0]]; !ok {
+			return ErrInvalidChargeRule
+		}
+		length := 5
+		if payeeCode[0] == '6' {
+			length = 9
+		}
+		if len(payeeCode) != length {
+			return ErrInvalidChargeRule
+		}
+	default:
+		return ErrInvalidChargeRule
+	}
+	return nil
+}
+
+// ValidateChargeRule validates a payee charge rule
+func ValidateChargeRule(req UpsertChargeRuleRequest) error {
+	if err := validateChargeRates(req.FineBps, req.InterestMonthlyBps, req.DiscountBps); err != nil {
+		return err
+	}
+	if req.DiscountDays < 0 || req.DiscountDays > 365 {
+		return ErrInvalidChargeRule
+	}
+	return nil
+}
+
+// ValidateRegisterBoletoCharges validates registered boleto charges and returns the discount deadline
+func ValidateRegisterBoletoCharges(req RegisterBoletoChargesRequest) (*time.Time, error) {
+	if err := validateChargeRates(req.FineBps, req.InterestMonthlyBps, req.DiscountBps); err != nil {
+		return nil, err
+	}
+	if req.DiscountUntil == "" {
+		if req.DiscountBps > 0 {
+			return nil, ErrInvalidChargeRule
+		}
+		return nil, nil
+	}
+
+	discountUntil, err := time.Parse("2006-01-02", req.DiscountUntil)
+	if err != nil {
+		return nil, ErrInvalidChargeRule
+	}
+	return &discountUntil, nil
+}
+
+// validateChargeRates validates fine, interest and discount rates (basis points)
+func validateChargeRates(rates ...int64) error {
+	for _, rate := range rates {
+		if rate < 0 || rate > maxRateBps {
+			return ErrInvalidChargeRule
+		}
+	}
+	return nil
+}
+
+// ValidateSchedule validates the date of a scheduled payment (YYYY-MM-DD) and
+// returns it, moved to the next business day. The date must be from today up to
+// the effective due date of the bill, at most MaxScheduleDays ahead.
+func ValidateSchedule(value string, dueDate *time.Time, now time.Time) (time.Time, error) {
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, ErrInvalidSchedule
+	}
+	date = calendar.NextBusinessDay(date)
+
+	today := calendar.DateOf(now)
+	if date.Before(today) || date.After(today.AddDate(0, 0, MaxScheduleDays)) {
+		return time.Time{}, ErrInvalidSchedule
+	}
+	if dueDate != nil && date.After(calendar.NextBusinessDay(*dueDate)) {
+		return time.Time{}, ErrInvalidSchedule
+	}
+	return date, nil
+}
+
+// ValidateIssueBoleto validates a boleto to issue, with its payer document
+// already normalized to digits, and returns its due date and discount deadline.
+// The due date must be from today up to MaxIssuanceDays ahead and the discount
+// deadline up to the due date.
+func ValidateIssueBoleto(req IssueBoletoRequest, now time.Time) (time.Time, *time.Time, error) {
+	if err := ValidateAmount(req.AmountCents); err != nil {
+		return time.Time{}, nil, err
+	}
+
+	// Payer and description
+	name := strings.TrimSpace(req.PayerName)
+	if name == "" || utf8.RuneCountInString(name) > MaxPayerNameLength ||
+		utf8.RuneCountInString(req.Description) > MaxBoletoDescriptionLength {
+		return time.Time{}, nil, ErrInvalidIssuance
+	}
+	var documentErr error
+	switch len(req.PayerDocument) {
+	case 11:
+		documentErr = transfers.ValidateCPF(req.PayerDocument)
+	case 14:
+		documentErr = transfers.ValidateCNPJ(req.PayerDocument)
+	default:
+		documentErr = ErrInvalidIssuance
+	}
+	if documentErr != nil {
+		return time.Time{}, nil, ErrInvalidIssuance
+	}
+
+	// Due date
+	dueDate, err := time.Parse("2006-01-02", req.DueDate)
+	if err != nil {
+		return time.Time{}, nil, ErrInvalidIssuance
+	}
+	today := calendar.DateOf(now)
+	if dueDate.Before(today) || dueDate.After(today.AddDate(0, 0, MaxIssuanceDays)) {
+		return time.Time{}, nil, ErrInvalidIssuance
+	}
+
+	// Charges, registered like RegisterBoletoCharges
+	discountUntil, err := ValidateRegisterBoletoCharges(RegisterBoletoChargesRequest{
+		FineBps:            req.FineBps,
+		InterestMonthlyBps: req.InterestMonthlyBps,
+		DiscountBps:        req.DiscountBps,
+		DiscountUntil:      req.DiscountUntil,
+	})
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	if discountUntil != nil && discountUntil.After(dueDate) {
+		return time.Time{}, nil, ErrInvalidChargeRule
+	}
+
+	return dueDate, discountUntil, nil
+}
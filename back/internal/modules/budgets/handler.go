@@ -209,6 +209,8 @@ func (h *Handler) handleBudgetError(w http.ResponseWriter, err error) {
 		response.Error(w, http.StatusConflict, "BUDGET_006", "Budget already exists for this category and period", nil)
 	case errors.Is(err, ErrUnauthorized):
 		response.Error(w, http.StatusForbidden, "BUDGET_007", "Unauthorized to access this budget", nil)
+	case errors.Is(err, ErrInvalidDateRange):
+		response.Error(w, http.StatusBadRequest, "BUDGET_008", "Invalid date range (use YYYY-MM-DD)", nil)
 	default:
 		response.Error(w, http.StatusInternalServerError, "SYS_001", "Internal server error", nil)
 	}
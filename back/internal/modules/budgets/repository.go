@@ -3,6 +3,7 @@ package budgets
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
@@ -169,3 +170,18 @@ func (r *Repository) ResetSpent(ctx context.Context, userID string) error {
 
 	return r.queries.ResetBudgetSpent(ctx, userUUID)
 }
+
+// GetCardSpendingByCategory aggregates card spending by merchant_category in a date range.
+// A user's merchant category override replaces the category of that merchant's transactions.
+func (r *Repository) GetCardSpendingByCategory(ctx context.Context, userID string, startDate, endDate time.Time) ([]db.GetCardTransactionsByCategoryRow, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queries.GetCardTransactionsByCategory(ctx, db.GetCardTransactionsByCategoryParams{
+		UserID:    userUUID,
+		StartDate: startDate,
+		EndDate:   endDate,
+	})
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"sort"
 
 	"github.com/google/uuid"
 	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+	"github.com/lauratech/fin/back/internal/shared/mcc"
 )
 
 // Service handles business logic for budgets
@@ -276,19 +278,63 @@ func (s *Service) GetBudgetSummary(ctx context.Context, userID string) (*BudgetA
 
 // GetCategorySpending returns spending breakdown by category from card transactions
 func (s *Service) GetCategorySpending(ctx context.Context, userID string, startDate, endDate string) (map[string]interface{}, error) {
-	// For now, return budget-based analytics
-	// TODO: Integrate with card_transactions table for actual spending data
-	summary, err := s.GetBudgetSummary(ctx, userID)
+	// 1. Resolve period (defaults to current month)
+	start, end, err := ParseDateRange(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Aggregate card spending by merchant_category (user merchant overrides applied)
+	rows, err := s.repo.GetCardSpendingByCategory(ctx, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Normalize categories through the MCC mapping (raw MCCs and legacy values)
+	totals := make(map[string]int64)
+	totalSpentCents := int64(0)
+	for _, row := range rows {
+		category := mcc.Categorize(row.MerchantCategory)
+		totals[category] += row.TotalAmountCents
+		totalSpentCents += row.TotalAmountCents
+	}
+
+	// 4. Attach budget limits for matching categories
+	dbBudgets, err := s.repo.List(ctx, userID, 100, 0)
 	if err != nil {
 		return nil, err
 	}
+	budgetByCategory := make(map[string]int64)
+	for _, dbBudget := range dbBudgets {
+		budgetByCategory[dbBudget.Category] += dbBudget.LimitCents
+	}
+
+	categories := make([]*CategorySpending, 0, len(totals))
+	for category, total := range totals {
+		percentage := 0.0
+		if totalSpentCents > 0 {
+			percentage = (float64(total) / float64(totalSpentCents)) * 100
+		}
+
+		categories = append(categories, &CategorySpending{
+			Category:    category,
+			TotalCents:  total,
+			Percentage:  percentage,
+			BudgetCents: budgetByCategory[category],
+		})
+	}
+
+	// Largest spending first
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i].TotalCents > categories[j].TotalCents
+	})
 
 	return map[string]interface{}{
-		"categories":        summary.CategoryBreakdown,
-		"total_spent_cents": summary.TotalSpentCents,
+		"categories":        categories,
+		"total_spent_cents": totalSpentCents,
 		"period": map[string]string{
-			"start_date": startDate,
-			"end_date":   endDate,
+			"start_date": start.Format("2006-01-02"),
+			"end_date":   end.AddDate(0, 0, -1).Format("2006-01-02"),
 		},
 	}, nil
 }
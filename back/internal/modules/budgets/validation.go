@@ -2,21 +2,9 @@ package budgets
 
 import (
 	"time"
-)
 
-// ValidCategory represents valid budget categories
-var ValidCategories = map[string]bool{
-	"groceries":      true,
-	"dining":         true,
-	"transportation": true,
-	"entertainment":  true,
-	"shopping":       true,
-	"bills":          true,
-	"health":         true,
-	"education":      true,
-	"travel":         true,
-	"other":          true,
-}
+	"github.com/lauratech/fin/back/internal/shared/mcc"
+)
 
 // ValidPeriods represents valid budget periods
 var ValidPeriods = map[string]bool{
@@ -26,8 +14,9 @@ var ValidPeriods = map[string]bool{
 }
 
 // ValidateCategory validates budget category
+// Budgets share the category list of the MCC mapping so card spending can be matched
 func ValidateCategory(category string) error {
-	if !ValidCategories[category] {
+	if !mcc.IsCategory(category) {
 		return ErrInvalidCategory
 	}
 	return nil
@@ -63,6 +52,36 @@ func ValidateThreshold(threshold int16) error {
 	return nil
 }
 
+// ParseDateRange parses an optional YYYY-MM-DD range (inclusive)
+// Defaults to the current month when both dates are empty
+func ParseDateRange(startDate, endDate string) (time.Time, time.Time, error) {
+	if startDate == "" && endDate == "" {
+		return CalculateDateRange("monthly")
+	}
+
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidDateRange
+	}
+
+	// Without an end date, include today
+	now := time.Now()
+	end := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	if endDate != "" {
+		parsed, err := time.Parse("2006-01-02", endDate)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidDateRange
+		}
+		end = parsed.AddDate(0, 0, 1) // Include the whole end day
+	}
+
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, ErrInvalidDateRange
+	}
+
+	return start, end, nil
+}
+
 // CalculateDateRange calculates start and end dates for a period
 func CalculateDateRange(period string) (time.Time, time.Time, error) {
 	now := time.Now()
@@ -0,0 +1,58 @@
+package budgets
+
+import "testing"
+
+// TestValidateCategory tests budget categories against the shared MCC categories
+func TestValidateCategory(t *testing.T) {
+	tests := []struct {
+		category    string
+		expectError bool
+	}{
+		{"groceries", false},
+		{"dining", false},
+		{"travel", false},
+		{"gambling", false},
+		{"other", false},
+		{"food", true},
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.category, func(t *testing.T) {
+			err := ValidateCategory(tt.category)
+			if (err != nil) != tt.expectError {
+				t.Errorf("ValidateCategory(%s) error = %v, expectError %v", tt.category, err, tt.expectError)
+			}
+		})
+	}
+}
+
+// TestParseDateRange tests analytics date range parsing
+func TestParseDateRange(t *testing.T) {
+	tests := []struct {
+		name        string
+		start       string
+		end         string
+		expectError bool
+	}{
+		{"Default current month", "", "", false},
+		{"Explicit range", "2025-01-01", "2025-01-31", false},
+		{"Single day", "2025-01-15", "2025-01-15", false},
+		{"Start only", "2025-01-01", "", false},
+		{"End before start", "2025-02-01", "2025-01-01", true},
+		{"Invalid format", "01/01/2025", "", true},
+		{"End only", "", "2025-01-31", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := ParseDateRange(tt.start, tt.end)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("ParseDateRange(%q, %q) error = %v, expectError %v", tt.start, tt.end, err, tt.expectError)
+			}
+			if err == nil && !start.Before(end) {
+				t.Errorf("expected start %v before end %v", start, end)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package cards
+
+import (
+	"strings"
+
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+)
+
+// Authorization channels (entry modes)
+const (
+	ChannelEcommerce   = "ecommerce"   // Card-not-present
+	ChannelPOSChip     = "pos_chip"    // EMV chip + PIN
+	ChannelContactless = "contactless" // NFC
+	ChannelMagstripe   = "magstripe"   // Magnetic stripe fallback
+	ChannelATM         = "atm"         // Cash withdrawal
+)
+
+// HomeCountry is the issuer country; any other merchant country is international
+const HomeCountry = "BR"
+
+// validChannels lists accepted authorization channels
+var validChannels = map[string]bool{
+	ChannelEcommerce:   true,
+	ChannelPOSChip:     true,
+	ChannelContactless: true,
+	ChannelMagstripe:   true,
+	ChannelATM:         true,
+}
+
+// ValidateAuthorizationContext validates channel, country and amount of an authorization
+func ValidateAuthorizationContext(auth AuthorizationContext) error {
+	if auth.AmountCents <= 0 {
+		return ErrInvalidAmount
+	}
+	if !validChannels[auth.Channel] {
+		return ErrInvalidChannel
+	}
+	if auth.Country != "" && !isCountryCode(auth.Country) {
+		return ErrInvalidCountry
+	}
+	return nil
+}
+
+// IsInternational reports whether the merchant is outside the home country
+// An empty country is treated as domestic
+func (a AuthorizationContext) IsInternational() bool {
+	return a.Country != "" && !strings.EqualFold(a.Country, HomeCountry)
+}
+
+// checkChannelRules enforces the card's stored security settings for an authorization
+func checkChannelRules(card *db.Card, auth AuthorizationContext) error {
+	// 1. Virtual cards only exist card-not-present
+	if card.Type == "virtual" && auth.Channel != ChannelEcommerce {
+		return ErrChannelNotSupported
+	}
+
+	// 2. Online (card-not-present) transactions
+	if auth.Channel == ChannelEcommerce && card.BlockOnline.Valid && card.BlockOnline.Bool {
+		return ErrOnlineBlocked
+	}
+
+	// 3. Contactless (enabled by default)
+	if auth.Channel == ChannelContactless && card.IsContactless.Valid && !card.IsContactless.Bool {
+		return ErrContactlessBlocked
+	}
+
+	// 4. International: declined when explicitly blocked or disabled (unset means
+	// enabled, as for cards issued before the setting was enforced)
+	if auth.IsInternational() {
+		if card.BlockInternational.Valid && card.BlockInternational.Bool {
+			return ErrInternationalBlocked
+		}
+		if card.IsInternational.Valid && !card.IsInternational.Bool {
+			return ErrInternationalBlocked
+		}
+	}
+
+	return nil
+}
+
+// isCountryCode checks for an ISO 3166-1 alpha-2 code (two letters)
+func isCountryCode(country string) bool {
+	if len(country) != 2 {
+		return false
+	}
+	for _, c := range country {
+		if (c < 'A' || c > 'Z') && (c < 'a' || c > 'z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+package cards
+
+import (
+	"database/sql"
+	"testing"
+
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+)
+
+// TestValidateAuthorizationContext tests authorization context validation
+func TestValidateAuthorizationContext(t *testing.T) {
+	tests := []struct {
+		name     string
+		auth     AuthorizationContext
+		expected error
+	}{
+		{"Valid ecommerce", AuthorizationContext{AmountCents: 1000, Channel: ChannelEcommerce, Country: "BR"}, nil},
+		{"Valid without country", AuthorizationContext{AmountCents: 1000, Channel: ChannelPOSChip}, nil},
+		{"Zero amount", AuthorizationContext{AmountCents: 0, Channel: ChannelPOSChip}, ErrInvalidAmount},
+		{"Unknown channel", AuthorizationContext{AmountCents: 1000, Channel: "telepathy"}, ErrInvalidChannel},
+		{"Empty channel", AuthorizationContext{AmountCents: 1000}, ErrInvalidChannel},
+		{"Invalid country", AuthorizationContext{AmountCents: 1000, Channel: ChannelATM, Country: "BRA"}, ErrInvalidCountry},
+		{"Numeric country", AuthorizationContext{AmountCents: 1000, Channel: ChannelATM, Country: "12"}, ErrInvalidCountry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateAuthorizationContext(tt.auth); err != tt.expected {
+				t.Errorf("ValidateAuthorizationContext() error = %v, expected %v", err, tt.expected)
+			}
+		})
+	}
+}
+
+// TestCheckChannelRules tests every decline path for stored security settings
+func TestCheckChannelRules(t *testing.T) {
+	on := sql.NullBool{Bool: true, Valid: true}
+	off := sql.NullBool{Bool: false, Valid: true}
+
+	physical := func(contactless, international, blockInternational, blockOnline sql.NullBool) *db.Card {
+		return &db.Card{
+			Type:               "physical",
+			IsContactless:      contactless,
+			IsInternational:    international,
+			BlockInternational: blockInternational,
+			BlockOnline:        blockOnline,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		card     *db.Card
+		auth     AuthorizationContext
+		expected error
+	}{
+		// Approvals
+		{"Domestic chip", physical(on, off, off, off), AuthorizationContext{Channel: ChannelPOSChip, Country: "BR"}, nil},
+		{"Domestic contactless", physical(on, off, off, off), AuthorizationContext{Channel: ChannelContactless}, nil},
+		{"Contactless default enabled", physical(sql.NullBool{}, off, off, off), AuthorizationContext{Channel: ChannelContactless}, nil},
+		{"Domestic online", physical(on, off, off, off), AuthorizationContext{Channel: ChannelEcommerce, Country: "br"}, nil},
+		{"International enabled", physical(on, on, off, off), AuthorizationContext{Channel: ChannelPOSChip, Country: "US"}, nil},
+		{"International not set", physical(on, sql.NullBool{}, off, off), AuthorizationContext{Channel: ChannelPOSChip, Country: "AR"}, nil},
+		{"Domestic ATM", physical(on, off, off, off), AuthorizationContext{Channel: ChannelATM}, nil},
+		{"Domestic magstripe", physical(on, off, off, off), AuthorizationContext{Channel: ChannelMagstripe}, nil},
+		{"Virtual online", &db.Card{Type: "virtual"}, AuthorizationContext{Channel: ChannelEcommerce}, nil},
+
+		// Declines
+		{"Online blocked", physical(on, off, off, on), AuthorizationContext{Channel: ChannelEcommerce}, ErrOnlineBlocked},
+		{"Contactless disabled", physical(off, off, off, off), AuthorizationContext{Channel: ChannelContactless}, ErrContactlessBlocked},
+		{"International not enabled", physical(on, off, off, off), AuthorizationContext{Channel: ChannelPOSChip, Country: "US"}, ErrInternationalBlocked},
+		{"International blocked", physical(on, on, on, off), AuthorizationContext{Channel: ChannelEcommerce, Country: "US"}, ErrInternationalBlocked},
+		{"International not set but blocked", physical(on, sql.NullBool{}, on, off), AuthorizationContext{Channel: ChannelPOSChip, Country: "AR"}, ErrInternationalBlocked},
+		{"Virtual at POS", &db.Card{Type: "virtual"}, AuthorizationContext{Channel: ChannelPOSChip}, ErrChannelNotSupported},
+		{"Virtual contactless", &db.Card{Type: "virtual"}, AuthorizationContext{Channel: ChannelContactless}, ErrChannelNotSupported},
+		{"Virtual at ATM", &db.Card{Type: "virtual"}, AuthorizationContext{Channel: ChannelATM}, ErrChannelNotSupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkChannelRules(tt.card, tt.auth); err != tt.expected {
+				t.Errorf("checkChannelRules() error = %v, expected %v", err, tt.expected)
+			}
+		})
+	}
+}
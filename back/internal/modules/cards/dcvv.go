@@ -0,0 +1,76 @@
+package cards
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// dcvvSecretSize is the size of the per-card dynamic CVV secret (256 bits)
+const dcvvSecretSize = 32
+
+// GenerateDynamicCVVSecret generates a random per-card secret for dynamic CVV
+func GenerateDynamicCVVSecret() ([]byte, error) {
+	secret := make([]byte, dcvvSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate dynamic CVV secret: %w", err)
+	}
+	return secret, nil
+}
+
+// ComputeDynamicCVV derives the 3-digit CVV for the time window containing t
+//
+// Algorithm (TOTP-style, RFC 6238 with HMAC-SHA256):
+// 1. counter = unix(t) / window
+// 2. mac = HMAC-SHA256(secret, counter as 8-byte big-endian)
+// 3. dynamic truncation (RFC 4226 §5.3) to a 31-bit integer
+// 4. CVV = integer mod 1000, zero-padded to 3 digits
+func ComputeDynamicCVV(secret []byte, t time.Time, window time.Duration) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(t.Unix()/windowSeconds(window)))
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%03d", code%1000)
+}
+
+// VerifyDynamicCVV checks a CVV against the current window and up to drift
+// windows on either side (to tolerate clock skew and checkout latency)
+func VerifyDynamicCVV(secret []byte, cvv string, now time.Time, window time.Duration, drift int) bool {
+	if len(cvv) != 3 {
+		return false
+	}
+
+	valid := 0
+	for i := -drift; i <= drift; i++ {
+		expected := ComputeDynamicCVV(secret, now.Add(time.Duration(i)*window), window)
+		// Check every window to keep timing independent of the match position
+		valid |= subtle.ConstantTimeCompare([]byte(expected), []byte(cvv))
+	}
+
+	return valid == 1
+}
+
+// DynamicCVVValidUntil returns the end of the time window containing t
+func DynamicCVVValidUntil(t time.Time, window time.Duration) time.Time {
+	seconds := windowSeconds(window)
+	return time.Unix((t.Unix()/seconds+1)*seconds, 0).UTC()
+}
+
+// windowSeconds converts a window to whole seconds (minimum 1)
+func windowSeconds(window time.Duration) int64 {
+	seconds := int64(window / time.Second)
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
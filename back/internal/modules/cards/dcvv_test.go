@@ -0,0 +1,84 @@
+package cards
+
+import (
+	"testing"
+	"time"
+)
+
+// TestComputeDynamicCVV tests CVV derivation per time window
+func TestComputeDynamicCVV(t *testing.T) {
+	secret := []byte("01234567890123456789012345678901")
+	window := 5 * time.Minute
+	base := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	cvv := ComputeDynamicCVV(secret, base, window)
+	if len(cvv) != 3 {
+		t.Fatalf("expected 3-digit CVV, got %q", cvv)
+	}
+
+	// Same window yields the same CVV
+	if got := ComputeDynamicCVV(secret, base.Add(4*time.Minute), window); got != cvv {
+		t.Errorf("expected same CVV within window, got %s and %s", cvv, got)
+	}
+
+	// Different secret yields a different sequence
+	other := []byte("10987654321098765432109876543210")
+	same := 0
+	for i := 0; i < 20; i++ {
+		ts := base.Add(time.Duration(i) * window)
+		if ComputeDynamicCVV(secret, ts, window) == ComputeDynamicCVV(other, ts, window) {
+			same++
+		}
+	}
+	if same > 2 {
+		t.Errorf("different secrets produced %d identical CVVs out of 20", same)
+	}
+}
+
+// TestVerifyDynamicCVV tests verification with drift windows
+func TestVerifyDynamicCVV(t *testing.T) {
+	secret := []byte("01234567890123456789012345678901")
+	window := 5 * time.Minute
+	issuedAt := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	cvv := ComputeDynamicCVV(secret, issuedAt, window)
+
+	tests := []struct {
+		name     string
+		cvv      string
+		now      time.Time
+		drift    int
+		expected bool
+	}{
+		{"same window", cvv, issuedAt.Add(time.Minute), 0, true},
+		{"next window without drift", cvv, issuedAt.Add(window), 0, false},
+		{"next window with drift 1", cvv, issuedAt.Add(window), 1, true},
+		{"previous window with drift 1", cvv, issuedAt.Add(-window), 1, true},
+		{"two windows later with drift 1", cvv, issuedAt.Add(2 * window), 1, false},
+		{"wrong length", "12", issuedAt, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Skip the rare case where neighbouring windows collide on the same CVV
+			if !tt.expected && tt.cvv == ComputeDynamicCVV(secret, tt.now, window) {
+				t.Skip("CVV collision between windows")
+			}
+
+			got := VerifyDynamicCVV(secret, tt.cvv, tt.now, window, tt.drift)
+			if got != tt.expected {
+				t.Errorf("VerifyDynamicCVV() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestDynamicCVVValidUntil tests window end calculation
+func TestDynamicCVVValidUntil(t *testing.T) {
+	window := 5 * time.Minute
+	now := time.Date(2025, 1, 15, 12, 3, 20, 0, time.UTC)
+	expected := time.Date(2025, 1, 15, 12, 5, 0, 0, time.UTC)
+
+	if got := DynamicCVVValidUntil(now, window); !got.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
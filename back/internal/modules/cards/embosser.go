@@ -0,0 +1,224 @@
+package cards
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Embosser file layout (fixed width, one record per line, every line EmbosserRecordLength
+// characters plus "\n"). Text fields are upper-case ASCII, left-aligned and space-padded;
+// numeric fields are zero-padded.
+//
+//	Header  H | batch ID (36) | created at YYYYMMDDHHMMSS (14) | layout version (4)
+//	Detail  D | card ID (36) | PAN (19) | expiry MMYY (4) | CVV (4) | embossed name (26) |
+//	          recipient (40) | street (50) | number (10) | complement (30) |
+//	          neighborhood (30) | city (30) | UF (2) | CEP (8)
+//	Trailer T | detail record count (8)
+const (
+	EmbosserLayoutVersion = "0001"
+	EmbosserRecordLength  = 290
+
+	// EmbossedNameLength is the longest name that fits on the plastic
+	EmbossedNameLength = 26
+)
+
+// EmbosserRecord holds the data printed on and shipped with one plastic
+type EmbosserRecord struct {
+	CardID      string
+	CardNumber  string
+	ExpiryMonth int
+	ExpiryYear  int
+	CVV         string
+	HolderName  string
+	Address     ShippingAddress
+}
+
+// accentReplacer folds Portuguese accented letters to ASCII
+var accentReplacer = strings.NewReplacer(
+	"Á", "A", "À", "A", "Â", "A", "Ã", "A", "Ä", "A",
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"É", "E", "È", "E", "Ê", "E", "Ë", "E",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"Í", "I", "Ì", "I", "Î", "I", "Ï", "I",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"Ó", "O", "Ò", "O", "Ô", "O", "Õ", "O", "Ö", "O",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"Ú", "U", "Ù", "U", "Û", "U", "Ü", "U",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"Ç", "C", "ç", "c", "Ñ", "N", "ñ", "n",
+)
+
+// embosserText converts text to upper-case printable ASCII
+// Characters the embosser cannot print become spaces; runs of spaces are collapsed.
+func embosserText(value string) string {
+	folded := strings.ToUpper(accentReplacer.Replace(value))
+
+	var b strings.Builder
+	for _, c := range folded {
+		if c < ' ' || c > '~' {
+			c = ' '
+		}
+		b.WriteRune(c)
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// EmbossedName returns the holder name as printed on the plastic (at most EmbossedNameLength characters)
+// Long names keep whole words from the start where possible.
+func EmbossedName(name string) string {
+	text := embosserText(name)
+	if len(text) <= EmbossedNameLength {
+		return text
+	}
+
+	words := strings.Fields(text)
+	embossed := words[0]
+	for _, word := range words[1:] {
+		if len(embossed)+1+len(word) > EmbossedNameLength {
+			break
+		}
+		embossed += " " + word
+	}
+	return fixedText(embossed, EmbossedNameLength)
+}
+
+// fixedText left-aligns value in a field of width characters (truncating if needed)
+func fixedText(value string, width int) string {
+	if len(value) > width {
+		return value[:width]
+	}
+	return value + strings.Repeat(" ", width-len(value))
+}
+
+// fixedNumber zero-pads a non-negative number to width digits
+func fixedNumber(value, width int) string {
+	return fmt.Sprintf("%0*d", width, value)
+}
+
+// padRecord pads a record line to EmbosserRecordLength
+func padRecord(line string) string {
+	return fixedText(line, EmbosserRecordLength)
+}
+
+// formatEmbosserRecord formats a detail record
+func formatEmbosserRecord(record EmbosserRecord) string {
+	var b strings.Builder
+	b.WriteString("D")
+	b.WriteString(fixedText(record.CardID, 36))
+	b.WriteString(fixedText(record.CardNumber, 19))
+	b.WriteString(fixedNumber(record.ExpiryMonth, 2))
+	b.WriteString(fixedNumber(record.ExpiryYear%100, 2))
+	b.WriteString(fixedText(record.CVV, 4))
+	b.WriteString(fixedText(EmbossedName(record.HolderName), EmbossedNameLength))
+	b.WriteString(fixedText(embosserText(record.Address.RecipientName), 40))
+	b.WriteString(fixedText(embosserText(record.Address.Street), 50))
+	b.WriteString(fixedText(embosserText(record.Address.Number), 10))
+	b.WriteString(fixedText(embosserText(record.Address.Complement), 30))
+	b.WriteString(fixedText(embosserText(record.Address.Neighborhood), 30))
+	b.WriteString(fixedText(embosserText(record.Address.City), 30))
+	b.WriteString(fixedText(embosserText(record.Address.State), 2))
+	b.WriteString(fixedText(record.Address.PostalCode, 8))
+	return padRecord(b.String())
+}
+
+// WriteEmbosserFile writes a complete embosser file (header, details, trailer)
+func WriteEmbosserFile(w io.Writer, batchID string, createdAt time.Time, records []EmbosserRecord) error {
+	bw := bufio.NewWriter(w)
+
+	lines := make([]string, 0, len(records)+2)
+	lines = append(lines, padRecord("H"+fixedText(batchID, 36)+createdAt.UTC().Format("20060102150405")+EmbosserLayoutVersion))
+	for _, record := range records {
+		lines = append(lines, formatEmbosserRecord(record))
+	}
+	lines = append(lines, padRecord("T"+fixedNumber(len(records), 8)))
+
+	for _, line := range lines {
+		if _, err := bw.WriteString(line + "\n"); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// ExportEmbosserBatch writes up to limit requested physical cards to an embosser file
+// and marks their shipments as produced.
+//
+// The file is written inside the transaction that marks the shipments, so a failed
+// export leaves the cards requested. If the caller cannot persist the file after a
+// successful export, the batch ID identifies the shipments to reset.
+func (s *Service) ExportEmbosserBatch(ctx context.Context, w io.Writer, limit int) (*EmbosserBatch, error) {
+	batchID := uuid.New()
+	createdAt := time.Now()
+	count := 0
+
+	err := s.executeInTransaction(ctx, func(tx *sql.Tx) error {
+		// 1. Lock requested shipments (concurrent exports skip them)
+		shipments, err := s.repo.ListShipmentsForEmbossing(ctx, tx, limit)
+		if err != nil {
+			return err
+		}
+		if len(shipments) == 0 {
+			return nil
+		}
+
+		// 2. Build records with the decrypted card data
+		records := make([]EmbosserRecord, 0, len(shipments))
+		for i := range shipments {
+			shipment := &shipments[i]
+			card, err := s.repo.GetForUpdate(ctx, tx, shipment.CardID.String())
+			if err != nil {
+				return err
+			}
+
+			cardNumber, err := s.repo.DecryptCardNumber(card)
+			if err != nil {
+				return err
+			}
+			cvv, err := s.repo.DecryptCVV(card)
+			if err != nil {
+				return err
+			}
+
+			records = append(records, EmbosserRecord{
+				CardID:      card.ID.String(),
+				CardNumber:  cardNumber,
+				ExpiryMonth: int(card.ExpiryMonth),
+				ExpiryYear:  int(card.ExpiryYear),
+				CVV:         cvv,
+				HolderName:  card.HolderName,
+				Address:     dbShipmentToCardShipment(shipment).Address,
+			})
+		}
+
+		// 3. Write file before marking the batch
+		if err := WriteEmbosserFile(w, batchID.String(), createdAt, records); err != nil {
+			return err
+		}
+
+		// 4. Mark shipments as produced
+		for i := range shipments {
+			if err := s.repo.MarkShipmentProduced(ctx, tx, shipments[i].ID, batchID); err != nil {
+				return err
+			}
+		}
+
+		count = len(records)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &EmbosserBatch{
+		BatchID:   batchID.String(),
+		Records:   count,
+		CreatedAt: createdAt,
+	}, nil
+}
@@ -0,0 +1,123 @@
+package cards
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestEmbossedName tests holder name conversion for the plastic
+func TestEmbossedName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"Maria Silva", "MARIA SILVA"},
+		{"  João   Conceição ", "JOAO CONCEICAO"},
+		{"Ana Luísa d'Ávila", "ANA LUISA D'AVILA"},
+		{"Maria Aparecida dos Santos Oliveira", "MARIA APARECIDA DOS SANTOS"},
+		{"Bartholomew-Christopherson Junior", "BARTHOLOMEW-CHRISTOPHERSON"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EmbossedName(tt.name)
+			if got != tt.expected {
+				t.Errorf("EmbossedName(%q) = %q, want %q", tt.name, got, tt.expected)
+			}
+			if len(got) > EmbossedNameLength {
+				t.Errorf("EmbossedName(%q) has %d characters, max %d", tt.name, len(got), EmbossedNameLength)
+			}
+		})
+	}
+}
+
+// TestWriteEmbosserFile tests the fixed-width embosser file layout
+func TestWriteEmbosserFile(t *testing.T) {
+	records := []EmbosserRecord{
+		{
+			CardID:      "7f1c5a2e-3b4d-4e6f-8a9b-0c1d2e3f4a5b",
+			CardNumber:  "4111111111111111",
+			ExpiryMonth: 3,
+			ExpiryYear:  2031,
+			CVV:         "123",
+			HolderName:  "José da Silva",
+			Address: ShippingAddress{
+				RecipientName: "José da Silva",
+				Street:        "Rua Açaí",
+				Number:        "42",
+				Neighborhood:  "Centro",
+				City:          "Belém",
+				State:         "PA",
+				PostalCode:    "66010000",
+			},
+		},
+		{
+			CardID:      "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d",
+			CardNumber:  "6363691234567890129",
+			ExpiryMonth: 12,
+			ExpiryYear:  2030,
+			CVV:         "9876",
+			HolderName:  "Ana Souza",
+		},
+	}
+
+	var buf bytes.Buffer
+	createdAt := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+	if err := WriteEmbosserFile(&buf, "batch-0001", createdAt, records); err != nil {
+		t.Fatalf("WriteEmbosserFile() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(records)+2 {
+		t.Fatalf("got %d lines, want %d", len(lines), len(records)+2)
+	}
+	for i, line := range lines {
+		if len(line) != EmbosserRecordLength {
+			t.Errorf("line %d has %d characters, want %d", i, len(line), EmbosserRecordLength)
+		}
+		for _, c := range line {
+			if c < ' ' || c > '~' {
+				t.Errorf("line %d contains non-printable character %q", i, c)
+			}
+		}
+	}
+
+	// Header
+	if !strings.HasPrefix(lines[0], "Hbatch-0001") || lines[0][37:51] != "20261018093000" || lines[0][51:55] != EmbosserLayoutVersion {
+		t.Errorf("unexpected header %q", strings.TrimRight(lines[0], " "))
+	}
+
+	// Detail: fields at fixed offsets
+	detail := lines[1]
+	fields := []struct {
+		name          string
+		start, length int
+		expected      string
+	}{
+		{"type", 0, 1, "D"},
+		{"card id", 1, 36, "7f1c5a2e-3b4d-4e6f-8a9b-0c1d2e3f4a5b"},
+		{"pan", 37, 19, "4111111111111111   "},
+		{"expiry", 56, 4, "0331"},
+		{"cvv", 60, 4, "123 "},
+		{"name", 64, 26, fixedText("JOSE DA SILVA", 26)},
+		{"street", 130, 50, fixedText("RUA ACAI", 50)},
+		{"city", 250, 30, fixedText("BELEM", 30)},
+		{"uf", 280, 2, "PA"},
+		{"cep", 282, 8, "66010000"},
+	}
+	for _, f := range fields {
+		if got := detail[f.start : f.start+f.length]; got != f.expected {
+			t.Errorf("detail %s = %q, want %q", f.name, got, f.expected)
+		}
+	}
+	if lines[2][56:60] != "1230" {
+		t.Errorf("second detail expiry = %q, want 1230", lines[2][56:60])
+	}
+
+	// Trailer
+	if !strings.HasPrefix(lines[3], "T00000002") {
+		t.Errorf("unexpected trailer %q", strings.TrimRight(lines[3], " "))
+	}
+}
@@ -10,6 +10,21 @@ var (
 	ErrCardCancelled = errors.New("card has been cancelled")
 	ErrCardBlocked   = errors.New("card is blocked")
 
+	// Replacement errors
+	ErrInvalidCancelReason = errors.New("invalid cancellation reason")
+	ErrCardAlreadyReplaced = errors.New("card has already been replaced")
+
+	// Physical card shipping and activation errors
+	ErrShippingAddressRequired   = errors.New("shipping address is required for physical cards only")
+	ErrInvalidShippingAddress    = errors.New("invalid shipping address")
+	ErrShipmentNotFound          = errors.New("card shipment not found")
+	ErrInvalidShipmentTransition = errors.New("invalid card shipment status transition")
+	ErrInvalidTrackingInfo       = errors.New("carrier and tracking code are required")
+	ErrCardNotActivated          = errors.New("card has not been activated")
+	ErrCardAlreadyActivated      = errors.New("card has already been activated")
+	ErrActivationNotAvailable    = errors.New("card cannot be activated before it is shipped")
+	ErrActivationFailed          = errors.New("last four digits or CVV do not match")
+
 	// Validation errors
 	ErrInvalidCardNumber = errors.New("invalid card number")
 	ErrInvalidCVV        = errors.New("invalid CVV")
@@ -18,6 +33,22 @@ var (
 	ErrInvalidExpiryDate = errors.New("invalid expiry date")
 	ErrInvalidCardType   = errors.New("invalid card type")
 	ErrInvalidCardBrand  = errors.New("invalid card brand")
+	ErrInvalidCVVMode    = errors.New("invalid CVV mode")
+	ErrDynamicCVVVirtual = errors.New("dynamic CVV is only available for virtual cards")
+
+	// Profile errors
+	ErrInvalidCardProfile = errors.New("invalid card profile")
+	ErrProfileVirtualOnly = errors.New("card profiles are only available for virtual cards")
+	ErrInvalidAmountCap   = errors.New("invalid amount cap")
+	ErrInvalidExpiryDays  = errors.New("invalid expiry in days")
+	ErrMerchantNotAllowed = errors.New("card is locked to a different merchant")
+	ErrAmountCapExceeded  = errors.New("card amount cap exceeded")
+
+	// Category control errors
+	ErrInvalidCategory       = errors.New("invalid merchant category")
+	ErrDuplicateCategory     = errors.New("duplicate merchant category")
+	ErrCategoryBlocked       = errors.New("merchant category blocked")
+	ErrCategoryLimitExceeded = errors.New("merchant category monthly limit exceeded")
 
 	// Limit errors
 	ErrDailyLimitExceeded   = errors.New("daily spending limit exceeded")
@@ -30,9 +61,40 @@ var (
 	ErrPINMismatch  = errors.New("current PIN does not match")
 
 	// Security errors
-	ErrInternationalBlocked = errors.New("international transactions blocked")
-	ErrOnlineBlocked        = errors.New("online transactions blocked")
-	ErrContactlessBlocked   = errors.New("contactless transactions blocked")
+	ErrInternationalBlocked  = errors.New("international transactions blocked")
+	ErrOnlineBlocked         = errors.New("online transactions blocked")
+	ErrContactlessBlocked    = errors.New("contactless transactions blocked")
+	ErrCVVVerificationFailed = errors.New("CVV verification failed")
+	ErrChannelNotSupported   = errors.New("channel not supported for this card")
+	ErrCVVRequired           = errors.New("CVV is required for this authorization")
+
+	// Fraud errors
+	ErrFraudDeclined          = errors.New("transaction declined by fraud rules")
+	ErrStepUpRequired         = errors.New("additional authentication required")
+	ErrFraudRuleNotFound      = errors.New("fraud rule not found")
+	ErrInvalidFraudRule       = errors.New("invalid fraud rule score or params")
+	ErrInvalidFraudThresholds = errors.New("invalid fraud thresholds")
+
+	// Transaction listing errors
+	ErrInvalidTransactionFilter = errors.New("invalid transaction filter")
+	ErrInvalidCursor            = errors.New("invalid pagination cursor")
+
+	// Authorization context errors
+	ErrInvalidChannel = errors.New("invalid authorization channel")
+	ErrInvalidCountry = errors.New("invalid merchant country")
+	ErrInvalidAmount  = errors.New("invalid authorization amount")
+
+	// Reveal errors
+	ErrRevealNotAllowed   = errors.New("card details can only be revealed for active virtual cards")
+	ErrRevealTokenInvalid = errors.New("reveal token is invalid, expired or already used")
+
+	// Issuing errors
+	ErrBINNotConfigured    = errors.New("no active BIN range configured for brand and card type")
+	ErrPANGenerationFailed = errors.New("could not generate a unique card number")
+
+	// Tokenization errors
+	ErrInvalidPANToken       = errors.New("invalid PAN token")
+	ErrPANTokenizationFailed = errors.New("could not generate a unique PAN token")
 
 	// Encryption errors
 	ErrEncryptionFailed = errors.New("encryption failed")
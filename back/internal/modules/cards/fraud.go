@@ -0,0 +1,360 @@
+package cards
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+)
+
+// Fraud decisions
+const (
+	FraudApprove = "approve"
+	FraudDecline = "decline"
+	FraudStepUp  = "step_up"
+)
+
+// Fraud rule codes (fraud_rules.code)
+const (
+	FraudRuleVelocity           = "velocity"
+	FraudRuleAmountAboveMedian  = "amount_above_median"
+	FraudRuleFirstInternational = "first_international"
+	FraudRuleNewMCC             = "new_mcc"
+	FraudRuleCardTesting        = "card_testing"
+)
+
+// MaxFraudScore caps the sum of triggered rule scores
+const MaxFraudScore = 100
+
+// EvaluateFraudRules scores an authorization against the enabled rules.
+// Each triggered rule adds its score (capped at MaxFraudScore); the total is
+// compared to the thresholds. A holder who already passed a step-up challenge
+// is approved instead of being challenged again.
+func EvaluateFraudRules(rules []FraudRule, thresholds FraudThresholds, auth AuthorizationContext, mccCode string, signals FraudSignals) *FraudAssessment {
+	assessment := &FraudAssessment{
+		Decision: FraudApprove,
+		Reasons:  []FraudReason{},
+	}
+
+	for _, rule := range rules {
+		if !rule.IsEnabled {
+			continue
+		}
+
+		detail, triggered := evaluateFraudRule(rule, auth, mccCode, signals)
+		if !triggered {
+			continue
+		}
+
+		assessment.Score += rule.Score
+		assessment.Reasons = append(assessment.Reasons, FraudReason{
+			Rule:   rule.Code,
+			Score:  rule.Score,
+			Detail: detail,
+		})
+	}
+	if assessment.Score > MaxFraudScore {
+		assessment.Score = MaxFraudScore
+	}
+
+	switch {
+	case assessment.Score >= thresholds.DeclineScore:
+		assessment.Decision = FraudDecline
+	case assessment.Score >= thresholds.StepUpScore && !auth.StepUpCompleted:
+		assessment.Decision = FraudStepUp
+	}
+
+	return assessment
+}
+
+// evaluateFraudRule reports whether a rule fires and explains why
+func evaluateFraudRule(rule FraudRule, auth AuthorizationContext, mccCode string, signals FraudSignals) (string, bool) {
+	p := rule.Params
+
+	switch rule.Code {
+	case FraudRuleVelocity:
+		// The current attempt counts towards the window
+		if attempts := signals.RecentAuthorizations + 1; attempts > p.MaxCount {
+			return fmt.Sprintf("%d authorizations in %d minutes (max %d)", attempts, p.WindowMinutes, p.MaxCount), true
+		}
+
+	case FraudRuleAmountAboveMedian:
+		if signals.LookbackTransactions < p.MinTransactions || signals.MedianAmountCents <= 0 {
+			return "", false
+		}
+		if float64(auth.AmountCents) > float64(signals.MedianAmountCents)*p.Multiplier {
+			return fmt.Sprintf("amount %d is more than %gx the median of %d over %d days",
+				auth.AmountCents, p.Multiplier, signals.MedianAmountCents, p.LookbackDays), true
+		}
+
+	case FraudRuleFirstInternational:
+		if auth.IsInternational() && signals.InternationalTransactions == 0 {
+			return fmt.Sprintf("first purchase abroad (%s)", auth.Country), true
+		}
+
+	case FraudRuleNewMCC:
+		// Only for users with enough history to know their usual merchants
+		if mccCode == "" || signals.CompletedTransactions < p.MinTransactions {
+			return "", false
+		}
+		if signals.MCCTransactions == 0 {
+			return fmt.Sprintf("first purchase with MCC %s", mccCode), true
+		}
+
+	case FraudRuleCardTesting:
+		if auth.AmountCents > p.SmallAmountCents {
+			return "", false
+		}
+		if attempts := signals.RecentSmallAuthorizations + 1; attempts > p.MaxCount {
+			return fmt.Sprintf("%d authorizations up to %d cents in %d minutes (max %d)",
+				attempts, p.SmallAmountCents, p.WindowMinutes, p.MaxCount), true
+		}
+	}
+
+	return "", false
+}
+
+// ValidateFraudRule validates the score and params of a fraud rule
+func ValidateFraudRule(code string, score int, p FraudRuleParams) error {
+	if score < 0 || score > MaxFraudScore {
+		return ErrInvalidFraudRule
+	}
+
+	switch code {
+	case FraudRuleVelocity:
+		if p.MaxCount <= 0 || p.WindowMinutes <= 0 {
+			return ErrInvalidFraudRule
+		}
+	case FraudRuleAmountAboveMedian:
+		if p.Multiplier <= 1 || p.LookbackDays <= 0 || p.MinTransactions <= 0 {
+			return ErrInvalidFraudRule
+		}
+	case FraudRuleFirstInternational:
+	case FraudRuleNewMCC:
+		if p.MinTransactions < 0 {
+			return ErrInvalidFraudRule
+		}
+	case FraudRuleCardTesting:
+		if p.SmallAmountCents <= 0 || p.MaxCount <= 0 || p.WindowMinutes <= 0 {
+			return ErrInvalidFraudRule
+		}
+	default:
+		return ErrFraudRuleNotFound
+	}
+
+	return nil
+}
+
+// ValidateFraudThresholds validates the step-up and decline scores
+func ValidateFraudThresholds(t FraudThresholds) error {
+	if t.StepUpScore <= 0 || t.DeclineScore < t.StepUpScore {
+		return ErrInvalidFraudThresholds
+	}
+	return nil
+}
+
+// assessFraud loads the fraud rules and the signals they need, then scores the authorization.
+// Runs inside the authorization transaction (the card row is locked, so concurrent
+// authorizations of the same card are scored one after the other).
+func (s *Service) assessFraud(ctx context.Context, tx *sql.Tx, card *db.Card, auth AuthorizationContext, mccCode string) (*FraudAssessment, error) {
+	// 1. Load rules and thresholds
+	dbRules, err := s.repo.ListEnabledFraudRules(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	thresholds, err := s.repo.GetFraudThresholds(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]FraudRule, 0, len(dbRules))
+	for i := range dbRules {
+		rule, err := dbFraudRuleToFraudRule(&dbRules[i])
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, *rule)
+	}
+
+	// 2. Load only the signals the enabled rules need
+	now := time.Now()
+	var signals FraudSignals
+	for _, rule := range rules {
+		p := rule.Params
+
+		switch rule.Code {
+		case FraudRuleVelocity:
+			since := now.Add(-time.Duration(p.WindowMinutes) * time.Minute)
+			signals.RecentAuthorizations, err = s.repo.CountCardAuthorizationsSince(ctx, tx, card.ID, since)
+		case FraudRuleCardTesting:
+			if auth.AmountCents <= p.SmallAmountCents {
+				since := now.Add(-time.Duration(p.WindowMinutes) * time.Minute)
+				signals.RecentSmallAuthorizations, err = s.repo.CountCardSmallAuthorizationsSince(ctx, tx, card.ID, since, p.SmallAmountCents)
+			}
+		case FraudRuleAmountAboveMedian:
+			signals.MedianAmountCents, signals.LookbackTransactions, err = s.repo.GetUserSpendingProfile(ctx, tx, card.UserID, now.AddDate(0, 0, -p.LookbackDays))
+		case FraudRuleFirstInternational:
+			if auth.IsInternational() {
+				signals.InternationalTransactions, err = s.repo.CountUserInternationalTransactions(ctx, tx, card.UserID)
+			}
+		case FraudRuleNewMCC:
+			if mccCode != "" {
+				signals.CompletedTransactions, err = s.repo.CountUserCompletedTransactions(ctx, tx, card.UserID)
+				if err == nil {
+					signals.MCCTransactions, err = s.repo.CountUserTransactionsByMCC(ctx, tx, card.UserID, mccCode)
+				}
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. Score
+	return EvaluateFraudRules(rules, *thresholds, auth, mccCode, signals), nil
+}
+
+// logFraudDecision stores the fraud assessment of an authorization with its explanation.
+// tx is nil for declined and stepped-up authorizations (their transaction was rolled back).
+func (s *Service) logFraudDecision(ctx context.Context, tx *sql.Tx, card *db.Card, auth AuthorizationContext, mccCode string, transactionID uuid.NullUUID, assessment *FraudAssessment) error {
+	explanation, err := json.Marshal(assessment.Reasons)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.CreateFraudDecision(ctx, tx, db.CreateCardFraudDecisionParams{
+		CardID:            card.ID,
+		UserID:            card.UserID,
+		CardTransactionID: transactionID,
+		AmountCents:       auth.AmountCents,
+		MerchantName:      auth.MerchantName,
+		Mcc:               sql.NullString{String: mccCode, Valid: mccCode != ""},
+		Channel:           auth.Channel,
+		MerchantCountry:   sql.NullString{String: auth.Country, Valid: auth.Country != ""},
+		Decision:          assessment.Decision,
+		Score:             int32(assessment.Score),
+		Explanation:       explanation,
+	})
+}
+
+// ListFraudRules returns all fraud rules and the decision thresholds
+func (s *Service) ListFraudRules(ctx context.Context) (*FraudRulesResponse, error) {
+	dbRules, err := s.repo.ListFraudRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	thresholds, err := s.repo.GetFraudThresholds(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]FraudRule, 0, len(dbRules))
+	for i := range dbRules {
+		rule, err := dbFraudRuleToFraudRule(&dbRules[i])
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, *rule)
+	}
+
+	return &FraudRulesResponse{
+		Rules:      rules,
+		Thresholds: *thresholds,
+	}, nil
+}
+
+// UpdateFraudRule enables, disables or re-tunes a fraud rule
+// Internal callers only (risk team tooling).
+func (s *Service) UpdateFraudRule(ctx context.Context, caller, code string, req UpdateFraudRuleRequest) (*FraudRule, error) {
+	// 1. Get current rule
+	current, err := s.repo.GetFraudRule(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := dbFraudRuleToFraudRule(current)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Apply changes and validate
+	if req.IsEnabled != nil {
+		rule.IsEnabled = *req.IsEnabled
+	}
+	if req.Score != nil {
+		rule.Score = *req.Score
+	}
+	if req.Params != nil {
+		rule.Params = *req.Params
+	}
+	if err := ValidateFraudRule(rule.Code, rule.Score, rule.Params); err != nil {
+		return nil, err
+	}
+
+	// 3. Store
+	updated, err := s.repo.UpdateFraudRule(ctx, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.repo.CreateFraudAuditLog(ctx, "FRAUD_RULE", updated.ID.String(), "FRAUD_RULE_UPDATED", map[string]interface{}{
+		"caller":     caller,
+		"rule":       rule.Code,
+		"is_enabled": rule.IsEnabled,
+		"score":      rule.Score,
+		"params":     rule.Params,
+	})
+
+	return dbFraudRuleToFraudRule(updated)
+}
+
+// UpdateFraudThresholds changes the step-up and decline scores
+// Internal callers only (risk team tooling).
+func (s *Service) UpdateFraudThresholds(ctx context.Context, caller string, thresholds FraudThresholds) (*FraudThresholds, error) {
+	if err := ValidateFraudThresholds(thresholds); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.UpdateFraudThresholds(ctx, thresholds)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.repo.CreateFraudAuditLog(ctx, "FRAUD_SETTINGS", uuid.Nil.String(), "FRAUD_THRESHOLDS_UPDATED", map[string]interface{}{
+		"caller":        caller,
+		"step_up_score": thresholds.StepUpScore,
+		"decline_score": thresholds.DeclineScore,
+	})
+
+	return updated, nil
+}
+
+// ListCardFraudDecisions returns a page of logged fraud decisions of a card
+// Internal callers only (fraud analysts).
+func (s *Service) ListCardFraudDecisions(ctx context.Context, cardID string, page, limit int) ([]*FraudDecision, int64, error) {
+	cardUUID, err := uuid.Parse(cardID)
+	if err != nil {
+		return nil, 0, ErrCardNotFound
+	}
+
+	offset := (page - 1) * limit
+	dbDecisions, err := s.repo.ListCardFraudDecisions(ctx, cardUUID, int32(limit), int32(offset))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.CountCardFraudDecisions(ctx, cardUUID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	decisions := make([]*FraudDecision, len(dbDecisions))
+	for i := range dbDecisions {
+		decisions[i] = dbFraudDecisionToFraudDecision(&dbDecisions[i])
+	}
+
+	return decisions, total, nil
+}
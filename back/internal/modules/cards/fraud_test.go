@@ -0,0 +1,259 @@
+package cards
+
+import (
+	"testing"
+)
+
+// defaultFraudRules mirrors the rules seeded by the fraud rules migration
+func defaultFraudRules() []FraudRule {
+	return []FraudRule{
+		{Code: FraudRuleVelocity, IsEnabled: true, Score: 40, Params: FraudRuleParams{MaxCount: 5, WindowMinutes: 10}},
+		{Code: FraudRuleAmountAboveMedian, IsEnabled: true, Score: 35, Params: FraudRuleParams{Multiplier: 5, LookbackDays: 90, MinTransactions: 5}},
+		{Code: FraudRuleFirstInternational, IsEnabled: true, Score: 30},
+		{Code: FraudRuleNewMCC, IsEnabled: true, Score: 15, Params: FraudRuleParams{MinTransactions: 10}},
+		{Code: FraudRuleCardTesting, IsEnabled: true, Score: 50, Params: FraudRuleParams{SmallAmountCents: 200, MaxCount: 3, WindowMinutes: 60}},
+	}
+}
+
+// TestEvaluateFraudRules tests scoring and the approve / step-up / decline decision
+func TestEvaluateFraudRules(t *testing.T) {
+	thresholds := FraudThresholds{StepUpScore: 40, DeclineScore: 80}
+
+	// A regular customer: enough history, usual amount, domestic, known MCC
+	usual := FraudSignals{
+		MedianAmountCents:     5000,
+		LookbackTransactions:  30,
+		CompletedTransactions: 30,
+		MCCTransactions:       4,
+	}
+	domestic := AuthorizationContext{AmountCents: 6000, MerchantName: "Mercado", Channel: ChannelPOSChip, Country: "BR"}
+
+	tests := []struct {
+		name         string
+		auth         AuthorizationContext
+		mcc          string
+		signals      func(FraudSignals) FraudSignals
+		wantDecision string
+		wantScore    int
+		wantRules    []string
+	}{
+		{
+			name:         "usual purchase",
+			auth:         domestic,
+			mcc:          "5411",
+			signals:      func(s FraudSignals) FraudSignals { return s },
+			wantDecision: FraudApprove,
+		},
+		{
+			name:         "new MCC alone",
+			auth:         domestic,
+			mcc:          "7995",
+			signals:      func(s FraudSignals) FraudSignals { s.MCCTransactions = 0; return s },
+			wantDecision: FraudApprove,
+			wantScore:    15,
+			wantRules:    []string{FraudRuleNewMCC},
+		},
+		{
+			name:         "new MCC ignored without history",
+			auth:         domestic,
+			mcc:          "7995",
+			signals:      func(s FraudSignals) FraudSignals { s.MCCTransactions = 0; s.CompletedTransactions = 3; return s },
+			wantDecision: FraudApprove,
+		},
+		{
+			name:         "velocity",
+			auth:         domestic,
+			mcc:          "5411",
+			signals:      func(s FraudSignals) FraudSignals { s.RecentAuthorizations = 5; return s },
+			wantDecision: FraudStepUp,
+			wantScore:    40,
+			wantRules:    []string{FraudRuleVelocity},
+		},
+		{
+			name:         "velocity at the limit",
+			auth:         domestic,
+			mcc:          "5411",
+			signals:      func(s FraudSignals) FraudSignals { s.RecentAuthorizations = 4; return s },
+			wantDecision: FraudApprove,
+		},
+		{
+			name:         "amount far above median",
+			auth:         AuthorizationContext{AmountCents: 25001, Channel: ChannelEcommerce, Country: "BR"},
+			mcc:          "5411",
+			signals:      func(s FraudSignals) FraudSignals { return s },
+			wantDecision: FraudApprove,
+			wantScore:    35,
+			wantRules:    []string{FraudRuleAmountAboveMedian},
+		},
+		{
+			name:         "amount above median without history",
+			auth:         AuthorizationContext{AmountCents: 25001, Channel: ChannelEcommerce, Country: "BR"},
+			mcc:          "5411",
+			signals:      func(s FraudSignals) FraudSignals { s.LookbackTransactions = 2; return s },
+			wantDecision: FraudApprove,
+		},
+		{
+			name:         "first purchase abroad with large amount",
+			auth:         AuthorizationContext{AmountCents: 30000, Channel: ChannelEcommerce, Country: "US"},
+			mcc:          "5411",
+			signals:      func(s FraudSignals) FraudSignals { return s },
+			wantDecision: FraudStepUp,
+			wantScore:    65,
+			wantRules:    []string{FraudRuleAmountAboveMedian, FraudRuleFirstInternational},
+		},
+		{
+			name:         "abroad again",
+			auth:         AuthorizationContext{AmountCents: 6000, Channel: ChannelEcommerce, Country: "US"},
+			mcc:          "5411",
+			signals:      func(s FraudSignals) FraudSignals { s.InternationalTransactions = 2; return s },
+			wantDecision: FraudApprove,
+		},
+		{
+			name:         "card testing",
+			auth:         AuthorizationContext{AmountCents: 100, Channel: ChannelEcommerce},
+			mcc:          "5411",
+			signals:      func(s FraudSignals) FraudSignals { s.RecentSmallAuthorizations = 3; return s },
+			wantDecision: FraudStepUp,
+			wantScore:    50,
+			wantRules:    []string{FraudRuleCardTesting},
+		},
+		{
+			name: "card testing with velocity",
+			auth: AuthorizationContext{AmountCents: 100, Channel: ChannelEcommerce},
+			mcc:  "5411",
+			signals: func(s FraudSignals) FraudSignals {
+				s.RecentSmallAuthorizations = 6
+				s.RecentAuthorizations = 6
+				return s
+			},
+			wantDecision: FraudDecline,
+			wantScore:    90,
+			wantRules:    []string{FraudRuleVelocity, FraudRuleCardTesting},
+		},
+		{
+			name: "every rule capped at max score",
+			auth: AuthorizationContext{AmountCents: 150, Channel: ChannelEcommerce, Country: "US"},
+			mcc:  "7995",
+			signals: func(s FraudSignals) FraudSignals {
+				s.MedianAmountCents = 20
+				s.MCCTransactions = 0
+				s.RecentAuthorizations = 10
+				s.RecentSmallAuthorizations = 10
+				return s
+			},
+			wantDecision: FraudDecline,
+			wantScore:    MaxFraudScore,
+			wantRules:    []string{FraudRuleVelocity, FraudRuleAmountAboveMedian, FraudRuleFirstInternational, FraudRuleNewMCC, FraudRuleCardTesting},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EvaluateFraudRules(defaultFraudRules(), thresholds, tt.auth, tt.mcc, tt.signals(usual))
+
+			if got.Decision != tt.wantDecision {
+				t.Errorf("Decision = %q, want %q", got.Decision, tt.wantDecision)
+			}
+			if got.Score != tt.wantScore {
+				t.Errorf("Score = %d, want %d", got.Score, tt.wantScore)
+			}
+			if len(got.Reasons) != len(tt.wantRules) {
+				t.Fatalf("Reasons = %+v, want rules %v", got.Reasons, tt.wantRules)
+			}
+			for i, rule := range tt.wantRules {
+				if got.Reasons[i].Rule != rule || got.Reasons[i].Detail == "" {
+					t.Errorf("Reasons[%d] = %+v, want rule %q with detail", i, got.Reasons[i], rule)
+				}
+			}
+		})
+	}
+}
+
+// TestEvaluateFraudRulesStepUpCompleted tests that a completed challenge approves instead of challenging again
+func TestEvaluateFraudRulesStepUpCompleted(t *testing.T) {
+	thresholds := FraudThresholds{StepUpScore: 40, DeclineScore: 80}
+	signals := FraudSignals{RecentAuthorizations: 5}
+
+	auth := AuthorizationContext{AmountCents: 1000, Channel: ChannelEcommerce}
+	if got := EvaluateFraudRules(defaultFraudRules(), thresholds, auth, "", signals); got.Decision != FraudStepUp {
+		t.Fatalf("without challenge: Decision = %q, want %q", got.Decision, FraudStepUp)
+	}
+
+	auth.StepUpCompleted = true
+	got := EvaluateFraudRules(defaultFraudRules(), thresholds, auth, "", signals)
+	if got.Decision != FraudApprove || got.Score != 40 {
+		t.Errorf("after challenge: Decision = %q, Score = %d, want %q with score 40", got.Decision, got.Score, FraudApprove)
+	}
+
+	// A completed challenge does not override a decline
+	signals.RecentSmallAuthorizations = 3
+	auth.AmountCents = 100
+	if got := EvaluateFraudRules(defaultFraudRules(), thresholds, auth, "", signals); got.Decision != FraudDecline {
+		t.Errorf("decline score: Decision = %q, want %q", got.Decision, FraudDecline)
+	}
+}
+
+// TestEvaluateFraudRulesDisabled tests that disabled rules never fire
+func TestEvaluateFraudRulesDisabled(t *testing.T) {
+	rules := defaultFraudRules()
+	rules[0].IsEnabled = false
+
+	auth := AuthorizationContext{AmountCents: 1000, Channel: ChannelEcommerce}
+	got := EvaluateFraudRules(rules, FraudThresholds{StepUpScore: 40, DeclineScore: 80}, auth, "", FraudSignals{RecentAuthorizations: 50})
+	if got.Decision != FraudApprove || got.Score != 0 || len(got.Reasons) != 0 {
+		t.Errorf("EvaluateFraudRules() = %+v, want approve with no reasons", got)
+	}
+}
+
+// TestValidateFraudRule tests rule score and params validation
+func TestValidateFraudRule(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		score    int
+		params   FraudRuleParams
+		expected error
+	}{
+		{"Valid velocity", FraudRuleVelocity, 40, FraudRuleParams{MaxCount: 5, WindowMinutes: 10}, nil},
+		{"Velocity without window", FraudRuleVelocity, 40, FraudRuleParams{MaxCount: 5}, ErrInvalidFraudRule},
+		{"Valid median", FraudRuleAmountAboveMedian, 35, FraudRuleParams{Multiplier: 3.5, LookbackDays: 30, MinTransactions: 5}, nil},
+		{"Median multiplier of 1", FraudRuleAmountAboveMedian, 35, FraudRuleParams{Multiplier: 1, LookbackDays: 30, MinTransactions: 5}, ErrInvalidFraudRule},
+		{"First international without params", FraudRuleFirstInternational, 30, FraudRuleParams{}, nil},
+		{"New MCC without history requirement", FraudRuleNewMCC, 15, FraudRuleParams{}, nil},
+		{"Card testing without amount", FraudRuleCardTesting, 50, FraudRuleParams{MaxCount: 3, WindowMinutes: 60}, ErrInvalidFraudRule},
+		{"Zero score", FraudRuleFirstInternational, 0, FraudRuleParams{}, nil},
+		{"Negative score", FraudRuleFirstInternational, -1, FraudRuleParams{}, ErrInvalidFraudRule},
+		{"Score above max", FraudRuleFirstInternational, 101, FraudRuleParams{}, ErrInvalidFraudRule},
+		{"Unknown rule", "geo_velocity", 10, FraudRuleParams{}, ErrFraudRuleNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateFraudRule(tt.code, tt.score, tt.params); err != tt.expected {
+				t.Errorf("ValidateFraudRule() error = %v, expected %v", err, tt.expected)
+			}
+		})
+	}
+}
+
+// TestValidateFraudThresholds tests step-up and decline score validation
+func TestValidateFraudThresholds(t *testing.T) {
+	tests := []struct {
+		name       string
+		thresholds FraudThresholds
+		expected   error
+	}{
+		{"Default", FraudThresholds{StepUpScore: 40, DeclineScore: 80}, nil},
+		{"Same score", FraudThresholds{StepUpScore: 60, DeclineScore: 60}, nil},
+		{"Decline below step-up", FraudThresholds{StepUpScore: 60, DeclineScore: 50}, ErrInvalidFraudThresholds},
+		{"Zero step-up", FraudThresholds{StepUpScore: 0, DeclineScore: 80}, ErrInvalidFraudThresholds},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateFraudThresholds(tt.thresholds); err != tt.expected {
+				t.Errorf("ValidateFraudThresholds() error = %v, expected %v", err, tt.expected)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package cards
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/lauratech/fin/back/internal/shared/response"
@@ -45,23 +46,7 @@ func (h *Handler) CreateCard(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Return card summary (not full card with sensitive data)
-	summary := &CardSummary{
-		ID:                       card.ID,
-		Type:                     card.Type,
-		Brand:                    card.Brand,
-		Status:                   card.Status,
-		LastFourDigits:           card.LastFourDigits,
-		HolderName:               card.HolderName,
-		ExpiryMonth:              card.ExpiryMonth,
-		ExpiryYear:               card.ExpiryYear,
-		DailyLimitCents:          card.DailyLimitCents,
-		MonthlyLimitCents:        card.MonthlyLimitCents,
-		CurrentDailySpentCents:   card.CurrentDailySpentCents,
-		CurrentMonthlySpentCents: card.CurrentMonthlySpentCents,
-		IsContactless:            card.IsContactless,
-		IsInternational:          card.IsInternational,
-		CreatedAt:                card.CreatedAt,
-	}
+	summary := cardToCardSummary(card)
 
 	response.Success(w, http.StatusCreated, summary, r.Context())
 }
@@ -297,6 +282,476 @@ func (h *Handler) CancelCard(w http.ResponseWriter, r *http.Request) {
 	response.Success(w, http.StatusOK, map[string]string{"message": "Card cancelled successfully"}, r.Context())
 }
 
+// ReplaceCard cancels a lost, stolen or damaged card and issues a new one
+// POST /api/cards/{id}/replace
+func (h *Handler) ReplaceCard(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	// Extract card ID from URL
+	cardID := chi.URLParam(r, "id")
+	if cardID == "" {
+		response.Error(w, http.StatusBadRequest, "VAL_002", "Card ID is required", nil)
+		return
+	}
+
+	// Decode request
+	var req ReplaceCardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "VAL_001", "Invalid request body", nil)
+		return
+	}
+
+	// Replace card
+	result, err := h.service.ReplaceCard(r.Context(), userID, cardID, req)
+	if err != nil {
+		h.handleCardError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusCreated, result, r.Context())
+}
+
+// GetShipment returns the current shipment of a physical card
+// GET /api/cards/{id}/shipment
+func (h *Handler) GetShipment(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	// Extract card ID from URL
+	cardID := chi.URLParam(r, "id")
+	if cardID == "" {
+		response.Error(w, http.StatusBadRequest, "VAL_002", "Card ID is required", nil)
+		return
+	}
+
+	shipment, err := h.service.GetCardShipment(r.Context(), userID, cardID)
+	if err != nil {
+		h.handleCardError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, shipment, r.Context())
+}
+
+// ActivateCard activates a delivered physical card
+// POST /api/cards/{id}/activate
+func (h *Handler) ActivateCard(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	// Extract card ID from URL
+	cardID := chi.URLParam(r, "id")
+	if cardID == "" {
+		response.Error(w, http.StatusBadRequest, "VAL_002", "Card ID is required", nil)
+		return
+	}
+
+	// Decode request
+	var req ActivateCardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "VAL_001", "Invalid request body", nil)
+		return
+	}
+
+	card, err := h.service.ActivateCard(r.Context(), userID, cardID, req)
+	if err != nil {
+		h.handleCardError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, card, r.Context())
+}
+
+// MarkShipped handles POST /internal/cards/shipments/{id}/shipped
+// Internal callers only: the card processor reports the carrier and tracking code
+func (h *Handler) MarkShipped(w http.ResponseWriter, r *http.Request) {
+	// Extract calling service from context (set by InternalAuth)
+	caller, ok := r.Context().Value("internal_service").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	shipmentID := chi.URLParam(r, "id")
+	if shipmentID == "" {
+		response.Error(w, http.StatusBadRequest, "VAL_002", "Shipment ID is required", nil)
+		return
+	}
+
+	// Decode request
+	var req ShipCardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "VAL_001", "Invalid request body", nil)
+		return
+	}
+
+	shipment, err := h.service.MarkCardShipped(r.Context(), caller, shipmentID, req)
+	if err != nil {
+		h.handleCardError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, shipment, r.Context())
+}
+
+// MarkDelivered handles POST /internal/cards/shipments/{id}/delivered
+// Internal callers only: the card processor reports the delivery
+func (h *Handler) MarkDelivered(w http.ResponseWriter, r *http.Request) {
+	// Extract calling service from context (set by InternalAuth)
+	caller, ok := r.Context().Value("internal_service").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	shipmentID := chi.URLParam(r, "id")
+	if shipmentID == "" {
+		response.Error(w, http.StatusBadRequest, "VAL_002", "Shipment ID is required", nil)
+		return
+	}
+
+	shipment, err := h.service.MarkCardDelivered(r.Context(), caller, shipmentID)
+	if err != nil {
+		h.handleCardError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, shipment, r.Context())
+}
+
+// GetCategoryControls lists merchant category rules for a card
+// GET /api/cards/{id}/categories
+func (h *Handler) GetCategoryControls(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	// Extract card ID from URL
+	cardID := chi.URLParam(r, "id")
+	if cardID == "" {
+		response.Error(w, http.StatusBadRequest, "VAL_002", "Card ID is required", nil)
+		return
+	}
+
+	controls, err := h.service.GetCategoryControls(r.Context(), userID, cardID)
+	if err != nil {
+		h.handleCardError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, controls, r.Context())
+}
+
+// UpdateCategoryControls replaces merchant category rules for a card
+// PUT /api/cards/{id}/categories
+func (h *Handler) UpdateCategoryControls(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	// Extract card ID from URL
+	cardID := chi.URLParam(r, "id")
+	if cardID == "" {
+		response.Error(w, http.StatusBadRequest, "VAL_002", "Card ID is required", nil)
+		return
+	}
+
+	// Decode request
+	var req UpdateCategoryControlsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "VAL_001", "Invalid request body", nil)
+		return
+	}
+
+	// Replace rules
+	if err := h.service.UpdateCategoryControls(r.Context(), userID, cardID, req); err != nil {
+		h.handleCardError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, map[string]string{"message": "Category controls updated successfully"}, r.Context())
+}
+
+// RequestReveal verifies the PIN and issues a single-use reveal token
+// POST /api/cards/{id}/reveal
+func (h *Handler) RequestReveal(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	// Extract card ID from URL
+	cardID := chi.URLParam(r, "id")
+	if cardID == "" {
+		response.Error(w, http.StatusBadRequest, "VAL_002", "Card ID is required", nil)
+		return
+	}
+
+	// Decode request
+	var req RevealCardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "VAL_001", "Invalid request body", nil)
+		return
+	}
+
+	setNoStoreHeaders(w)
+
+	// Step-up verification
+	token, err := h.service.RequestReveal(r.Context(), userID, cardID, req)
+	if err != nil {
+		h.handleCardError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusCreated, token, r.Context())
+}
+
+// ConsumeReveal returns the full card number and CVV for a valid reveal token
+// POST /api/cards/{id}/reveal/consume
+func (h *Handler) ConsumeReveal(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	// Extract card ID from URL
+	cardID := chi.URLParam(r, "id")
+	if cardID == "" {
+		response.Error(w, http.StatusBadRequest, "VAL_002", "Card ID is required", nil)
+		return
+	}
+
+	// Decode request
+	var req ConsumeRevealRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "VAL_001", "Invalid request body", nil)
+		return
+	}
+
+	// Sensitive response - must never be stored by browsers or proxies
+	setNoStoreHeaders(w)
+
+	data, err := h.service.ConsumeReveal(r.Context(), userID, cardID, req)
+	if err != nil {
+		h.handleCardError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, data, r.Context())
+}
+
+// TokenizePAN handles POST /internal/cards/tokenize
+// Internal callers only: resolves a PAN (e.g. from an authorization message) to its card and token
+func (h *Handler) TokenizePAN(w http.ResponseWriter, r *http.Request) {
+	// Extract calling service from context (set by InternalAuth)
+	caller, ok := r.Context().Value("internal_service").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	// Decode request
+	var req TokenizePANRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "VAL_001", "Invalid request body", nil)
+		return
+	}
+
+	result, err := h.service.TokenizePAN(r.Context(), caller, req)
+	if err != nil {
+		h.handleCardError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, result, r.Context())
+}
+
+// Detokenize handles POST /internal/cards/detokenize
+// Internal callers only: returns the PAN behind a token
+func (h *Handler) Detokenize(w http.ResponseWriter, r *http.Request) {
+	// Extract calling service from context (set by InternalAuth)
+	caller, ok := r.Context().Value("internal_service").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	// Decode request
+	var req DetokenizeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "VAL_001", "Invalid request body", nil)
+		return
+	}
+
+	// Sensitive response - must never be stored by browsers or proxies
+	setNoStoreHeaders(w)
+
+	result, err := h.service.Detokenize(r.Context(), caller, req)
+	if err != nil {
+		h.handleCardError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, result, r.Context())
+}
+
+// ListFraudRules handles GET /internal/fraud/rules
+// Internal callers only: returns the fraud rules and decision thresholds
+func (h *Handler) ListFraudRules(w http.ResponseWriter, r *http.Request) {
+	if _, ok := r.Context().Value("internal_service").(string); !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	rules, err := h.service.ListFraudRules(r.Context())
+	if err != nil {
+		h.handleCardError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, rules, r.Context())
+}
+
+// UpdateFraudRule handles PATCH /internal/fraud/rules/{code}
+// Internal callers only: enables, disables or re-tunes a fraud rule
+func (h *Handler) UpdateFraudRule(w http.ResponseWriter, r *http.Request) {
+	// Extract calling service from context (set by InternalAuth)
+	caller, ok := r.Context().Value("internal_service").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	code := chi.URLParam(r, "code")
+	if code == "" {
+		response.Error(w, http.StatusBadRequest, "VAL_002", "Rule code is required", nil)
+		return
+	}
+
+	// Decode request
+	var req UpdateFraudRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "VAL_001", "Invalid request body", nil)
+		return
+	}
+
+	rule, err := h.service.UpdateFraudRule(r.Context(), caller, code, req)
+	if err != nil {
+		h.handleCardError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, rule, r.Context())
+}
+
+// UpdateFraudThresholds handles PUT /internal/fraud/thresholds
+// Internal callers only: changes the step-up and decline scores
+func (h *Handler) UpdateFraudThresholds(w http.ResponseWriter, r *http.Request) {
+	// Extract calling service from context (set by InternalAuth)
+	caller, ok := r.Context().Value("internal_service").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	// Decode request
+	var req FraudThresholds
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "VAL_001", "Invalid request body", nil)
+		return
+	}
+
+	thresholds, err := h.service.UpdateFraudThresholds(r.Context(), caller, req)
+	if err != nil {
+		h.handleCardError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, thresholds, r.Context())
+}
+
+// ListCardFraudDecisions handles GET /internal/cards/{id}/fraud-decisions
+// Internal callers only: fraud analysts review why authorizations were approved, challenged or declined
+func (h *Handler) ListCardFraudDecisions(w http.ResponseWriter, r *http.Request) {
+	if _, ok := r.Context().Value("internal_service").(string); !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	cardID := chi.URLParam(r, "id")
+	if cardID == "" {
+		response.Error(w, http.StatusBadRequest, "VAL_002", "Card ID is required", nil)
+		return
+	}
+
+	// Parse pagination parameters
+	page := 1
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	limit := 20
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+
+	decisions, total, err := h.service.ListCardFraudDecisions(r.Context(), cardID, page, limit)
+	if err != nil {
+		h.handleCardError(w, err)
+		return
+	}
+
+	// Calculate pagination metadata
+	totalPages := int(total) / limit
+	if int(total)%limit != 0 {
+		totalPages++
+	}
+
+	pagination := response.Pagination{
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: totalPages,
+		HasMore:    page < totalPages,
+	}
+
+	response.Paginated(w, http.StatusOK, decisions, pagination, r.Context())
+}
+
+// setNoStoreHeaders disables caching for responses carrying sensitive card data
+func setNoStoreHeaders(w http.ResponseWriter) {
+	w.Header().Set("Cache-Control", "no-store, no-cache, must-revalidate, private")
+	w.Header().Set("Pragma", "no-cache")
+	w.Header().Set("Expires", "0")
+}
+
 // handleCardError maps service errors to HTTP responses
 func (h *Handler) handleCardError(w http.ResponseWriter, err error) {
 	switch err {
@@ -312,6 +767,32 @@ func (h *Handler) handleCardError(w http.ResponseWriter, err error) {
 	case ErrCardBlocked:
 		response.Error(w, http.StatusBadRequest, "CARD_005", "Card is blocked", nil)
 
+	// Replacement errors
+	case ErrInvalidCancelReason:
+		response.Error(w, http.StatusBadRequest, "VAL_014", "Invalid reason (must be lost, stolen or damaged; user_request for cancellation only)", nil)
+	case ErrCardAlreadyReplaced:
+		response.Error(w, http.StatusConflict, "CARD_006", "Card has already been replaced", nil)
+
+	// Shipping and activation errors
+	case ErrShippingAddressRequired:
+		response.Error(w, http.StatusBadRequest, "VAL_015", "Shipping address is required for physical cards and not allowed for virtual cards", nil)
+	case ErrInvalidShippingAddress:
+		response.Error(w, http.StatusBadRequest, "VAL_016", "Invalid shipping address (street, number, neighborhood, city, UF and 8-digit CEP are required)", nil)
+	case ErrShipmentNotFound:
+		response.Error(w, http.StatusNotFound, "SHIP_001", "Card shipment not found", nil)
+	case ErrInvalidShipmentTransition:
+		response.Error(w, http.StatusConflict, "SHIP_002", "Invalid card shipment status transition", nil)
+	case ErrInvalidTrackingInfo:
+		response.Error(w, http.StatusBadRequest, "VAL_017", "Carrier and tracking code are required", nil)
+	case ErrCardNotActivated:
+		response.Error(w, http.StatusBadRequest, "CARD_007", "Card has not been activated", nil)
+	case ErrCardAlreadyActivated:
+		response.Error(w, http.StatusConflict, "CARD_008", "Card has already been activated", nil)
+	case ErrActivationNotAvailable:
+		response.Error(w, http.StatusConflict, "CARD_009", "Card cannot be activated before it is shipped", nil)
+	case ErrActivationFailed:
+		response.Error(w, http.StatusForbidden, "CARD_010", "Last four digits or CVV do not match", nil)
+
 	// Validation errors
 	case ErrInvalidCardNumber:
 		response.Error(w, http.StatusBadRequest, "VAL_003", "Invalid card number", nil)
@@ -327,6 +808,34 @@ func (h *Handler) handleCardError(w http.ResponseWriter, err error) {
 		response.Error(w, http.StatusBadRequest, "VAL_008", "Invalid card type (must be physical or virtual)", nil)
 	case ErrInvalidCardBrand:
 		response.Error(w, http.StatusBadRequest, "VAL_009", "Invalid card brand (must be visa, mastercard, or elo)", nil)
+	case ErrInvalidCVVMode:
+		response.Error(w, http.StatusBadRequest, "VAL_010", "Invalid CVV mode (must be static or dynamic)", nil)
+	case ErrDynamicCVVVirtual:
+		response.Error(w, http.StatusBadRequest, "VAL_011", "Dynamic CVV is only available for virtual cards", nil)
+
+	// Profile errors
+	case ErrInvalidCardProfile:
+		response.Error(w, http.StatusBadRequest, "PROF_001", "Invalid card profile (must be standard, single_use, merchant_locked, or amount_capped)", nil)
+	case ErrProfileVirtualOnly:
+		response.Error(w, http.StatusBadRequest, "PROF_002", "Card profiles are only available for virtual cards", nil)
+	case ErrInvalidAmountCap:
+		response.Error(w, http.StatusBadRequest, "PROF_003", "Amount cap is required for amount_capped cards and not allowed otherwise", nil)
+	case ErrInvalidExpiryDays:
+		response.Error(w, http.StatusBadRequest, "PROF_004", "Invalid expiry in days (must be between 1 and 365)", nil)
+	case ErrMerchantNotAllowed:
+		response.Error(w, http.StatusForbidden, "PROF_005", "Card is locked to a different merchant", nil)
+	case ErrAmountCapExceeded:
+		response.Error(w, http.StatusBadRequest, "PROF_006", "Card amount cap exceeded", nil)
+
+	// Category control errors
+	case ErrInvalidCategory:
+		response.Error(w, http.StatusBadRequest, "CAT_001", "Invalid merchant category", nil)
+	case ErrDuplicateCategory:
+		response.Error(w, http.StatusBadRequest, "CAT_002", "Duplicate merchant category", nil)
+	case ErrCategoryBlocked:
+		response.Error(w, http.StatusForbidden, "CAT_003", "Merchant category is blocked on this card", nil)
+	case ErrCategoryLimitExceeded:
+		response.Error(w, http.StatusBadRequest, "CAT_004", "Merchant category monthly limit exceeded", nil)
 
 	// Limit errors
 	case ErrDailyLimitExceeded:
@@ -351,6 +860,50 @@ func (h *Handler) handleCardError(w http.ResponseWriter, err error) {
 		response.Error(w, http.StatusForbidden, "SEC_002", "Online transactions are blocked", nil)
 	case ErrContactlessBlocked:
 		response.Error(w, http.StatusForbidden, "SEC_003", "Contactless transactions are blocked", nil)
+	case ErrCVVVerificationFailed:
+		response.Error(w, http.StatusForbidden, "SEC_004", "CVV verification failed", nil)
+	case ErrChannelNotSupported:
+		response.Error(w, http.StatusForbidden, "SEC_005", "Channel not supported for this card", nil)
+	case ErrCVVRequired:
+		response.Error(w, http.StatusForbidden, "SEC_006", "CVV is required for card-not-present and dynamic CVV authorizations", nil)
+	case ErrFraudDeclined:
+		response.Error(w, http.StatusForbidden, "FRAUD_001", "Transaction declined by fraud rules", nil)
+	case ErrStepUpRequired:
+		response.Error(w, http.StatusUnauthorized, "FRAUD_002", "Additional authentication required", nil)
+	case ErrFraudRuleNotFound:
+		response.Error(w, http.StatusNotFound, "FRAUD_003", "Fraud rule not found", nil)
+	case ErrInvalidFraudRule:
+		response.Error(w, http.StatusBadRequest, "VAL_018", "Invalid fraud rule (score 0-100 and positive windows, counts and multiplier above 1)", nil)
+	case ErrInvalidFraudThresholds:
+		response.Error(w, http.StatusBadRequest, "VAL_019", "Invalid fraud thresholds (step-up score must be positive and not above the decline score)", nil)
+	case ErrInvalidTransactionFilter:
+		response.Error(w, http.StatusBadRequest, "VAL_020", "Invalid transaction filter (dates YYYY-MM-DD, known status and category, positive amounts in cents)", nil)
+	case ErrInvalidCursor:
+		response.Error(w, http.StatusBadRequest, "VAL_021", "Invalid pagination cursor", nil)
+	case ErrInvalidChannel:
+		response.Error(w, http.StatusBadRequest, "VAL_012", "Invalid authorization channel", nil)
+	case ErrInvalidCountry:
+		response.Error(w, http.StatusBadRequest, "VAL_013", "Invalid merchant country (must be ISO 3166-1 alpha-2)", nil)
+	case ErrInvalidAmount:
+		response.Error(w, http.StatusBadRequest, "VAL_022", "Invalid authorization amount (must be positive, in cents)", nil)
+
+	// Reveal errors
+	case ErrRevealNotAllowed:
+		response.Error(w, http.StatusForbidden, "REVEAL_001", "Card details can only be revealed for active virtual cards", nil)
+	case ErrRevealTokenInvalid:
+		response.Error(w, http.StatusUnauthorized, "REVEAL_002", "Reveal token is invalid, expired or already used", nil)
+
+	// Issuing errors
+	case ErrBINNotConfigured:
+		response.Error(w, http.StatusServiceUnavailable, "ISSUE_001", "Card issuing is not configured for this brand and card type", nil)
+	case ErrPANGenerationFailed:
+		response.Error(w, http.StatusServiceUnavailable, "ISSUE_002", "Could not generate a unique card number, please retry", nil)
+
+	// Tokenization errors
+	case ErrInvalidPANToken:
+		response.Error(w, http.StatusBadRequest, "TOKEN_001", "Invalid PAN token", nil)
+	case ErrPANTokenizationFailed:
+		response.Error(w, http.StatusServiceUnavailable, "TOKEN_002", "Could not generate a unique PAN token, please retry", nil)
 
 	// Encryption errors
 	case ErrEncryptionFailed:
@@ -369,7 +922,7 @@ func (h *Handler) handleCardError(w http.ResponseWriter, err error) {
 }
 
 // ListCardTransactions lists transactions for a specific card
-// GET /api/cards/{id}/transactions
+// GET /api/cards/{id}/transactions (same filters and pagination as GET /api/transactions)
 func (h *Handler) ListCardTransactions(w http.ResponseWriter, r *http.Request) {
 	userID, ok := r.Context().Value("user_id").(string)
 	if !ok {
@@ -383,21 +936,18 @@ func (h *Handler) ListCardTransactions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Parse pagination (default values)
-	limit := int32(20)
-	offset := int32(0)
-
-	transactions, err := h.service.ListCardTransactions(r.Context(), userID, cardID, limit, offset)
+	filter, err := ParseTransactionFilter(r.URL.Query())
 	if err != nil {
 		h.handleCardError(w, err)
 		return
 	}
+	filter.CardID = cardID
 
-	response.Success(w, http.StatusOK, transactions, r.Context())
+	h.listTransactions(w, r, userID, filter)
 }
 
 // ListUserTransactions lists all user's card transactions
-// GET /api/transactions
+// GET /api/transactions?card_id=&from=&to=&status=&category=&q=&min_amount=&max_amount=&international=&cursor=&limit=
 func (h *Handler) ListUserTransactions(w http.ResponseWriter, r *http.Request) {
 	userID, ok := r.Context().Value("user_id").(string)
 	if !ok {
@@ -405,13 +955,39 @@ func (h *Handler) ListUserTransactions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	transactions, err := h.service.ListUserCardTransactions(r.Context(), userID, 50, 0)
+	filter, err := ParseTransactionFilter(r.URL.Query())
 	if err != nil {
 		h.handleCardError(w, err)
 		return
 	}
 
-	response.Success(w, http.StatusOK, transactions, r.Context())
+	h.listTransactions(w, r, userID, filter)
+}
+
+// listTransactions writes a cursor-paginated page of filtered card transactions
+func (h *Handler) listTransactions(w http.ResponseWriter, r *http.Request, userID string, filter *TransactionFilter) {
+	// Parse pagination parameters
+	limit := DefaultTransactionPageSize
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= MaxTransactionPageSize {
+			limit = l
+		}
+	}
+
+	page, err := h.service.ListTransactions(r.Context(), userID, filter, r.URL.Query().Get("cursor"), limit)
+	if err != nil {
+		h.handleCardError(w, err)
+		return
+	}
+
+	pagination := response.CursorPagination{
+		Limit:      limit,
+		Total:      int(page.Totals.Count),
+		NextCursor: page.NextCursor,
+		HasMore:    page.NextCursor != "",
+	}
+
+	response.CursorPaginated(w, http.StatusOK, page.Transactions, pagination, page.Totals, r.Context())
 }
 
 // ExportTransactions exports user transactions to CSV
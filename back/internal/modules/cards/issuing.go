@@ -0,0 +1,103 @@
+package cards
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+)
+
+// maxPANAttempts bounds retries when a generated PAN collides with an existing card
+const maxPANAttempts = 10
+
+// GenerateCVV derives a CVV2-like 3-digit code from the PAN, expiry and the issuer service key
+//
+// Algorithm:
+// 1. mac = HMAC-SHA256(key, PAN || YYMM || "000")   ("000" = CVV2 service code)
+// 2. Decimalize the hex digest: take decimal digits first, then map a-f to 0-5
+// 3. CVV = first 3 decimalized digits
+//
+// The derivation is deterministic, so the issuer can re-derive the CVV for
+// verification without storing it in clear.
+func GenerateCVV(key []byte, pan string, expiryMonth, expiryYear int) string {
+	mac := hmac.New(sha256.New, key)
+	fmt.Fprintf(mac, "%s%02d%02d000", cleanCardNumber(pan), expiryYear%100, expiryMonth)
+	digest := hex.EncodeToString(mac.Sum(nil))
+
+	cvv := make([]byte, 0, 3)
+
+	// First pass: decimal digits
+	for i := 0; i < len(digest) && len(cvv) < 3; i++ {
+		if digest[i] >= '0' && digest[i] <= '9' {
+			cvv = append(cvv, digest[i])
+		}
+	}
+
+	// Second pass: hex letters a-f become 0-5
+	for i := 0; i < len(digest) && len(cvv) < 3; i++ {
+		if digest[i] >= 'a' && digest[i] <= 'f' {
+			cvv = append(cvv, '0'+(digest[i]-'a'))
+		}
+	}
+
+	return string(cvv)
+}
+
+// PANFingerprint returns the keyed HMAC-SHA256 fingerprint of a PAN (hex)
+// Used for uniqueness checks and lookups without decrypting card_number_encrypted
+func PANFingerprint(key []byte, pan string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(cleanCardNumber(pan)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// issuePAN generates a unique PAN from the configured BIN ranges of a brand and product
+func (s *Service) issuePAN(ctx context.Context, brand, product string) (pan, fingerprint string, err error) {
+	// 1. Load issuer BIN ranges
+	ranges, err := s.repo.ListActiveBINRanges(ctx, brand, product)
+	if err != nil {
+		return "", "", err
+	}
+	if len(ranges) == 0 {
+		return "", "", ErrBINNotConfigured
+	}
+
+	for attempt := 0; attempt < maxPANAttempts; attempt++ {
+		// 2. Pick a range and generate a Luhn-valid PAN
+		binRange, err := pickBINRange(ranges)
+		if err != nil {
+			return "", "", err
+		}
+
+		pan, err := GenerateCardNumber(binRange.Prefix, int(binRange.PanLength))
+		if err != nil {
+			return "", "", err
+		}
+
+		// 3. Ensure no existing card has the same PAN
+		fingerprint := PANFingerprint(s.cfg.PANFingerprintKey, pan)
+		exists, err := s.repo.PANFingerprintExists(ctx, fingerprint)
+		if err != nil {
+			return "", "", err
+		}
+		if !exists {
+			return pan, fingerprint, nil
+		}
+	}
+
+	return "", "", ErrPANGenerationFailed
+}
+
+// pickBINRange selects a BIN range uniformly at random
+func pickBINRange(ranges []db.CardBinRange) (*db.CardBinRange, error) {
+	idx, err := rand.Int(rand.Reader, big.NewInt(int64(len(ranges))))
+	if err != nil {
+		return nil, err
+	}
+	return &ranges[idx.Int64()], nil
+}
@@ -0,0 +1,84 @@
+package cards
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+// TestGenerateCVV tests deterministic server-side CVV derivation
+func TestGenerateCVV(t *testing.T) {
+	key := []byte("test-cvv-key-0123456789abcdef012")
+	pan := "4984021234567897"
+
+	cvv := GenerateCVV(key, pan, 12, 2030)
+	if err := ValidateCVV(cvv); err != nil || len(cvv) != 3 {
+		t.Fatalf("expected valid 3-digit CVV, got %q", cvv)
+	}
+
+	// Deterministic for the same inputs (formatting is ignored)
+	if got := GenerateCVV(key, "4984 0212 3456 7897", 12, 2030); got != cvv {
+		t.Errorf("expected same CVV for same PAN, got %s and %s", cvv, got)
+	}
+
+	// Any input change should (almost always) change the CVV
+	variants := []string{
+		GenerateCVV(key, pan, 11, 2030),
+		GenerateCVV(key, pan, 12, 2031),
+		GenerateCVV(key, "4984021234567805", 12, 2030),
+		GenerateCVV([]byte("another-cvv-key-0123456789abcdef"), pan, 12, 2030),
+	}
+	same := 0
+	for _, v := range variants {
+		if v == cvv {
+			same++
+		}
+	}
+	if same > 1 {
+		t.Errorf("CVV did not change for %d of %d input variations", same, len(variants))
+	}
+}
+
+// TestPANFingerprint tests keyed PAN fingerprints
+func TestPANFingerprint(t *testing.T) {
+	key := []byte("test-fingerprint-key-0123456789a")
+	pan := "4984021234567897"
+
+	fp := PANFingerprint(key, pan)
+	if len(fp) != 64 {
+		t.Fatalf("expected 64-char hex fingerprint, got %d", len(fp))
+	}
+	if PANFingerprint(key, "4984-0212-3456-7897") != fp {
+		t.Error("fingerprint should ignore formatting")
+	}
+	if PANFingerprint([]byte("other-fingerprint-key-0123456789"), pan) == fp {
+		t.Error("fingerprint should depend on the key")
+	}
+	if PANFingerprint(key, "4984021234567805") == fp {
+		t.Error("different PANs should have different fingerprints")
+	}
+}
+
+// TestUniqueViolationError tests mapping PAN and token collisions on insert
+func TestUniqueViolationError(t *testing.T) {
+	other := errors.New("connection reset")
+	tests := []struct {
+		err  error
+		want error
+	}{
+		{&pq.Error{Code: "23505", Constraint: "idx_cards_pan_fingerprint"}, ErrPANGenerationFailed},
+		{&pq.Error{Code: "23505", Constraint: "idx_cards_pan_token"}, ErrPANTokenizationFailed},
+		{other, other},
+	}
+	for _, tt := range tests {
+		if got := uniqueViolationError(tt.err); got != tt.want {
+			t.Errorf("uniqueViolationError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+
+	unrelated := &pq.Error{Code: "23505", Constraint: "cards_pkey"}
+	if got := uniqueViolationError(unrelated); got != unrelated {
+		t.Errorf("uniqueViolationError(%v) = %v, want it unchanged", unrelated, got)
+	}
+}
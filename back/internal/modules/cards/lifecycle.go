@@ -0,0 +1,191 @@
+package cards
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lauratech/fin/back/internal/modules/notifications"
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+)
+
+// Expiry lifecycle defaults
+const (
+	DefaultRenewalLeadDays  = 45
+	DefaultExpiryNoticeDays = 30
+)
+
+// LifecycleResult summarizes one run of the expiry lifecycle job
+type LifecycleResult struct {
+	Expired  int `json:"expired"`
+	Renewed  int `json:"renewed"`
+	Notified int `json:"notified"`
+	Failed   int `json:"failed"`
+}
+
+// RunExpiryLifecycle moves expired cards to "expired", renews physical cards
+// close to expiry and notifies users of cards about to expire.
+//
+// The job is idempotent: notifications are deduplicated per card and expiry,
+// and renewed cards leave the renewal window.
+func (s *Service) RunExpiryLifecycle(ctx context.Context, now time.Time) (*LifecycleResult, error) {
+	result := &LifecycleResult{}
+	var errs []error
+
+	// 1. Put pending same-PAN renewals into effect once the current plastic
+	// expires, then expire cards past their expiry date
+	if _, err := s.repo.ApplyOverdueRenewals(ctx, now); err != nil {
+		return nil, err
+	}
+	expired, err := s.repo.ExpireCards(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	for i := range expired {
+		card := &expired[i]
+		result.Expired++
+		s.notifyCard(ctx, result, &errs, card, notifications.TypeCardExpired,
+			"Card expired",
+			fmt.Sprintf("Your card ending in %s expired and can no longer be used.", card.LastFourDigits))
+	}
+
+	// 2. Renew physical cards inside the renewal window
+	renewalLeadDays := s.cfg.RenewalLeadDays
+	if renewalLeadDays <= 0 {
+		renewalLeadDays = DefaultRenewalLeadDays
+	}
+	due, err := s.repo.ListCardsDueForRenewal(ctx, now, now.AddDate(0, 0, renewalLeadDays))
+	if err != nil {
+		return nil, err
+	}
+	for i := range due {
+		card := &due[i]
+		renewed, err := s.renewCard(ctx, card)
+		if err != nil {
+			result.Failed++
+			errs = append(errs, fmt.Errorf("renew card %s: %w", card.ID, err))
+			continue
+		}
+		if !renewed {
+			continue
+		}
+		result.Renewed++
+
+		message := fmt.Sprintf("Your card ending in %s was renewed. A new card is on its way.", card.LastFourDigits)
+		if s.cfg.RenewSamePAN {
+			message = fmt.Sprintf("Your card ending in %s was renewed with a new expiry date and security code. The card number stays the same; "+
+				"keep using your current card until you activate the new one.", card.LastFourDigits)
+		}
+		s.notifyCard(ctx, result, &errs, card, notifications.TypeCardRenewed, "Card renewed", message)
+	}
+
+	// 3. Notify cards about to expire (renewed cards already left the window)
+	expiryNoticeDays := s.cfg.ExpiryNoticeDays
+	if expiryNoticeDays <= 0 {
+		expiryNoticeDays = DefaultExpiryNoticeDays
+	}
+	expiring, err := s.repo.ListCardsExpiringBetween(ctx, now, now.AddDate(0, 0, expiryNoticeDays))
+	if err != nil {
+		return nil, err
+	}
+	for i := range expiring {
+		card := &expiring[i]
+		s.notifyCard(ctx, result, &errs, card, notifications.TypeCardExpiring,
+			"Card expiring soon",
+			fmt.Sprintf("Your card ending in %s expires on %02d/%d.", card.LastFourDigits, card.ExpiryMonth, card.ExpiryYear))
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// renewCard renews a physical card before it expires.
+// With RenewSamePAN the card keeps its number and a new plastic is shipped with
+// a new expiry and CVV, which take effect when the holder activates it (or when
+// the current expiry passes), so the plastic in hand keeps working meanwhile;
+// otherwise a new card is issued with the same settings and the old card stays
+// usable until it expires. Returns false if the card was renewed concurrently.
+func (s *Service) renewCard(ctx context.Context, card *db.Card) (bool, error) {
+	// 1. Same PAN: pending expiry and CVV on the existing card, re-shipped to the same address
+	if s.cfg.RenewSamePAN {
+		cardNumber, err := s.repo.DecryptCardNumber(card)
+		if err != nil {
+			return false, err
+		}
+
+		address, err := s.reissueShippingAddress(ctx, card, nil)
+		if err != nil {
+			return false, err
+		}
+
+		expiresAt := CalculateExpiryDate(card.Type)
+		cvv := GenerateCVV(s.cfg.CVVKey, cardNumber, int(expiresAt.Month()), expiresAt.Year())
+
+		renewed := false
+		err = s.executeInTransaction(ctx, func(tx *sql.Tx) error {
+			renewed, err = s.repo.RenewInPlace(ctx, tx, card, cvv, expiresAt)
+			if err != nil || !renewed {
+				return err
+			}
+			_, err = s.repo.CreateShipment(ctx, tx, card.ID.String(), address)
+			return err
+		})
+		return renewed, err
+	}
+
+	// 2. New PAN: reissue and link the old card to the new one
+	controls, err := s.repo.ListCategoryControls(ctx, card.ID.String())
+	if err != nil {
+		return false, err
+	}
+
+	renewed := false
+	err = s.executeInTransaction(ctx, func(tx *sql.Tx) error {
+		oldCard, err := s.repo.GetForUpdate(ctx, tx, card.ID.String())
+		if err != nil {
+			return err
+		}
+		if oldCard.ReplacedByCardID.Valid || oldCard.Status != "active" {
+			return nil
+		}
+
+		if _, err := s.issueReplacement(ctx, tx, oldCard, controls, true, nil); err != nil {
+			return err
+		}
+		renewed = true
+		return nil
+	})
+	return renewed, err
+}
+
+// notifyCard raises a card notification, deduplicated per card and expiry
+func (s *Service) notifyCard(ctx context.Context, result *LifecycleResult, errs *[]error, card *db.Card, notificationType, title, message string) {
+	if s.notifications == nil {
+		return
+	}
+
+	created, err := s.notifications.Notify(ctx, notifications.NotifyRequest{
+		UserID:       card.UserID.String(),
+		Type:         notificationType,
+		Title:        title,
+		Message:      message,
+		ResourceType: "card",
+		ResourceID:   card.ID.String(),
+		DedupKey:     expiryNotificationKey(notificationType, card),
+	})
+	if err != nil {
+		result.Failed++
+		*errs = append(*errs, fmt.Errorf("notify card %s: %w", card.ID, err))
+		return
+	}
+	if created {
+		result.Notified++
+	}
+}
+
+// expiryNotificationKey identifies a lifecycle notification for one card expiry,
+// so each event is notified once per expiry date
+func expiryNotificationKey(notificationType string, card *db.Card) string {
+	return fmt.Sprintf("%s:%s:%04d-%02d", notificationType, card.ID, card.ExpiryYear, card.ExpiryMonth)
+}
@@ -0,0 +1,40 @@
+package cards
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+)
+
+// TestExpiryNotificationKey tests that lifecycle notifications are keyed per card, type and expiry
+func TestExpiryNotificationKey(t *testing.T) {
+	cardID := uuid.MustParse("7f1c5a2e-3b4d-4e6f-8a9b-0c1d2e3f4a5b")
+	otherID := uuid.MustParse("0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d")
+
+	card := &db.Card{ID: cardID, ExpiryMonth: 3, ExpiryYear: 2027}
+	renewed := &db.Card{ID: cardID, ExpiryMonth: 3, ExpiryYear: 2032}
+	other := &db.Card{ID: otherID, ExpiryMonth: 3, ExpiryYear: 2027}
+
+	key := expiryNotificationKey("card_expiring", card)
+	if key != "card_expiring:7f1c5a2e-3b4d-4e6f-8a9b-0c1d2e3f4a5b:2027-03" {
+		t.Errorf("expiryNotificationKey() = %s", key)
+	}
+
+	tests := []struct {
+		name  string
+		other string
+	}{
+		{"different type", expiryNotificationKey("card_expired", card)},
+		{"different expiry", expiryNotificationKey("card_expiring", renewed)},
+		{"different card", expiryNotificationKey("card_expiring", other)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.other == key {
+				t.Errorf("expected distinct keys, both are %s", key)
+			}
+		})
+	}
+}
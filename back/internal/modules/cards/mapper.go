@@ -1,6 +1,7 @@
 package cards
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strings"
@@ -19,7 +20,14 @@ func dbCardToCard(dbCard *db.Card, cardNumber, cvv string) *Card {
 		Status:                   dbCard.Status,
 		CardNumber:               cardNumber,
 		CVV:                      cvv,
+		CVVMode:                  dbCard.CvvMode,
+		Profile:                  dbCard.Profile,
+		LockedMerchantName:       dbCard.LockedMerchantName.String,
+		LockedMerchantCategory:   dbCard.LockedMerchantCategory.String,
+		AmountCapCents:           dbCard.AmountCapCents.Int64,
+		TotalSpentCents:          dbCard.TotalSpentCents,
 		LastFourDigits:           dbCard.LastFourDigits,
+		PANToken:                 dbCard.PanToken.String,
 		HolderName:               dbCard.HolderName,
 		ExpiryMonth:              int(dbCard.ExpiryMonth),
 		ExpiryYear:               int(dbCard.ExpiryYear),
@@ -54,6 +62,18 @@ func dbCardToCard(dbCard *db.Card, cardNumber, cvv string) *Card {
 	if dbCard.BlockedAt.Valid {
 		card.BlockedAt = &dbCard.BlockedAt.Time
 	}
+	if dbCard.CancellationReason.Valid {
+		card.CancellationReason = dbCard.CancellationReason.String
+	}
+	if dbCard.CancelledAt.Valid {
+		card.CancelledAt = &dbCard.CancelledAt.Time
+	}
+	if dbCard.ReplacedByCardID.Valid {
+		card.ReplacedByCardID = dbCard.ReplacedByCardID.UUID.String()
+	}
+	if dbCard.ActivatedAt.Valid {
+		card.ActivatedAt = &dbCard.ActivatedAt.Time
+	}
 
 	return card
 }
@@ -73,6 +93,8 @@ func dbCardToCardSummary(dbCard *db.Card) *CardSummary {
 		ExpiryYear:               int(dbCard.ExpiryYear),
 		CurrentDailySpentCents:   dbCard.CurrentDailySpentCents.Int64,
 		CurrentMonthlySpentCents: dbCard.CurrentMonthlySpentCents.Int64,
+		CVVMode:                  dbCard.CvvMode,
+		Profile:                  dbCard.Profile,
 		CreatedAt:                dbCard.CreatedAt.Time,
 	}
 
@@ -89,6 +111,9 @@ func dbCardToCardSummary(dbCard *db.Card) *CardSummary {
 	if dbCard.IsInternational.Valid {
 		summary.IsInternational = dbCard.IsInternational.Bool
 	}
+	if dbCard.ActivatedAt.Valid {
+		summary.ActivatedAt = &dbCard.ActivatedAt.Time
+	}
 
 	return summary
 }
@@ -115,15 +140,103 @@ func cardToCardDetails(card *Card, hasPIN bool) *CardDetails {
 		BlockInternational:       card.BlockInternational,
 		BlockOnline:              card.BlockOnline,
 		HasPIN:                   hasPIN,
+		CVVMode:                  card.CVVMode,
+		Profile:                  card.Profile,
+		LockedMerchantName:       card.LockedMerchantName,
+		LockedMerchantCategory:   card.LockedMerchantCategory,
+		AmountCapCents:           card.AmountCapCents,
+		TotalSpentCents:          card.TotalSpentCents,
 		CreatedAt:                card.CreatedAt,
 		UpdatedAt:                card.UpdatedAt,
 		ExpiresAt:                card.ExpiresAt,
 		BlockedAt:                card.BlockedAt,
+		CancellationReason:       card.CancellationReason,
+		CancelledAt:              card.CancelledAt,
+		ReplacedByCardID:         card.ReplacedByCardID,
+		ActivatedAt:              card.ActivatedAt,
 	}
 
 	return details
 }
 
+// cardToCardSummary converts a card to a summary (no sensitive data)
+// Use this for responses about a newly issued card
+func cardToCardSummary(card *Card) *CardSummary {
+	return &CardSummary{
+		ID:                       card.ID,
+		UserID:                   card.UserID,
+		Type:                     card.Type,
+		Brand:                    card.Brand,
+		Status:                   card.Status,
+		LastFourDigits:           card.LastFourDigits,
+		HolderName:               card.HolderName,
+		ExpiryMonth:              card.ExpiryMonth,
+		ExpiryYear:               card.ExpiryYear,
+		DailyLimitCents:          card.DailyLimitCents,
+		MonthlyLimitCents:        card.MonthlyLimitCents,
+		CurrentDailySpentCents:   card.CurrentDailySpentCents,
+		CurrentMonthlySpentCents: card.CurrentMonthlySpentCents,
+		IsContactless:            card.IsContactless,
+		IsInternational:          card.IsInternational,
+		CVVMode:                  card.CVVMode,
+		Profile:                  card.Profile,
+		CreatedAt:                card.CreatedAt,
+		ActivatedAt:              card.ActivatedAt,
+	}
+}
+
+// dbShipmentToCardShipment converts a database shipment to a card shipment
+func dbShipmentToCardShipment(dbShipment *db.CardShipment) *CardShipment {
+	shipment := &CardShipment{
+		ID:     dbShipment.ID.String(),
+		CardID: dbShipment.CardID.String(),
+		Status: dbShipment.Status,
+		Address: ShippingAddress{
+			RecipientName: dbShipment.RecipientName,
+			Street:        dbShipment.Street,
+			Number:        dbShipment.Number,
+			Complement:    dbShipment.Complement.String,
+			Neighborhood:  dbShipment.Neighborhood,
+			City:          dbShipment.City,
+			State:         dbShipment.State,
+			PostalCode:    dbShipment.PostalCode,
+		},
+		Carrier:      dbShipment.Carrier.String,
+		TrackingCode: dbShipment.TrackingCode.String,
+		RequestedAt:  dbShipment.RequestedAt.Time,
+	}
+
+	// Handle nullable fields
+	if dbShipment.ProducedAt.Valid {
+		shipment.ProducedAt = &dbShipment.ProducedAt.Time
+	}
+	if dbShipment.ShippedAt.Valid {
+		shipment.ShippedAt = &dbShipment.ShippedAt.Time
+	}
+	if dbShipment.DeliveredAt.Valid {
+		shipment.DeliveredAt = &dbShipment.DeliveredAt.Time
+	}
+	if dbShipment.ActivatedAt.Valid {
+		shipment.ActivatedAt = &dbShipment.ActivatedAt.Time
+	}
+
+	return shipment
+}
+
+// dbCategoryControlToCategoryControl converts a category rule with its current spending
+func dbCategoryControlToCategoryControl(dbControl *db.CardCategoryControl, spentCents int64) CategoryControl {
+	control := CategoryControl{
+		Category:            dbControl.Category,
+		IsBlocked:           dbControl.IsBlocked,
+		SpentThisMonthCents: spentCents,
+	}
+	if dbControl.MonthlyLimitCents.Valid {
+		limit := dbControl.MonthlyLimitCents.Int64
+		control.MonthlyLimitCents = &limit
+	}
+	return control
+}
+
 // maskCardNumber masks a card number for display
 // Example: "4532123456789012" -> "**** **** **** 9012"
 func maskCardNumber(cardNumber string) string {
@@ -238,3 +351,43 @@ func formatExpiryDate(month, year int) string {
 	yearShort := year % 100
 	return fmt.Sprintf("%02d/%02d", month, yearShort)
 }
+
+// dbFraudRuleToFraudRule converts a database fraud rule to domain fraud rule
+func dbFraudRuleToFraudRule(dbRule *db.FraudRule) (*FraudRule, error) {
+	rule := &FraudRule{
+		Code:        dbRule.Code,
+		Description: dbRule.Description,
+		IsEnabled:   dbRule.IsEnabled,
+		Score:       int(dbRule.Score),
+		UpdatedAt:   dbRule.UpdatedAt.Time,
+	}
+	if err := json.Unmarshal(dbRule.Params, &rule.Params); err != nil {
+		return nil, fmt.Errorf("fraud rule %s: invalid params: %w", dbRule.Code, err)
+	}
+	return rule, nil
+}
+
+// dbFraudDecisionToFraudDecision converts a logged fraud decision to domain model
+func dbFraudDecisionToFraudDecision(dbDecision *db.CardFraudDecision) *FraudDecision {
+	decision := &FraudDecision{
+		ID:           dbDecision.ID.String(),
+		CardID:       dbDecision.CardID.String(),
+		AmountCents:  dbDecision.AmountCents,
+		MerchantName: dbDecision.MerchantName,
+		MCC:          dbDecision.Mcc.String,
+		Channel:      dbDecision.Channel,
+		Country:      dbDecision.MerchantCountry.String,
+		Decision:     dbDecision.Decision,
+		Score:        int(dbDecision.Score),
+		Reasons:      []FraudReason{},
+		CreatedAt:    dbDecision.CreatedAt.Time,
+	}
+	if dbDecision.CardTransactionID.Valid {
+		decision.TransactionID = dbDecision.CardTransactionID.UUID.String()
+	}
+
+	// The explanation is written by the service; an unreadable one is returned empty
+	_ = json.Unmarshal(dbDecision.Explanation, &decision.Reasons)
+
+	return decision
+}
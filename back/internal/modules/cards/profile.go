@@ -0,0 +1,82 @@
+package cards
+
+import (
+	"strings"
+
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+)
+
+// Card profiles
+const (
+	ProfileStandard       = "standard"
+	ProfileSingleUse      = "single_use"      // Auto-cancelled after the first successful authorization
+	ProfileMerchantLocked = "merchant_locked" // Bound to the first merchant it is used at
+	ProfileAmountCapped   = "amount_capped"   // Lifetime spending capped at amount_cap_cents
+)
+
+// MaxProfileExpiryDays is the longest expiry allowed for profiled cards
+const MaxProfileExpiryDays = 365
+
+// ValidateCardProfile validates profile settings on card creation
+// Non-standard profiles are virtual-only; amount_capped requires a cap
+func ValidateCardProfile(profile, cardType string, amountCapCents int64, expiryDays int) error {
+	switch profile {
+	case ProfileStandard:
+		if amountCapCents != 0 || expiryDays != 0 {
+			return ErrInvalidCardProfile
+		}
+		return nil
+	case ProfileSingleUse, ProfileMerchantLocked, ProfileAmountCapped:
+		// Validated below
+	default:
+		return ErrInvalidCardProfile
+	}
+
+	if cardType != "virtual" {
+		return ErrProfileVirtualOnly
+	}
+
+	if profile == ProfileAmountCapped {
+		if amountCapCents <= 0 {
+			return ErrInvalidAmountCap
+		}
+	} else if amountCapCents != 0 {
+		return ErrInvalidAmountCap
+	}
+
+	if expiryDays < 0 || expiryDays > MaxProfileExpiryDays {
+		return ErrInvalidExpiryDays
+	}
+
+	return nil
+}
+
+// checkProfileRules enforces profile restrictions on an authorization
+// card must be the locked row; single-use cancellation happens after success
+func checkProfileRules(card *db.Card, amountCents int64, merchantName, merchantCategory string) error {
+	switch card.Profile {
+	case ProfileMerchantLocked:
+		// First use binds the card; later uses must match
+		if !card.LockedMerchantName.Valid {
+			return nil
+		}
+		if !sameMerchant(card.LockedMerchantName.String, merchantName) {
+			return ErrMerchantNotAllowed
+		}
+		if card.LockedMerchantCategory.Valid && card.LockedMerchantCategory.String != merchantCategory {
+			return ErrMerchantNotAllowed
+		}
+
+	case ProfileAmountCapped:
+		if card.AmountCapCents.Valid && card.TotalSpentCents+amountCents > card.AmountCapCents.Int64 {
+			return ErrAmountCapExceeded
+		}
+	}
+
+	return nil
+}
+
+// sameMerchant compares merchant names ignoring case and surrounding spaces
+func sameMerchant(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
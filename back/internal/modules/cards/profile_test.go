@@ -0,0 +1,132 @@
+package cards
+
+import (
+	"database/sql"
+	"testing"
+
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+)
+
+// TestValidateCardProfile tests profile validation on card creation
+func TestValidateCardProfile(t *testing.T) {
+	tests := []struct {
+		name           string
+		profile        string
+		cardType       string
+		amountCapCents int64
+		expiryDays     int
+		expected       error
+	}{
+		{"Standard", ProfileStandard, "physical", 0, 0, nil},
+		{"Standard with cap", ProfileStandard, "virtual", 1000, 0, ErrInvalidCardProfile},
+		{"Single use virtual", ProfileSingleUse, "virtual", 0, 7, nil},
+		{"Single use physical", ProfileSingleUse, "physical", 0, 0, ErrProfileVirtualOnly},
+		{"Merchant locked", ProfileMerchantLocked, "virtual", 0, 0, nil},
+		{"Amount capped", ProfileAmountCapped, "virtual", 50000, 30, nil},
+		{"Amount capped without cap", ProfileAmountCapped, "virtual", 0, 30, ErrInvalidAmountCap},
+		{"Cap on non-capped profile", ProfileSingleUse, "virtual", 50000, 0, ErrInvalidAmountCap},
+		{"Expiry too long", ProfileAmountCapped, "virtual", 50000, 400, ErrInvalidExpiryDays},
+		{"Negative expiry", ProfileSingleUse, "virtual", 0, -1, ErrInvalidExpiryDays},
+		{"Unknown profile", "burner", "virtual", 0, 0, ErrInvalidCardProfile},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCardProfile(tt.profile, tt.cardType, tt.amountCapCents, tt.expiryDays)
+			if err != tt.expected {
+				t.Errorf("ValidateCardProfile() error = %v, expected %v", err, tt.expected)
+			}
+		})
+	}
+}
+
+// TestCheckProfileRules tests profile enforcement during authorization
+func TestCheckProfileRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		card     db.Card
+		amount   int64
+		merchant string
+		category string
+		expected error
+	}{
+		{
+			name:     "Standard card",
+			card:     db.Card{Profile: ProfileStandard},
+			amount:   1000,
+			merchant: "Any Shop",
+			expected: nil,
+		},
+		{
+			name:     "Merchant locked first use",
+			card:     db.Card{Profile: ProfileMerchantLocked},
+			amount:   1000,
+			merchant: "Netflix",
+			category: "streaming",
+			expected: nil,
+		},
+		{
+			name: "Merchant locked same merchant",
+			card: db.Card{
+				Profile:                ProfileMerchantLocked,
+				LockedMerchantName:     sql.NullString{String: "Netflix", Valid: true},
+				LockedMerchantCategory: sql.NullString{String: "streaming", Valid: true},
+			},
+			amount:   1000,
+			merchant: " netflix ",
+			category: "streaming",
+			expected: nil,
+		},
+		{
+			name: "Merchant locked different merchant",
+			card: db.Card{
+				Profile:            ProfileMerchantLocked,
+				LockedMerchantName: sql.NullString{String: "Netflix", Valid: true},
+			},
+			amount:   1000,
+			merchant: "Other Shop",
+			expected: ErrMerchantNotAllowed,
+		},
+		{
+			name: "Merchant locked different category",
+			card: db.Card{
+				Profile:                ProfileMerchantLocked,
+				LockedMerchantName:     sql.NullString{String: "Netflix", Valid: true},
+				LockedMerchantCategory: sql.NullString{String: "streaming", Valid: true},
+			},
+			amount:   1000,
+			merchant: "Netflix",
+			category: "games",
+			expected: ErrMerchantNotAllowed,
+		},
+		{
+			name: "Amount capped within cap",
+			card: db.Card{
+				Profile:         ProfileAmountCapped,
+				AmountCapCents:  sql.NullInt64{Int64: 10000, Valid: true},
+				TotalSpentCents: 4000,
+			},
+			amount:   6000,
+			expected: nil,
+		},
+		{
+			name: "Amount capped exceeded",
+			card: db.Card{
+				Profile:         ProfileAmountCapped,
+				AmountCapCents:  sql.NullInt64{Int64: 10000, Valid: true},
+				TotalSpentCents: 4000,
+			},
+			amount:   6001,
+			expected: ErrAmountCapExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkProfileRules(&tt.card, tt.amount, tt.merchant, tt.category)
+			if err != tt.expected {
+				t.Errorf("checkProfileRules() error = %v, expected %v", err, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package cards
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+)
+
+// Cancellation reasons
+const (
+	CancelReasonLost        = "lost"
+	CancelReasonStolen      = "stolen"
+	CancelReasonDamaged     = "damaged"
+	CancelReasonUserRequest = "user_request"
+)
+
+// RecurringMerchantLookback is how far back charges count towards recurring merchant detection
+const RecurringMerchantLookback = 12 * 30 * 24 * time.Hour
+
+// ValidateCancelReason validates the reason given when cancelling a card
+func ValidateCancelReason(reason string) error {
+	switch reason {
+	case CancelReasonLost, CancelReasonStolen, CancelReasonDamaged, CancelReasonUserRequest:
+		return nil
+	default:
+		return ErrInvalidCancelReason
+	}
+}
+
+// ValidateReplacementReason validates the reason given when replacing a card
+// Replacement is for cards that can no longer be used (user_request is a plain cancellation)
+func ValidateReplacementReason(reason string) error {
+	switch reason {
+	case CancelReasonLost, CancelReasonStolen, CancelReasonDamaged:
+		return nil
+	default:
+		return ErrInvalidCancelReason
+	}
+}
+
+// statusForCancelReason maps a cancellation reason to the card status
+// Lost and stolen cards keep a distinct status for fraud investigation
+func statusForCancelReason(reason string) string {
+	switch reason {
+	case CancelReasonLost:
+		return "lost"
+	case CancelReasonStolen:
+		return "stolen"
+	default:
+		return "cancelled"
+	}
+}
+
+// isClosedStatus reports whether a card status is terminal (card can never be used again)
+func isClosedStatus(status string) bool {
+	return status == "cancelled" || status == "lost" || status == "stolen"
+}
+
+// ReplaceCard cancels a card and issues a new PAN with the same settings
+func (s *Service) ReplaceCard(ctx context.Context, userID, cardID string, req ReplaceCardRequest) (*ReplaceCardResponse, error) {
+	// 1. Validate reason
+	if err := ValidateReplacementReason(req.Reason); err != nil {
+		return nil, err
+	}
+
+	// 2. Verify ownership
+	summary, err := s.repo.GetByIDForSummary(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	if summary.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	// 3. Category rules are copied to the new card
+	controls, err := s.repo.ListCategoryControls(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *ReplaceCardResponse
+
+	err = s.executeInTransaction(ctx, func(tx *sql.Tx) error {
+		// 4. Lock old card
+		oldCard, err := s.repo.GetForUpdate(ctx, tx, cardID)
+		if err != nil {
+			return err
+		}
+		if oldCard.ReplacedByCardID.Valid {
+			return ErrCardAlreadyReplaced
+		}
+
+		// 5. Cancel old card with the reason
+		if !isClosedStatus(oldCard.Status) {
+			if err := s.repo.CancelCard(ctx, tx, cardID, statusForCancelReason(req.Reason), req.Reason); err != nil {
+				return err
+			}
+		}
+
+		// 6. Issue the new card and link the old one to it
+		result, err = s.issueReplacement(ctx, tx, oldCard, controls, req.MigrateRecurringMerchants, req.ShippingAddress)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// issueReplacement issues a new PAN with the settings of oldCard (locked by the caller)
+// and links oldCard to it. Shared by replacement and renewal.
+// Physical cards are shipped to shippingAddress (nil keeps the old card's address).
+func (s *Service) issueReplacement(ctx context.Context, tx *sql.Tx, oldCard *db.Card, controls []db.CardCategoryControl, migrateRecurringMerchants bool, shippingAddress *ShippingAddress) (*ReplaceCardResponse, error) {
+	oldCardID := oldCard.ID.String()
+	physical := oldCard.Type == "physical"
+
+	// 1. Resolve delivery address (physical cards only)
+	if !physical && shippingAddress != nil {
+		return nil, ErrShippingAddressRequired
+	}
+	var address ShippingAddress
+	if physical {
+		resolved, err := s.reissueShippingAddress(ctx, oldCard, shippingAddress)
+		if err != nil {
+			return nil, err
+		}
+		address = resolved
+	}
+
+	// 2. Issue new PAN (same brand and product)
+	cardNumber, fingerprint, err := s.issuePAN(ctx, oldCard.Brand, oldCard.Type)
+	if err != nil {
+		return nil, err
+	}
+	panToken, err := s.newPANToken(ctx, cardNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Create new card with the same settings and limits
+	params, err := s.reissueParams(oldCard, cardNumber, fingerprint, panToken)
+	if err != nil {
+		return nil, err
+	}
+	params.RequiresActivation = physical
+	newCard, err := s.repo.CreateWithTx(ctx, tx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if physical {
+		if _, err := s.repo.CreateShipment(ctx, tx, newCard.ID, address); err != nil {
+			return nil, err
+		}
+	}
+
+	if oldCard.LockedMerchantName.Valid {
+		if err := s.repo.LockMerchant(ctx, tx, newCard.ID, oldCard.LockedMerchantName.String, oldCard.LockedMerchantCategory.String); err != nil {
+			return nil, err
+		}
+		newCard.LockedMerchantName = oldCard.LockedMerchantName.String
+		newCard.LockedMerchantCategory = oldCard.LockedMerchantCategory.String
+	}
+
+	if len(controls) > 0 {
+		if err := s.repo.ReplaceCategoryControls(ctx, tx, newCard.ID, categoryControlRequests(controls)); err != nil {
+			return nil, err
+		}
+	}
+
+	// 4. Link old card to the new one
+	if err := s.repo.SetReplacement(ctx, tx, oldCardID, newCard.ID); err != nil {
+		return nil, err
+	}
+
+	// 5. Account-updater stand-in: queue recurring merchants for the new card
+	migrated := []string{}
+	if migrateRecurringMerchants {
+		merchants, err := s.repo.ListRecurringMerchants(ctx, tx, oldCardID, time.Now().Add(-RecurringMerchantLookback))
+		if err != nil {
+			return nil, err
+		}
+		for _, merchant := range merchants {
+			if err := s.repo.CreateAccountUpdate(ctx, tx, oldCardID, newCard.ID, merchant.MerchantName, merchant.MerchantCategory); err != nil {
+				return nil, err
+			}
+			migrated = append(migrated, merchant.MerchantName)
+		}
+	}
+
+	return &ReplaceCardResponse{
+		ReplacedCardID:    oldCardID,
+		Card:              cardToCardSummary(newCard),
+		MigratedMerchants: migrated,
+	}, nil
+}
+
+// reissueParams builds the parameters of a replacement card from the old card row
+func (s *Service) reissueParams(oldCard *db.Card, cardNumber, fingerprint, panToken string) (CreateCardParams, error) {
+	// 1. New expiry (profiled cards keep their original lifetime) and CVV
+	expiresAt := CalculateExpiryDate(oldCard.Type)
+	if oldCard.Profile != ProfileStandard && oldCard.ExpiresAt.Valid && oldCard.CreatedAt.Valid {
+		expiresAt = time.Now().Add(oldCard.ExpiresAt.Time.Sub(oldCard.CreatedAt.Time))
+	}
+	expiryMonth := int(expiresAt.Month())
+	expiryYear := expiresAt.Year()
+	cvv := GenerateCVV(s.cfg.CVVKey, cardNumber, expiryMonth, expiryYear)
+
+	// 2. Dynamic CVV cards get a fresh secret
+	var dcvvSecret []byte
+	if oldCard.CvvMode == "dynamic" {
+		secret, err := GenerateDynamicCVVSecret()
+		if err != nil {
+			return CreateCardParams{}, err
+		}
+		dcvvSecret = secret
+	}
+
+	// 3. Amount-capped cards carry over only the remaining cap
+	var amountCap int64
+	if oldCard.AmountCapCents.Valid {
+		amountCap = oldCard.AmountCapCents.Int64 - oldCard.TotalSpentCents
+		if amountCap <= 0 {
+			return CreateCardParams{}, ErrAmountCapExceeded
+		}
+	}
+
+	return CreateCardParams{
+		UserID:             oldCard.UserID.String(),
+		Type:               oldCard.Type,
+		Brand:              oldCard.Brand,
+		CardNumber:         cardNumber,
+		PANFingerprint:     fingerprint,
+		PANToken:           panToken,
+		CVV:                cvv,
+		CVVMode:            oldCard.CvvMode,
+		DynamicCVVSecret:   dcvvSecret,
+		Profile:            oldCard.Profile,
+		AmountCapCents:     amountCap,
+		PINHash:            oldCard.PinHash.String,
+		HolderName:         oldCard.HolderName,
+		ExpiryMonth:        expiryMonth,
+		ExpiryYear:         expiryYear,
+		DailyLimitCents:    oldCard.DailyLimitCents.Int64,
+		MonthlyLimitCents:  oldCard.MonthlyLimitCents.Int64,
+		IsContactless:      oldCard.IsContactless.Bool,
+		IsInternational:    oldCard.IsInternational.Bool,
+		BlockInternational: oldCard.BlockInternational.Bool,
+		BlockOnline:        oldCard.BlockOnline.Bool,
+		ExpiresAt:          expiresAt,
+	}, nil
+}
+
+// categoryControlRequests converts stored category rules back to requests
+func categoryControlRequests(controls []db.CardCategoryControl) []CategoryControlRequest {
+	requests := make([]CategoryControlRequest, len(controls))
+	for i, control := range controls {
+		requests[i] = CategoryControlRequest{
+			Category:  control.Category,
+			IsBlocked: control.IsBlocked,
+		}
+		if control.MonthlyLimitCents.Valid {
+			limit := control.MonthlyLimitCents.Int64
+			requests[i].MonthlyLimitCents = &limit
+		}
+	}
+	return requests
+}
@@ -0,0 +1,129 @@
+package cards
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+)
+
+// TestCancelReasons tests cancellation and replacement reason validation
+func TestCancelReasons(t *testing.T) {
+	tests := []struct {
+		reason        string
+		cancelErr     error
+		replaceErr    error
+		expectedState string
+	}{
+		{CancelReasonLost, nil, nil, "lost"},
+		{CancelReasonStolen, nil, nil, "stolen"},
+		{CancelReasonDamaged, nil, nil, "cancelled"},
+		{CancelReasonUserRequest, nil, ErrInvalidCancelReason, "cancelled"},
+		{"expired", ErrInvalidCancelReason, ErrInvalidCancelReason, "cancelled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reason, func(t *testing.T) {
+			if err := ValidateCancelReason(tt.reason); err != tt.cancelErr {
+				t.Errorf("ValidateCancelReason(%s) = %v, want %v", tt.reason, err, tt.cancelErr)
+			}
+			if err := ValidateReplacementReason(tt.reason); err != tt.replaceErr {
+				t.Errorf("ValidateReplacementReason(%s) = %v, want %v", tt.reason, err, tt.replaceErr)
+			}
+			if status := statusForCancelReason(tt.reason); status != tt.expectedState {
+				t.Errorf("statusForCancelReason(%s) = %s, want %s", tt.reason, status, tt.expectedState)
+			}
+			if !isClosedStatus(statusForCancelReason(tt.reason)) {
+				t.Errorf("status for %s should be closed", tt.reason)
+			}
+		})
+	}
+
+	for _, status := range []string{"active", "blocked", "expired"} {
+		if isClosedStatus(status) {
+			t.Errorf("status %s should not be closed", status)
+		}
+	}
+}
+
+// TestReissueParams tests that a replacement card keeps the old card's settings
+func TestReissueParams(t *testing.T) {
+	s := &Service{cfg: Config{CVVKey: []byte("test-cvv-key-0123456789abcdef012")}}
+	pan := "4984021234567897"
+
+	oldCard := &db.Card{
+		UserID:             uuid.New(),
+		Type:               "virtual",
+		Brand:              "visa",
+		CvvMode:            "static",
+		Profile:            ProfileStandard,
+		PinHash:            sql.NullString{String: "$argon2id$hash", Valid: true},
+		HolderName:         "MARIA SILVA",
+		DailyLimitCents:    sql.NullInt64{Int64: 100000, Valid: true},
+		MonthlyLimitCents:  sql.NullInt64{Int64: 900000, Valid: true},
+		IsContactless:      sql.NullBool{Bool: false, Valid: true},
+		IsInternational:    sql.NullBool{Bool: true, Valid: true},
+		BlockInternational: sql.NullBool{Bool: false, Valid: true},
+		BlockOnline:        sql.NullBool{Bool: true, Valid: true},
+	}
+
+	params, err := s.reissueParams(oldCard, pan, "fingerprint", "token")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if params.UserID != oldCard.UserID.String() || params.Type != "virtual" || params.Brand != "visa" {
+		t.Errorf("owner, type or brand not kept: %+v", params)
+	}
+	if params.DailyLimitCents != 100000 || params.MonthlyLimitCents != 900000 {
+		t.Errorf("limits not kept: daily=%d monthly=%d", params.DailyLimitCents, params.MonthlyLimitCents)
+	}
+	if params.IsContactless || !params.IsInternational || params.BlockInternational || !params.BlockOnline {
+		t.Errorf("security settings not kept: %+v", params)
+	}
+	if params.PINHash != oldCard.PinHash.String {
+		t.Error("PIN hash should be kept on reissue")
+	}
+	if params.CVV != GenerateCVV(s.cfg.CVVKey, pan, params.ExpiryMonth, params.ExpiryYear) {
+		t.Error("CVV should be derived from the new PAN and expiry")
+	}
+	if !params.ExpiresAt.After(time.Now().AddDate(2, 11, 0)) {
+		t.Errorf("expected a fresh 3-year expiry, got %v", params.ExpiresAt)
+	}
+}
+
+// TestReissueParamsAmountCap tests that amount-capped cards only carry over the remaining cap
+func TestReissueParamsAmountCap(t *testing.T) {
+	s := &Service{cfg: Config{CVVKey: []byte("test-cvv-key-0123456789abcdef012")}}
+	created := time.Now().AddDate(0, 0, -10)
+
+	oldCard := &db.Card{
+		UserID:          uuid.New(),
+		Type:            "virtual",
+		Brand:           "visa",
+		CvvMode:         "static",
+		Profile:         ProfileAmountCapped,
+		AmountCapCents:  sql.NullInt64{Int64: 50000, Valid: true},
+		TotalSpentCents: 20000,
+		CreatedAt:       sql.NullTime{Time: created, Valid: true},
+		ExpiresAt:       sql.NullTime{Time: created.AddDate(0, 0, 30), Valid: true},
+	}
+
+	params, err := s.reissueParams(oldCard, "4984021234567897", "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if params.AmountCapCents != 30000 {
+		t.Errorf("expected remaining cap 30000, got %d", params.AmountCapCents)
+	}
+	if lifetime := params.ExpiresAt.Sub(time.Now()); lifetime < 29*24*time.Hour || lifetime > 31*24*time.Hour {
+		t.Errorf("expected the original 30-day lifetime, got %v", lifetime)
+	}
+
+	oldCard.TotalSpentCents = 50000
+	if _, err := s.reissueParams(oldCard, "4984021234567897", "", ""); err != ErrAmountCapExceeded {
+		t.Errorf("expected ErrAmountCapExceeded for exhausted cap, got %v", err)
+	}
+}
@@ -3,41 +3,65 @@ package cards
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lauratech/fin/back/internal/shared/crypto"
 	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+	"github.com/lib/pq"
+	"github.com/sqlc-dev/pqtype"
 )
 
 // Repository handles card data access with encryption/decryption
 type Repository struct {
-	queries       *db.Queries
-	encryptionKey []byte
+	queries *db.Queries
+	keyring *crypto.Keyring
 }
 
-// NewRepository creates a new card repository with encryption key
-func NewRepository(database *sql.DB, encryptionKey string) *Repository {
+// NewRepository creates a new card repository with the encryption keyring
+func NewRepository(database *sql.DB, keyring *crypto.Keyring) *Repository {
 	return &Repository{
-		queries:       db.New(database),
-		encryptionKey: []byte(encryptionKey),
+		queries: db.New(database),
+		keyring: keyring,
 	}
 }
 
 // Create creates a new card (encrypts sensitive data before storage)
 func (r *Repository) Create(ctx context.Context, params CreateCardParams) (*Card, error) {
+	return r.create(ctx, r.queries, params)
+}
+
+// CreateWithTx creates a new card inside an existing transaction
+func (r *Repository) CreateWithTx(ctx context.Context, tx *sql.Tx, params CreateCardParams) (*Card, error) {
+	return r.create(ctx, r.queries.WithTx(tx), params)
+}
+
+// create encrypts sensitive data and inserts the card using the given queries
+func (r *Repository) create(ctx context.Context, queries *db.Queries, params CreateCardParams) (*Card, error) {
 	// 1. Encrypt card number
-	cardNumberEncrypted, err := crypto.EncryptString(params.CardNumber, r.encryptionKey)
+	cardNumberEncrypted, err := r.keyring.EncryptString(params.CardNumber)
 	if err != nil {
 		return nil, ErrEncryptionFailed
 	}
 
 	// 2. Encrypt CVV
-	cvvEncrypted, err := crypto.EncryptString(params.CVV, r.encryptionKey)
+	cvvEncrypted, err := r.keyring.EncryptString(params.CVV)
 	if err != nil {
 		return nil, ErrEncryptionFailed
 	}
 
-	// 3. Hash PIN if provided
+	// 3. Encrypt dynamic CVV secret if provided
+	var dcvvSecretEncrypted []byte
+	if params.DynamicCVVSecret != nil {
+		dcvvSecretEncrypted, err = r.keyring.Encrypt(params.DynamicCVVSecret)
+		if err != nil {
+			return nil, ErrEncryptionFailed
+		}
+	}
+
+	// 4. Hash PIN if provided (or keep an existing hash on reissue)
 	var pinHash sql.NullString
 	if params.PIN != "" {
 		hash, err := crypto.HashPIN(params.PIN)
@@ -45,13 +69,15 @@ func (r *Repository) Create(ctx context.Context, params CreateCardParams) (*Card
 			return nil, err
 		}
 		pinHash = sql.NullString{String: hash, Valid: true}
+	} else if params.PINHash != "" {
+		pinHash = sql.NullString{String: params.PINHash, Valid: true}
 	}
 
-	// 4. Extract last 4 digits for unencrypted storage (for display)
+	// 5. Extract last 4 digits for unencrypted storage (for display)
 	lastFour := params.CardNumber[len(params.CardNumber)-4:]
 
-	// 5. Call SQLC generated query
-	dbCard, err := r.queries.CreateCard(ctx, db.CreateCardParams{
+	// 6. Call SQLC generated query
+	dbCard, err := queries.CreateCard(ctx, db.CreateCardParams{
 		UserID:                   uuid.MustParse(params.UserID),
 		Type:                     params.Type,
 		Brand:                    params.Brand,
@@ -72,13 +98,39 @@ func (r *Repository) Create(ctx context.Context, params CreateCardParams) (*Card
 		BlockInternational:       sql.NullBool{Bool: params.BlockInternational, Valid: true},
 		BlockOnline:              sql.NullBool{Bool: params.BlockOnline, Valid: true},
 		ExpiresAt:                sql.NullTime{Time: params.ExpiresAt, Valid: true},
+		CvvMode:                  params.CVVMode,
+		DcvvSecretEncrypted:      dcvvSecretEncrypted,
+		Profile:                  params.Profile,
+		AmountCapCents:           sql.NullInt64{Int64: params.AmountCapCents, Valid: params.AmountCapCents > 0},
+		PanFingerprint:           sql.NullString{String: params.PANFingerprint, Valid: params.PANFingerprint != ""},
+		PanToken:                 sql.NullString{String: params.PANToken, Valid: params.PANToken != ""},
+		EncryptionKeyVersion:     int32(r.keyring.CurrentVersion()),
+		ActivatedAt:              sql.NullTime{Time: time.Now(), Valid: !params.RequiresActivation},
 	})
 	if err != nil {
-		return nil, err
+		return nil, uniqueViolationError(err)
 	}
 
-	// 6. Convert to domain model (with decrypted data for return)
-	return dbCardToCard(&dbCard, params.CardNumber, params.CVV), nil
+	// 7. Convert to domain model (with decrypted data for return)
+	card := dbCardToCard(&dbCard, params.CardNumber, params.CVV)
+	card.DynamicCVVSecret = params.DynamicCVVSecret
+	return card, nil
+}
+
+// uniqueViolationError maps the insert of a PAN or PAN token taken concurrently
+// by another card (the uniqueness checks run before the insert) to its error
+func uniqueViolationError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) || pqErr.Code.Name() != "unique_violation" {
+		return err
+	}
+	switch pqErr.Constraint {
+	case "idx_cards_pan_fingerprint":
+		return ErrPANGenerationFailed
+	case "idx_cards_pan_token":
+		return ErrPANTokenizationFailed
+	}
+	return err
 }
 
 // GetByID retrieves a card by ID (decrypts sensitive data)
@@ -92,20 +144,66 @@ func (r *Repository) GetByID(ctx context.Context, cardID string) (*Card, error)
 		return nil, err
 	}
 
-	// 2. Decrypt card number
-	cardNumber, err := crypto.DecryptString(dbCard.CardNumberEncrypted, r.encryptionKey)
+	// 2. Decrypt and map to domain model
+	return r.decryptCard(ctx, &dbCard)
+}
+
+// GetByPANFingerprint retrieves a card by the keyed fingerprint of its PAN
+func (r *Repository) GetByPANFingerprint(ctx context.Context, fingerprint string) (*Card, error) {
+	dbCard, err := r.queries.GetCardByPANFingerprint(ctx, sql.NullString{String: fingerprint, Valid: true})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrCardNotFound
+		}
+		return nil, err
+	}
+
+	return r.decryptCard(ctx, &dbCard)
+}
+
+// GetByPANToken retrieves a card by its PAN token
+func (r *Repository) GetByPANToken(ctx context.Context, token string) (*Card, error) {
+	dbCard, err := r.queries.GetCardByPANToken(ctx, sql.NullString{String: token, Valid: true})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrCardNotFound
+		}
+		return nil, err
+	}
+
+	return r.decryptCard(ctx, &dbCard)
+}
+
+// decryptCard decrypts sensitive fields and maps a database card to the domain model
+// Cards encrypted under an older key are re-encrypted to the current key on read
+func (r *Repository) decryptCard(ctx context.Context, dbCard *db.Card) (*Card, error) {
+	// 1. Decrypt card number
+	cardNumber, err := r.keyring.DecryptString(dbCard.CardNumberEncrypted)
 	if err != nil {
 		return nil, ErrDecryptionFailed
 	}
 
-	// 3. Decrypt CVV
-	cvv, err := crypto.DecryptString(dbCard.CvvEncrypted, r.encryptionKey)
+	// 2. Decrypt CVV
+	cvv, err := r.keyring.DecryptString(dbCard.CvvEncrypted)
 	if err != nil {
 		return nil, ErrDecryptionFailed
 	}
 
-	// 4. Map to domain model (including decrypted data)
-	return dbCardToCard(&dbCard, cardNumber, cvv), nil
+	// 3. Decrypt dynamic CVV secret (dynamic mode only)
+	secret, err := r.DecryptDynamicCVVSecret(dbCard)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Lazy re-encryption (best effort - cmd/rekey catches anything missed)
+	if r.NeedsRekey(dbCard) {
+		_, _ = r.RekeyCard(ctx, dbCard)
+	}
+
+	// 5. Map to domain model (including decrypted data)
+	card := dbCardToCard(dbCard, cardNumber, cvv)
+	card.DynamicCVVSecret = secret
+	return card, nil
 }
 
 // GetByIDForSummary retrieves a card without decrypting sensitive data
@@ -132,20 +230,12 @@ func (r *Repository) ListUserCards(ctx context.Context, userID string) ([]Card,
 
 	// 2. Decrypt each card
 	cards := make([]Card, len(dbCards))
-	for i, dbCard := range dbCards {
-		// Decrypt card number
-		cardNumber, err := crypto.DecryptString(dbCard.CardNumberEncrypted, r.encryptionKey)
+	for i := range dbCards {
+		card, err := r.decryptCard(ctx, &dbCards[i])
 		if err != nil {
-			return nil, ErrDecryptionFailed
-		}
-
-		// Decrypt CVV
-		cvv, err := crypto.DecryptString(dbCard.CvvEncrypted, r.encryptionKey)
-		if err != nil {
-			return nil, ErrDecryptionFailed
+			return nil, err
 		}
-
-		cards[i] = *dbCardToCard(&dbCard, cardNumber, cvv)
+		cards[i] = *card
 	}
 
 	return cards, nil
@@ -273,9 +363,43 @@ func (r *Repository) GetForUpdate(ctx context.Context, tx *sql.Tx, cardID string
 	return &dbCard, nil
 }
 
+// DecryptCVV decrypts the static CVV of a locked card row
+func (r *Repository) DecryptCVV(dbCard *db.Card) (string, error) {
+	cvv, err := r.keyring.DecryptString(dbCard.CvvEncrypted)
+	if err != nil {
+		return "", ErrDecryptionFailed
+	}
+	return cvv, nil
+}
+
+// DecryptRenewalCVV decrypts the CVV of a pending same-PAN renewal ("" when none is pending)
+func (r *Repository) DecryptRenewalCVV(dbCard *db.Card) (string, error) {
+	if len(dbCard.RenewalCvvEncrypted) == 0 {
+		return "", nil
+	}
+	cvv, err := r.keyring.DecryptString(dbCard.RenewalCvvEncrypted)
+	if err != nil {
+		return "", ErrDecryptionFailed
+	}
+	return cvv, nil
+}
+
+// DecryptDynamicCVVSecret decrypts the dynamic CVV secret (nil for static cards)
+func (r *Repository) DecryptDynamicCVVSecret(dbCard *db.Card) ([]byte, error) {
+	if len(dbCard.DcvvSecretEncrypted) == 0 {
+		return nil, nil
+	}
+	secret, err := r.keyring.Decrypt(dbCard.DcvvSecretEncrypted)
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+	return secret, nil
+}
+
 // UpdateSpentAmounts updates spent amounts (for transaction processing)
-func (r *Repository) UpdateSpentAmounts(ctx context.Context, cardID string, dailySpent, monthlySpent int64) error {
-	err := r.queries.UpdateCardSpentAmounts(ctx, db.UpdateCardSpentAmountsParams{
+// Runs inside the transaction holding the card lock
+func (r *Repository) UpdateSpentAmounts(ctx context.Context, tx *sql.Tx, cardID string, dailySpent, monthlySpent int64) error {
+	err := r.queries.WithTx(tx).UpdateCardSpentAmounts(ctx, db.UpdateCardSpentAmountsParams{
 		ID:                       uuid.MustParse(cardID),
 		CurrentDailySpentCents:   sql.NullInt64{Int64: dailySpent, Valid: true},
 		CurrentMonthlySpentCents: sql.NullInt64{Int64: monthlySpent, Valid: true},
@@ -289,16 +413,142 @@ func (r *Repository) UpdateSpentAmounts(ctx context.Context, cardID string, dail
 	return nil
 }
 
-// CancelCard cancels a card (soft delete)
-func (r *Repository) CancelCard(ctx context.Context, cardID string) error {
-	err := r.queries.DeleteCard(ctx, uuid.MustParse(cardID))
+// UpdateTotalSpent updates the lifetime spent amount (for amount-capped cards)
+// Runs inside the transaction holding the card lock
+func (r *Repository) UpdateTotalSpent(ctx context.Context, tx *sql.Tx, cardID string, totalSpent int64) error {
+	return r.queries.WithTx(tx).UpdateCardTotalSpent(ctx, db.UpdateCardTotalSpentParams{
+		ID:              uuid.MustParse(cardID),
+		TotalSpentCents: totalSpent,
+	})
+}
+
+// LockMerchant binds a merchant-locked card to its first merchant
+// Runs inside the transaction holding the card lock
+func (r *Repository) LockMerchant(ctx context.Context, tx *sql.Tx, cardID, merchantName, merchantCategory string) error {
+	return r.queries.WithTx(tx).LockCardMerchant(ctx, db.LockCardMerchantParams{
+		ID:                     uuid.MustParse(cardID),
+		LockedMerchantName:     sql.NullString{String: merchantName, Valid: true},
+		LockedMerchantCategory: sql.NullString{String: merchantCategory, Valid: merchantCategory != ""},
+	})
+}
+
+// UpdateStatusWithTx updates card status inside an existing transaction
+func (r *Repository) UpdateStatusWithTx(ctx context.Context, tx *sql.Tx, cardID, status string) error {
+	return r.queries.WithTx(tx).UpdateCardStatus(ctx, db.UpdateCardStatusParams{
+		ID:     uuid.MustParse(cardID),
+		Status: status,
+	})
+}
+
+// ListCategoryControls lists category rules for a card
+func (r *Repository) ListCategoryControls(ctx context.Context, cardID string) ([]db.CardCategoryControl, error) {
+	return r.queries.ListCardCategoryControls(ctx, uuid.MustParse(cardID))
+}
+
+// ReplaceCategoryControls replaces all category rules of a card
+func (r *Repository) ReplaceCategoryControls(ctx context.Context, tx *sql.Tx, cardID string, controls []CategoryControlRequest) error {
+	qtx := r.queries.WithTx(tx)
+	cardUUID := uuid.MustParse(cardID)
+
+	if err := qtx.DeleteCardCategoryControls(ctx, cardUUID); err != nil {
+		return err
+	}
+
+	for _, control := range controls {
+		var limit sql.NullInt64
+		if control.MonthlyLimitCents != nil {
+			limit = sql.NullInt64{Int64: *control.MonthlyLimitCents, Valid: true}
+		}
+
+		_, err := qtx.CreateCardCategoryControl(ctx, db.CreateCardCategoryControlParams{
+			CardID:            cardUUID,
+			Category:          control.Category,
+			IsBlocked:         control.IsBlocked,
+			MonthlyLimitCents: limit,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetCategoryControl retrieves the rule for a category (nil if the card has none)
+func (r *Repository) GetCategoryControl(ctx context.Context, tx *sql.Tx, cardID, category string) (*db.CardCategoryControl, error) {
+	control, err := r.queries.WithTx(tx).GetCardCategoryControl(ctx, db.GetCardCategoryControlParams{
+		CardID:   uuid.MustParse(cardID),
+		Category: category,
+	})
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return ErrCardNotFound
+			return nil, nil
 		}
-		return err
+		return nil, err
 	}
-	return nil
+	return &control, nil
+}
+
+// SumCategorySpent sums completed spending in a category since a given time
+func (r *Repository) SumCategorySpent(ctx context.Context, tx *sql.Tx, cardID, category string, since time.Time) (int64, error) {
+	queries := r.queries
+	if tx != nil {
+		queries = queries.WithTx(tx)
+	}
+	return queries.SumCardCategorySpent(ctx, db.SumCardCategorySpentParams{
+		CardID:           uuid.MustParse(cardID),
+		MerchantCategory: sql.NullString{String: category, Valid: true},
+		Since:            since,
+	})
+}
+
+// CancelCard cancels a card (soft delete), recording the reason
+// status is "cancelled", or "lost"/"stolen" for cards reported missing
+func (r *Repository) CancelCard(ctx context.Context, tx *sql.Tx, cardID, status, reason string) error {
+	return r.queries.WithTx(tx).CancelCardWithReason(ctx, db.CancelCardWithReasonParams{
+		ID:                 uuid.MustParse(cardID),
+		Status:             status,
+		CancellationReason: sql.NullString{String: reason, Valid: reason != ""},
+	})
+}
+
+// SetReplacement links a cancelled card to the card issued in its place
+func (r *Repository) SetReplacement(ctx context.Context, tx *sql.Tx, oldCardID, newCardID string) error {
+	return r.queries.WithTx(tx).SetCardReplacement(ctx, db.SetCardReplacementParams{
+		ID:               uuid.MustParse(oldCardID),
+		ReplacedByCardID: uuid.NullUUID{UUID: uuid.MustParse(newCardID), Valid: true},
+	})
+}
+
+// GetReplacedCardID returns the ID of the card a card replaced ("" if none)
+func (r *Repository) GetReplacedCardID(ctx context.Context, cardID string) (string, error) {
+	dbCard, err := r.queries.GetReplacedCard(ctx, uuid.NullUUID{UUID: uuid.MustParse(cardID), Valid: true})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+	return dbCard.ID.String(), nil
+}
+
+// ListRecurringMerchants returns merchants charging the card in at least two distinct months since a date
+func (r *Repository) ListRecurringMerchants(ctx context.Context, tx *sql.Tx, cardID string, since time.Time) ([]db.ListCardRecurringMerchantsRow, error) {
+	return r.queries.WithTx(tx).ListCardRecurringMerchants(ctx, db.ListCardRecurringMerchantsParams{
+		CardID:          uuid.MustParse(cardID),
+		TransactionDate: since,
+	})
+}
+
+// CreateAccountUpdate queues a recurring merchant update for a replacement card
+func (r *Repository) CreateAccountUpdate(ctx context.Context, tx *sql.Tx, oldCardID, newCardID, merchantName, merchantCategory string) error {
+	_, err := r.queries.WithTx(tx).CreateCardAccountUpdate(ctx, db.CreateCardAccountUpdateParams{
+		OldCardID:        uuid.MustParse(oldCardID),
+		NewCardID:        uuid.MustParse(newCardID),
+		MerchantName:     merchantName,
+		MerchantCategory: sql.NullString{String: merchantCategory, Valid: merchantCategory != ""},
+	})
+	return err
 }
 
 // CountUserCards counts total cards for a user
@@ -320,25 +570,27 @@ func (r *Repository) CountUserActiveCards(ctx context.Context, userID string) (i
 }
 
 // CreateCardTransaction persists a card transaction
+// Runs inside the transaction holding the card lock
 func (r *Repository) CreateCardTransaction(
 	ctx context.Context,
+	tx *sql.Tx,
 	params db.CreateCardTransactionParams,
 ) (*db.CardTransaction, error) {
-	tx, err := r.queries.CreateCardTransaction(ctx, params)
+	txn, err := r.queries.WithTx(tx).CreateCardTransaction(ctx, params)
 	if err != nil {
 		return nil, err
 	}
-	return &tx, nil
+	return &txn, nil
 }
 
-// ListCardTransactions retrieves transactions for a card with pagination
-func (r *Repository) ListCardTransactions(
+// ListUserCardTransactions retrieves all user card transactions
+func (r *Repository) ListUserCardTransactions(
 	ctx context.Context,
-	cardID uuid.UUID,
+	userID uuid.UUID,
 	limit, offset int32,
 ) ([]db.CardTransaction, error) {
-	txs, err := r.queries.ListCardTransactions(ctx, db.ListCardTransactionsParams{
-		CardID: cardID,
+	txs, err := r.queries.ListUserCardTransactions(ctx, db.ListUserCardTransactionsParams{
+		UserID: userID,
 		Limit:  limit,
 		Offset: offset,
 	})
@@ -348,45 +600,545 @@ func (r *Repository) ListCardTransactions(
 	return txs, nil
 }
 
-// ListUserCardTransactions retrieves all user card transactions
-func (r *Repository) ListUserCardTransactions(
+// ListFilteredTransactions retrieves up to limit filtered card transactions after the cursor (nil = first page)
+func (r *Repository) ListFilteredTransactions(
 	ctx context.Context,
-	userID uuid.UUID,
-	limit, offset int32,
+	params db.ListFilteredCardTransactionsParams,
+	after *TransactionCursor,
+	limit int32,
 ) ([]db.CardTransaction, error) {
-	txs, err := r.queries.ListUserCardTransactions(ctx, db.ListUserCardTransactionsParams{
-		UserID: userID,
-		Limit:  limit,
-		Offset: offset,
+	if after != nil {
+		params.CursorDate = sql.NullTime{Time: after.TransactionDate, Valid: true}
+		params.CursorID = uuid.NullUUID{UUID: uuid.MustParse(after.ID), Valid: true}
+	}
+	params.RowLimit = limit
+
+	return r.queries.ListFilteredCardTransactions(ctx, params)
+}
+
+// SummarizeFilteredTransactions counts and sums every card transaction matching the filters
+func (r *Repository) SummarizeFilteredTransactions(ctx context.Context, params db.ListFilteredCardTransactionsParams) (*TransactionTotals, error) {
+	row, err := r.queries.SummarizeFilteredCardTransactions(ctx, db.SummarizeFilteredCardTransactionsParams{
+		UserID:          params.UserID,
+		CardID:          params.CardID,
+		StartDate:       params.StartDate,
+		EndDate:         params.EndDate,
+		Status:          params.Status,
+		Category:        params.Category,
+		Search:          params.Search,
+		MinAmountCents:  params.MinAmountCents,
+		MaxAmountCents:  params.MaxAmountCents,
+		IsInternational: params.IsInternational,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return txs, nil
+	return &TransactionTotals{
+		Count:       row.TransactionCount,
+		AmountCents: row.TotalAmountCents,
+	}, nil
 }
 
-// GetCardTransactionsByCategory retrieves spending by category
-func (r *Repository) GetCardTransactionsByCategory(
-	ctx context.Context,
-	userID uuid.UUID,
-	startDate, endDate sql.NullTime,
-) ([]db.GetCardTransactionsByCategoryRow, error) {
-	return r.queries.GetCardTransactionsByCategory(ctx, db.GetCardTransactionsByCategoryParams{
-		UserID:    userID,
-		StartDate: startDate.Time,
-		EndDate:   endDate.Time,
+// GetMerchantCategoryOverrides returns the user's merchant category overrides by merchant key
+func (r *Repository) GetMerchantCategoryOverrides(ctx context.Context, userID uuid.UUID) (map[string]string, error) {
+	overrides, err := r.queries.ListUserMerchantCategoryOverrides(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string]string, len(overrides))
+	for _, override := range overrides {
+		byKey[override.MerchantKey] = override.Category
+	}
+	return byKey, nil
+}
+
+// CreateRevealToken stores the hash of a single-use reveal token
+func (r *Repository) CreateRevealToken(ctx context.Context, cardID, userID, tokenHash string, expiresAt time.Time) error {
+	_, err := r.queries.CreateCardRevealToken(ctx, db.CreateCardRevealTokenParams{
+		CardID:    uuid.MustParse(cardID),
+		UserID:    uuid.MustParse(userID),
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
 	})
+	return err
 }
 
-// GetCardTransactionsByDateRange retrieves transactions in a date range
-func (r *Repository) GetCardTransactionsByDateRange(
-	ctx context.Context,
-	userID uuid.UUID,
-	startDate, endDate sql.NullTime,
-) ([]db.CardTransaction, error) {
-	return r.queries.GetCardTransactionsByDateRange(ctx, db.GetCardTransactionsByDateRangeParams{
-		UserID:    userID,
-		StartDate: startDate.Time,
-		EndDate:   endDate.Time,
+// ConsumeRevealToken atomically marks a reveal token as used and returns it
+func (r *Repository) ConsumeRevealToken(ctx context.Context, tokenHash string) (*db.CardRevealToken, error) {
+	token, err := r.queries.ConsumeCardRevealToken(ctx, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrRevealTokenInvalid
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// CreateAuditLog writes an explicit audit entry for a card operation
+// Used for events that must be traceable beyond the generic request audit (e.g. PAN reveals)
+func (r *Repository) CreateAuditLog(ctx context.Context, userID, cardID, action, status string, details map[string]interface{}) error {
+	return r.createAuditLog(ctx, userID, "CARD", cardID, action, status, details)
+}
+
+// CreateFraudAuditLog writes an audit entry for a change to the fraud configuration
+func (r *Repository) CreateFraudAuditLog(ctx context.Context, resourceType, resourceID, action string, details map[string]interface{}) error {
+	return r.createAuditLog(ctx, "", resourceType, resourceID, action, "success", details)
+}
+
+// createAuditLog writes an explicit audit entry for a resource
+func (r *Repository) createAuditLog(ctx context.Context, userID, resourceType, resourceID, action, status string, details map[string]interface{}) error {
+	var userUUID uuid.NullUUID
+	if parsed, err := uuid.Parse(userID); err == nil {
+		userUUID = uuid.NullUUID{UUID: parsed, Valid: true}
+	}
+
+	// Invalid IDs are logged as uuid.Nil rather than dropped
+	resourceUUID, _ := uuid.Parse(resourceID)
+
+	var newValues pqtype.NullRawMessage
+	if details != nil {
+		if data, err := json.Marshal(details); err == nil {
+			newValues = pqtype.NullRawMessage{RawMessage: data, Valid: true}
+		}
+	}
+
+	requestID, _ := ctx.Value("request_id").(string)
+
+	_, err := r.queries.CreateAuditLog(ctx, db.CreateAuditLogParams{
+		UserID:       userUUID,
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceUUID,
+		NewValues:    newValues,
+		RequestID:    sql.NullString{String: requestID, Valid: requestID != ""},
+		Status:       status,
 	})
+	return err
+}
+
+// ListActiveBINRanges returns the issuer BIN ranges enabled for a brand and product
+func (r *Repository) ListActiveBINRanges(ctx context.Context, brand, product string) ([]db.CardBinRange, error) {
+	return r.queries.ListActiveCardBINRanges(ctx, db.ListActiveCardBINRangesParams{
+		Brand:   brand,
+		Product: product,
+	})
+}
+
+// PANFingerprintExists reports whether a card with the given PAN fingerprint exists
+func (r *Repository) PANFingerprintExists(ctx context.Context, fingerprint string) (bool, error) {
+	count, err := r.queries.CountCardsByPANFingerprint(ctx, sql.NullString{String: fingerprint, Valid: true})
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// PANTokenExists reports whether a PAN token is already assigned to a card
+func (r *Repository) PANTokenExists(ctx context.Context, token string) (bool, error) {
+	count, err := r.queries.CountCardsByPANToken(ctx, sql.NullString{String: token, Valid: true})
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// SetPANToken assigns a PAN token to a card that has none
+// Returns sql.ErrNoRows if the card already has a token
+func (r *Repository) SetPANToken(ctx context.Context, cardID, token string) (string, error) {
+	dbCard, err := r.queries.SetCardPANToken(ctx, db.SetCardPANTokenParams{
+		ID:       uuid.MustParse(cardID),
+		PanToken: sql.NullString{String: token, Valid: true},
+	})
+	if err != nil {
+		return "", uniqueViolationError(err)
+	}
+	return dbCard.PanToken.String, nil
+}
+
+// DecryptCardNumber decrypts the PAN of a card row
+func (r *Repository) DecryptCardNumber(dbCard *db.Card) (string, error) {
+	cardNumber, err := r.keyring.DecryptString(dbCard.CardNumberEncrypted)
+	if err != nil {
+		return "", ErrDecryptionFailed
+	}
+	return cardNumber, nil
+}
+
+// RekeyCard re-encrypts a card's ciphertexts under the current key version
+// Returns false if the card was re-encrypted or its CVVs changed concurrently (nothing written)
+func (r *Repository) RekeyCard(ctx context.Context, dbCard *db.Card) (bool, error) {
+	cardNumberEncrypted, err := r.keyring.Reencrypt(dbCard.CardNumberEncrypted)
+	if err != nil {
+		return false, ErrDecryptionFailed
+	}
+
+	cvvEncrypted, err := r.keyring.Reencrypt(dbCard.CvvEncrypted)
+	if err != nil {
+		return false, ErrDecryptionFailed
+	}
+
+	var dcvvSecretEncrypted []byte
+	if len(dbCard.DcvvSecretEncrypted) > 0 {
+		dcvvSecretEncrypted, err = r.keyring.Reencrypt(dbCard.DcvvSecretEncrypted)
+		if err != nil {
+			return false, ErrDecryptionFailed
+		}
+	}
+
+	var renewalCVVEncrypted []byte
+	if len(dbCard.RenewalCvvEncrypted) > 0 {
+		renewalCVVEncrypted, err = r.keyring.Reencrypt(dbCard.RenewalCvvEncrypted)
+		if err != nil {
+			return false, ErrDecryptionFailed
+		}
+	}
+
+	rows, err := r.queries.UpdateCardEncryption(ctx, db.UpdateCardEncryptionParams{
+		ID:                          dbCard.ID,
+		CardNumberEncrypted:         cardNumberEncrypted,
+		CvvEncrypted:                cvvEncrypted,
+		DcvvSecretEncrypted:         dcvvSecretEncrypted,
+		EncryptionKeyVersion:        int32(r.keyring.CurrentVersion()),
+		RenewalCvvEncrypted:         renewalCVVEncrypted,
+		PreviousKeyVersion:          dbCard.EncryptionKeyVersion,
+		PreviousCvvEncrypted:        dbCard.CvvEncrypted,
+		PreviousDcvvSecretEncrypted: dbCard.DcvvSecretEncrypted,
+		PreviousRenewalCvvEncrypted: dbCard.RenewalCvvEncrypted,
+	})
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+// NeedsRekey reports whether a card's ciphertexts are not under the current key version
+func (r *Repository) NeedsRekey(dbCard *db.Card) bool {
+	return uint32(dbCard.EncryptionKeyVersion) != r.keyring.CurrentVersion()
+}
+
+// ListCardsForRekey returns up to limit cards not under the current key or without a PAN
+// fingerprint, ordered by ID after afterID
+func (r *Repository) ListCardsForRekey(ctx context.Context, afterID uuid.UUID, limit int) ([]db.Card, error) {
+	return r.queries.ListCardsForRekey(ctx, db.ListCardsForRekeyParams{
+		ID:                   afterID,
+		EncryptionKeyVersion: int32(r.keyring.CurrentVersion()),
+		Limit:                int32(limit),
+	})
+}
+
+// CountCardsForRekey counts cards not under the current key or without a PAN fingerprint
+func (r *Repository) CountCardsForRekey(ctx context.Context) (int64, error) {
+	return r.queries.CountCardsForRekey(ctx, int32(r.keyring.CurrentVersion()))
+}
+
+// SetPANFingerprint backfills the PAN fingerprint of a card issued before fingerprints existed
+func (r *Repository) SetPANFingerprint(ctx context.Context, cardID uuid.UUID, fingerprint string) error {
+	return r.queries.SetCardPANFingerprint(ctx, db.SetCardPANFingerprintParams{
+		ID:             cardID,
+		PanFingerprint: sql.NullString{String: fingerprint, Valid: true},
+	})
+}
+
+// ExpireCards moves active and blocked cards past their expiry to "expired"
+func (r *Repository) ExpireCards(ctx context.Context, now time.Time) ([]db.Card, error) {
+	return r.queries.ExpireCards(ctx, sql.NullTime{Time: now, Valid: true})
+}
+
+// ApplyOverdueRenewals puts into effect the pending same-PAN renewals of usable
+// cards whose current expiry is at or before now, and returns the cards
+func (r *Repository) ApplyOverdueRenewals(ctx context.Context, now time.Time) ([]db.Card, error) {
+	return r.queries.ApplyOverdueCardRenewals(ctx, sql.NullTime{Time: now, Valid: true})
+}
+
+// ListCardsDueForRenewal returns active physical cards expiring in (now, renewBefore] not renewed yet
+func (r *Repository) ListCardsDueForRenewal(ctx context.Context, now, renewBefore time.Time) ([]db.Card, error) {
+	return r.queries.ListCardsDueForRenewal(ctx, db.ListCardsDueForRenewalParams{
+		Now:         sql.NullTime{Time: now, Valid: true},
+		RenewBefore: sql.NullTime{Time: renewBefore, Valid: true},
+	})
+}
+
+// ListCardsExpiringBetween returns usable cards expiring in (from, to] that were not replaced
+func (r *Repository) ListCardsExpiringBetween(ctx context.Context, from, to time.Time) ([]db.Card, error) {
+	return r.queries.ListCardsExpiringBetween(ctx, db.ListCardsExpiringBetweenParams{
+		FromTime: sql.NullTime{Time: from, Valid: true},
+		ToTime:   sql.NullTime{Time: to, Valid: true},
+	})
+}
+
+// RenewInPlace keeps a card's PAN and stores a new expiry and CVV as a pending
+// renewal; the current ones stay in effect until the new plastic is activated.
+// All ciphertexts are written under the current key version.
+// Returns false if the card expiry changed or it was renewed concurrently (nothing written).
+func (r *Repository) RenewInPlace(ctx context.Context, tx *sql.Tx, dbCard *db.Card, cvv string, expiresAt time.Time) (bool, error) {
+	cardNumberEncrypted, err := r.keyring.Reencrypt(dbCard.CardNumberEncrypted)
+	if err != nil {
+		return false, ErrDecryptionFailed
+	}
+
+	cvvEncrypted, err := r.keyring.Reencrypt(dbCard.CvvEncrypted)
+	if err != nil {
+		return false, ErrDecryptionFailed
+	}
+
+	var dcvvSecretEncrypted []byte
+	if len(dbCard.DcvvSecretEncrypted) > 0 {
+		dcvvSecretEncrypted, err = r.keyring.Reencrypt(dbCard.DcvvSecretEncrypted)
+		if err != nil {
+			return false, ErrDecryptionFailed
+		}
+	}
+
+	renewalCVVEncrypted, err := r.keyring.EncryptString(cvv)
+	if err != nil {
+		return false, ErrEncryptionFailed
+	}
+
+	rows, err := r.queries.WithTx(tx).RenewCardInPlace(ctx, db.RenewCardInPlaceParams{
+		ID:                   dbCard.ID,
+		CardNumberEncrypted:  cardNumberEncrypted,
+		CvvEncrypted:         cvvEncrypted,
+		DcvvSecretEncrypted:  dcvvSecretEncrypted,
+		EncryptionKeyVersion: int32(r.keyring.CurrentVersion()),
+		RenewalCvvEncrypted:  renewalCVVEncrypted,
+		RenewalExpiryMonth:   sql.NullInt16{Int16: int16(expiresAt.Month()), Valid: true},
+		RenewalExpiryYear:    sql.NullInt16{Int16: int16(expiresAt.Year()), Valid: true},
+		RenewalExpiresAt:     sql.NullTime{Time: expiresAt, Valid: true},
+		PreviousExpiresAt:    dbCard.ExpiresAt,
+	})
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+// CreateShipment requests production and delivery of a physical card
+func (r *Repository) CreateShipment(ctx context.Context, tx *sql.Tx, cardID string, address ShippingAddress) (*db.CardShipment, error) {
+	shipment, err := r.queries.WithTx(tx).CreateCardShipment(ctx, db.CreateCardShipmentParams{
+		CardID:        uuid.MustParse(cardID),
+		RecipientName: address.RecipientName,
+		Street:        address.Street,
+		Number:        address.Number,
+		Complement:    sql.NullString{String: address.Complement, Valid: address.Complement != ""},
+		Neighborhood:  address.Neighborhood,
+		City:          address.City,
+		State:         address.State,
+		PostalCode:    address.PostalCode,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &shipment, nil
+}
+
+// GetLatestShipment returns the current shipment of a card
+func (r *Repository) GetLatestShipment(ctx context.Context, cardID string) (*db.CardShipment, error) {
+	shipment, err := r.queries.GetLatestCardShipment(ctx, uuid.MustParse(cardID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrShipmentNotFound
+		}
+		return nil, err
+	}
+	return &shipment, nil
+}
+
+// GetShipmentForUpdate locks a shipment for a status change
+func (r *Repository) GetShipmentForUpdate(ctx context.Context, tx *sql.Tx, shipmentID uuid.UUID) (*db.CardShipment, error) {
+	shipment, err := r.queries.WithTx(tx).GetCardShipmentForUpdate(ctx, shipmentID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrShipmentNotFound
+		}
+		return nil, err
+	}
+	return &shipment, nil
+}
+
+// ListShipmentsForEmbossing locks up to limit requested shipments of usable cards
+func (r *Repository) ListShipmentsForEmbossing(ctx context.Context, tx *sql.Tx, limit int) ([]db.CardShipment, error) {
+	return r.queries.WithTx(tx).ListShipmentsForEmbossing(ctx, int32(limit))
+}
+
+// MarkShipmentProduced records that a card was sent to the embosser in a batch
+func (r *Repository) MarkShipmentProduced(ctx context.Context, tx *sql.Tx, shipmentID, batchID uuid.UUID) error {
+	return r.queries.WithTx(tx).MarkShipmentProduced(ctx, db.MarkShipmentProducedParams{
+		ID:              shipmentID,
+		EmbosserBatchID: uuid.NullUUID{UUID: batchID, Valid: true},
+	})
+}
+
+// MarkShipmentShipped records the carrier and tracking code of a produced card
+func (r *Repository) MarkShipmentShipped(ctx context.Context, tx *sql.Tx, shipmentID uuid.UUID, carrier, trackingCode string) error {
+	return r.queries.WithTx(tx).MarkShipmentShipped(ctx, db.MarkShipmentShippedParams{
+		ID:           shipmentID,
+		Carrier:      sql.NullString{String: carrier, Valid: true},
+		TrackingCode: sql.NullString{String: trackingCode, Valid: true},
+	})
+}
+
+// MarkShipmentDelivered records the delivery of a shipped card
+func (r *Repository) MarkShipmentDelivered(ctx context.Context, tx *sql.Tx, shipmentID uuid.UUID) error {
+	return r.queries.WithTx(tx).MarkShipmentDelivered(ctx, shipmentID)
+}
+
+// ActivateShipment activates a physical card, puts its pending renewal (if
+// any) into effect and closes its shipment
+func (r *Repository) ActivateShipment(ctx context.Context, tx *sql.Tx, cardID, shipmentID uuid.UUID) error {
+	queries := r.queries.WithTx(tx)
+	if err := queries.ActivateCard(ctx, cardID); err != nil {
+		return err
+	}
+	if err := queries.ApplyCardRenewal(ctx, cardID); err != nil {
+		return err
+	}
+	return queries.MarkShipmentActivated(ctx, shipmentID)
+}
+
+// fraudQueries returns queries bound to tx, or to the database when tx is nil
+func (r *Repository) fraudQueries(tx *sql.Tx) *db.Queries {
+	if tx != nil {
+		return r.queries.WithTx(tx)
+	}
+	return r.queries
+}
+
+// ListFraudRules returns all fraud rules
+func (r *Repository) ListFraudRules(ctx context.Context) ([]db.FraudRule, error) {
+	return r.queries.ListFraudRules(ctx)
+}
+
+// ListEnabledFraudRules returns the fraud rules scored on authorizations
+func (r *Repository) ListEnabledFraudRules(ctx context.Context, tx *sql.Tx) ([]db.FraudRule, error) {
+	return r.fraudQueries(tx).ListEnabledFraudRules(ctx)
+}
+
+// GetFraudRule retrieves a fraud rule by code
+func (r *Repository) GetFraudRule(ctx context.Context, code string) (*db.FraudRule, error) {
+	rule, err := r.queries.GetFraudRuleByCode(ctx, code)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrFraudRuleNotFound
+		}
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// UpdateFraudRule stores the state, score and params of a fraud rule
+func (r *Repository) UpdateFraudRule(ctx context.Context, rule *FraudRule) (*db.FraudRule, error) {
+	params, err := json.Marshal(rule.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := r.queries.UpdateFraudRule(ctx, db.UpdateFraudRuleParams{
+		Code:      rule.Code,
+		IsEnabled: rule.IsEnabled,
+		Score:     int32(rule.Score),
+		Params:    params,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrFraudRuleNotFound
+		}
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// GetFraudThresholds returns the fraud decision thresholds
+func (r *Repository) GetFraudThresholds(ctx context.Context, tx *sql.Tx) (*FraudThresholds, error) {
+	settings, err := r.fraudQueries(tx).GetFraudSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &FraudThresholds{
+		StepUpScore:  int(settings.StepUpScore),
+		DeclineScore: int(settings.DeclineScore),
+	}, nil
+}
+
+// UpdateFraudThresholds stores the fraud decision thresholds
+func (r *Repository) UpdateFraudThresholds(ctx context.Context, thresholds FraudThresholds) (*FraudThresholds, error) {
+	settings, err := r.queries.UpdateFraudSettings(ctx, db.UpdateFraudSettingsParams{
+		StepUpScore:  int32(thresholds.StepUpScore),
+		DeclineScore: int32(thresholds.DeclineScore),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &FraudThresholds{
+		StepUpScore:  int(settings.StepUpScore),
+		DeclineScore: int(settings.DeclineScore),
+	}, nil
+}
+
+// CountCardAuthorizationsSince counts scored authorizations on a card since a time
+func (r *Repository) CountCardAuthorizationsSince(ctx context.Context, tx *sql.Tx, cardID uuid.UUID, since time.Time) (int64, error) {
+	return r.fraudQueries(tx).CountCardFraudDecisionsSince(ctx, db.CountCardFraudDecisionsSinceParams{
+		CardID: cardID,
+		Since:  sql.NullTime{Time: since, Valid: true},
+	})
+}
+
+// CountCardSmallAuthorizationsSince counts scored authorizations up to maxAmountCents on a card since a time
+func (r *Repository) CountCardSmallAuthorizationsSince(ctx context.Context, tx *sql.Tx, cardID uuid.UUID, since time.Time, maxAmountCents int64) (int64, error) {
+	return r.fraudQueries(tx).CountCardSmallFraudDecisionsSince(ctx, db.CountCardSmallFraudDecisionsSinceParams{
+		CardID:         cardID,
+		Since:          sql.NullTime{Time: since, Valid: true},
+		MaxAmountCents: maxAmountCents,
+	})
+}
+
+// GetUserSpendingProfile returns the median amount and count of the user's completed card transactions since a time
+func (r *Repository) GetUserSpendingProfile(ctx context.Context, tx *sql.Tx, userID uuid.UUID, since time.Time) (int64, int64, error) {
+	profile, err := r.fraudQueries(tx).GetUserCardSpendingProfile(ctx, db.GetUserCardSpendingProfileParams{
+		UserID: userID,
+		Since:  since,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return profile.MedianAmountCents, profile.TransactionCount, nil
+}
+
+// CountUserCompletedTransactions counts the user's completed card transactions
+func (r *Repository) CountUserCompletedTransactions(ctx context.Context, tx *sql.Tx, userID uuid.UUID) (int64, error) {
+	return r.fraudQueries(tx).CountUserCompletedCardTransactions(ctx, userID)
+}
+
+// CountUserInternationalTransactions counts the user's completed international card transactions
+func (r *Repository) CountUserInternationalTransactions(ctx context.Context, tx *sql.Tx, userID uuid.UUID) (int64, error) {
+	return r.fraudQueries(tx).CountUserInternationalCardTransactions(ctx, userID)
+}
+
+// CountUserTransactionsByMCC counts the user's completed card transactions with an MCC
+func (r *Repository) CountUserTransactionsByMCC(ctx context.Context, tx *sql.Tx, userID uuid.UUID, mccCode string) (int64, error) {
+	return r.fraudQueries(tx).CountUserCardTransactionsByMCC(ctx, db.CountUserCardTransactionsByMCCParams{
+		UserID: userID,
+		Mcc:    sql.NullString{String: mccCode, Valid: true},
+	})
+}
+
+// CreateFraudDecision logs the fraud assessment of an authorization
+func (r *Repository) CreateFraudDecision(ctx context.Context, tx *sql.Tx, params db.CreateCardFraudDecisionParams) error {
+	_, err := r.fraudQueries(tx).CreateCardFraudDecision(ctx, params)
+	return err
+}
+
+// ListCardFraudDecisions retrieves logged fraud decisions of a card with pagination
+func (r *Repository) ListCardFraudDecisions(ctx context.Context, cardID uuid.UUID, limit, offset int32) ([]db.CardFraudDecision, error) {
+	return r.queries.ListCardFraudDecisions(ctx, db.ListCardFraudDecisionsParams{
+		CardID: cardID,
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+// CountCardFraudDecisions counts logged fraud decisions of a card
+func (r *Repository) CountCardFraudDecisions(ctx context.Context, cardID uuid.UUID) (int64, error) {
+	return r.queries.CountCardFraudDecisions(ctx, cardID)
 }
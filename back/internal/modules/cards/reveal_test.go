@@ -0,0 +1,45 @@
+package cards
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+)
+
+var errDatabaseDown = errors.New("database down")
+
+// downConnector opens connections on which every statement fails
+type downConnector struct{}
+
+func (downConnector) Connect(context.Context) (driver.Conn, error) { return downConn{}, nil }
+func (downConnector) Driver() driver.Driver                        { return nil }
+
+type downConn struct{}
+
+func (downConn) Prepare(string) (driver.Stmt, error) { return nil, errDatabaseDown }
+func (downConn) Close() error                        { return nil }
+func (downConn) Begin() (driver.Tx, error)           { return nil, errDatabaseDown }
+
+// TestAuditRevealFailure tests that a reveal audit that cannot be written is reported
+func TestAuditRevealFailure(t *testing.T) {
+	database := sql.OpenDB(downConnector{})
+	defer database.Close()
+	s := NewService(NewRepository(database, nil), database, Config{}, nil)
+
+	ctx := context.Background()
+	userID := "0b9f3c1e-6a2d-4c47-9d7e-2f1a5b8c9d0e"
+	cardID := "6d1e2f3a-4b5c-4d6e-8f70-8192a3b4c5d6"
+
+	// A reveal that would succeed fails with the audit error
+	if err := s.auditReveal(ctx, userID, cardID, "CARD_REVEALED", nil); !errors.Is(err, errDatabaseDown) {
+		t.Errorf("auditReveal() error = %v, want %v", err, errDatabaseDown)
+	}
+
+	// A reveal that already failed keeps its own error
+	data, err := s.ConsumeReveal(ctx, userID, cardID, ConsumeRevealRequest{})
+	if data != nil || err != ErrRevealTokenInvalid {
+		t.Errorf("ConsumeReveal() = %v, %v, want nil, %v", data, err, ErrRevealTokenInvalid)
+	}
+}
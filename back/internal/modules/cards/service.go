@@ -2,24 +2,57 @@ package cards
 
 import (
 	"context"
+	"crypto/subtle"
 	"database/sql"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lauratech/fin/back/internal/modules/notifications"
+	"github.com/lauratech/fin/back/internal/shared/crypto"
 	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+	"github.com/lauratech/fin/back/internal/shared/mcc"
+	"github.com/lauratech/fin/back/internal/shared/merchant"
 )
 
+// RevealTokenTTL is how long a reveal token stays valid after step-up verification
+const RevealTokenTTL = 60 * time.Second
+
+// Config holds card service settings
+type Config struct {
+	// Dynamic CVV: each CVV is valid for one window; authorization also
+	// accepts DynamicCVVDriftWindows windows before and after the current one
+	DynamicCVVWindow       time.Duration
+	DynamicCVVDriftWindows int
+
+	// Issuing: CVVKey derives server-side CVVs; PANFingerprintKey keys the
+	// PAN fingerprint used for uniqueness checks and lookups
+	CVVKey            []byte
+	PANFingerprintKey []byte
+
+	// Expiry lifecycle: physical cards are renewed RenewalLeadDays before
+	// expiry (keeping the PAN if RenewSamePAN) and users are notified
+	// ExpiryNoticeDays before a card expires
+	RenewalLeadDays  int
+	ExpiryNoticeDays int
+	RenewSamePAN     bool
+}
+
 // Service handles card business logic
 type Service struct {
-	repo *Repository
-	db   *sql.DB
+	repo          *Repository
+	db            *sql.DB
+	cfg           Config
+	notifications *notifications.Service
 }
 
 // NewService creates a new card service
-func NewService(repo *Repository, database *sql.DB) *Service {
+func NewService(repo *Repository, database *sql.DB, cfg Config, notificationsService *notifications.Service) *Service {
 	return &Service{
-		repo: repo,
-		db:   database,
+		repo:          repo,
+		db:            database,
+		cfg:           cfg,
+		notifications: notificationsService,
 	}
 }
 
@@ -35,36 +68,54 @@ func (s *Service) CreateCard(ctx context.Context, userID string, req CreateCardR
 		return nil, err
 	}
 
-	// 3. Validate or generate card number
-	var cardNumber string
-	if req.CardNumber != "" {
-		// Validate provided card number
-		if err := ValidateCardNumber(req.CardNumber); err != nil {
+	// 3. Validate PIN if provided
+	if req.PIN != "" {
+		if err := ValidatePIN(req.PIN); err != nil {
 			return nil, err
 		}
-		cardNumber = req.CardNumber
-	} else {
-		// Auto-generate card number
-		generated, err := GenerateCardNumber(req.Brand)
+	}
+
+	// 4. Validate CVV mode (dynamic CVV requires a per-card secret)
+	cvvMode := req.CVVMode
+	if cvvMode == "" {
+		cvvMode = "static"
+	}
+	if err := ValidateCVVMode(cvvMode, req.Type); err != nil {
+		return nil, err
+	}
+
+	var dcvvSecret []byte
+	if cvvMode == "dynamic" {
+		secret, err := GenerateDynamicCVVSecret()
 		if err != nil {
 			return nil, err
 		}
-		cardNumber = generated
+		dcvvSecret = secret
 	}
 
-	// 4. Validate CVV format
-	if err := ValidateCVV(req.CVV); err != nil {
+	// 5. Validate card profile
+	profile := req.Profile
+	if profile == "" {
+		profile = ProfileStandard
+	}
+	if err := ValidateCardProfile(profile, req.Type, req.AmountCapCents, req.ExpiryDays); err != nil {
 		return nil, err
 	}
 
-	// 5. Validate PIN if provided
-	if req.PIN != "" {
-		if err := ValidatePIN(req.PIN); err != nil {
+	// 6. Physical cards are shipped to the holder and need an address
+	var shippingAddress ShippingAddress
+	if (req.Type == "physical") != (req.ShippingAddress != nil) {
+		return nil, ErrShippingAddressRequired
+	}
+	if req.ShippingAddress != nil {
+		address, err := NormalizeShippingAddress(*req.ShippingAddress, req.HolderName)
+		if err != nil {
 			return nil, err
 		}
+		shippingAddress = address
 	}
 
-	// 6. Set default limits if not provided
+	// 7. Set default limits if not provided
 	dailyLimit := req.DailyLimitCents
 	if dailyLimit == 0 {
 		dailyLimit = 500000 // R$ 5,000 default
@@ -75,23 +126,47 @@ func (s *Service) CreateCard(ctx context.Context, userID string, req CreateCardR
 		monthlyLimit = 5000000 // R$ 50,000 default
 	}
 
-	// 7. Validate limits
+	// 8. Validate limits
 	if dailyLimit < 0 || monthlyLimit < 0 {
 		return nil, ErrInvalidLimit
 	}
 
-	// 8. Calculate expiry date
+	// 9. Calculate expiry date (profiled cards may expire in days)
 	expiresAt := CalculateExpiryDate(req.Type)
+	if req.ExpiryDays > 0 {
+		expiresAt = time.Now().AddDate(0, 0, req.ExpiryDays)
+	}
 	expiryMonth := int(expiresAt.Month())
 	expiryYear := expiresAt.Year()
 
-	// 9. Call repository (which handles encryption)
-	return s.repo.Create(ctx, CreateCardParams{
+	// 10. Issue PAN from the configured BIN ranges
+	cardNumber, fingerprint, err := s.issuePAN(ctx, req.Brand, req.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	// 11. Derive CVV server-side (never accepted from the client)
+	cvv := GenerateCVV(s.cfg.CVVKey, cardNumber, expiryMonth, expiryYear)
+
+	// 12. Tokenize PAN for downstream systems
+	panToken, err := s.newPANToken(ctx, cardNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	// 13. Call repository (which handles encryption)
+	params := CreateCardParams{
 		UserID:             userID,
 		Type:               req.Type,
 		Brand:              req.Brand,
 		CardNumber:         cardNumber,
-		CVV:                req.CVV,
+		PANFingerprint:     fingerprint,
+		PANToken:           panToken,
+		CVV:                cvv,
+		CVVMode:            cvvMode,
+		DynamicCVVSecret:   dcvvSecret,
+		Profile:            profile,
+		AmountCapCents:     req.AmountCapCents,
 		PIN:                req.PIN,
 		HolderName:         req.HolderName,
 		ExpiryMonth:        expiryMonth,
@@ -103,7 +178,26 @@ func (s *Service) CreateCard(ctx context.Context, userID string, req CreateCardR
 		BlockInternational: false,
 		BlockOnline:        false,
 		ExpiresAt:          expiresAt,
+		RequiresActivation: req.Type == "physical",
+	}
+	if req.Type != "physical" {
+		return s.repo.Create(ctx, params)
+	}
+
+	// 14. Physical cards: request production and delivery with the card
+	var card *Card
+	err = s.executeInTransaction(ctx, func(tx *sql.Tx) error {
+		card, err = s.repo.CreateWithTx(ctx, tx, params)
+		if err != nil {
+			return err
+		}
+		_, err = s.repo.CreateShipment(ctx, tx, card.ID, shippingAddress)
+		return err
 	})
+	if err != nil {
+		return nil, err
+	}
+	return card, nil
 }
 
 // GetCardByID retrieves card details (returns masked card number)
@@ -122,7 +216,26 @@ func (s *Service) GetCardByID(ctx context.Context, userID, cardID string) (*Card
 	// 3. Convert to card details (masks card number)
 	// Note: hasPIN check would require additional repository method
 	hasPIN := false // TODO: Implement proper PIN check
-	return cardToCardDetails(card, hasPIN), nil
+	details := cardToCardDetails(card, hasPIN)
+
+	// 4. Link to the card this one replaced
+	details.ReplacesCardID, err = s.repo.GetReplacedCardID(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 5. Physical cards: current shipment (none for cards issued before shipping existed)
+	if card.Type == "physical" {
+		shipment, err := s.repo.GetLatestShipment(ctx, cardID)
+		if err != nil && err != ErrShipmentNotFound {
+			return nil, err
+		}
+		if shipment != nil {
+			details.Shipment = dbShipmentToCardShipment(shipment)
+		}
+	}
+
+	return details, nil
 }
 
 // ListUserCards lists all cards for a user (without sensitive data)
@@ -145,7 +258,7 @@ func (s *Service) BlockCard(ctx context.Context, userID, cardID string) error {
 	if card.Status == "blocked" {
 		return nil // Already blocked
 	}
-	if card.Status == "cancelled" {
+	if isClosedStatus(card.Status) {
 		return ErrCardCancelled
 	}
 
@@ -165,7 +278,7 @@ func (s *Service) UnblockCard(ctx context.Context, userID, cardID string) error
 	}
 
 	// 2. Check if card can be unblocked
-	if card.Status == "cancelled" {
+	if isClosedStatus(card.Status) {
 		return ErrCardCancelled
 	}
 	if card.Status != "blocked" {
@@ -258,9 +371,17 @@ func (s *Service) VerifyPIN(ctx context.Context, cardID, pin string) (bool, erro
 	return s.repo.VerifyPIN(ctx, cardID, pin)
 }
 
-// CancelCard cancels a card permanently
+// CancelCard cancels a card permanently, recording the reason
 func (s *Service) CancelCard(ctx context.Context, userID, cardID string, reason string) error {
-	// 1. Verify ownership
+	// 1. Validate reason
+	if reason == "" {
+		reason = CancelReasonUserRequest
+	}
+	if err := ValidateCancelReason(reason); err != nil {
+		return err
+	}
+
+	// 2. Verify ownership
 	card, err := s.repo.GetByIDForSummary(ctx, cardID)
 	if err != nil {
 		return err
@@ -269,28 +390,217 @@ func (s *Service) CancelCard(ctx context.Context, userID, cardID string, reason
 		return ErrUnauthorized
 	}
 
-	// 2. Check if already cancelled
-	if card.Status == "cancelled" {
+	// 3. Check if already cancelled
+	if isClosedStatus(card.Status) {
 		return nil // Already cancelled
 	}
 
-	// 3. Cancel card (soft delete)
-	return s.repo.CancelCard(ctx, cardID)
+	// 4. Cancel card (soft delete) - lost/stolen keep a distinct status
+	return s.executeInTransaction(ctx, func(tx *sql.Tx) error {
+		return s.repo.CancelCard(ctx, tx, cardID, statusForCancelReason(reason), reason)
+	})
 }
 
-// ProcessCardTransaction processes a card transaction (checks limits, updates spent, persists transaction)
-// This would be called by a card transaction processor
-func (s *Service) ProcessCardTransaction(
-	ctx context.Context,
-	cardID string,
-	amountCents int64,
-	merchantName string,
-	merchantCategory string,
-	isInternational bool,
-) error {
+// GetCategoryControls lists the category rules of a card with this month's spending
+func (s *Service) GetCategoryControls(ctx context.Context, userID, cardID string) (*CategoryControlsResponse, error) {
+	// 1. Verify ownership
+	card, err := s.repo.GetByIDForSummary(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	if card.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	// 2. Load rules
+	dbControls, err := s.repo.ListCategoryControls(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Attach current month spending per category
+	monthStart := startOfMonth(time.Now())
+	controls := make([]CategoryControl, len(dbControls))
+	for i, dbControl := range dbControls {
+		spent, err := s.repo.SumCategorySpent(ctx, nil, cardID, dbControl.Category, monthStart)
+		if err != nil {
+			return nil, err
+		}
+		controls[i] = dbCategoryControlToCategoryControl(&dbControl, spent)
+	}
+
+	return &CategoryControlsResponse{
+		Controls:            controls,
+		AvailableCategories: mcc.Categories(),
+	}, nil
+}
+
+// UpdateCategoryControls replaces the category rules of a card
+func (s *Service) UpdateCategoryControls(ctx context.Context, userID, cardID string, req UpdateCategoryControlsRequest) error {
+	// 1. Validate rules
+	if err := ValidateCategoryControls(req.Controls); err != nil {
+		return err
+	}
+
+	// 2. Verify ownership
+	card, err := s.repo.GetByIDForSummary(ctx, cardID)
+	if err != nil {
+		return err
+	}
+	if card.UserID != userID {
+		return ErrUnauthorized
+	}
+	if isClosedStatus(card.Status) {
+		return ErrCardCancelled
+	}
+
+	// 3. Replace rules atomically
 	return s.executeInTransaction(ctx, func(tx *sql.Tx) error {
+		return s.repo.ReplaceCategoryControls(ctx, tx, cardID, req.Controls)
+	})
+}
+
+// RequestReveal performs step-up verification (PIN) and issues a single-use reveal token
+// Every attempt, successful or not, is written to the audit log; no token is
+// returned unless the attempt is on record
+func (s *Service) RequestReveal(ctx context.Context, userID, cardID string, req RevealCardRequest) (resp *RevealTokenResponse, err error) {
+	defer func() {
+		if auditErr := s.auditReveal(ctx, userID, cardID, "CARD_REVEAL_REQUESTED", err); auditErr != nil && err == nil {
+			resp, err = nil, auditErr
+		}
+	}()
+
+	// 1. Verify ownership
+	card, err := s.repo.GetByIDForSummary(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	if card.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	// 2. Only active virtual cards can be revealed
+	if card.Type != "virtual" || card.Status != "active" {
+		return nil, ErrRevealNotAllowed
+	}
+
+	// 3. Step-up: verify PIN
+	match, err := s.repo.VerifyPIN(ctx, cardID, req.PIN)
+	if err != nil {
+		return nil, err
+	}
+	if !match {
+		return nil, ErrPINIncorrect
+	}
+
+	// 4. Issue token (only the hash is persisted)
+	token, err := crypto.GenerateToken(32)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(RevealTokenTTL)
+
+	if err := s.repo.CreateRevealToken(ctx, cardID, userID, crypto.HashToken(token), expiresAt); err != nil {
+		return nil, err
+	}
+
+	return &RevealTokenResponse{
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// ConsumeReveal exchanges a reveal token for the full card data (exactly once)
+// The PAN and CVV are only returned when the reveal is on record
+func (s *Service) ConsumeReveal(ctx context.Context, userID, cardID string, req ConsumeRevealRequest) (data *RevealedCardData, err error) {
+	defer func() {
+		if auditErr := s.auditReveal(ctx, userID, cardID, "CARD_REVEALED", err); auditErr != nil && err == nil {
+			data, err = nil, auditErr
+		}
+	}()
+
+	if req.Token == "" {
+		return nil, ErrRevealTokenInvalid
+	}
+
+	// 1. Consume token atomically (fails if expired or already used)
+	token, err := s.repo.ConsumeRevealToken(ctx, crypto.HashToken(req.Token))
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Token must have been issued for this card and user
+	if token.CardID.String() != cardID || token.UserID.String() != userID {
+		return nil, ErrRevealTokenInvalid
+	}
+
+	// 3. Load decrypted card and re-check state
+	card, err := s.repo.GetByID(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	if card.Type != "virtual" || card.Status != "active" {
+		return nil, ErrRevealNotAllowed
+	}
+
+	data = &RevealedCardData{
+		CardNumber:  card.CardNumber,
+		CVV:         card.CVV,
+		CVVMode:     card.CVVMode,
+		HolderName:  card.HolderName,
+		ExpiryMonth: card.ExpiryMonth,
+		ExpiryYear:  card.ExpiryYear,
+	}
+
+	// 4. Dynamic CVV: reveal the code for the current window instead of the static one
+	if card.CVVMode == "dynamic" {
+		now := time.Now()
+		validUntil := DynamicCVVValidUntil(now, s.cfg.DynamicCVVWindow)
+		data.CVV = ComputeDynamicCVV(card.DynamicCVVSecret, now, s.cfg.DynamicCVVWindow)
+		data.CVVValidUntil = &validUntil
+	}
+
+	return data, nil
+}
+
+// auditReveal records a reveal event and returns the audit write error
+func (s *Service) auditReveal(ctx context.Context, userID, cardID, action string, err error) error {
+	status := "success"
+	var details map[string]interface{}
+	if err != nil {
+		status = "failure"
+		details = map[string]interface{}{"error": err.Error()}
+	}
+
+	return s.repo.CreateAuditLog(ctx, userID, cardID, action, status, details)
+}
+
+// ProcessCardTransaction processes a card transaction (checks limits, scores fraud, updates spent, persists transaction)
+// This would be called by a card transaction processor. The fraud assessment is returned
+// with ErrFraudDeclined and ErrStepUpRequired so the processor can challenge the holder.
+func (s *Service) ProcessCardTransaction(ctx context.Context, cardID string, auth AuthorizationContext) (*FraudAssessment, error) {
+	// Validate authorization context
+	if err := ValidateAuthorizationContext(auth); err != nil {
+		return nil, err
+	}
+	auth.Country = strings.ToUpper(auth.Country)
+	isInternational := auth.IsInternational()
+
+	// Enrich the descriptor (canonical merchant, logo, city) and map it to a spending category:
+	// the merchant catalog first, then the reported MCC (or category name)
+	enriched := merchant.Enrich(auth.MerchantName, auth.MerchantCategory)
+	category := enriched.Category
+	var mccCode sql.NullString
+	if mcc.IsMCC(auth.MerchantCategory) {
+		mccCode = sql.NullString{String: auth.MerchantCategory, Valid: true}
+	}
+
+	var card *db.Card
+	var assessment *FraudAssessment
+	err := s.executeInTransaction(ctx, func(tx *sql.Tx) error {
 		// 1. Lock card record
-		card, err := s.repo.GetForUpdate(ctx, tx, cardID)
+		var err error
+		card, err = s.repo.GetForUpdate(ctx, tx, cardID)
 		if err != nil {
 			return err
 		}
@@ -300,23 +610,51 @@ func (s *Service) ProcessCardTransaction(
 			if card.Status == "blocked" {
 				return ErrCardBlocked
 			}
-			if card.Status == "cancelled" {
+			if isClosedStatus(card.Status) {
 				return ErrCardCancelled
 			}
+			if card.Status == "expired" {
+				return ErrCardExpired
+			}
 			return ErrCardNotActive
 		}
 
+		// Physical cards authorize only after the holder activates them
+		if !card.ActivatedAt.Valid {
+			return ErrCardNotActivated
+		}
+
 		// 3. Check if card is expired
 		if card.ExpiresAt.Valid && card.ExpiresAt.Time.Before(time.Now()) {
 			return ErrCardExpired
 		}
 
-		// 4. Check international transactions
-		if isInternational && card.BlockInternational.Valid && card.BlockInternational.Bool {
-			return ErrInternationalBlocked
+		// 4. Verify CVV: required card-not-present and for dynamic CVV cards, checked whenever presented
+		if auth.CVV == "" && (auth.Channel == ChannelEcommerce || card.CvvMode == "dynamic") {
+			return ErrCVVRequired
+		}
+		if auth.CVV != "" {
+			if err := s.verifyCVV(card, auth.CVV); err != nil {
+				return err
+			}
 		}
 
-		// 5. Check daily limit
+		// 5. Enforce security settings (online, contactless, international)
+		if err := checkChannelRules(card, auth); err != nil {
+			return err
+		}
+
+		// 6. Enforce card profile (merchant lock, amount cap)
+		if err := checkProfileRules(card, auth.AmountCents, auth.MerchantName, auth.MerchantCategory); err != nil {
+			return err
+		}
+
+		// 7. Enforce category controls (blocked categories, monthly category limits)
+		if err := s.checkCategoryControls(ctx, tx, cardID, category, auth.AmountCents); err != nil {
+			return err
+		}
+
+		// 8. Check daily limit
 		currentDailySpent := int64(0)
 		if card.CurrentDailySpentCents.Valid {
 			currentDailySpent = card.CurrentDailySpentCents.Int64
@@ -326,11 +664,11 @@ func (s *Service) ProcessCardTransaction(
 			dailyLimit = card.DailyLimitCents.Int64
 		}
 
-		if currentDailySpent+amountCents > dailyLimit {
+		if currentDailySpent+auth.AmountCents > dailyLimit {
 			return ErrDailyLimitExceeded
 		}
 
-		// 6. Check monthly limit
+		// 9. Check monthly limit
 		currentMonthlySpent := int64(0)
 		if card.CurrentMonthlySpentCents.Valid {
 			currentMonthlySpent = card.CurrentMonthlySpentCents.Int64
@@ -340,36 +678,154 @@ func (s *Service) ProcessCardTransaction(
 			monthlyLimit = card.MonthlyLimitCents.Int64
 		}
 
-		if currentMonthlySpent+amountCents > monthlyLimit {
+		if currentMonthlySpent+auth.AmountCents > monthlyLimit {
 			return ErrMonthlyLimitExceeded
 		}
 
-		// 7. Update spent amounts
-		newDaily := currentDailySpent + amountCents
-		newMonthly := currentMonthlySpent + amountCents
+		// 10. Score fraud rules
+		assessment, err = s.assessFraud(ctx, tx, card, auth, mccCode.String)
+		if err != nil {
+			return err
+		}
+		switch assessment.Decision {
+		case FraudDecline:
+			return ErrFraudDeclined
+		case FraudStepUp:
+			return ErrStepUpRequired
+		}
+
+		// 11. Update spent amounts
+		newDaily := currentDailySpent + auth.AmountCents
+		newMonthly := currentMonthlySpent + auth.AmountCents
 
-		err = s.repo.UpdateSpentAmounts(ctx, cardID, newDaily, newMonthly)
+		err = s.repo.UpdateSpentAmounts(ctx, tx, cardID, newDaily, newMonthly)
 		if err != nil {
 			return err
 		}
 
-		// 8. Persist card transaction
-		_, err = s.repo.CreateCardTransaction(ctx, db.CreateCardTransactionParams{
-			CardID:           card.ID,
-			UserID:           card.UserID,
-			AmountCents:      amountCents,
-			MerchantName:     merchantName,
-			MerchantCategory: sql.NullString{String: merchantCategory, Valid: merchantCategory != ""},
-			Status:           "completed",
-			IsInternational:  sql.NullBool{Bool: isInternational, Valid: true},
-			TransactionDate:  time.Now(),
+		err = s.repo.UpdateTotalSpent(ctx, tx, cardID, card.TotalSpentCents+auth.AmountCents)
+		if err != nil {
+			return err
+		}
+
+		// 12. Persist card transaction with its fraud decision
+		transaction, err := s.repo.CreateCardTransaction(ctx, tx, db.CreateCardTransactionParams{
+			CardID:              card.ID,
+			UserID:              card.UserID,
+			AmountCents:         auth.AmountCents,
+			MerchantName:        auth.MerchantName,
+			MerchantCategory:    sql.NullString{String: category, Valid: true},
+			Status:              "completed",
+			IsInternational:     sql.NullBool{Bool: isInternational, Valid: true},
+			TransactionDate:     time.Now(),
+			Mcc:                 mccCode,
+			Channel:             sql.NullString{String: auth.Channel, Valid: true},
+			MerchantCountry:     sql.NullString{String: auth.Country, Valid: auth.Country != ""},
+			MerchantKey:         sql.NullString{String: enriched.Key, Valid: true}, // Set even when empty: marks the row as enriched
+			MerchantDisplayName: sql.NullString{String: enriched.Name, Valid: enriched.Name != ""},
+			MerchantLogoKey:     sql.NullString{String: enriched.LogoKey, Valid: enriched.LogoKey != ""},
+			MerchantCity:        sql.NullString{String: enriched.City, Valid: enriched.City != ""},
 		})
 		if err != nil {
 			return err
 		}
 
+		transactionID := uuid.NullUUID{UUID: transaction.ID, Valid: true}
+		if err := s.logFraudDecision(ctx, tx, card, auth, mccCode.String, transactionID, assessment); err != nil {
+			return err
+		}
+
+		// 13. Apply post-authorization profile effects
+		switch card.Profile {
+		case ProfileSingleUse:
+			return s.repo.UpdateStatusWithTx(ctx, tx, cardID, "cancelled")
+		case ProfileMerchantLocked:
+			if !card.LockedMerchantName.Valid {
+				return s.repo.LockMerchant(ctx, tx, cardID, auth.MerchantName, auth.MerchantCategory)
+			}
+		}
+
 		return nil
 	})
+
+	// Declined and stepped-up attempts are rolled back but still logged,
+	// so they count towards the velocity and card testing rules
+	if err == ErrFraudDeclined || err == ErrStepUpRequired {
+		_ = s.logFraudDecision(ctx, nil, card, auth, mccCode.String, uuid.NullUUID{}, assessment)
+		return assessment, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return assessment, nil
+}
+
+// checkCategoryControls enforces the card's rule for a merchant category
+func (s *Service) checkCategoryControls(ctx context.Context, tx *sql.Tx, cardID, category string, amountCents int64) error {
+	control, err := s.repo.GetCategoryControl(ctx, tx, cardID, category)
+	if err != nil {
+		return err
+	}
+	if control == nil {
+		return nil
+	}
+
+	if control.IsBlocked {
+		return ErrCategoryBlocked
+	}
+
+	if control.MonthlyLimitCents.Valid {
+		spent, err := s.repo.SumCategorySpent(ctx, tx, cardID, category, startOfMonth(time.Now()))
+		if err != nil {
+			return err
+		}
+		if spent+amountCents > control.MonthlyLimitCents.Int64 {
+			return ErrCategoryLimitExceeded
+		}
+	}
+
+	return nil
+}
+
+// startOfMonth returns midnight on the first day of t's month
+func startOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// verifyCVV checks a presented CVV against the card's static or dynamic CVV
+func (s *Service) verifyCVV(card *db.Card, cvv string) error {
+	if card.CvvMode == "dynamic" {
+		secret, err := s.repo.DecryptDynamicCVVSecret(card)
+		if err != nil {
+			return err
+		}
+		if secret == nil || !VerifyDynamicCVV(secret, cvv, time.Now(), s.cfg.DynamicCVVWindow, s.cfg.DynamicCVVDriftWindows) {
+			return ErrCVVVerificationFailed
+		}
+		return nil
+	}
+
+	expected, err := s.repo.DecryptCVV(card)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(cvv)) != 1 {
+		return ErrCVVVerificationFailed
+	}
+	return nil
+}
+
+// verifyRenewalCVV checks a CVV against the pending same-PAN renewal of a locked card row
+func (s *Service) verifyRenewalCVV(card *db.Card, cvv string) error {
+	expected, err := s.repo.DecryptRenewalCVV(card)
+	if err != nil {
+		return err
+	}
+	if expected == "" || subtle.ConstantTimeCompare([]byte(expected), []byte(cvv)) != 1 {
+		return ErrCVVVerificationFailed
+	}
+	return nil
 }
 
 // executeInTransaction executes a function within a database transaction
@@ -399,22 +855,6 @@ func (s *Service) executeInTransaction(ctx context.Context, fn func(*sql.Tx) err
 	return tx.Commit()
 }
 
-// ListCardTransactions retrieves transactions for a specific card
-func (s *Service) ListCardTransactions(ctx context.Context, userID, cardID string, limit, offset int32) ([]db.CardTransaction, error) {
-	// Verify card belongs to user
-	card, err := s.repo.GetByID(ctx, cardID)
-	if err != nil {
-		return nil, err
-	}
-
-	if card.UserID != userID {
-		return nil, ErrUnauthorized
-	}
-
-	cardUUID, _ := uuid.Parse(cardID)
-	return s.repo.ListCardTransactions(ctx, cardUUID, limit, offset)
-}
-
 // ListUserCardTransactions retrieves all user's card transactions
 func (s *Service) ListUserCardTransactions(ctx context.Context, userID string, limit, offset int32) ([]db.CardTransaction, error) {
 	userUUID, _ := uuid.Parse(userID)
@@ -0,0 +1,337 @@
+package cards
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lauratech/fin/back/internal/modules/notifications"
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+)
+
+// Physical card shipment statuses
+const (
+	ShipmentRequested = "requested"
+	ShipmentProduced  = "produced"
+	ShipmentShipped   = "shipped"
+	ShipmentDelivered = "delivered"
+	ShipmentActivated = "activated"
+)
+
+// brazilianStates holds the valid UFs for shipping addresses
+var brazilianStates = map[string]bool{
+	"AC": true, "AL": true, "AP": true, "AM": true, "BA": true, "CE": true, "DF": true,
+	"ES": true, "GO": true, "MA": true, "MT": true, "MS": true, "MG": true, "PA": true,
+	"PB": true, "PR": true, "PE": true, "PI": true, "RJ": true, "RN": true, "RS": true,
+	"RO": true, "RR": true, "SC": true, "SP": true, "SE": true, "TO": true,
+}
+
+// NormalizeShippingAddress validates a shipping address and returns it in storage form
+// (trimmed fields, upper-case UF, CEP digits only). The recipient defaults to holderName.
+func NormalizeShippingAddress(address ShippingAddress, holderName string) (ShippingAddress, error) {
+	normalized := ShippingAddress{
+		RecipientName: strings.TrimSpace(address.RecipientName),
+		Street:        strings.TrimSpace(address.Street),
+		Number:        strings.TrimSpace(address.Number),
+		Complement:    strings.TrimSpace(address.Complement),
+		Neighborhood:  strings.TrimSpace(address.Neighborhood),
+		City:          strings.TrimSpace(address.City),
+		State:         strings.ToUpper(strings.TrimSpace(address.State)),
+		PostalCode:    strings.NewReplacer("-", "", ".", "", " ", "").Replace(address.PostalCode),
+	}
+	if normalized.RecipientName == "" {
+		normalized.RecipientName = strings.TrimSpace(holderName)
+	}
+
+	if normalized.RecipientName == "" || normalized.Street == "" || normalized.Number == "" ||
+		normalized.Neighborhood == "" || normalized.City == "" {
+		return ShippingAddress{}, ErrInvalidShippingAddress
+	}
+	if len(normalized.RecipientName) > 255 || len(normalized.Street) > 255 || len(normalized.Number) > 20 ||
+		len(normalized.Complement) > 100 || len(normalized.Neighborhood) > 100 || len(normalized.City) > 100 {
+		return ShippingAddress{}, ErrInvalidShippingAddress
+	}
+	if !brazilianStates[normalized.State] {
+		return ShippingAddress{}, ErrInvalidShippingAddress
+	}
+	if len(normalized.PostalCode) != 8 || !isDigits(normalized.PostalCode) {
+		return ShippingAddress{}, ErrInvalidShippingAddress
+	}
+
+	return normalized, nil
+}
+
+// ValidateShipmentTransition validates a shipment status change
+// A shipped card can be activated before the carrier confirms delivery
+func ValidateShipmentTransition(from, to string) error {
+	allowed := map[string][]string{
+		ShipmentRequested: {ShipmentProduced},
+		ShipmentProduced:  {ShipmentShipped},
+		ShipmentShipped:   {ShipmentDelivered, ShipmentActivated},
+		ShipmentDelivered: {ShipmentActivated},
+	}
+
+	for _, next := range allowed[from] {
+		if next == to {
+			return nil
+		}
+	}
+	return ErrInvalidShipmentTransition
+}
+
+// isDigits reports whether s is non-empty and contains only ASCII digits
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// reissueShippingAddress returns the delivery address of a card reissued in place of oldCard:
+// the override if given, otherwise the address of the old card's latest shipment
+func (s *Service) reissueShippingAddress(ctx context.Context, oldCard *db.Card, override *ShippingAddress) (ShippingAddress, error) {
+	if override != nil {
+		return NormalizeShippingAddress(*override, oldCard.HolderName)
+	}
+
+	shipment, err := s.repo.GetLatestShipment(ctx, oldCard.ID.String())
+	if err != nil {
+		if err == ErrShipmentNotFound {
+			// Cards issued before shipping existed have no address on file
+			return ShippingAddress{}, ErrShippingAddressRequired
+		}
+		return ShippingAddress{}, err
+	}
+
+	return dbShipmentToCardShipment(shipment).Address, nil
+}
+
+// GetCardShipment returns the current shipment of a user's physical card
+func (s *Service) GetCardShipment(ctx context.Context, userID, cardID string) (*CardShipment, error) {
+	// 1. Verify ownership
+	summary, err := s.repo.GetByIDForSummary(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	if summary.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+
+	// 2. Get latest shipment
+	shipment, err := s.repo.GetLatestShipment(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dbShipmentToCardShipment(shipment), nil
+}
+
+// ActivateCard activates a shipped physical card.
+// The holder proves possession of the plastic with its last four digits and CVV.
+func (s *Service) ActivateCard(ctx context.Context, userID, cardID string, req ActivateCardRequest) (result *CardSummary, err error) {
+	defer func() {
+		status := "success"
+		details := map[string]interface{}{}
+		if err != nil {
+			status = "failure"
+			details["error"] = err.Error()
+		}
+		_ = s.repo.CreateAuditLog(ctx, userID, cardID, "CARD_ACTIVATED", status, details)
+	}()
+
+	// 1. Verify ownership
+	summary, err := s.repo.GetByIDForSummary(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	if summary.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+	if summary.Type != "physical" {
+		return nil, ErrActivationNotAvailable
+	}
+
+	// 2. Get current shipment
+	latest, err := s.repo.GetLatestShipment(ctx, cardID)
+	if err != nil {
+		if err == ErrShipmentNotFound {
+			return nil, ErrCardAlreadyActivated
+		}
+		return nil, err
+	}
+
+	err = s.executeInTransaction(ctx, func(tx *sql.Tx) error {
+		// 3. Lock card and shipment
+		card, err := s.repo.GetForUpdate(ctx, tx, cardID)
+		if err != nil {
+			return err
+		}
+		if isClosedStatus(card.Status) {
+			return ErrCardCancelled
+		}
+		if card.Status == "expired" {
+			return ErrCardExpired
+		}
+
+		shipment, err := s.repo.GetShipmentForUpdate(ctx, tx, latest.ID)
+		if err != nil {
+			return err
+		}
+		if shipment.Status == ShipmentActivated {
+			return ErrCardAlreadyActivated
+		}
+		if err := ValidateShipmentTransition(shipment.Status, ShipmentActivated); err != nil {
+			return ErrActivationNotAvailable
+		}
+
+		// 4. Verify printed data (constant time); a renewed plastic carries the pending CVV
+		lastFourMatch := subtle.ConstantTimeCompare([]byte(card.LastFourDigits), []byte(req.LastFourDigits)) == 1
+		var cvvErr error
+		if card.RenewalExpiresAt.Valid {
+			cvvErr = s.verifyRenewalCVV(card, req.CVV)
+		} else {
+			cvvErr = s.verifyCVV(card, req.CVV)
+		}
+		if !lastFourMatch || cvvErr == ErrCVVVerificationFailed {
+			return ErrActivationFailed
+		}
+		if cvvErr != nil {
+			return cvvErr
+		}
+
+		// 5. Activate card (no-op for renewed cards already active), apply a pending
+		// renewal and close shipment
+		return s.repo.ActivateShipment(ctx, tx, card.ID, shipment.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByIDForSummary(ctx, cardID)
+}
+
+// MarkCardShipped records that a produced card was handed to the carrier
+// Internal callers only (card processor). Repeating the same update is a no-op.
+func (s *Service) MarkCardShipped(ctx context.Context, caller, shipmentID string, req ShipCardRequest) (*CardShipment, error) {
+	// 1. Validate request
+	carrier := strings.TrimSpace(req.Carrier)
+	trackingCode := strings.TrimSpace(req.TrackingCode)
+	if carrier == "" || trackingCode == "" || len(carrier) > 50 || len(trackingCode) > 100 {
+		return nil, ErrInvalidTrackingInfo
+	}
+
+	id, err := uuid.Parse(shipmentID)
+	if err != nil {
+		return nil, ErrShipmentNotFound
+	}
+
+	var shipment *db.CardShipment
+	err = s.executeInTransaction(ctx, func(tx *sql.Tx) error {
+		// 2. Lock shipment and validate transition
+		shipment, err = s.repo.GetShipmentForUpdate(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if shipment.Status == ShipmentShipped && shipment.TrackingCode.String == trackingCode {
+			return nil
+		}
+		if err := ValidateShipmentTransition(shipment.Status, ShipmentShipped); err != nil {
+			return err
+		}
+
+		// 3. Record carrier and tracking code
+		if err := s.repo.MarkShipmentShipped(ctx, tx, id, carrier, trackingCode); err != nil {
+			return err
+		}
+		shipment, err = s.repo.GetShipmentForUpdate(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Let the holder know the card is on its way
+	if err := s.notifyShipment(ctx, shipment, notifications.TypeCardShipped, "Card shipped",
+		fmt.Sprintf("Your new card was shipped by %s. Tracking code: %s.", shipment.Carrier.String, shipment.TrackingCode.String)); err != nil {
+		return nil, err
+	}
+
+	_ = s.repo.CreateAuditLog(ctx, "", shipment.CardID.String(), "CARD_SHIPPED", "success", map[string]interface{}{
+		"caller":      caller,
+		"shipment_id": shipmentID,
+	})
+
+	return dbShipmentToCardShipment(shipment), nil
+}
+
+// MarkCardDelivered records the delivery of a shipped card
+// Internal callers only (card processor). Repeating the update is a no-op.
+func (s *Service) MarkCardDelivered(ctx context.Context, caller, shipmentID string) (*CardShipment, error) {
+	id, err := uuid.Parse(shipmentID)
+	if err != nil {
+		return nil, ErrShipmentNotFound
+	}
+
+	var shipment *db.CardShipment
+	err = s.executeInTransaction(ctx, func(tx *sql.Tx) error {
+		// 1. Lock shipment and validate transition
+		shipment, err = s.repo.GetShipmentForUpdate(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if shipment.Status == ShipmentDelivered || shipment.Status == ShipmentActivated {
+			return nil
+		}
+		if err := ValidateShipmentTransition(shipment.Status, ShipmentDelivered); err != nil {
+			return err
+		}
+
+		// 2. Record delivery
+		if err := s.repo.MarkShipmentDelivered(ctx, tx, id); err != nil {
+			return err
+		}
+		shipment, err = s.repo.GetShipmentForUpdate(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.repo.CreateAuditLog(ctx, "", shipment.CardID.String(), "CARD_DELIVERED", "success", map[string]interface{}{
+		"caller":      caller,
+		"shipment_id": shipmentID,
+	})
+
+	return dbShipmentToCardShipment(shipment), nil
+}
+
+// notifyShipment notifies the card holder about a shipment, once per shipment and type
+func (s *Service) notifyShipment(ctx context.Context, shipment *db.CardShipment, notificationType, title, message string) error {
+	if s.notifications == nil {
+		return nil
+	}
+
+	summary, err := s.repo.GetByIDForSummary(ctx, shipment.CardID.String())
+	if err != nil {
+		return err
+	}
+
+	_, err = s.notifications.Notify(ctx, notifications.NotifyRequest{
+		UserID:       summary.UserID,
+		Type:         notificationType,
+		Title:        title,
+		Message:      message,
+		ResourceType: "card",
+		ResourceID:   summary.ID,
+		DedupKey:     fmt.Sprintf("%s:%s", notificationType, shipment.ID),
+	})
+	return err
+}
@@ -0,0 +1,81 @@
+package cards
+
+import "testing"
+
+// TestNormalizeShippingAddress tests shipping address validation and normalization
+func TestNormalizeShippingAddress(t *testing.T) {
+	valid := ShippingAddress{
+		Street:       " Av. Paulista ",
+		Number:       "1000",
+		Complement:   "Apto 12",
+		Neighborhood: "Bela Vista",
+		City:         "São Paulo",
+		State:        "sp",
+		PostalCode:   "01310-100",
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(a *ShippingAddress)
+		wantErr error
+	}{
+		{"valid address", func(a *ShippingAddress) {}, nil},
+		{"missing street", func(a *ShippingAddress) { a.Street = " " }, ErrInvalidShippingAddress},
+		{"missing number", func(a *ShippingAddress) { a.Number = "" }, ErrInvalidShippingAddress},
+		{"missing neighborhood", func(a *ShippingAddress) { a.Neighborhood = "" }, ErrInvalidShippingAddress},
+		{"missing city", func(a *ShippingAddress) { a.City = "" }, ErrInvalidShippingAddress},
+		{"unknown UF", func(a *ShippingAddress) { a.State = "XX" }, ErrInvalidShippingAddress},
+		{"short CEP", func(a *ShippingAddress) { a.PostalCode = "0131010" }, ErrInvalidShippingAddress},
+		{"non-numeric CEP", func(a *ShippingAddress) { a.PostalCode = "01310-1AB" }, ErrInvalidShippingAddress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address := valid
+			tt.modify(&address)
+
+			_, err := NormalizeShippingAddress(address, "Maria Silva")
+			if err != tt.wantErr {
+				t.Errorf("NormalizeShippingAddress() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	normalized, err := NormalizeShippingAddress(valid, " Maria Silva ")
+	if err != nil {
+		t.Fatalf("NormalizeShippingAddress() error = %v", err)
+	}
+	if normalized.Street != "Av. Paulista" || normalized.State != "SP" || normalized.PostalCode != "01310100" {
+		t.Errorf("NormalizeShippingAddress() = %+v", normalized)
+	}
+	if normalized.RecipientName != "Maria Silva" {
+		t.Errorf("RecipientName = %q, want holder name", normalized.RecipientName)
+	}
+}
+
+// TestValidateShipmentTransition tests the physical card shipment state machine
+func TestValidateShipmentTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		wantErr  error
+	}{
+		{ShipmentRequested, ShipmentProduced, nil},
+		{ShipmentProduced, ShipmentShipped, nil},
+		{ShipmentShipped, ShipmentDelivered, nil},
+		{ShipmentShipped, ShipmentActivated, nil},
+		{ShipmentDelivered, ShipmentActivated, nil},
+		{ShipmentRequested, ShipmentShipped, ErrInvalidShipmentTransition},
+		{ShipmentRequested, ShipmentActivated, ErrInvalidShipmentTransition},
+		{ShipmentProduced, ShipmentActivated, ErrInvalidShipmentTransition},
+		{ShipmentDelivered, ShipmentShipped, ErrInvalidShipmentTransition},
+		{ShipmentActivated, ShipmentDelivered, ErrInvalidShipmentTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+"->"+tt.to, func(t *testing.T) {
+			if err := ValidateShipmentTransition(tt.from, tt.to); err != tt.wantErr {
+				t.Errorf("ValidateShipmentTransition(%s, %s) = %v, want %v", tt.from, tt.to, err, tt.wantErr)
+			}
+		})
+	}
+}
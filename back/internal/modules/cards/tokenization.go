@@ -0,0 +1,186 @@
+package cards
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"math/big"
+)
+
+// GeneratePANToken returns a format-preserving surrogate for a PAN
+//
+// The token has the same length as the PAN, keeps its last four digits
+// (so receipts and support flows keep working) and is forced to fail the
+// Luhn check, so it can never be confused with a real card number.
+func GeneratePANToken(pan string) (string, error) {
+	cleaned := cleanCardNumber(pan)
+	if err := ValidateCardNumber(cleaned); err != nil {
+		return "", err
+	}
+
+	randomLength := len(cleaned) - 4
+	token := make([]byte, 0, len(cleaned))
+	for i := 0; i < randomLength; i++ {
+		digit, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		token = append(token, byte('0'+digit.Int64()))
+	}
+	token = append(token, cleaned[randomLength:]...)
+
+	// Changing a single digit always changes the Luhn sum modulo 10
+	if isValidLuhn(string(token)) {
+		token[randomLength-1] = '0' + (token[randomLength-1]-'0'+1)%10
+	}
+
+	return string(token), nil
+}
+
+// ValidatePANToken checks that a value has the shape of a PAN token
+func ValidatePANToken(token string) error {
+	if len(token) < 13 || len(token) > 19 {
+		return ErrInvalidPANToken
+	}
+	if !digitsRegex.MatchString(token) {
+		return ErrInvalidPANToken
+	}
+	// Real PANs pass Luhn; tokens never do
+	if isValidLuhn(token) {
+		return ErrInvalidPANToken
+	}
+	return nil
+}
+
+// newPANToken generates a PAN token not yet assigned to any card
+func (s *Service) newPANToken(ctx context.Context, pan string) (string, error) {
+	for attempt := 0; attempt < maxPANAttempts; attempt++ {
+		token, err := GeneratePANToken(pan)
+		if err != nil {
+			return "", err
+		}
+
+		exists, err := s.repo.PANTokenExists(ctx, token)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return token, nil
+		}
+	}
+
+	return "", ErrPANTokenizationFailed
+}
+
+// ensurePANToken returns the card's PAN token, assigning one to cards issued before tokenization
+func (s *Service) ensurePANToken(ctx context.Context, card *Card) (string, error) {
+	if card.PANToken != "" {
+		return card.PANToken, nil
+	}
+
+	token, err := s.newPANToken(ctx, card.CardNumber)
+	if err != nil {
+		return "", err
+	}
+
+	assigned, err := s.repo.SetPANToken(ctx, card.ID, token)
+	if err == sql.ErrNoRows {
+		// Assigned concurrently - use the stored token
+		stored, err := s.repo.GetByID(ctx, card.ID)
+		if err != nil {
+			return "", err
+		}
+		return stored.PANToken, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return assigned, nil
+}
+
+// TokenizePAN finds the card for a PAN and returns its surrogate token
+// Internal callers only (authorization messages, chargebacks)
+func (s *Service) TokenizePAN(ctx context.Context, caller string, req TokenizePANRequest) (result *TokenizedCard, err error) {
+	defer func() {
+		status := "success"
+		cardID := ""
+		if err != nil {
+			status = "failure"
+		}
+		if result != nil {
+			cardID = result.CardID
+		}
+		_ = s.repo.CreateAuditLog(ctx, "", cardID, "CARD_PAN_TOKENIZED", status, map[string]interface{}{
+			"caller": caller,
+		})
+	}()
+
+	// 1. Validate PAN
+	if err := ValidateCardNumber(req.CardNumber); err != nil {
+		return nil, err
+	}
+
+	// 2. Deterministic lookup through the keyed fingerprint
+	fingerprint := PANFingerprint(s.cfg.PANFingerprintKey, req.CardNumber)
+	card, err := s.repo.GetByPANFingerprint(ctx, fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Assign a token if the card predates tokenization
+	token, err := s.ensurePANToken(ctx, card)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenizedCard{
+		CardID:         card.ID,
+		Token:          token,
+		Brand:          card.Brand,
+		Status:         card.Status,
+		LastFourDigits: card.LastFourDigits,
+	}, nil
+}
+
+// Detokenize returns the PAN behind a token
+// Internal callers only; every call is audited with the calling service
+func (s *Service) Detokenize(ctx context.Context, caller string, req DetokenizeRequest) (result *DetokenizedCard, err error) {
+	defer func() {
+		status := "success"
+		cardID := ""
+		if err != nil {
+			status = "failure"
+		}
+		if result != nil {
+			cardID = result.CardID
+		}
+		// The PAN is only released when the access is on record
+		auditErr := s.repo.CreateAuditLog(ctx, "", cardID, "CARD_DETOKENIZED", status, map[string]interface{}{
+			"caller": caller,
+		})
+		if auditErr != nil && err == nil {
+			result, err = nil, auditErr
+		}
+	}()
+
+	// 1. Validate token format
+	if err := ValidatePANToken(req.Token); err != nil {
+		return nil, err
+	}
+
+	// 2. Resolve token (decrypts the PAN)
+	card, err := s.repo.GetByPANToken(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DetokenizedCard{
+		CardID:      card.ID,
+		CardNumber:  card.CardNumber,
+		Brand:       card.Brand,
+		Status:      card.Status,
+		ExpiryMonth: card.ExpiryMonth,
+		ExpiryYear:  card.ExpiryYear,
+	}, nil
+}
@@ -0,0 +1,68 @@
+package cards
+
+import (
+	"testing"
+)
+
+// TestGeneratePANToken tests format-preserving PAN tokens
+func TestGeneratePANToken(t *testing.T) {
+	pans := []string{
+		"4984021234567897",    // 16 digits
+		"4111111111119",       // 13 digits
+		"6363691234567890129", // 19 digits
+	}
+
+	for _, pan := range pans {
+		for i := 0; i < 100; i++ {
+			token, err := GeneratePANToken(pan)
+			if err != nil {
+				t.Fatalf("unexpected error for %s: %v", pan, err)
+			}
+
+			if len(token) != len(pan) {
+				t.Errorf("expected length %d, got %d", len(pan), len(token))
+			}
+			if token[len(token)-4:] != pan[len(pan)-4:] {
+				t.Errorf("expected last four %s, got %s", pan[len(pan)-4:], token[len(token)-4:])
+			}
+			if isValidLuhn(token) {
+				t.Errorf("token %s must fail the Luhn check", token)
+			}
+			if err := ValidatePANToken(token); err != nil {
+				t.Errorf("generated token %s rejected: %v", token, err)
+			}
+		}
+	}
+}
+
+// TestGeneratePANTokenInvalidPAN tests tokenization of invalid PANs
+func TestGeneratePANTokenInvalidPAN(t *testing.T) {
+	if _, err := GeneratePANToken("4984021234567890"); err != ErrInvalidCardNumber {
+		t.Errorf("expected ErrInvalidCardNumber, got %v", err)
+	}
+}
+
+// TestValidatePANToken tests PAN token format validation
+func TestValidatePANToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"Luhn-invalid digits", "4984021234567890", false},
+		{"real PAN", "4984021234567897", true},
+		{"too short", "123456789012", true},
+		{"too long", "12345678901234567890", true},
+		{"non-digits", "49840212345678a0", true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePANToken(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePANToken(%s) error = %v, wantErr %v", tt.token, err, tt.wantErr)
+			}
+		})
+	}
+}
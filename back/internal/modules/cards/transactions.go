@@ -0,0 +1,239 @@
+package cards
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lauratech/fin/back/internal/shared/calendar"
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+	"github.com/lauratech/fin/back/internal/shared/mcc"
+)
+
+// ParseTransactionFilter reads the listing filters from query parameters:
+// card_id, from and to (YYYY-MM-DD, both inclusive), status, category,
+// q (raw or enriched merchant name search), min_amount and max_amount (cents) and international (true/false)
+func ParseTransactionFilter(query url.Values) (*TransactionFilter, error) {
+	filter := &TransactionFilter{}
+
+	if cardID := query.Get("card_id"); cardID != "" {
+		if _, err := uuid.Parse(cardID); err != nil {
+			return nil, ErrInvalidTransactionFilter
+		}
+		filter.CardID = cardID
+	}
+
+	// 1. Date range in Brasília days (to is inclusive, stored as the next midnight)
+	if from := query.Get("from"); from != "" {
+		start, err := time.ParseInLocation("2006-01-02", from, calendar.Location)
+		if err != nil {
+			return nil, ErrInvalidTransactionFilter
+		}
+		filter.From = &start
+	}
+	if to := query.Get("to"); to != "" {
+		end, err := time.ParseInLocation("2006-01-02", to, calendar.Location)
+		if err != nil {
+			return nil, ErrInvalidTransactionFilter
+		}
+		end = end.AddDate(0, 0, 1)
+		filter.To = &end
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, ErrInvalidTransactionFilter
+	}
+
+	// 2. Status and category
+	if status := strings.ToLower(query.Get("status")); status != "" {
+		if !TransactionStatuses[status] {
+			return nil, ErrInvalidTransactionFilter
+		}
+		filter.Status = status
+	}
+	if category := strings.ToLower(query.Get("category")); category != "" {
+		if !mcc.IsCategory(category) {
+			return nil, ErrInvalidTransactionFilter
+		}
+		filter.Category = category
+	}
+
+	// 3. Merchant search
+	filter.Search = strings.TrimSpace(query.Get("q"))
+	if len(filter.Search) > 100 {
+		return nil, ErrInvalidTransactionFilter
+	}
+
+	// 4. Amount range
+	var err error
+	if filter.MinAmountCents, err = parseAmountParam(query.Get("min_amount")); err != nil {
+		return nil, err
+	}
+	if filter.MaxAmountCents, err = parseAmountParam(query.Get("max_amount")); err != nil {
+		return nil, err
+	}
+	if filter.MinAmountCents != nil && filter.MaxAmountCents != nil && *filter.MinAmountCents > *filter.MaxAmountCents {
+		return nil, ErrInvalidTransactionFilter
+	}
+
+	// 5. International flag
+	if international := query.Get("international"); international != "" {
+		value, err := strconv.ParseBool(international)
+		if err != nil {
+			return nil, ErrInvalidTransactionFilter
+		}
+		filter.International = &value
+	}
+
+	return filter, nil
+}
+
+// parseAmountParam parses an optional positive amount in cents
+func parseAmountParam(value string) (*int64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	amount, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || amount <= 0 {
+		return nil, ErrInvalidTransactionFilter
+	}
+	return &amount, nil
+}
+
+// EncodeTransactionCursor returns the opaque cursor pointing after a transaction
+func EncodeTransactionCursor(cursor TransactionCursor) string {
+	raw := cursor.TransactionDate.UTC().Format(time.RFC3339Nano) + "|" + cursor.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeTransactionCursor parses a cursor returned by EncodeTransactionCursor
+func DecodeTransactionCursor(value string) (*TransactionCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	date, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	transactionDate, err := time.Parse(time.RFC3339Nano, date)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &TransactionCursor{TransactionDate: transactionDate, ID: id}, nil
+}
+
+// escapeLikePattern escapes the ILIKE wildcards in a search term
+func escapeLikePattern(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// ListTransactions returns a page of the user's card transactions matching filter,
+// newest first, with totals over every matching transaction
+func (s *Service) ListTransactions(ctx context.Context, userID string, filter *TransactionFilter, cursor string, limit int) (*TransactionPage, error) {
+	// 1. Verify card belongs to user when filtering by card
+	if filter.CardID != "" {
+		if _, err := uuid.Parse(filter.CardID); err != nil {
+			return nil, ErrCardNotFound
+		}
+		card, err := s.repo.GetByIDForSummary(ctx, filter.CardID)
+		if err != nil {
+			return nil, err
+		}
+		if card.UserID != userID {
+			return nil, ErrUnauthorized
+		}
+	}
+
+	// 2. Decode cursor
+	var after *TransactionCursor
+	if cursor != "" {
+		var err error
+		if after, err = DecodeTransactionCursor(cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. Fetch one extra row to know whether another page exists
+	userUUID, _ := uuid.Parse(userID)
+	params := transactionFilterParams(userUUID, filter)
+	transactions, err := s.repo.ListFilteredTransactions(ctx, params, after, int32(limit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	page := &TransactionPage{Transactions: transactions}
+	if len(transactions) > limit {
+		page.Transactions = transactions[:limit]
+		last := page.Transactions[limit-1]
+		page.NextCursor = EncodeTransactionCursor(TransactionCursor{
+			TransactionDate: last.TransactionDate,
+			ID:              last.ID.String(),
+		})
+	}
+
+	// 4. Show the user's merchant category overrides
+	overrides, err := s.repo.GetMerchantCategoryOverrides(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	applyMerchantOverrides(page.Transactions, overrides)
+
+	// 5. Totals over every page
+	totals, err := s.repo.SummarizeFilteredTransactions(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	page.Totals = *totals
+
+	return page, nil
+}
+
+// transactionFilterParams maps a listing filter to query parameters (unset filters are NULL)
+func transactionFilterParams(userID uuid.UUID, filter *TransactionFilter) db.ListFilteredCardTransactionsParams {
+	params := db.ListFilteredCardTransactionsParams{
+		UserID:   userID,
+		Status:   sql.NullString{String: filter.Status, Valid: filter.Status != ""},
+		Category: sql.NullString{String: filter.Category, Valid: filter.Category != ""},
+		Search:   sql.NullString{String: escapeLikePattern(filter.Search), Valid: filter.Search != ""},
+	}
+
+	if filter.CardID != "" {
+		params.CardID = uuid.NullUUID{UUID: uuid.MustParse(filter.CardID), Valid: true}
+	}
+	if filter.From != nil {
+		params.StartDate = sql.NullTime{Time: *filter.From, Valid: true}
+	}
+	if filter.To != nil {
+		params.EndDate = sql.NullTime{Time: *filter.To, Valid: true}
+	}
+	if filter.MinAmountCents != nil {
+		params.MinAmountCents = sql.NullInt64{Int64: *filter.MinAmountCents, Valid: true}
+	}
+	if filter.MaxAmountCents != nil {
+		params.MaxAmountCents = sql.NullInt64{Int64: *filter.MaxAmountCents, Valid: true}
+	}
+	if filter.International != nil {
+		params.IsInternational = sql.NullBool{Bool: *filter.International, Valid: true}
+	}
+
+	return params
+}
+
+// applyMerchantOverrides replaces the category of transactions whose merchant the user re-categorized
+func applyMerchantOverrides(transactions []db.CardTransaction, overrides map[string]string) {
+	for i := range transactions {
+		if category, ok := overrides[transactions[i].MerchantKey.String]; ok && transactions[i].MerchantKey.Valid {
+			transactions[i].MerchantCategory = sql.NullString{String: category, Valid: true}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package cards
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/lauratech/fin/back/internal/shared/calendar"
+)
+
+// TestParseTransactionFilter tests query parameter parsing and validation
+func TestParseTransactionFilter(t *testing.T) {
+	query := url.Values{
+		"card_id":       {"0b9f3c1e-6a2d-4c47-9d7e-2f1a5b8c9d0e"},
+		"from":          {"2026-03-01"},
+		"to":            {"2026-03-31"},
+		"status":        {"Completed"},
+		"category":      {"groceries"},
+		"q":             {"  padaria  "},
+		"min_amount":    {"500"},
+		"max_amount":    {"20000"},
+		"international": {"false"},
+	}
+
+	filter, err := ParseTransactionFilter(query)
+	if err != nil {
+		t.Fatalf("ParseTransactionFilter() error = %v", err)
+	}
+
+	if filter.CardID != "0b9f3c1e-6a2d-4c47-9d7e-2f1a5b8c9d0e" {
+		t.Errorf("CardID = %q", filter.CardID)
+	}
+	if want := time.Date(2026, 3, 1, 0, 0, 0, 0, calendar.Location); filter.From == nil || !filter.From.Equal(want) {
+		t.Errorf("From = %v, want %v", filter.From, want)
+	}
+	// to is inclusive: the whole last day is listed
+	if want := time.Date(2026, 4, 1, 0, 0, 0, 0, calendar.Location); filter.To == nil || !filter.To.Equal(want) {
+		t.Errorf("To = %v, want %v", filter.To, want)
+	}
+	if filter.Status != "completed" || filter.Category != "groceries" || filter.Search != "padaria" {
+		t.Errorf("Status = %q, Category = %q, Search = %q", filter.Status, filter.Category, filter.Search)
+	}
+	if filter.MinAmountCents == nil || *filter.MinAmountCents != 500 || filter.MaxAmountCents == nil || *filter.MaxAmountCents != 20000 {
+		t.Errorf("amount range = %v - %v", filter.MinAmountCents, filter.MaxAmountCents)
+	}
+	if filter.International == nil || *filter.International {
+		t.Errorf("International = %v, want false", filter.International)
+	}
+
+	// Days are Brasília days: 22:00 local on the 31st is already the 1st in UTC
+	lateEvening := time.Date(2026, 3, 31, 22, 0, 0, 0, calendar.Location)
+	if lateEvening.Before(*filter.From) || !lateEvening.Before(*filter.To) {
+		t.Errorf("%v outside [%v, %v)", lateEvening, filter.From, filter.To)
+	}
+	nextMorning := time.Date(2026, 4, 1, 0, 30, 0, 0, calendar.Location)
+	if nextMorning.Before(*filter.To) {
+		t.Errorf("%v before %v, want excluded", nextMorning, filter.To)
+	}
+
+	empty, err := ParseTransactionFilter(url.Values{})
+	if err != nil {
+		t.Fatalf("empty query: error = %v", err)
+	}
+	if empty.From != nil || empty.To != nil || empty.MinAmountCents != nil || empty.International != nil || empty.Status != "" {
+		t.Errorf("empty query: filter = %+v, want no filters", empty)
+	}
+}
+
+// TestParseTransactionFilterInvalid tests every rejected query parameter
+func TestParseTransactionFilterInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		query url.Values
+	}{
+		{"Invalid card ID", url.Values{"card_id": {"card-1"}}},
+		{"Invalid from", url.Values{"from": {"01/03/2026"}}},
+		{"Invalid to", url.Values{"to": {"2026-02-30"}}},
+		{"Range reversed", url.Values{"from": {"2026-03-10"}, "to": {"2026-03-01"}}},
+		{"Unknown status", url.Values{"status": {"approved"}}},
+		{"Unknown category", url.Values{"category": {"5411"}}},
+		{"Search too long", url.Values{"q": {string(make([]byte, 101))}}},
+		{"Zero amount", url.Values{"min_amount": {"0"}}},
+		{"Decimal amount", url.Values{"max_amount": {"10.50"}}},
+		{"Amount range reversed", url.Values{"min_amount": {"5000"}, "max_amount": {"100"}}},
+		{"Invalid international", url.Values{"international": {"abroad"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseTransactionFilter(tt.query); err != ErrInvalidTransactionFilter {
+				t.Errorf("ParseTransactionFilter() error = %v, expected %v", err, ErrInvalidTransactionFilter)
+			}
+		})
+	}
+
+	// A single day is a valid range
+	if _, err := ParseTransactionFilter(url.Values{"from": {"2026-03-10"}, "to": {"2026-03-10"}}); err != nil {
+		t.Errorf("single day: error = %v", err)
+	}
+}
+
+// TestTransactionCursor tests cursor round trip and rejection of tampered cursors
+func TestTransactionCursor(t *testing.T) {
+	cursor := TransactionCursor{
+		TransactionDate: time.Date(2026, 3, 14, 18, 5, 9, 123456000, time.FixedZone("BRT", -3*3600)),
+		ID:              "0b9f3c1e-6a2d-4c47-9d7e-2f1a5b8c9d0e",
+	}
+
+	decoded, err := DecodeTransactionCursor(EncodeTransactionCursor(cursor))
+	if err != nil {
+		t.Fatalf("DecodeTransactionCursor() error = %v", err)
+	}
+	if !decoded.TransactionDate.Equal(cursor.TransactionDate) || decoded.ID != cursor.ID {
+		t.Errorf("DecodeTransactionCursor() = %+v, want %+v", decoded, cursor)
+	}
+
+	for _, value := range []string{"not base64!", "bm8tc2VwYXJhdG9y", "MjAyNi0wMy0xNHxub3QtYS11dWlk"} {
+		if _, err := DecodeTransactionCursor(value); err != ErrInvalidCursor {
+			t.Errorf("DecodeTransactionCursor(%q) error = %v, expected %v", value, err, ErrInvalidCursor)
+		}
+	}
+}
+
+// TestEscapeLikePattern tests that search terms match literally
+func TestEscapeLikePattern(t *testing.T) {
+	if got := escapeLikePattern(`100%_off\`); got != `100\%\_off\\` {
+		t.Errorf("escapeLikePattern() = %q", got)
+	}
+}
@@ -1,6 +1,10 @@
 package cards
 
-import "time"
+import (
+	"time"
+
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+)
 
 // Card represents a card with sensitive data (internal use only)
 // This struct contains decrypted card data and should NEVER be serialized to JSON directly
@@ -12,7 +16,15 @@ type Card struct {
 	Status                   string     `json:"status"`
 	CardNumber               string     `json:"-"` // NEVER expose in JSON - security critical
 	CVV                      string     `json:"-"` // NEVER expose in JSON - security critical
+	CVVMode                  string     `json:"cvv_mode"`
+	DynamicCVVSecret         []byte     `json:"-"` // NEVER expose in JSON - security critical
+	Profile                  string     `json:"profile"`
+	LockedMerchantName       string     `json:"locked_merchant_name,omitempty"`
+	LockedMerchantCategory   string     `json:"locked_merchant_category,omitempty"`
+	AmountCapCents           int64      `json:"amount_cap_cents,omitempty"`
+	TotalSpentCents          int64      `json:"total_spent_cents"`
 	LastFourDigits           string     `json:"last_four_digits"`
+	PANToken                 string     `json:"pan_token,omitempty"` // Format-preserving surrogate, safe for downstream systems
 	HolderName               string     `json:"holder_name"`
 	ExpiryMonth              int        `json:"expiry_month"`
 	ExpiryYear               int        `json:"expiry_year"`
@@ -28,38 +40,21 @@ type Card struct {
 	UpdatedAt                time.Time  `json:"updated_at"`
 	ExpiresAt                time.Time  `json:"expires_at"`
 	BlockedAt                *time.Time `json:"blocked_at,omitempty"`
+	CancellationReason       string     `json:"cancellation_reason,omitempty"`
+	CancelledAt              *time.Time `json:"cancelled_at,omitempty"`
+	ReplacedByCardID         string     `json:"replaced_by_card_id,omitempty"`
+	ActivatedAt              *time.Time `json:"activated_at,omitempty"` // Nil for physical cards awaiting activation
 }
 
 // CardSummary represents card info for listing (no sensitive data)
 // Use this for GET /cards list responses
 type CardSummary struct {
-	ID                       string    `json:"id"`
-	UserID                   string    `json:"user_id"`
-	Type                     string    `json:"type"`
-	Brand                    string    `json:"brand"`
-	Status                   string    `json:"status"`
-	LastFourDigits           string    `json:"last_four_digits"`
-	HolderName               string    `json:"holder_name"`
-	ExpiryMonth              int       `json:"expiry_month"`
-	ExpiryYear               int       `json:"expiry_year"`
-	DailyLimitCents          int64     `json:"daily_limit_cents"`
-	MonthlyLimitCents        int64     `json:"monthly_limit_cents"`
-	CurrentDailySpentCents   int64     `json:"current_daily_spent_cents"`
-	CurrentMonthlySpentCents int64     `json:"current_monthly_spent_cents"`
-	IsContactless            bool      `json:"is_contactless"`
-	IsInternational          bool      `json:"is_international"`
-	CreatedAt                time.Time `json:"created_at"`
-}
-
-// CardDetails represents card info with masked number (for GET /cards/{id})
-// Use this for individual card detail responses
-type CardDetails struct {
 	ID                       string     `json:"id"`
+	UserID                   string     `json:"user_id"`
 	Type                     string     `json:"type"`
 	Brand                    string     `json:"brand"`
 	Status                   string     `json:"status"`
 	LastFourDigits           string     `json:"last_four_digits"`
-	MaskedCardNumber         string     `json:"masked_card_number"` // e.g., "**** **** **** 1234"
 	HolderName               string     `json:"holder_name"`
 	ExpiryMonth              int        `json:"expiry_month"`
 	ExpiryYear               int        `json:"expiry_year"`
@@ -69,25 +64,68 @@ type CardDetails struct {
 	CurrentMonthlySpentCents int64      `json:"current_monthly_spent_cents"`
 	IsContactless            bool       `json:"is_contactless"`
 	IsInternational          bool       `json:"is_international"`
-	BlockInternational       bool       `json:"block_international"`
-	BlockOnline              bool       `json:"block_online"`
-	HasPIN                   bool       `json:"has_pin"`
+	CVVMode                  string     `json:"cvv_mode"`
+	Profile                  string     `json:"profile"`
 	CreatedAt                time.Time  `json:"created_at"`
-	UpdatedAt                time.Time  `json:"updated_at"`
-	ExpiresAt                time.Time  `json:"expires_at"`
-	BlockedAt                *time.Time `json:"blocked_at,omitempty"`
+	ActivatedAt              *time.Time `json:"activated_at,omitempty"` // Nil for physical cards awaiting activation
+}
+
+// CardDetails represents card info with masked number (for GET /cards/{id})
+// Use this for individual card detail responses
+type CardDetails struct {
+	ID                       string        `json:"id"`
+	Type                     string        `json:"type"`
+	Brand                    string        `json:"brand"`
+	Status                   string        `json:"status"`
+	LastFourDigits           string        `json:"last_four_digits"`
+	MaskedCardNumber         string        `json:"masked_card_number"` // e.g., "**** **** **** 1234"
+	HolderName               string        `json:"holder_name"`
+	ExpiryMonth              int           `json:"expiry_month"`
+	ExpiryYear               int           `json:"expiry_year"`
+	DailyLimitCents          int64         `json:"daily_limit_cents"`
+	MonthlyLimitCents        int64         `json:"monthly_limit_cents"`
+	CurrentDailySpentCents   int64         `json:"current_daily_spent_cents"`
+	CurrentMonthlySpentCents int64         `json:"current_monthly_spent_cents"`
+	IsContactless            bool          `json:"is_contactless"`
+	IsInternational          bool          `json:"is_international"`
+	BlockInternational       bool          `json:"block_international"`
+	BlockOnline              bool          `json:"block_online"`
+	HasPIN                   bool          `json:"has_pin"`
+	CVVMode                  string        `json:"cvv_mode"`
+	Profile                  string        `json:"profile"`
+	LockedMerchantName       string        `json:"locked_merchant_name,omitempty"`
+	LockedMerchantCategory   string        `json:"locked_merchant_category,omitempty"`
+	AmountCapCents           int64         `json:"amount_cap_cents,omitempty"`
+	TotalSpentCents          int64         `json:"total_spent_cents"`
+	CreatedAt                time.Time     `json:"created_at"`
+	UpdatedAt                time.Time     `json:"updated_at"`
+	ExpiresAt                time.Time     `json:"expires_at"`
+	BlockedAt                *time.Time    `json:"blocked_at,omitempty"`
+	CancellationReason       string        `json:"cancellation_reason,omitempty"`
+	CancelledAt              *time.Time    `json:"cancelled_at,omitempty"`
+	ReplacedByCardID         string        `json:"replaced_by_card_id,omitempty"` // Card issued in place of this one
+	ReplacesCardID           string        `json:"replaces_card_id,omitempty"`    // Card this one replaced
+	ActivatedAt              *time.Time    `json:"activated_at,omitempty"`        // Nil for physical cards awaiting activation
+	Shipment                 *CardShipment `json:"shipment,omitempty"`            // Latest shipment (physical cards)
 }
 
 // CreateCardRequest for POST /api/cards
 type CreateCardRequest struct {
 	Type              string `json:"type" validate:"required,oneof=physical virtual"`
 	Brand             string `json:"brand" validate:"required,oneof=visa mastercard elo"`
-	CardNumber        string `json:"card_number,omitempty"` // Optional - auto-generate if empty
-	CVV               string `json:"cvv" validate:"required"`
-	PIN               string `json:"pin,omitempty"` // Optional on creation
+	CVVMode           string `json:"cvv_mode,omitempty" validate:"omitempty,oneof=static dynamic"` // Optional - dynamic is virtual-only
+	PIN               string `json:"pin,omitempty"`                                                // Optional on creation
 	HolderName        string `json:"holder_name" validate:"required"`
 	DailyLimitCents   int64  `json:"daily_limit_cents,omitempty"`
 	MonthlyLimitCents int64  `json:"monthly_limit_cents,omitempty"`
+
+	// Virtual card profiles (optional)
+	Profile        string `json:"profile,omitempty" validate:"omitempty,oneof=standard single_use merchant_locked amount_capped"`
+	AmountCapCents int64  `json:"amount_cap_cents,omitempty"` // Required for amount_capped
+	ExpiryDays     int    `json:"expiry_days,omitempty"`      // Optional - overrides default expiry for profiled cards
+
+	// Physical cards only (required)
+	ShippingAddress *ShippingAddress `json:"shipping_address,omitempty"`
 }
 
 // UpdateLimitsRequest for PATCH /api/cards/{id}/limits
@@ -115,14 +153,263 @@ type CancelCardRequest struct {
 	Reason string `json:"reason" validate:"required,oneof=lost stolen damaged user_request"`
 }
 
+// ReplaceCardRequest for POST /api/cards/{id}/replace
+type ReplaceCardRequest struct {
+	Reason                    string `json:"reason" validate:"required,oneof=lost stolen damaged"`
+	MigrateRecurringMerchants bool   `json:"migrate_recurring_merchants"` // Queue account updates for card-on-file merchants
+
+	// Physical cards only - defaults to the address of the card being replaced
+	ShippingAddress *ShippingAddress `json:"shipping_address,omitempty"`
+}
+
+// ReplaceCardResponse is returned after a card is replaced
+type ReplaceCardResponse struct {
+	ReplacedCardID    string       `json:"replaced_card_id"`
+	Card              *CardSummary `json:"card"`
+	MigratedMerchants []string     `json:"migrated_merchants"`
+}
+
+// ShippingAddress is the delivery address of a physical card
+type ShippingAddress struct {
+	RecipientName string `json:"recipient_name,omitempty"` // Defaults to the card holder name
+	Street        string `json:"street" validate:"required"`
+	Number        string `json:"number" validate:"required"`
+	Complement    string `json:"complement,omitempty"`
+	Neighborhood  string `json:"neighborhood" validate:"required"`
+	City          string `json:"city" validate:"required"`
+	State         string `json:"state" validate:"required,len=2"` // UF, e.g. "SP"
+	PostalCode    string `json:"postal_code" validate:"required"` // CEP, 8 digits (hyphen allowed)
+}
+
+// CardShipment tracks the production and delivery of a physical card
+// Lifecycle: requested -> produced -> shipped -> delivered -> activated
+type CardShipment struct {
+	ID           string          `json:"id"`
+	CardID       string          `json:"card_id"`
+	Status       string          `json:"status"`
+	Address      ShippingAddress `json:"address"`
+	Carrier      string          `json:"carrier,omitempty"`
+	TrackingCode string          `json:"tracking_code,omitempty"`
+	RequestedAt  time.Time       `json:"requested_at"`
+	ProducedAt   *time.Time      `json:"produced_at,omitempty"`
+	ShippedAt    *time.Time      `json:"shipped_at,omitempty"`
+	DeliveredAt  *time.Time      `json:"delivered_at,omitempty"`
+	ActivatedAt  *time.Time      `json:"activated_at,omitempty"`
+}
+
+// ActivateCardRequest for POST /api/cards/{id}/activate
+// The holder proves possession of the plastic with the printed data
+type ActivateCardRequest struct {
+	LastFourDigits string `json:"last_four_digits" validate:"required,len=4"`
+	CVV            string `json:"cvv" validate:"required"`
+}
+
+// ShipCardRequest for POST /internal/cards/shipments/{id}/shipped
+type ShipCardRequest struct {
+	Carrier      string `json:"carrier" validate:"required"`
+	TrackingCode string `json:"tracking_code" validate:"required"`
+}
+
+// EmbosserBatch summarizes an embosser file export
+type EmbosserBatch struct {
+	BatchID   string    `json:"batch_id"`
+	Records   int       `json:"records"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TokenizePANRequest for POST /internal/cards/tokenize
+type TokenizePANRequest struct {
+	CardNumber string `json:"card_number" validate:"required"`
+}
+
+// TokenizedCard is returned by a PAN lookup (no sensitive data)
+type TokenizedCard struct {
+	CardID         string `json:"card_id"`
+	Token          string `json:"token"`
+	Brand          string `json:"brand"`
+	Status         string `json:"status"`
+	LastFourDigits string `json:"last_four_digits"`
+}
+
+// DetokenizeRequest for POST /internal/cards/detokenize
+type DetokenizeRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// DetokenizedCard holds the PAN behind a token
+// Responses carrying this struct must never be cached
+type DetokenizedCard struct {
+	CardID      string `json:"card_id"`
+	CardNumber  string `json:"card_number"`
+	Brand       string `json:"brand"`
+	Status      string `json:"status"`
+	ExpiryMonth int    `json:"expiry_month"`
+	ExpiryYear  int    `json:"expiry_year"`
+}
+
+// RevealCardRequest for POST /api/cards/{id}/reveal
+type RevealCardRequest struct {
+	PIN string `json:"pin" validate:"required"` // Step-up verification
+}
+
+// RevealTokenResponse is returned after successful step-up verification
+type RevealTokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ConsumeRevealRequest for POST /api/cards/{id}/reveal/consume
+type ConsumeRevealRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// RevealedCardData holds the full card data returned exactly once per reveal token
+// Responses carrying this struct must never be cached
+type RevealedCardData struct {
+	CardNumber    string     `json:"card_number"`
+	CVV           string     `json:"cvv"`
+	CVVMode       string     `json:"cvv_mode"`
+	CVVValidUntil *time.Time `json:"cvv_valid_until,omitempty"` // Set for dynamic CVV only
+	HolderName    string     `json:"holder_name"`
+	ExpiryMonth   int        `json:"expiry_month"`
+	ExpiryYear    int        `json:"expiry_year"`
+}
+
+// AuthorizationContext describes a card authorization request from the processor
+type AuthorizationContext struct {
+	AmountCents      int64
+	MerchantName     string
+	MerchantCategory string // MCC (preferred) or category name
+	Channel          string // ecommerce, pos_chip, contactless, magstripe, atm
+	Country          string // Merchant country (ISO 3166-1 alpha-2); empty = domestic
+	CVV              string // Required card-not-present and for dynamic CVV cards; empty when not presented
+	StepUpCompleted  bool   // Holder passed a step-up challenge (3-D Secure, PIN)
+}
+
+// FraudRuleParams holds the configurable knobs of a fraud rule (fraud_rules.params)
+// Each rule uses a subset of the fields.
+type FraudRuleParams struct {
+	MaxCount         int64   `json:"max_count,omitempty"`          // velocity, card_testing
+	WindowMinutes    int     `json:"window_minutes,omitempty"`     // velocity, card_testing
+	Multiplier       float64 `json:"multiplier,omitempty"`         // amount_above_median
+	LookbackDays     int     `json:"lookback_days,omitempty"`      // amount_above_median
+	MinTransactions  int64   `json:"min_transactions,omitempty"`   // amount_above_median, new_mcc
+	SmallAmountCents int64   `json:"small_amount_cents,omitempty"` // card_testing
+}
+
+// FraudRule represents a fraud rule scored on every authorization
+type FraudRule struct {
+	Code        string          `json:"code"`
+	Description string          `json:"description"`
+	IsEnabled   bool            `json:"is_enabled"`
+	Score       int             `json:"score"`
+	Params      FraudRuleParams `json:"params"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// FraudThresholds holds the scores at which an authorization is stepped up or declined
+type FraudThresholds struct {
+	StepUpScore  int `json:"step_up_score"`
+	DeclineScore int `json:"decline_score"`
+}
+
+// FraudRulesResponse for GET /internal/fraud/rules
+type FraudRulesResponse struct {
+	Rules      []FraudRule     `json:"rules"`
+	Thresholds FraudThresholds `json:"thresholds"`
+}
+
+// UpdateFraudRuleRequest for PATCH /internal/fraud/rules/{code}
+// Omitted fields keep their current value.
+type UpdateFraudRuleRequest struct {
+	IsEnabled *bool            `json:"is_enabled,omitempty"`
+	Score     *int             `json:"score,omitempty"`
+	Params    *FraudRuleParams `json:"params,omitempty"`
+}
+
+// FraudSignals holds the history the fraud rules are evaluated against
+type FraudSignals struct {
+	RecentAuthorizations      int64 // Scored authorizations on the card in the velocity window
+	RecentSmallAuthorizations int64 // Small scored authorizations on the card in the card testing window
+	MedianAmountCents         int64 // Median completed transaction of the user in the lookback window
+	LookbackTransactions      int64 // Completed transactions of the user in the lookback window
+	CompletedTransactions     int64 // All completed transactions of the user
+	InternationalTransactions int64 // Completed international transactions of the user
+	MCCTransactions           int64 // Completed transactions of the user with the authorization MCC
+}
+
+// FraudReason explains one triggered fraud rule
+type FraudReason struct {
+	Rule   string `json:"rule"`
+	Score  int    `json:"score"`
+	Detail string `json:"detail"`
+}
+
+// FraudAssessment is the fraud engine result for one authorization
+type FraudAssessment struct {
+	Decision string        `json:"decision"` // approve, decline, step_up
+	Score    int           `json:"score"`    // 0-100
+	Reasons  []FraudReason `json:"reasons"`
+}
+
+// FraudDecision is a logged fraud assessment of an authorization
+type FraudDecision struct {
+	ID            string        `json:"id"`
+	CardID        string        `json:"card_id"`
+	TransactionID string        `json:"transaction_id,omitempty"` // Set for approved authorizations
+	AmountCents   int64         `json:"amount_cents"`
+	MerchantName  string        `json:"merchant_name"`
+	MCC           string        `json:"mcc,omitempty"`
+	Channel       string        `json:"channel"`
+	Country       string        `json:"country,omitempty"`
+	Decision      string        `json:"decision"`
+	Score         int           `json:"score"`
+	Reasons       []FraudReason `json:"reasons"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+// CategoryControl represents a per-card merchant category rule
+type CategoryControl struct {
+	Category            string `json:"category"`
+	IsBlocked           bool   `json:"is_blocked"`
+	MonthlyLimitCents   *int64 `json:"monthly_limit_cents,omitempty"` // nil = no category limit
+	SpentThisMonthCents int64  `json:"spent_this_month_cents"`
+}
+
+// CategoryControlsResponse for GET /api/cards/{id}/categories
+type CategoryControlsResponse struct {
+	Controls            []CategoryControl `json:"controls"`
+	AvailableCategories []string          `json:"available_categories"`
+}
+
+// CategoryControlRequest is a single category rule in UpdateCategoryControlsRequest
+type CategoryControlRequest struct {
+	Category          string `json:"category" validate:"required"`
+	IsBlocked         bool   `json:"is_blocked"`
+	MonthlyLimitCents *int64 `json:"monthly_limit_cents,omitempty" validate:"omitempty,min=0"`
+}
+
+// UpdateCategoryControlsRequest for PUT /api/cards/{id}/categories
+// Replaces all category rules of the card
+type UpdateCategoryControlsRequest struct {
+	Controls []CategoryControlRequest `json:"controls"`
+}
+
 // CreateCardParams holds parameters for creating a card in the repository
 type CreateCardParams struct {
 	UserID             string
 	Type               string
 	Brand              string
 	CardNumber         string
+	PANFingerprint     string // Keyed HMAC of the PAN (see PANFingerprint)
+	PANToken           string // Format-preserving surrogate (see GeneratePANToken)
 	CVV                string
+	CVVMode            string
+	DynamicCVVSecret   []byte // Plaintext secret, encrypted by the repository
+	Profile            string
+	AmountCapCents     int64
 	PIN                string
+	PINHash            string // Existing Argon2id hash, kept on reissue (ignored if PIN is set)
 	HolderName         string
 	ExpiryMonth        int
 	ExpiryYear         int
@@ -133,6 +420,7 @@ type CreateCardParams struct {
 	BlockInternational bool
 	BlockOnline        bool
 	ExpiresAt          time.Time
+	RequiresActivation bool // Physical cards stay inactive until the holder activates them
 }
 
 // UpdateLimitsParams holds parameters for updating card limits
@@ -148,3 +436,49 @@ type SecuritySettingsParams struct {
 	BlockInternational bool
 	BlockOnline        bool
 }
+
+// Transaction listing defaults
+const (
+	DefaultTransactionPageSize = 20
+	MaxTransactionPageSize     = 100
+)
+
+// TransactionStatuses lists the card transaction statuses (card_transactions.status)
+var TransactionStatuses = map[string]bool{
+	"pending":   true,
+	"completed": true,
+	"declined":  true,
+	"refunded":  true,
+}
+
+// TransactionFilter narrows a card transaction listing; zero values match any transaction
+type TransactionFilter struct {
+	CardID         string
+	From           *time.Time // Inclusive
+	To             *time.Time // Exclusive
+	Status         string
+	Category       string
+	Search         string // Substring of the merchant name (case-insensitive)
+	MinAmountCents *int64
+	MaxAmountCents *int64
+	International  *bool
+}
+
+// TransactionCursor is the last transaction of a page; the next page starts after it
+type TransactionCursor struct {
+	TransactionDate time.Time
+	ID              string
+}
+
+// TransactionTotals sums every transaction matching a filter, across all pages
+type TransactionTotals struct {
+	Count       int64 `json:"count"`
+	AmountCents int64 `json:"amount_cents"`
+}
+
+// TransactionPage is one page of a filtered card transaction listing
+type TransactionPage struct {
+	Transactions []db.CardTransaction
+	NextCursor   string // Empty on the last page
+	Totals       TransactionTotals
+}
@@ -8,8 +8,13 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/lauratech/fin/back/internal/shared/mcc"
 )
 
+// digitsRegex matches values made only of digits (BIN prefixes, PAN tokens)
+var digitsRegex = regexp.MustCompile(`^\d+$`)
+
 // ValidateCardNumber validates a card number using the Luhn algorithm
 func ValidateCardNumber(cardNumber string) error {
 	// Remove spaces and dashes
@@ -81,36 +86,20 @@ func calculateLuhnChecksum(partial string) int {
 	return checksum
 }
 
-// GenerateCardNumber generates a valid card number with Luhn checksum for the specified brand
-func GenerateCardNumber(brand string) (string, error) {
-	var prefix string
-	var length int
-
-	// Set IIN (Issuer Identification Number) prefix and length based on brand
-	switch strings.ToLower(brand) {
-	case "visa":
-		prefix = "4"
-		length = 16
-	case "mastercard":
-		// Mastercard ranges: 51-55 or 2221-2720
-		// Use 5x prefix for simplicity (51-55 range)
-		prefixes := []string{"51", "52", "53", "54", "55"}
-		idx, _ := rand.Int(rand.Reader, big.NewInt(int64(len(prefixes))))
-		prefix = prefixes[idx.Int64()]
-		length = 16
-	case "elo":
-		// Elo BINs start with 636368, 438935, 504175, 451416, 636297, 506726
-		// Use 636368 for simplicity
-		prefix = "636368"
-		length = 16
-	default:
-		return "", ErrInvalidCardBrand
+// GenerateCardNumber generates a valid card number with Luhn checksum from an issuer BIN prefix
+// BIN prefixes are issuer configuration (see card_bin_ranges), never client input
+func GenerateCardNumber(prefix string, length int) (string, error) {
+	if length < 13 || length > 19 {
+		return "", fmt.Errorf("invalid card number length %d", length)
+	}
+	if prefix == "" || !digitsRegex.MatchString(prefix) {
+		return "", fmt.Errorf("invalid BIN prefix %q", prefix)
 	}
 
 	// Generate random middle digits
 	middleLength := length - len(prefix) - 1 // -1 for check digit
 	if middleLength < 0 {
-		return "", fmt.Errorf("invalid card number length for brand %s", brand)
+		return "", fmt.Errorf("BIN prefix %q too long for card number length %d", prefix, length)
 	}
 
 	middle := ""
@@ -297,3 +286,40 @@ func ValidateCardBrand(brand string) error {
 
 	return nil
 }
+
+// ValidateCVVMode validates the CVV mode for a card type
+// Dynamic CVV is only supported on virtual (card-not-present) cards
+func ValidateCVVMode(mode, cardType string) error {
+	switch mode {
+	case "static":
+		return nil
+	case "dynamic":
+		if cardType != "virtual" {
+			return ErrDynamicCVVVirtual
+		}
+		return nil
+	default:
+		return ErrInvalidCVVMode
+	}
+}
+
+// ValidateCategoryControls validates category rules (known categories, no duplicates, non-negative limits)
+func ValidateCategoryControls(controls []CategoryControlRequest) error {
+	seen := make(map[string]bool, len(controls))
+
+	for _, control := range controls {
+		if !mcc.IsCategory(control.Category) {
+			return ErrInvalidCategory
+		}
+		if seen[control.Category] {
+			return ErrDuplicateCategory
+		}
+		seen[control.Category] = true
+
+		if control.MonthlyLimitCents != nil && *control.MonthlyLimitCents < 0 {
+			return ErrInvalidLimit
+		}
+	}
+
+	return nil
+}
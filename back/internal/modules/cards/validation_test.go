@@ -32,16 +32,26 @@ func TestValidateCardNumber(t *testing.T) {
 	}
 }
 
-// TestGenerateCardNumber tests card number generation for different brands
+// TestGenerateCardNumber tests card number generation from issuer BIN prefixes
 func TestGenerateCardNumber(t *testing.T) {
-	brands := []string{"visa", "mastercard", "elo"}
+	tests := []struct {
+		name   string
+		prefix string
+		length int
+	}{
+		{"visa", "498402", 16},
+		{"mastercard", "522841", 16},
+		{"elo", "636368", 16},
+		{"short PAN", "4", 13},
+		{"long PAN", "62123456", 19},
+	}
 
-	for _, brand := range brands {
-		t.Run(brand, func(t *testing.T) {
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
 			// Generate card number
-			cardNumber, err := GenerateCardNumber(brand)
+			cardNumber, err := GenerateCardNumber(tt.prefix, tt.length)
 			if err != nil {
-				t.Fatalf("GenerateCardNumber(%s) failed: %v", brand, err)
+				t.Fatalf("GenerateCardNumber(%s, %d) failed: %v", tt.prefix, tt.length, err)
 			}
 
 			// Validate generated number passes Luhn
@@ -50,25 +60,36 @@ func TestGenerateCardNumber(t *testing.T) {
 			}
 
 			// Check length
-			if len(cardNumber) != 16 {
-				t.Errorf("Expected 16 digits, got %d", len(cardNumber))
+			if len(cardNumber) != tt.length {
+				t.Errorf("Expected %d digits, got %d", tt.length, len(cardNumber))
 			}
 
-			// Verify brand prefix
-			switch brand {
-			case "visa":
-				if cardNumber[0] != '4' {
-					t.Errorf("Visa card should start with 4, got %c", cardNumber[0])
-				}
-			case "mastercard":
-				firstTwo := cardNumber[0:2]
-				if firstTwo < "51" || firstTwo > "55" {
-					t.Errorf("Mastercard should start with 51-55, got %s", firstTwo)
-				}
-			case "elo":
-				if cardNumber[0:6] != "636368" {
-					t.Errorf("Elo card should start with 636368, got %s", cardNumber[0:6])
-				}
+			// Verify BIN prefix
+			if cardNumber[:len(tt.prefix)] != tt.prefix {
+				t.Errorf("Card number should start with %s, got %s", tt.prefix, cardNumber)
+			}
+		})
+	}
+}
+
+// TestGenerateCardNumberInvalid tests rejection of invalid BIN configuration
+func TestGenerateCardNumberInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		length int
+	}{
+		{"Empty prefix", "", 16},
+		{"Non-numeric prefix", "4a", 16},
+		{"Length too short", "4", 12},
+		{"Length too long", "4", 20},
+		{"Prefix too long", "4984021234567890", 16},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := GenerateCardNumber(tt.prefix, tt.length); err == nil {
+				t.Errorf("GenerateCardNumber(%q, %d) expected error", tt.prefix, tt.length)
 			}
 		})
 	}
@@ -79,7 +100,7 @@ func TestGenerateCardNumberUniqueness(t *testing.T) {
 	generated := make(map[string]bool)
 
 	for i := 0; i < 100; i++ {
-		cardNumber, err := GenerateCardNumber("visa")
+		cardNumber, err := GenerateCardNumber("498402", 16)
 		if err != nil {
 			t.Fatalf("Generation failed: %v", err)
 		}
@@ -297,7 +318,7 @@ func TestCalculateLuhnChecksum(t *testing.T) {
 // BenchmarkGenerateCardNumber benchmarks card number generation
 func BenchmarkGenerateCardNumber(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		_, _ = GenerateCardNumber("visa")
+		_, _ = GenerateCardNumber("498402", 16)
 	}
 }
 
@@ -318,3 +339,30 @@ func BenchmarkValidatePIN(b *testing.B) {
 		_ = ValidatePIN(pin)
 	}
 }
+
+// TestValidateCategoryControls tests category rule validation
+func TestValidateCategoryControls(t *testing.T) {
+	limit := int64(10000)
+	negative := int64(-1)
+
+	tests := []struct {
+		name     string
+		controls []CategoryControlRequest
+		expected error
+	}{
+		{"Empty", nil, nil},
+		{"Block gambling", []CategoryControlRequest{{Category: "gambling", IsBlocked: true}}, nil},
+		{"Limit dining", []CategoryControlRequest{{Category: "dining", MonthlyLimitCents: &limit}}, nil},
+		{"Unknown category", []CategoryControlRequest{{Category: "casinos", IsBlocked: true}}, ErrInvalidCategory},
+		{"Duplicate category", []CategoryControlRequest{{Category: "crypto"}, {Category: "crypto"}}, ErrDuplicateCategory},
+		{"Negative limit", []CategoryControlRequest{{Category: "travel", MonthlyLimitCents: &negative}}, ErrInvalidLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateCategoryControls(tt.controls); err != tt.expected {
+				t.Errorf("ValidateCategoryControls() error = %v, expected %v", err, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package disputes
+
+import "errors"
+
+var (
+	// ErrDisputeNotFound is returned when a dispute is not found
+	ErrDisputeNotFound = errors.New("dispute not found")
+
+	// ErrTransactionNotFound is returned when the disputed card transaction is not found
+	ErrTransactionNotFound = errors.New("card transaction not found")
+
+	// ErrUnauthorizedAccess is returned when user tries to access another user's dispute or transaction
+	ErrUnauthorizedAccess = errors.New("unauthorized access to dispute")
+
+	// ErrInvalidReason is returned when the dispute reason is not a known reason code
+	ErrInvalidReason = errors.New("invalid dispute reason")
+
+	// ErrInvalidEvidence is returned when the evidence text is missing or too long
+	ErrInvalidEvidence = errors.New("evidence must be between 20 and 5000 characters")
+
+	// ErrInvalidAmount is returned when the disputed amount does not fit the reason and transaction
+	ErrInvalidAmount = errors.New("invalid disputed amount")
+
+	// ErrInvalidOutcome is returned when a resolution outcome is not won or lost
+	ErrInvalidOutcome = errors.New("invalid dispute outcome")
+
+	// ErrTransactionNotDisputable is returned when the transaction is not a completed purchase
+	ErrTransactionNotDisputable = errors.New("only completed transactions can be disputed")
+
+	// ErrFilingWindowExpired is returned when the transaction is too old to be disputed
+	ErrFilingWindowExpired = errors.New("dispute filing window has expired")
+
+	// ErrDisputeAlreadyOpen is returned when the transaction already has a dispute in progress
+	ErrDisputeAlreadyOpen = errors.New("transaction already has a dispute in progress")
+
+	// ErrInvalidStatusTransition is returned when a dispute status change is not allowed
+	ErrInvalidStatusTransition = errors.New("invalid dispute status transition")
+)
@@ -0,0 +1,212 @@
+package disputes
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/lauratech/fin/back/internal/shared/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Handler handles HTTP requests for card disputes
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new disputes handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// OpenDispute handles POST /api/disputes
+func (h *Handler) OpenDispute(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	// Decode request body
+	var req CreateDisputeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "VAL_001", "Invalid request body", nil)
+		return
+	}
+
+	// Open dispute
+	dispute, err := h.service.OpenDispute(r.Context(), userID, req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	// Return success response
+	response.Success(w, http.StatusCreated, dispute, r.Context())
+}
+
+// ListDisputes handles GET /api/disputes
+func (h *Handler) ListDisputes(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	// Parse pagination parameters
+	page := 1
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	limit := 20
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+
+	// List disputes
+	disputes, total, err := h.service.ListUserDisputes(r.Context(), userID, page, limit)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	// Calculate pagination metadata
+	totalPages := int(total) / limit
+	if int(total)%limit != 0 {
+		totalPages++
+	}
+
+	pagination := response.Pagination{
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: totalPages,
+		HasMore:    page < totalPages,
+	}
+
+	// Return paginated response
+	response.Paginated(w, http.StatusOK, disputes, pagination, r.Context())
+}
+
+// GetDispute handles GET /api/disputes/{id}
+func (h *Handler) GetDispute(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	// Get dispute ID from URL
+	disputeID := chi.URLParam(r, "id")
+	if disputeID == "" {
+		response.Error(w, http.StatusBadRequest, "VAL_001", "Dispute ID is required", nil)
+		return
+	}
+
+	dispute, err := h.service.GetDispute(r.Context(), userID, disputeID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	// Return success response
+	response.Success(w, http.StatusOK, dispute, r.Context())
+}
+
+// GrantProvisionalCredit handles POST /internal/disputes/{id}/provisional-credit
+// Internal callers only: the card processor credits the holder during the investigation
+func (h *Handler) GrantProvisionalCredit(w http.ResponseWriter, r *http.Request) {
+	// Extract calling service from context (set by InternalAuth)
+	caller, ok := r.Context().Value("internal_service").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	disputeID := chi.URLParam(r, "id")
+	if disputeID == "" {
+		response.Error(w, http.StatusBadRequest, "VAL_001", "Dispute ID is required", nil)
+		return
+	}
+
+	dispute, err := h.service.GrantProvisionalCredit(r.Context(), caller, disputeID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, dispute, r.Context())
+}
+
+// ResolveDispute handles POST /internal/disputes/{id}/resolve
+// Internal callers only: the card processor reports the chargeback outcome
+func (h *Handler) ResolveDispute(w http.ResponseWriter, r *http.Request) {
+	// Extract calling service from context (set by InternalAuth)
+	caller, ok := r.Context().Value("internal_service").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	disputeID := chi.URLParam(r, "id")
+	if disputeID == "" {
+		response.Error(w, http.StatusBadRequest, "VAL_001", "Dispute ID is required", nil)
+		return
+	}
+
+	// Decode request
+	var req ResolveDisputeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "VAL_001", "Invalid request body", nil)
+		return
+	}
+
+	dispute, err := h.service.ResolveDispute(r.Context(), caller, disputeID, req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, http.StatusOK, dispute, r.Context())
+}
+
+// handleError maps domain errors to HTTP responses
+func (h *Handler) handleError(w http.ResponseWriter, err error) {
+	switch err {
+	case ErrDisputeNotFound:
+		response.Error(w, http.StatusNotFound, "DISPUTE_001", "Dispute not found", nil)
+	case ErrTransactionNotFound:
+		response.Error(w, http.StatusNotFound, "DISPUTE_002", "Card transaction not found", nil)
+	case ErrUnauthorizedAccess:
+		response.Error(w, http.StatusForbidden, "AUTH_002", "Unauthorized access to dispute", nil)
+	case ErrTransactionNotDisputable:
+		response.Error(w, http.StatusBadRequest, "DISPUTE_003", "Only completed transactions can be disputed", nil)
+	case ErrFilingWindowExpired:
+		response.Error(w, http.StatusBadRequest, "DISPUTE_004", "Dispute filing window has expired (120 days)", nil)
+	case ErrDisputeAlreadyOpen:
+		response.Error(w, http.StatusConflict, "DISPUTE_005", "Transaction already has a dispute in progress", nil)
+	case ErrInvalidStatusTransition:
+		response.Error(w, http.StatusConflict, "DISPUTE_006", "Invalid dispute status transition", nil)
+	case ErrInvalidReason:
+		response.Error(w, http.StatusBadRequest, "VAL_201", "Invalid dispute reason (fraud, not_received, duplicate, wrong_amount)", nil)
+	case ErrInvalidEvidence:
+		response.Error(w, http.StatusBadRequest, "VAL_202", "Evidence must be between 20 and 5000 characters", nil)
+	case ErrInvalidAmount:
+		response.Error(w, http.StatusBadRequest, "VAL_203", "Invalid disputed amount", nil)
+	case ErrInvalidOutcome:
+		response.Error(w, http.StatusBadRequest, "VAL_204", "Invalid dispute outcome (won, lost)", nil)
+	default:
+		response.Error(w, http.StatusInternalServerError, "SYS_001", "Internal server error", nil)
+	}
+}
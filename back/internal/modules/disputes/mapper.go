@@ -0,0 +1,45 @@
+package disputes
+
+import (
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+)
+
+// dbDisputeToDispute converts a database dispute to domain dispute
+func dbDisputeToDispute(dbDispute *db.CardDispute) *Dispute {
+	if dbDispute == nil {
+		return nil
+	}
+
+	dispute := &Dispute{
+		ID:                     dbDispute.ID.String(),
+		CardID:                 dbDispute.CardID.String(),
+		TransactionID:          dbDispute.CardTransactionID.String(),
+		Reason:                 dbDispute.Reason,
+		Evidence:               dbDispute.Evidence,
+		AmountCents:            dbDispute.AmountCents,
+		Status:                 dbDispute.Status,
+		ProvisionalCreditCents: dbDispute.ProvisionalCreditCents,
+		ReversalPendingCents:   dbDispute.ReversalPendingCents,
+		ProvisionalCreditDueAt: dbDispute.ProvisionalCreditDueAt,
+		ResolutionDueAt:        dbDispute.ResolutionDueAt,
+		CreatedAt:              dbDispute.CreatedAt.Time,
+		UpdatedAt:              dbDispute.UpdatedAt.Time,
+	}
+
+	if dbDispute.SupportTicketID.Valid {
+		dispute.SupportTicketID = dbDispute.SupportTicketID.UUID.String()
+	}
+	if dbDispute.ResolutionNote.Valid {
+		dispute.ResolutionNote = dbDispute.ResolutionNote.String
+	}
+	if dbDispute.ProvisionalCreditedAt.Valid {
+		creditedAt := dbDispute.ProvisionalCreditedAt.Time
+		dispute.ProvisionalCreditedAt = &creditedAt
+	}
+	if dbDispute.ResolvedAt.Valid {
+		resolvedAt := dbDispute.ResolvedAt.Time
+		dispute.ResolvedAt = &resolvedAt
+	}
+
+	return dispute
+}
@@ -0,0 +1,232 @@
+package disputes
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/sqlc-dev/pqtype"
+)
+
+// Repository handles data access for card disputes
+type Repository struct {
+	db      *sql.DB
+	queries *db.Queries
+}
+
+// NewRepository creates a new disputes repository
+func NewRepository(database *sql.DB) *Repository {
+	return &Repository{
+		db:      database,
+		queries: db.New(database),
+	}
+}
+
+// Create stores a new dispute
+func (r *Repository) Create(ctx context.Context, tx *sql.Tx, params db.CreateCardDisputeParams) (*db.CardDispute, error) {
+	dispute, err := r.queries.WithTx(tx).CreateCardDispute(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return &dispute, nil
+}
+
+// GetByID retrieves a dispute by ID
+func (r *Repository) GetByID(ctx context.Context, disputeID string) (*db.CardDispute, error) {
+	id, err := uuid.Parse(disputeID)
+	if err != nil {
+		return nil, ErrDisputeNotFound
+	}
+
+	dispute, err := r.queries.GetCardDisputeByID(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrDisputeNotFound
+		}
+		return nil, err
+	}
+	return &dispute, nil
+}
+
+// GetForUpdate locks a dispute row for update within a transaction
+func (r *Repository) GetForUpdate(ctx context.Context, tx *sql.Tx, disputeID uuid.UUID) (*db.CardDispute, error) {
+	dispute, err := r.queries.WithTx(tx).GetCardDisputeForUpdate(ctx, disputeID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrDisputeNotFound
+		}
+		return nil, err
+	}
+	return &dispute, nil
+}
+
+// GetTransactionForUpdate locks a card transaction row for update within a transaction
+func (r *Repository) GetTransactionForUpdate(ctx context.Context, tx *sql.Tx, transactionID uuid.UUID) (*db.CardTransaction, error) {
+	transaction, err := r.queries.WithTx(tx).GetCardTransactionForUpdate(ctx, transactionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrTransactionNotFound
+		}
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// GetTransaction retrieves a card transaction by ID
+func (r *Repository) GetTransaction(ctx context.Context, transactionID uuid.UUID) (*db.CardTransaction, error) {
+	transaction, err := r.queries.GetCardTransactionByID(ctx, transactionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrTransactionNotFound
+		}
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// CountActiveForTransaction counts disputes in progress for a card transaction
+func (r *Repository) CountActiveForTransaction(ctx context.Context, tx *sql.Tx, transactionID uuid.UUID) (int64, error) {
+	return r.queries.WithTx(tx).CountActiveCardDisputesForTransaction(ctx, transactionID)
+}
+
+// UpdateTransactionStatus changes the status of a card transaction
+func (r *Repository) UpdateTransactionStatus(ctx context.Context, tx *sql.Tx, transactionID uuid.UUID, status string) error {
+	return r.queries.WithTx(tx).UpdateCardTransactionStatus(ctx, db.UpdateCardTransactionStatusParams{
+		ID:     transactionID,
+		Status: status,
+	})
+}
+
+// ListUserDisputes retrieves disputes for a user with pagination
+func (r *Repository) ListUserDisputes(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]db.CardDispute, error) {
+	return r.queries.ListUserCardDisputes(ctx, db.ListUserCardDisputesParams{
+		UserID: userID,
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+// CountUserDisputes counts all disputes for a user
+func (r *Repository) CountUserDisputes(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return r.queries.CountUserCardDisputes(ctx, userID)
+}
+
+// SetTicket links a dispute to its support ticket
+func (r *Repository) SetTicket(ctx context.Context, disputeID, ticketID uuid.UUID) error {
+	return r.queries.SetCardDisputeTicket(ctx, db.SetCardDisputeTicketParams{
+		ID:              disputeID,
+		SupportTicketID: uuid.NullUUID{UUID: ticketID, Valid: true},
+	})
+}
+
+// MarkProvisionalCredit records the provisional credit paid for a dispute
+func (r *Repository) MarkProvisionalCredit(ctx context.Context, tx *sql.Tx, disputeID uuid.UUID, amountCents int64) error {
+	return r.queries.WithTx(tx).MarkCardDisputeProvisionalCredit(ctx, db.MarkCardDisputeProvisionalCreditParams{
+		ID:                     disputeID,
+		ProvisionalCreditCents: amountCents,
+	})
+}
+
+// Resolve records the final status of a dispute and the part of its provisional
+// credit still to be reversed
+func (r *Repository) Resolve(ctx context.Context, tx *sql.Tx, disputeID uuid.UUID, status, note string, reversalPendingCents int64) error {
+	return r.queries.WithTx(tx).ResolveCardDispute(ctx, db.ResolveCardDisputeParams{
+		ID:                   disputeID,
+		Status:               status,
+		ResolutionNote:       sql.NullString{String: note, Valid: note != ""},
+		ReversalPendingCents: reversalPendingCents,
+	})
+}
+
+// SetReversalPending updates the part of a lost dispute's provisional credit still to be reversed
+func (r *Repository) SetReversalPending(ctx context.Context, tx *sql.Tx, disputeID uuid.UUID, amountCents int64) error {
+	return r.queries.WithTx(tx).SetCardDisputeReversalPending(ctx, db.SetCardDisputeReversalPendingParams{
+		ID:                   disputeID,
+		ReversalPendingCents: amountCents,
+	})
+}
+
+// CreditBalance adds amountCents to the user's balance
+func (r *Repository) CreditBalance(ctx context.Context, tx *sql.Tx, userID uuid.UUID, amountCents int64) error {
+	return r.queries.WithTx(tx).UpdateUserBalance(ctx, db.UpdateUserBalanceParams{
+		ID:           userID,
+		BalanceCents: sql.NullInt64{Int64: amountCents, Valid: true},
+	})
+}
+
+// DebitBalance takes up to amountCents from the user's balance, without taking
+// it below zero, and returns the amount taken
+func (r *Repository) DebitBalance(ctx context.Context, tx *sql.Tx, userID uuid.UUID, amountCents int64) (int64, error) {
+	qtx := r.queries.WithTx(tx)
+
+	user, err := qtx.GetUserForUpdate(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	debited := min(amountCents, user.BalanceCents.Int64)
+	if debited <= 0 {
+		return 0, nil
+	}
+
+	err = qtx.UpdateUserBalance(ctx, db.UpdateUserBalanceParams{
+		ID:           userID,
+		BalanceCents: sql.NullInt64{Int64: -debited, Valid: true},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return debited, nil
+}
+
+// ListPastProvisionalDeadline returns up to limit opened disputes whose provisional credit is due
+func (r *Repository) ListPastProvisionalDeadline(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error) {
+	return r.queries.ListCardDisputesPastProvisionalDeadline(ctx, db.ListCardDisputesPastProvisionalDeadlineParams{
+		ProvisionalCreditDueAt: now,
+		Limit:                  int32(limit),
+	})
+}
+
+// ListPastResolutionDeadline returns up to limit unresolved disputes whose resolution is overdue
+func (r *Repository) ListPastResolutionDeadline(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error) {
+	return r.queries.ListCardDisputesPastResolutionDeadline(ctx, db.ListCardDisputesPastResolutionDeadlineParams{
+		ResolutionDueAt: now,
+		Limit:           int32(limit),
+	})
+}
+
+// ListWithoutTicket returns up to limit disputes in progress with no support ticket
+func (r *Repository) ListWithoutTicket(ctx context.Context, limit int) ([]db.CardDispute, error) {
+	return r.queries.ListCardDisputesWithoutTicket(ctx, int32(limit))
+}
+
+// ListReversalPending returns up to limit lost disputes whose provisional credit is not fully reversed
+func (r *Repository) ListReversalPending(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	return r.queries.ListCardDisputesWithReversalPending(ctx, int32(limit))
+}
+
+// CreateAuditLog writes an explicit audit entry for a dispute operation
+func (r *Repository) CreateAuditLog(ctx context.Context, userID uuid.UUID, disputeID uuid.UUID, action, status string, details map[string]interface{}) error {
+	var newValues pqtype.NullRawMessage
+	if details != nil {
+		if data, err := json.Marshal(details); err == nil {
+			newValues = pqtype.NullRawMessage{RawMessage: data, Valid: true}
+		}
+	}
+
+	requestID, _ := ctx.Value("request_id").(string)
+
+	_, err := r.queries.CreateAuditLog(ctx, db.CreateAuditLogParams{
+		UserID:       uuid.NullUUID{UUID: userID, Valid: userID != uuid.Nil},
+		Action:       action,
+		ResourceType: "CARD_DISPUTE",
+		ResourceID:   disputeID,
+		NewValues:    newValues,
+		RequestID:    sql.NullString{String: requestID, Valid: requestID != ""},
+		Status:       status,
+	})
+	return err
+}
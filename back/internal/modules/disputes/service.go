@@ -0,0 +1,536 @@
+package disputes
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lauratech/fin/back/internal/modules/notifications"
+	"github.com/lauratech/fin/back/internal/modules/support"
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+
+	"github.com/google/uuid"
+)
+
+// DeadlineBatchSize is the maximum number of disputes handled per deadline step and run
+const DeadlineBatchSize = 500
+
+// Service handles business logic for card disputes
+type Service struct {
+	repo          *Repository
+	db            *sql.DB
+	support       *support.Service
+	notifications *notifications.Service
+}
+
+// NewService creates a new disputes service
+func NewService(repo *Repository, database *sql.DB, supportService *support.Service, notificationsService *notifications.Service) *Service {
+	return &Service{
+		repo:          repo,
+		db:            database,
+		support:       supportService,
+		notifications: notificationsService,
+	}
+}
+
+// OpenDispute disputes one of the user's card transactions and opens a linked support ticket.
+// If the ticket cannot be opened the dispute is kept; the deadlines job opens it later.
+func (s *Service) OpenDispute(ctx context.Context, userID string, req CreateDisputeRequest) (*Dispute, error) {
+	// 1. Validate request
+	if err := ValidateCreateDisputeRequest(req); err != nil {
+		return nil, err
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionUUID, err := uuid.Parse(req.TransactionID)
+	if err != nil {
+		return nil, ErrTransactionNotFound
+	}
+
+	now := time.Now()
+	var dbDispute *db.CardDispute
+	var transaction *db.CardTransaction
+	err = s.executeInTransaction(ctx, func(tx *sql.Tx) error {
+		// 2. Lock transaction (serializes disputes of the same transaction)
+		transaction, err = s.repo.GetTransactionForUpdate(ctx, tx, transactionUUID)
+		if err != nil {
+			return err
+		}
+		if transaction.UserID != userUUID {
+			return ErrUnauthorizedAccess
+		}
+
+		// 3. Check the transaction can be disputed
+		if transaction.Status != "completed" {
+			return ErrTransactionNotDisputable
+		}
+		if err := ValidateFilingWindow(transaction.TransactionDate, now); err != nil {
+			return err
+		}
+		amountCents, err := DisputedAmount(req.Reason, req.AmountCents, transaction.AmountCents)
+		if err != nil {
+			return err
+		}
+
+		active, err := s.repo.CountActiveForTransaction(ctx, tx, transactionUUID)
+		if err != nil {
+			return err
+		}
+		if active > 0 {
+			return ErrDisputeAlreadyOpen
+		}
+
+		// 4. Create dispute with its deadlines
+		provisionalCreditDueAt, resolutionDueAt := Deadlines(now)
+		dbDispute, err = s.repo.Create(ctx, tx, db.CreateCardDisputeParams{
+			UserID:                 userUUID,
+			CardID:                 transaction.CardID,
+			CardTransactionID:      transactionUUID,
+			Reason:                 req.Reason,
+			Evidence:               strings.TrimSpace(req.Evidence),
+			AmountCents:            amountCents,
+			ProvisionalCreditDueAt: provisionalCreditDueAt,
+			ResolutionDueAt:        resolutionDueAt,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 5. Open the linked support ticket
+	if err := s.openTicket(ctx, dbDispute, transaction); err != nil {
+		_ = s.repo.CreateAuditLog(ctx, userUUID, dbDispute.ID, "DISPUTE_TICKET_CREATED", "failure", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	_ = s.repo.CreateAuditLog(ctx, userUUID, dbDispute.ID, "DISPUTE_OPENED", "success", map[string]interface{}{
+		"transaction_id": transactionUUID.String(),
+		"reason":         dbDispute.Reason,
+		"amount_cents":   dbDispute.AmountCents,
+	})
+
+	// 6. Confirm to the holder
+	s.notify(ctx, dbDispute, notifications.TypeDisputeOpened, "Dispute opened",
+		fmt.Sprintf("We received your dispute of %s at %s. We will get back to you by %s.",
+			formatAmount(dbDispute.AmountCents), transaction.MerchantName, dbDispute.ResolutionDueAt.Format("02/01/2006")))
+
+	return dbDisputeToDispute(dbDispute), nil
+}
+
+// GetDispute retrieves one of the user's disputes
+func (s *Service) GetDispute(ctx context.Context, userID, disputeID string) (*Dispute, error) {
+	dbDispute, err := s.repo.GetByID(ctx, disputeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if dbDispute.UserID.String() != userID {
+		return nil, ErrUnauthorizedAccess
+	}
+
+	return dbDisputeToDispute(dbDispute), nil
+}
+
+// ListUserDisputes retrieves a page of the user's disputes
+func (s *Service) ListUserDisputes(ctx context.Context, userID string, page, limit int) ([]*Dispute, int64, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	dbDisputes, err := s.repo.ListUserDisputes(ctx, userUUID, int32(limit), int32(offset))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.CountUserDisputes(ctx, userUUID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	disputes := make([]*Dispute, len(dbDisputes))
+	for i := range dbDisputes {
+		disputes[i] = dbDisputeToDispute(&dbDisputes[i])
+	}
+
+	return disputes, total, nil
+}
+
+// GrantProvisionalCredit credits the disputed amount to the holder's balance while the dispute is investigated.
+// Internal callers only (card processor, deadlines job). Repeating the call is a no-op.
+func (s *Service) GrantProvisionalCredit(ctx context.Context, caller, disputeID string) (*Dispute, error) {
+	id, err := uuid.Parse(disputeID)
+	if err != nil {
+		return nil, ErrDisputeNotFound
+	}
+
+	var dbDispute *db.CardDispute
+	err = s.executeInTransaction(ctx, func(tx *sql.Tx) error {
+		// 1. Lock dispute and validate transition
+		dbDispute, err = s.repo.GetForUpdate(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if dbDispute.Status == StatusProvisionalCredit {
+			return nil
+		}
+		if err := ValidateStatusTransition(dbDispute.Status, StatusProvisionalCredit); err != nil {
+			return err
+		}
+
+		// 2. Credit the balance
+		if err := s.repo.CreditBalance(ctx, tx, dbDispute.UserID, dbDispute.AmountCents); err != nil {
+			return err
+		}
+		if err := s.repo.MarkProvisionalCredit(ctx, tx, id, dbDispute.AmountCents); err != nil {
+			return err
+		}
+
+		dbDispute, err = s.repo.GetForUpdate(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.repo.CreateAuditLog(ctx, dbDispute.UserID, dbDispute.ID, "DISPUTE_PROVISIONAL_CREDIT", "success", map[string]interface{}{
+		"caller":       caller,
+		"amount_cents": dbDispute.ProvisionalCreditCents,
+	})
+
+	// 3. Let the holder know
+	s.notify(ctx, dbDispute, notifications.TypeDisputeProvisionalCredit, "Provisional credit",
+		fmt.Sprintf("We credited %s to your balance while we investigate your dispute. The credit becomes final if the dispute is decided in your favor.",
+			formatAmount(dbDispute.ProvisionalCreditCents)))
+
+	return dbDisputeToDispute(dbDispute), nil
+}
+
+// ResolveDispute records the outcome of a dispute.
+// Won: the holder keeps the provisional credit (or is credited now) and a fully
+// disputed transaction is marked refunded. Lost: the provisional credit is taken
+// back; the part the balance does not cover stays pending and is taken by RunDeadlines.
+// Internal callers only (card processor, deadlines job). Repeating the same outcome is a no-op.
+func (s *Service) ResolveDispute(ctx context.Context, caller, disputeID string, req ResolveDisputeRequest) (*Dispute, error) {
+	// 1. Validate request
+	if err := ValidateOutcome(req.Outcome); err != nil {
+		return nil, err
+	}
+	note := strings.TrimSpace(req.Note)
+
+	id, err := uuid.Parse(disputeID)
+	if err != nil {
+		return nil, ErrDisputeNotFound
+	}
+
+	var dbDispute *db.CardDispute
+	var reversalPending int64
+	err = s.executeInTransaction(ctx, func(tx *sql.Tx) error {
+		// 2. Lock dispute and validate transition
+		dbDispute, err = s.repo.GetForUpdate(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if dbDispute.Status == req.Outcome {
+			return nil
+		}
+		if err := ValidateStatusTransition(dbDispute.Status, req.Outcome); err != nil {
+			return err
+		}
+
+		// 3. Settle the balance
+		switch req.Outcome {
+		case StatusWon:
+			if remaining := dbDispute.AmountCents - dbDispute.ProvisionalCreditCents; remaining > 0 {
+				if err := s.repo.CreditBalance(ctx, tx, dbDispute.UserID, remaining); err != nil {
+					return err
+				}
+			}
+
+			transaction, err := s.repo.GetTransactionForUpdate(ctx, tx, dbDispute.CardTransactionID)
+			if err != nil {
+				return err
+			}
+			if dbDispute.AmountCents == transaction.AmountCents {
+				if err := s.repo.UpdateTransactionStatus(ctx, tx, transaction.ID, "refunded"); err != nil {
+					return err
+				}
+			}
+		case StatusLost:
+			if dbDispute.ProvisionalCreditCents > 0 {
+				debited, err := s.repo.DebitBalance(ctx, tx, dbDispute.UserID, dbDispute.ProvisionalCreditCents)
+				if err != nil {
+					return err
+				}
+				reversalPending = dbDispute.ProvisionalCreditCents - debited
+			}
+		}
+
+		// 4. Record outcome
+		if err := s.repo.Resolve(ctx, tx, id, req.Outcome, note, reversalPending); err != nil {
+			return err
+		}
+
+		dbDispute, err = s.repo.GetForUpdate(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.repo.CreateAuditLog(ctx, dbDispute.UserID, dbDispute.ID, "DISPUTE_RESOLVED", "success", map[string]interface{}{
+		"caller":                 caller,
+		"outcome":                dbDispute.Status,
+		"reversal_pending_cents": dbDispute.ReversalPendingCents,
+	})
+
+	// 5. Resolve the linked ticket (the outcome is already recorded; failures are audited)
+	if dbDispute.SupportTicketID.Valid {
+		_, err := s.support.UpdateTicketStatus(ctx, dbDispute.UserID.String(), dbDispute.SupportTicketID.UUID.String(),
+			support.UpdateTicketStatusRequest{Status: "resolved"})
+		if err != nil && err != support.ErrInvalidStatusTransition {
+			_ = s.repo.CreateAuditLog(ctx, dbDispute.UserID, dbDispute.ID, "DISPUTE_TICKET_RESOLVED", "failure", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	// 6. Let the holder know
+	message := fmt.Sprintf("Your dispute of %s was decided in your favor. The amount is now final in your balance.", formatAmount(dbDispute.AmountCents))
+	if dbDispute.Status == StatusLost {
+		message = fmt.Sprintf("Your dispute of %s was not accepted.", formatAmount(dbDispute.AmountCents))
+		if dbDispute.ReversalPendingCents > 0 {
+			message += fmt.Sprintf(" The provisional credit of %s will be reversed; %s will be taken from your balance as funds arrive.",
+				formatAmount(dbDispute.ProvisionalCreditCents), formatAmount(dbDispute.ReversalPendingCents))
+		} else if dbDispute.ProvisionalCreditCents > 0 {
+			message += fmt.Sprintf(" The provisional credit of %s was reversed.", formatAmount(dbDispute.ProvisionalCreditCents))
+		}
+	}
+	if note != "" {
+		message += " " + note
+	}
+	s.notify(ctx, dbDispute, notifications.TypeDisputeResolved, "Dispute resolved", message)
+
+	return dbDisputeToDispute(dbDispute), nil
+}
+
+// RunDeadlines enforces dispute deadlines:
+// opens support tickets that could not be opened with the dispute, grants the
+// provisional credit of disputes past its due date, resolves disputes past
+// the resolution deadline in the holder's favor and takes back the pending
+// provisional credit of lost disputes as the balance allows.
+//
+// The job is idempotent.
+func (s *Service) RunDeadlines(ctx context.Context, now time.Time) (*DeadlineResult, error) {
+	result := &DeadlineResult{}
+	var errs []error
+
+	// 1. Open missing support tickets
+	withoutTicket, err := s.repo.ListWithoutTicket(ctx, DeadlineBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	for i := range withoutTicket {
+		dbDispute := &withoutTicket[i]
+		transaction, err := s.repo.GetTransaction(ctx, dbDispute.CardTransactionID)
+		if err == nil {
+			err = s.openTicket(ctx, dbDispute, transaction)
+		}
+		if err != nil {
+			result.Failed++
+			errs = append(errs, fmt.Errorf("open ticket for dispute %s: %w", dbDispute.ID, err))
+			continue
+		}
+		result.TicketsLinked++
+	}
+
+	// 2. Grant overdue provisional credits
+	provisionalDue, err := s.repo.ListPastProvisionalDeadline(ctx, now, DeadlineBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range provisionalDue {
+		if _, err := s.GrantProvisionalCredit(ctx, "dispute_deadlines", id.String()); err != nil {
+			result.Failed++
+			errs = append(errs, fmt.Errorf("provisional credit for dispute %s: %w", id, err))
+			continue
+		}
+		result.ProvisionalCredited++
+	}
+
+	// 3. Resolve overdue disputes in the holder's favor
+	resolutionDue, err := s.repo.ListPastResolutionDeadline(ctx, now, DeadlineBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range resolutionDue {
+		_, err := s.ResolveDispute(ctx, "dispute_deadlines", id.String(), ResolveDisputeRequest{
+			Outcome: StatusWon,
+			Note:    "The dispute was not resolved within the deadline.",
+		})
+		if err != nil {
+			result.Failed++
+			errs = append(errs, fmt.Errorf("resolve dispute %s: %w", id, err))
+			continue
+		}
+		result.Resolved++
+	}
+
+	// 4. Collect pending reversals of lost disputes
+	reversalPending, err := s.repo.ListReversalPending(ctx, DeadlineBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range reversalPending {
+		collected, err := s.collectReversal(ctx, id)
+		if err != nil {
+			result.Failed++
+			errs = append(errs, fmt.Errorf("collect reversal of dispute %s: %w", id, err))
+			continue
+		}
+		if collected > 0 {
+			result.ReversalsCollected++
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// collectReversal takes the pending provisional credit of a lost dispute from
+// the balance, as far as it goes, and returns the amount taken
+func (s *Service) collectReversal(ctx context.Context, id uuid.UUID) (int64, error) {
+	var dbDispute *db.CardDispute
+	var collected int64
+	err := s.executeInTransaction(ctx, func(tx *sql.Tx) error {
+		var err error
+		dbDispute, err = s.repo.GetForUpdate(ctx, tx, id)
+		if err != nil || dbDispute.ReversalPendingCents == 0 {
+			return err
+		}
+
+		collected, err = s.repo.DebitBalance(ctx, tx, dbDispute.UserID, dbDispute.ReversalPendingCents)
+		if err != nil || collected == 0 {
+			return err
+		}
+		return s.repo.SetReversalPending(ctx, tx, id, dbDispute.ReversalPendingCents-collected)
+	})
+	if err != nil || collected == 0 {
+		return 0, err
+	}
+
+	_ = s.repo.CreateAuditLog(ctx, dbDispute.UserID, dbDispute.ID, "DISPUTE_REVERSAL_COLLECTED", "success", map[string]interface{}{
+		"amount_cents":           collected,
+		"reversal_pending_cents": dbDispute.ReversalPendingCents - collected,
+	})
+	return collected, nil
+}
+
+// openTicket opens the support ticket that tracks a dispute and links it
+func (s *Service) openTicket(ctx context.Context, dbDispute *db.CardDispute, transaction *db.CardTransaction) error {
+	priority := "medium"
+	if dbDispute.Reason == ReasonFraud {
+		priority = "high"
+	}
+
+	ticket, err := s.support.CreateTicket(ctx, dbDispute.UserID.String(), support.CreateTicketRequest{
+		Category: "card",
+		Priority: priority,
+		Subject:  DisputeTicketSubject(dbDispute.Reason, transaction.MerchantName),
+		Description: fmt.Sprintf("Dispute %s\nTransaction: %s on %s (%s)\nDisputed amount: %s\nResolution due: %s\n\n%s",
+			dbDispute.ID, transaction.MerchantName, transaction.TransactionDate.Format("02/01/2006"),
+			formatAmount(transaction.AmountCents), formatAmount(dbDispute.AmountCents),
+			dbDispute.ResolutionDueAt.Format("02/01/2006"), dbDispute.Evidence),
+	})
+	if err != nil {
+		return err
+	}
+
+	ticketUUID, err := uuid.Parse(ticket.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.SetTicket(ctx, dbDispute.ID, ticketUUID); err != nil {
+		return err
+	}
+
+	dbDispute.SupportTicketID = uuid.NullUUID{UUID: ticketUUID, Valid: true}
+	return nil
+}
+
+// notify notifies the holder about a dispute, once per dispute and type.
+// Notifications are best effort: failures are audited and never fail the operation.
+func (s *Service) notify(ctx context.Context, dbDispute *db.CardDispute, notificationType, title, message string) {
+	if s.notifications == nil {
+		return
+	}
+
+	_, err := s.notifications.Notify(ctx, notifications.NotifyRequest{
+		UserID:       dbDispute.UserID.String(),
+		Type:         notificationType,
+		Title:        title,
+		Message:      message,
+		ResourceType: "dispute",
+		ResourceID:   dbDispute.ID.String(),
+		DedupKey:     fmt.Sprintf("%s:%s", notificationType, dbDispute.ID),
+	})
+	if err != nil {
+		_ = s.repo.CreateAuditLog(ctx, dbDispute.UserID, dbDispute.ID, "DISPUTE_NOTIFIED", "failure", map[string]interface{}{
+			"type":  notificationType,
+			"error": err.Error(),
+		})
+	}
+}
+
+// DisputeTicketSubject returns the subject of the support ticket linked to a dispute
+func DisputeTicketSubject(reason, merchantName string) string {
+	labels := map[string]string{
+		ReasonFraud:       "Unrecognized transaction",
+		ReasonNotReceived: "Product or service not received",
+		ReasonDuplicate:   "Duplicate charge",
+		ReasonWrongAmount: "Wrong amount charged",
+	}
+
+	subject := fmt.Sprintf("Card dispute: %s - %s", labels[reason], merchantName)
+	if len(subject) > 255 {
+		// Drop a multi-byte character cut in half by the truncation
+		subject = strings.ToValidUTF8(subject[:255], "")
+	}
+	return subject
+}
+
+// formatAmount formats cents as a BRL amount (R$ 1234.56)
+func formatAmount(cents int64) string {
+	return fmt.Sprintf("R$ %d.%02d", cents/100, cents%100)
+}
+
+// executeInTransaction executes a function within a database transaction
+func (s *Service) executeInTransaction(ctx context.Context, fn func(*sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,100 @@
+package disputes
+
+import "time"
+
+// Dispute reason codes
+const (
+	ReasonFraud       = "fraud"
+	ReasonNotReceived = "not_received"
+	ReasonDuplicate   = "duplicate"
+	ReasonWrongAmount = "wrong_amount"
+)
+
+// Dispute statuses
+const (
+	StatusOpened            = "opened"
+	StatusProvisionalCredit = "provisional_credit"
+	StatusWon               = "won"
+	StatusLost              = "lost"
+)
+
+// Dispute deadlines (calendar days)
+const (
+	// FilingWindowDays is how long after the transaction a dispute can be opened
+	FilingWindowDays = 120
+
+	// ProvisionalCreditDays is how long an opened dispute waits before the disputed
+	// amount is credited provisionally
+	ProvisionalCreditDays = 10
+
+	// ResolutionDays is how long the issuer has to resolve a dispute.
+	// Disputes still open after the deadline are resolved in the holder's favor.
+	ResolutionDays = 90
+)
+
+// Evidence length limits
+const (
+	MinEvidenceLength = 20
+	MaxEvidenceLength = 5000
+)
+
+// Dispute represents a card transaction dispute domain model
+type Dispute struct {
+	ID                     string     `json:"id"`
+	CardID                 string     `json:"card_id"`
+	TransactionID          string     `json:"transaction_id"`
+	SupportTicketID        string     `json:"support_ticket_id,omitempty"`
+	Reason                 string     `json:"reason"`
+	Evidence               string     `json:"evidence"`
+	AmountCents            int64      `json:"amount_cents"`
+	Status                 string     `json:"status"`
+	ProvisionalCreditCents int64      `json:"provisional_credit_cents"`
+	ReversalPendingCents   int64      `json:"reversal_pending_cents"` // Lost: provisional credit not yet taken back
+	ResolutionNote         string     `json:"resolution_note,omitempty"`
+	ProvisionalCreditDueAt time.Time  `json:"provisional_credit_due_at"`
+	ResolutionDueAt        time.Time  `json:"resolution_due_at"`
+	ProvisionalCreditedAt  *time.Time `json:"provisional_credited_at,omitempty"`
+	ResolvedAt             *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt              time.Time  `json:"created_at"`
+	UpdatedAt              time.Time  `json:"updated_at"`
+}
+
+// CreateDisputeRequest represents the request to dispute a card transaction.
+// AmountCents is required for wrong_amount (the overcharged part); the other
+// reasons dispute the full transaction amount and may omit it.
+type CreateDisputeRequest struct {
+	TransactionID string `json:"transaction_id"`
+	Reason        string `json:"reason"`
+	Evidence      string `json:"evidence"`
+	AmountCents   int64  `json:"amount_cents,omitempty"`
+}
+
+// ResolveDisputeRequest represents the outcome of a dispute reported by the card processor
+type ResolveDisputeRequest struct {
+	Outcome string `json:"outcome"`
+	Note    string `json:"note"`
+}
+
+// DeadlineResult summarizes one run of the dispute deadlines job
+type DeadlineResult struct {
+	TicketsLinked       int `json:"tickets_linked"`
+	ProvisionalCredited int `json:"provisional_credited"`
+	Resolved            int `json:"resolved"`
+	ReversalsCollected  int `json:"reversals_collected"`
+	Failed              int `json:"failed"`
+}
+
+// Valid dispute reasons
+var ValidReasons = map[string]bool{
+	ReasonFraud:       true,
+	ReasonNotReceived: true,
+	ReasonDuplicate:   true,
+	ReasonWrongAmount: true,
+}
+
+// AllowedStatusTransitions defines allowed dispute status transitions.
+// A dispute may be resolved before the provisional credit is due.
+var AllowedStatusTransitions = map[string][]string{
+	StatusOpened:            {StatusProvisionalCredit, StatusWon, StatusLost},
+	StatusProvisionalCredit: {StatusWon, StatusLost},
+}
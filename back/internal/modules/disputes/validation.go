@@ -0,0 +1,87 @@
+package disputes
+
+import (
+	"strings"
+	"time"
+)
+
+// ValidateReason validates a dispute reason code
+func ValidateReason(reason string) error {
+	if !ValidReasons[reason] {
+		return ErrInvalidReason
+	}
+	return nil
+}
+
+// ValidateEvidence validates the holder's description of the problem
+func ValidateEvidence(evidence string) error {
+	evidence = strings.TrimSpace(evidence)
+	if len(evidence) < MinEvidenceLength || len(evidence) > MaxEvidenceLength {
+		return ErrInvalidEvidence
+	}
+	return nil
+}
+
+// ValidateCreateDisputeRequest validates the create dispute request
+func ValidateCreateDisputeRequest(req CreateDisputeRequest) error {
+	if err := ValidateReason(req.Reason); err != nil {
+		return err
+	}
+
+	if err := ValidateEvidence(req.Evidence); err != nil {
+		return err
+	}
+
+	if req.AmountCents < 0 {
+		return ErrInvalidAmount
+	}
+
+	return nil
+}
+
+// DisputedAmount returns the amount under dispute for a transaction of transactionCents.
+// A wrong amount disputes part of the charge; the other reasons dispute all of it.
+func DisputedAmount(reason string, requestedCents, transactionCents int64) (int64, error) {
+	if reason == ReasonWrongAmount {
+		if requestedCents <= 0 || requestedCents >= transactionCents {
+			return 0, ErrInvalidAmount
+		}
+		return requestedCents, nil
+	}
+
+	if requestedCents != 0 && requestedCents != transactionCents {
+		return 0, ErrInvalidAmount
+	}
+	return transactionCents, nil
+}
+
+// ValidateFilingWindow checks that a transaction is recent enough to be disputed
+func ValidateFilingWindow(transactionDate, now time.Time) error {
+	if now.After(transactionDate.AddDate(0, 0, FilingWindowDays)) {
+		return ErrFilingWindowExpired
+	}
+	return nil
+}
+
+// ValidateOutcome validates a resolution outcome
+func ValidateOutcome(outcome string) error {
+	if outcome != StatusWon && outcome != StatusLost {
+		return ErrInvalidOutcome
+	}
+	return nil
+}
+
+// ValidateStatusTransition validates a dispute status change
+func ValidateStatusTransition(from, to string) error {
+	for _, next := range AllowedStatusTransitions[from] {
+		if next == to {
+			return nil
+		}
+	}
+	return ErrInvalidStatusTransition
+}
+
+// Deadlines returns the provisional credit and resolution deadlines of a dispute opened at openedAt
+func Deadlines(openedAt time.Time) (provisionalCreditDueAt, resolutionDueAt time.Time) {
+	return openedAt.AddDate(0, 0, ProvisionalCreditDays), openedAt.AddDate(0, 0, ResolutionDays)
+}
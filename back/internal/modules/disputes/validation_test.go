@@ -0,0 +1,131 @@
+package disputes
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// TestValidateCreateDisputeRequest tests reason codes and evidence limits
+func TestValidateCreateDisputeRequest(t *testing.T) {
+	evidence := "I did not make this purchase and never visited this store."
+
+	tests := []struct {
+		name    string
+		req     CreateDisputeRequest
+		wantErr error
+	}{
+		{"fraud", CreateDisputeRequest{Reason: ReasonFraud, Evidence: evidence}, nil},
+		{"not received", CreateDisputeRequest{Reason: ReasonNotReceived, Evidence: evidence}, nil},
+		{"duplicate", CreateDisputeRequest{Reason: ReasonDuplicate, Evidence: evidence}, nil},
+		{"wrong amount", CreateDisputeRequest{Reason: ReasonWrongAmount, Evidence: evidence, AmountCents: 500}, nil},
+		{"unknown reason", CreateDisputeRequest{Reason: "chargeback", Evidence: evidence}, ErrInvalidReason},
+		{"empty reason", CreateDisputeRequest{Evidence: evidence}, ErrInvalidReason},
+		{"short evidence", CreateDisputeRequest{Reason: ReasonFraud, Evidence: "   not me   "}, ErrInvalidEvidence},
+		{"long evidence", CreateDisputeRequest{Reason: ReasonFraud, Evidence: strings.Repeat("a", MaxEvidenceLength+1)}, ErrInvalidEvidence},
+		{"negative amount", CreateDisputeRequest{Reason: ReasonWrongAmount, Evidence: evidence, AmountCents: -1}, ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateCreateDisputeRequest(tt.req); err != tt.wantErr {
+				t.Errorf("ValidateCreateDisputeRequest() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestDisputedAmount tests the amount under dispute for each reason
+func TestDisputedAmount(t *testing.T) {
+	tests := []struct {
+		name      string
+		reason    string
+		requested int64
+		want      int64
+		wantErr   error
+	}{
+		{"fraud defaults to full amount", ReasonFraud, 0, 10000, nil},
+		{"duplicate with explicit full amount", ReasonDuplicate, 10000, 10000, nil},
+		{"not received partial amount", ReasonNotReceived, 5000, 0, ErrInvalidAmount},
+		{"wrong amount partial", ReasonWrongAmount, 2500, 2500, nil},
+		{"wrong amount missing", ReasonWrongAmount, 0, 0, ErrInvalidAmount},
+		{"wrong amount equal to charge", ReasonWrongAmount, 10000, 0, ErrInvalidAmount},
+		{"wrong amount above charge", ReasonWrongAmount, 12000, 0, ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DisputedAmount(tt.reason, tt.requested, 10000)
+			if err != tt.wantErr {
+				t.Fatalf("DisputedAmount() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("DisputedAmount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestValidateFilingWindow tests the dispute filing deadline
+func TestValidateFilingWindow(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	if err := ValidateFilingWindow(now.AddDate(0, 0, -FilingWindowDays), now); err != nil {
+		t.Errorf("last day of the window: error = %v, want nil", err)
+	}
+	if err := ValidateFilingWindow(now.AddDate(0, 0, -FilingWindowDays).Add(-time.Minute), now); err != ErrFilingWindowExpired {
+		t.Errorf("after the window: error = %v, want %v", err, ErrFilingWindowExpired)
+	}
+}
+
+// TestValidateStatusTransition tests the dispute state machine
+func TestValidateStatusTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		wantErr  error
+	}{
+		{StatusOpened, StatusProvisionalCredit, nil},
+		{StatusOpened, StatusWon, nil},
+		{StatusOpened, StatusLost, nil},
+		{StatusProvisionalCredit, StatusWon, nil},
+		{StatusProvisionalCredit, StatusLost, nil},
+		{StatusProvisionalCredit, StatusOpened, ErrInvalidStatusTransition},
+		{StatusWon, StatusLost, ErrInvalidStatusTransition},
+		{StatusLost, StatusWon, ErrInvalidStatusTransition},
+		{StatusWon, StatusProvisionalCredit, ErrInvalidStatusTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+"->"+tt.to, func(t *testing.T) {
+			if err := ValidateStatusTransition(tt.from, tt.to); err != tt.wantErr {
+				t.Errorf("ValidateStatusTransition(%q, %q) error = %v, want %v", tt.from, tt.to, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestDeadlines tests the provisional credit and resolution deadlines
+func TestDeadlines(t *testing.T) {
+	openedAt := time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC)
+
+	provisional, resolution := Deadlines(openedAt)
+	if want := time.Date(2026, 3, 20, 9, 30, 0, 0, time.UTC); !provisional.Equal(want) {
+		t.Errorf("provisional credit due = %v, want %v", provisional, want)
+	}
+	if want := time.Date(2026, 6, 8, 9, 30, 0, 0, time.UTC); !resolution.Equal(want) {
+		t.Errorf("resolution due = %v, want %v", resolution, want)
+	}
+}
+
+// TestDisputeTicketSubject tests the linked support ticket subject
+func TestDisputeTicketSubject(t *testing.T) {
+	if got := DisputeTicketSubject(ReasonDuplicate, "Padaria Pão Quente"); got != "Card dispute: Duplicate charge - Padaria Pão Quente" {
+		t.Errorf("DisputeTicketSubject() = %q", got)
+	}
+
+	long := DisputeTicketSubject(ReasonFraud, strings.Repeat("ã", 200))
+	if len(long) > 255 || !utf8.ValidString(long) {
+		t.Errorf("long subject: len = %d, valid UTF-8 = %v", len(long), utf8.ValidString(long))
+	}
+}
@@ -0,0 +1,14 @@
+package merchants
+
+import "errors"
+
+var (
+	// ErrMerchantNotFound is returned when the user has no card transaction with the merchant
+	ErrMerchantNotFound = errors.New("merchant not found")
+
+	// ErrOverrideNotFound is returned when the user has no category override for the merchant
+	ErrOverrideNotFound = errors.New("merchant category override not found")
+
+	// ErrInvalidCategory is returned when the override category is unknown
+	ErrInvalidCategory = errors.New("invalid merchant category")
+)
@@ -0,0 +1,102 @@
+package merchants
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lauratech/fin/back/internal/shared/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Handler handles HTTP requests for merchant category overrides
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new merchants handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// ListOverrides handles GET /api/merchants/overrides
+func (h *Handler) ListOverrides(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	// List overrides
+	overrides, err := h.service.ListOverrides(r.Context(), userID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	// Return success response
+	response.Success(w, http.StatusOK, overrides, r.Context())
+}
+
+// SetOverride handles PUT /api/merchants/overrides/{key}
+func (h *Handler) SetOverride(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	// Decode request body
+	var req SetCategoryOverrideRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.Error(w, http.StatusBadRequest, "VAL_001", "Invalid request body", nil)
+		return
+	}
+
+	// Set override
+	override, err := h.service.SetOverride(r.Context(), userID, chi.URLParam(r, "key"), req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	// Return success response
+	response.Success(w, http.StatusOK, override, r.Context())
+}
+
+// DeleteOverride handles DELETE /api/merchants/overrides/{key}
+func (h *Handler) DeleteOverride(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	// Delete override
+	if err := h.service.DeleteOverride(r.Context(), userID, chi.URLParam(r, "key")); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	// Return success response
+	response.Success(w, http.StatusOK, map[string]string{"message": "Merchant category override removed"}, r.Context())
+}
+
+// handleError maps domain errors to HTTP responses
+func (h *Handler) handleError(w http.ResponseWriter, err error) {
+	switch err {
+	case ErrMerchantNotFound:
+		response.Error(w, http.StatusNotFound, "MERCHANT_001", "Merchant not found in your card transactions", nil)
+	case ErrOverrideNotFound:
+		response.Error(w, http.StatusNotFound, "MERCHANT_002", "Merchant category override not found", nil)
+	case ErrInvalidCategory:
+		response.Error(w, http.StatusBadRequest, "VAL_301", "Invalid merchant category", nil)
+	default:
+		response.Error(w, http.StatusInternalServerError, "SYS_001", "Internal server error", nil)
+	}
+}
@@ -0,0 +1,15 @@
+package merchants
+
+import (
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+)
+
+// dbOverrideToCategoryOverride converts a database override to domain override
+func dbOverrideToCategoryOverride(dbOverride *db.MerchantCategoryOverride) *CategoryOverride {
+	return &CategoryOverride{
+		MerchantKey:  dbOverride.MerchantKey,
+		MerchantName: dbOverride.MerchantName,
+		Category:     dbOverride.Category,
+		UpdatedAt:    dbOverride.UpdatedAt.Time,
+	}
+}
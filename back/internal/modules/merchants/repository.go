@@ -0,0 +1,79 @@
+package merchants
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+
+	"github.com/google/uuid"
+)
+
+// Repository handles data access for merchant enrichment
+type Repository struct {
+	db      *sql.DB
+	queries *db.Queries
+}
+
+// NewRepository creates a new merchants repository
+func NewRepository(database *sql.DB) *Repository {
+	return &Repository{
+		db:      database,
+		queries: db.New(database),
+	}
+}
+
+// ListOverrides retrieves the user's merchant category overrides
+func (r *Repository) ListOverrides(ctx context.Context, userID uuid.UUID) ([]db.MerchantCategoryOverride, error) {
+	return r.queries.ListUserMerchantCategoryOverrides(ctx, userID)
+}
+
+// UpsertOverride creates or replaces the user's category override for a merchant
+func (r *Repository) UpsertOverride(ctx context.Context, params db.UpsertMerchantCategoryOverrideParams) (*db.MerchantCategoryOverride, error) {
+	override, err := r.queries.UpsertMerchantCategoryOverride(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return &override, nil
+}
+
+// DeleteOverride removes the user's category override for a merchant
+func (r *Repository) DeleteOverride(ctx context.Context, userID uuid.UUID, merchantKey string) error {
+	rows, err := r.queries.DeleteMerchantCategoryOverride(ctx, db.DeleteMerchantCategoryOverrideParams{
+		UserID:      userID,
+		MerchantKey: merchantKey,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrOverrideNotFound
+	}
+	return nil
+}
+
+// GetUserTransactionByMerchant retrieves a card transaction of the user with the merchant
+func (r *Repository) GetUserTransactionByMerchant(ctx context.Context, userID uuid.UUID, merchantKey string) (*db.CardTransaction, error) {
+	transaction, err := r.queries.GetUserCardTransactionByMerchantKey(ctx, db.GetUserCardTransactionByMerchantKeyParams{
+		UserID:      userID,
+		MerchantKey: sql.NullString{String: merchantKey, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrMerchantNotFound
+		}
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// ListUnenrichedTransactions retrieves card transactions stored before merchant enrichment
+func (r *Repository) ListUnenrichedTransactions(ctx context.Context, limit int32) ([]db.CardTransaction, error) {
+	return r.queries.ListUnenrichedCardTransactions(ctx, limit)
+}
+
+// SetTransactionMerchant stores the enriched merchant of a card transaction
+func (r *Repository) SetTransactionMerchant(ctx context.Context, params db.SetCardTransactionMerchantParams) error {
+	return r.queries.SetCardTransactionMerchant(ctx, params)
+}
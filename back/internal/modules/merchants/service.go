@@ -0,0 +1,152 @@
+package merchants
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+	"github.com/lauratech/fin/back/internal/shared/merchant"
+
+	"github.com/google/uuid"
+)
+
+// Service handles business logic for merchant enrichment and category overrides
+type Service struct {
+	repo *Repository
+}
+
+// NewService creates a new merchants service
+func NewService(repo *Repository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// ListOverrides returns the user's merchant category overrides
+func (s *Service) ListOverrides(ctx context.Context, userID string) ([]*CategoryOverride, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dbOverrides, err := s.repo.ListOverrides(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := make([]*CategoryOverride, len(dbOverrides))
+	for i := range dbOverrides {
+		overrides[i] = dbOverrideToCategoryOverride(&dbOverrides[i])
+	}
+	return overrides, nil
+}
+
+// SetOverride re-categorizes every transaction of a merchant for the user.
+// Overrides are applied when transactions are read, so they also cover past
+// transactions and are undone by deleting them.
+func (s *Service) SetOverride(ctx context.Context, userID, merchantKey string, req SetCategoryOverrideRequest) (*CategoryOverride, error) {
+	// 1. Validate request
+	if err := ValidateMerchantKey(merchantKey); err != nil {
+		return nil, err
+	}
+	category := strings.ToLower(strings.TrimSpace(req.Category))
+	if err := ValidateCategory(category); err != nil {
+		return nil, err
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. The user must have bought from the merchant
+	transaction, err := s.repo.GetUserTransactionByMerchant(ctx, userUUID, merchantKey)
+	if err != nil {
+		return nil, err
+	}
+	name := transaction.MerchantName
+	if transaction.MerchantDisplayName.Valid && transaction.MerchantDisplayName.String != "" {
+		name = transaction.MerchantDisplayName.String
+	}
+
+	// 3. Store override
+	dbOverride, err := s.repo.UpsertOverride(ctx, db.UpsertMerchantCategoryOverrideParams{
+		UserID:       userUUID,
+		MerchantKey:  merchantKey,
+		MerchantName: name,
+		Category:     category,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbOverrideToCategoryOverride(dbOverride), nil
+}
+
+// DeleteOverride restores the enriched category of a merchant for the user
+func (s *Service) DeleteOverride(ctx context.Context, userID, merchantKey string) error {
+	if err := ValidateMerchantKey(merchantKey); err != nil {
+		return ErrOverrideNotFound
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.DeleteOverride(ctx, userUUID, merchantKey)
+}
+
+// EnrichPendingTransactions enriches card transactions stored before merchant
+// enrichment, one batch at a time, until none is left.
+//
+// Every processed transaction gets a merchant key and a failed one is not
+// retried within the run, so the job always finishes.
+func (s *Service) EnrichPendingTransactions(ctx context.Context) (*EnrichmentResult, error) {
+	result := &EnrichmentResult{}
+	var errs []error
+	failed := make(map[uuid.UUID]bool)
+
+	for {
+		transactions, err := s.repo.ListUnenrichedTransactions(ctx, EnrichmentBatchSize)
+		if err != nil {
+			return result, errors.Join(append(errs, err)...)
+		}
+
+		pending := 0
+		for i := range transactions {
+			transaction := &transactions[i]
+			if failed[transaction.ID] {
+				continue
+			}
+			pending++
+
+			enriched := merchant.Enrich(transaction.MerchantName, EnrichmentSource(transaction.Mcc.String, transaction.MerchantCategory.String))
+			err := s.repo.SetTransactionMerchant(ctx, db.SetCardTransactionMerchantParams{
+				ID:                  transaction.ID,
+				MerchantKey:         sql.NullString{String: enriched.Key, Valid: true},
+				MerchantDisplayName: sql.NullString{String: enriched.Name, Valid: enriched.Name != ""},
+				MerchantLogoKey:     sql.NullString{String: enriched.LogoKey, Valid: enriched.LogoKey != ""},
+				MerchantCity:        sql.NullString{String: enriched.City, Valid: enriched.City != ""},
+				MerchantCategory:    sql.NullString{String: enriched.Category, Valid: true},
+			})
+			if err != nil {
+				failed[transaction.ID] = true
+				result.Failed++
+				errs = append(errs, fmt.Errorf("enrich card transaction %s: %w", transaction.ID, err))
+				continue
+			}
+			result.Enriched++
+		}
+
+		// Stop when the batch is short or only holds transactions that already failed
+		if len(transactions) < EnrichmentBatchSize || pending == 0 {
+			break
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
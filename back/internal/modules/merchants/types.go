@@ -0,0 +1,25 @@
+package merchants
+
+import "time"
+
+// EnrichmentBatchSize is how many card transactions the enrichment job loads at a time
+const EnrichmentBatchSize = 500
+
+// CategoryOverride is the category a user chose for every transaction of a merchant
+type CategoryOverride struct {
+	MerchantKey  string    `json:"merchant_key"`
+	MerchantName string    `json:"merchant_name"`
+	Category     string    `json:"category"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// SetCategoryOverrideRequest represents the request to re-categorize a merchant
+type SetCategoryOverrideRequest struct {
+	Category string `json:"category"`
+}
+
+// EnrichmentResult summarizes an enrichment job run
+type EnrichmentResult struct {
+	Enriched int
+	Failed   int
+}
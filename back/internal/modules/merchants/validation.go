@@ -0,0 +1,34 @@
+package merchants
+
+import (
+	"strings"
+
+	"github.com/lauratech/fin/back/internal/shared/mcc"
+	"github.com/lauratech/fin/back/internal/shared/merchant"
+)
+
+// ValidateCategory validates an override category
+// Overrides share the category list of the MCC mapping so budgets and analytics can match them
+func ValidateCategory(category string) error {
+	if !mcc.IsCategory(category) {
+		return ErrInvalidCategory
+	}
+	return nil
+}
+
+// ValidateMerchantKey validates a merchant key taken from the URL
+func ValidateMerchantKey(key string) error {
+	if strings.TrimSpace(key) == "" || len(key) > merchant.MaxKeyLength {
+		return ErrMerchantNotFound
+	}
+	return nil
+}
+
+// EnrichmentSource returns the merchant category value used to enrich a stored
+// transaction: the MCC when present, otherwise the stored category
+func EnrichmentSource(mccCode, merchantCategory string) string {
+	if mcc.IsMCC(mccCode) {
+		return mccCode
+	}
+	return merchantCategory
+}
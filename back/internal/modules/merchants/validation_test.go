@@ -0,0 +1,63 @@
+package merchants
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lauratech/fin/back/internal/shared/mcc"
+)
+
+// TestValidateCategory tests that overrides only accept spending categories
+func TestValidateCategory(t *testing.T) {
+	tests := []struct {
+		name     string
+		category string
+		expected error
+	}{
+		{"Groceries", mcc.CategoryGroceries, nil},
+		{"Other", mcc.CategoryOther, nil},
+		{"MCC code", "5411", ErrInvalidCategory},
+		{"Unknown", "pets", ErrInvalidCategory},
+		{"Empty", "", ErrInvalidCategory},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateCategory(tt.category); err != tt.expected {
+				t.Errorf("ValidateCategory() error = %v, expected %v", err, tt.expected)
+			}
+		})
+	}
+}
+
+// TestValidateMerchantKey tests merchant key validation
+func TestValidateMerchantKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		expected error
+	}{
+		{"Catalog key", "uber_eats", nil},
+		{"Slug", "padaria-do-ze", nil},
+		{"Blank", "  ", ErrMerchantNotFound},
+		{"Too long", strings.Repeat("a", 101), ErrMerchantNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateMerchantKey(tt.key); err != tt.expected {
+				t.Errorf("ValidateMerchantKey() error = %v, expected %v", err, tt.expected)
+			}
+		})
+	}
+}
+
+// TestEnrichmentSource tests that stored MCCs are preferred over stored categories
+func TestEnrichmentSource(t *testing.T) {
+	if got := EnrichmentSource("5812", "groceries"); got != "5812" {
+		t.Errorf("EnrichmentSource() = %q, want the MCC", got)
+	}
+	if got := EnrichmentSource("", "groceries"); got != "groceries" {
+		t.Errorf("EnrichmentSource() = %q, want the stored category", got)
+	}
+}
@@ -0,0 +1,11 @@
+package notifications
+
+import "errors"
+
+var (
+	// ErrNotificationNotFound is returned when a notification is not found for the user
+	ErrNotificationNotFound = errors.New("notification not found")
+
+	// ErrInvalidNotification is returned when a notification is missing required fields
+	ErrInvalidNotification = errors.New("notification requires user, type, title, message and dedup key")
+)
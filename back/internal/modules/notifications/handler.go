@@ -0,0 +1,108 @@
+package notifications
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/lauratech/fin/back/internal/shared/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Handler handles HTTP requests for notifications
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new notifications handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// ListNotifications handles GET /api/notifications
+func (h *Handler) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	// Parse pagination parameters
+	page := 1
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	limit := 20
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+
+	// List notifications
+	result, total, err := h.service.ListUserNotifications(r.Context(), userID, page, limit)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	// Calculate pagination metadata
+	totalPages := int(total) / limit
+	if int(total)%limit != 0 {
+		totalPages++
+	}
+
+	pagination := response.Pagination{
+		Page:       page,
+		Limit:      limit,
+		Total:      int(total),
+		TotalPages: totalPages,
+		HasMore:    page < totalPages,
+	}
+
+	// Return paginated response
+	response.Paginated(w, http.StatusOK, result, pagination, r.Context())
+}
+
+// MarkRead handles POST /api/notifications/{id}/read
+func (h *Handler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context
+	userID, ok := r.Context().Value("user_id").(string)
+	if !ok {
+		response.Error(w, http.StatusUnauthorized, "AUTH_001", "Unauthorized", nil)
+		return
+	}
+
+	// Get notification ID from URL
+	notificationID := chi.URLParam(r, "id")
+	if notificationID == "" {
+		response.Error(w, http.StatusBadRequest, "VAL_001", "Notification ID is required", nil)
+		return
+	}
+
+	if err := h.service.MarkRead(r.Context(), userID, notificationID); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	// Return success response
+	response.Success(w, http.StatusOK, map[string]string{"message": "Notification marked as read"}, r.Context())
+}
+
+// handleError maps domain errors to HTTP responses
+func (h *Handler) handleError(w http.ResponseWriter, err error) {
+	switch err {
+	case ErrNotificationNotFound:
+		response.Error(w, http.StatusNotFound, "NOTIF_001", "Notification not found", nil)
+	case ErrInvalidNotification:
+		response.Error(w, http.StatusBadRequest, "NOTIF_002", "Invalid notification", nil)
+	default:
+		response.Error(w, http.StatusInternalServerError, "SYS_001", "Internal server error", nil)
+	}
+}
@@ -0,0 +1,33 @@
+package notifications
+
+import (
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+)
+
+// dbNotificationToNotification converts a database notification to domain notification
+func dbNotificationToNotification(dbNotification *db.Notification) *Notification {
+	if dbNotification == nil {
+		return nil
+	}
+
+	notification := &Notification{
+		ID:        dbNotification.ID.String(),
+		Type:      dbNotification.Type,
+		Title:     dbNotification.Title,
+		Message:   dbNotification.Message,
+		CreatedAt: dbNotification.CreatedAt.Time,
+	}
+
+	if dbNotification.ResourceType.Valid {
+		notification.ResourceType = dbNotification.ResourceType.String
+	}
+	if dbNotification.ResourceID.Valid {
+		notification.ResourceID = dbNotification.ResourceID.UUID.String()
+	}
+	if dbNotification.ReadAt.Valid {
+		readAt := dbNotification.ReadAt.Time
+		notification.ReadAt = &readAt
+	}
+
+	return notification
+}
@@ -0,0 +1,72 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+
+	"github.com/google/uuid"
+)
+
+// Repository handles data access for notifications
+type Repository struct {
+	db      *sql.DB
+	queries *db.Queries
+}
+
+// NewRepository creates a new notifications repository
+func NewRepository(database *sql.DB) *Repository {
+	return &Repository{
+		db:      database,
+		queries: db.New(database),
+	}
+}
+
+// Create stores a notification.
+// Returns nil (and no error) if a notification with the same dedup key already exists.
+func (r *Repository) Create(ctx context.Context, params db.CreateNotificationParams) (*db.Notification, error) {
+	notification, err := r.queries.CreateNotification(ctx, params)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &notification, nil
+}
+
+// ListUserNotifications retrieves notifications for a user with pagination
+func (r *Repository) ListUserNotifications(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]db.Notification, error) {
+	return r.queries.ListUserNotifications(ctx, db.ListUserNotificationsParams{
+		UserID: userID,
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+// CountUserNotifications counts all notifications for a user
+func (r *Repository) CountUserNotifications(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return r.queries.CountUserNotifications(ctx, userID)
+}
+
+// CountUnread counts unread notifications for a user
+func (r *Repository) CountUnread(ctx context.Context, userID uuid.UUID) (int64, error) {
+	return r.queries.CountUnreadNotifications(ctx, userID)
+}
+
+// MarkRead marks a user's notification as read
+func (r *Repository) MarkRead(ctx context.Context, id, userID uuid.UUID) error {
+	rows, err := r.queries.MarkNotificationRead(ctx, db.MarkNotificationReadParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
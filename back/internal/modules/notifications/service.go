@@ -0,0 +1,111 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+
+	"github.com/google/uuid"
+)
+
+// Service handles business logic for user notifications
+type Service struct {
+	repo *Repository
+}
+
+// NewService creates a new notifications service
+func NewService(repo *Repository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// Notify raises a notification for a user and reports whether it was created.
+// Raising the same dedup key again is a no-op, so jobs can notify on every run.
+func (s *Service) Notify(ctx context.Context, req NotifyRequest) (bool, error) {
+	// 1. Validate request
+	if err := ValidateNotifyRequest(req); err != nil {
+		return false, err
+	}
+
+	// 2. Parse identifiers
+	userUUID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		return false, err
+	}
+
+	params := db.CreateNotificationParams{
+		UserID:   userUUID,
+		Type:     req.Type,
+		Title:    req.Title,
+		Message:  req.Message,
+		DedupKey: req.DedupKey,
+	}
+	if req.ResourceType != "" {
+		params.ResourceType = sql.NullString{String: req.ResourceType, Valid: true}
+	}
+	if req.ResourceID != "" {
+		resourceUUID, err := uuid.Parse(req.ResourceID)
+		if err != nil {
+			return false, err
+		}
+		params.ResourceID = uuid.NullUUID{UUID: resourceUUID, Valid: true}
+	}
+
+	// 3. Store (deduplicated by key)
+	notification, err := s.repo.Create(ctx, params)
+	if err != nil {
+		return false, err
+	}
+	return notification != nil, nil
+}
+
+// ListUserNotifications retrieves a page of notifications and the unread count
+func (s *Service) ListUserNotifications(ctx context.Context, userID string, page, limit int) (*ListNotificationsResponse, int64, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	dbNotifications, err := s.repo.ListUserNotifications(ctx, userUUID, int32(limit), int32(offset))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.repo.CountUserNotifications(ctx, userUUID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	unread, err := s.repo.CountUnread(ctx, userUUID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	notifications := make([]*Notification, len(dbNotifications))
+	for i := range dbNotifications {
+		notifications[i] = dbNotificationToNotification(&dbNotifications[i])
+	}
+
+	return &ListNotificationsResponse{
+		Notifications: notifications,
+		UnreadCount:   unread,
+	}, total, nil
+}
+
+// MarkRead marks one of the user's notifications as read
+func (s *Service) MarkRead(ctx context.Context, userID, notificationID string) error {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return err
+	}
+
+	notificationUUID, err := uuid.Parse(notificationID)
+	if err != nil {
+		return ErrNotificationNotFound
+	}
+
+	return s.repo.MarkRead(ctx, notificationUUID, userUUID)
+}
@@ -0,0 +1,50 @@
+package notifications
+
+import "time"
+
+// Notification types
+const (
+	TypeCardExpiring = "card_expiring"
+	TypeCardExpired  = "card_expired"
+	TypeCardRenewed  = "card_renewed"
+	TypeCardShipped  = "card_shipped"
+
+	TypeDisputeOpened            = "dispute_opened"
+	TypeDisputeProvisionalCredit = "dispute_provisional_credit"
+	TypeDisputeResolved          = "dispute_resolved"
+
+	TypeBillOverdue         = "bill_overdue"
+	TypeBillScheduledPaid   = "bill_scheduled_paid"
+	TypeBillScheduledFailed = "bill_scheduled_failed"
+	TypeBoletoPaid          = "boleto_paid"
+)
+
+// Notification represents a user notification domain model
+type Notification struct {
+	ID           string     `json:"id"`
+	Type         string     `json:"type"`
+	Title        string     `json:"title"`
+	Message      string     `json:"message"`
+	ResourceType string     `json:"resource_type,omitempty"`
+	ResourceID   string     `json:"resource_id,omitempty"`
+	ReadAt       *time.Time `json:"read_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// NotifyRequest represents a notification to be raised for a user.
+// DedupKey identifies the event: raising the same key twice creates a single notification.
+type NotifyRequest struct {
+	UserID       string
+	Type         string
+	Title        string
+	Message      string
+	ResourceType string
+	ResourceID   string
+	DedupKey     string
+}
+
+// ListNotificationsResponse represents a page of notifications with the unread count
+type ListNotificationsResponse struct {
+	Notifications []*Notification `json:"notifications"`
+	UnreadCount   int64           `json:"unread_count"`
+}
@@ -0,0 +1,16 @@
+package notifications
+
+import "strings"
+
+// ValidateNotifyRequest validates a notification before it is stored
+func ValidateNotifyRequest(req NotifyRequest) error {
+	if strings.TrimSpace(req.UserID) == "" ||
+		strings.TrimSpace(req.Type) == "" ||
+		strings.TrimSpace(req.Title) == "" ||
+		strings.TrimSpace(req.Message) == "" ||
+		strings.TrimSpace(req.DedupKey) == "" {
+		return ErrInvalidNotification
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package transfers
 
 import (
 	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
+	"github.com/lauratech/fin/back/internal/shared/payees"
 )
 
 // dbTransferToTransfer converts a database transfer to domain transfer
@@ -50,6 +51,9 @@ func dbTransferToTransfer(dbTransfer *db.Transfer) *Transfer {
 	if dbTransfer.RecipientBank.Valid {
 		bank := dbTransfer.RecipientBank.String
 		transfer.RecipientBank = &bank
+		if payee, ok := payees.LookupBank(bank); ok {
+			transfer.RecipientBankName = &payee.Name
+		}
 	}
 	if dbTransfer.RecipientBranch.Valid {
 		branch := dbTransfer.RecipientBranch.String
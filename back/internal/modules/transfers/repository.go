@@ -3,6 +3,7 @@ package transfers
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 	db "github.com/lauratech/fin/back/internal/shared/database/sqlc"
@@ -166,3 +167,11 @@ func (r *Repository) ListByUserAndStatus(ctx context.Context, userID uuid.UUID,
 		Status: status,
 	})
 }
+
+// ListDueScheduledTEDs lists TEDs whose settlement date is up to now
+func (r *Repository) ListDueScheduledTEDs(ctx context.Context, now time.Time, limit int32) ([]db.Transfer, error) {
+	return r.queries.ListDueScheduledTEDs(ctx, db.ListDueScheduledTEDsParams{
+		ScheduledFor: sql.NullTime{Time: now, Valid: true},
+		Limit:        limit,
+	})
+}
@@ -127,7 +127,8 @@ func (s *Service) ExecutePIX(ctx context.Context, userID string, req CreatePIXRe
 	return dbTransferToTransfer(transfer), nil
 }
 
-// ExecuteTED executes a TED transfer with R$ 10.00 fee
+// ExecuteTED executes a TED transfer with R$ 10.00 fee. TEDs requested outside
+// the TED window are debited at once and stay processing until they settle.
 func (s *Service) ExecuteTED(ctx context.Context, userID string, req CreateTEDRequest) (*Transfer, error) {
 	// Validate TED data
 	if err := ValidateTEDData(req); err != nil {
@@ -136,6 +137,18 @@ func (s *Service) ExecuteTED(ctx context.Context, userID string, req CreateTEDRe
 
 	totalAmount := req.AmountCents + TEDFeeCents
 
+	// Outside the TED window the transfer settles on the next business day
+	now := time.Now()
+	settleAt, immediate := TEDSettlementTime(now)
+	status := "completed"
+	completedAt := sql.NullTime{Time: now, Valid: true}
+	var scheduledFor sql.NullTime
+	if !immediate {
+		status = "processing"
+		completedAt = sql.NullTime{}
+		scheduledFor = sql.NullTime{Time: settleAt, Valid: true}
+	}
+
 	// Execute transfer in transaction
 	var transfer *db.Transfer
 	err := s.executeInTransaction(ctx, func(tx *sql.Tx) error {
@@ -196,7 +209,7 @@ func (s *Service) ExecuteTED(ctx context.Context, userID string, req CreateTEDRe
 		dbTransfer, err := qtx.CreateTransfer(ctx, db.CreateTransferParams{
 			UserID:               userUUID,
 			Type:                 "ted",
-			Status:               "completed",
+			Status:               status,
 			AmountCents:          req.AmountCents,
 			FeeCents:             sql.NullInt64{Int64: TEDFeeCents, Valid: true},
 			Currency:             sql.NullString{String: "BRL", Valid: true},
@@ -206,7 +219,8 @@ func (s *Service) ExecuteTED(ctx context.Context, userID string, req CreateTEDRe
 			RecipientBranch:      sql.NullString{String: req.RecipientBranch, Valid: true},
 			RecipientAccount:     sql.NullString{String: req.RecipientAccount, Valid: true},
 			RecipientAccountType: sql.NullString{String: req.RecipientAccountType, Valid: true},
-			CompletedAt:          sql.NullTime{Time: time.Now(), Valid: true},
+			ScheduledFor:         scheduledFor,
+			CompletedAt:          completedAt,
 		})
 		if err != nil {
 			return err
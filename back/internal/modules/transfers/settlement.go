@@ -0,0 +1,69 @@
+package transfers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lauratech/fin/back/internal/shared/calendar"
+)
+
+// TED window (Brasília time): TEDs settle on business days between the
+// opening and the cutoff. TEDs requested outside the window are debited at
+// once and settle at the opening of the next business day.
+const (
+	TEDWindowOpening = 6*time.Hour + 30*time.Minute
+	TEDWindowCutoff  = 17 * time.Hour
+
+	// SettlementBatchSize is how many scheduled TEDs a settlement run completes at most
+	SettlementBatchSize = 500
+)
+
+// TEDSettlementTime returns when a TED requested at now settles, and whether
+// it settles immediately (inside the TED window)
+func TEDSettlementTime(now time.Time) (time.Time, bool) {
+	local := now.In(calendar.Location)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, calendar.Location)
+	sinceMidnight := local.Sub(day)
+
+	if calendar.IsBusinessDay(day) && sinceMidnight >= TEDWindowOpening && sinceMidnight < TEDWindowCutoff {
+		return now, true
+	}
+
+	// Before the opening the TED settles the same day; otherwise on the next business day
+	if !calendar.IsBusinessDay(day) || sinceMidnight >= TEDWindowCutoff {
+		day = calendar.AddBusinessDays(day, 1)
+	}
+	return day.Add(TEDWindowOpening), false
+}
+
+// SettlementResult summarizes a scheduled TED settlement run
+type SettlementResult struct {
+	Settled int `json:"settled"`
+	Failed  int `json:"failed"`
+}
+
+// SettleScheduledTEDs completes the TEDs whose settlement time has come.
+func (s *Service) SettleScheduledTEDs(ctx context.Context, now time.Time) (*SettlementResult, error) {
+	result := &SettlementResult{}
+	var errs []error
+
+	// 1. Load TEDs due for settlement
+	transfers, err := s.repo.ListDueScheduledTEDs(ctx, now, SettlementBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Complete each one (the amount was debited when the TED was requested)
+	for _, transfer := range transfers {
+		if _, err := s.repo.UpdateStatus(ctx, transfer.ID.String(), "completed", nil); err != nil {
+			result.Failed++
+			errs = append(errs, fmt.Errorf("settle TED %s: %w", transfer.ID, err))
+			continue
+		}
+		result.Settled++
+	}
+
+	return result, errors.Join(errs...)
+}
@@ -0,0 +1,42 @@
+package transfers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lauratech/fin/back/internal/shared/calendar"
+)
+
+// TestTEDSettlementTime tests the TED window over business days, weekends and holidays
+func TestTEDSettlementTime(t *testing.T) {
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, calendar.Location)
+	}
+
+	tests := []struct {
+		name          string
+		now           time.Time
+		want          time.Time
+		wantImmediate bool
+	}{
+		{"Inside the window", at(3, 10, 10, 0), at(3, 10, 10, 0), true},
+		{"At the opening", at(3, 10, 6, 30), at(3, 10, 6, 30), true},
+		{"Before the opening", at(3, 10, 5, 0), at(3, 10, 6, 30), false},
+		{"At the cutoff", at(3, 10, 17, 0), at(3, 11, 6, 30), false},
+		{"Friday after the cutoff", at(3, 13, 18, 0), at(3, 16, 6, 30), false},
+		{"Saturday", at(3, 14, 10, 0), at(3, 16, 6, 30), false},
+		{"Good Friday", at(4, 3, 10, 0), at(4, 6, 6, 30), false},
+		{"Before Carnaval", at(2, 13, 20, 0), at(2, 18, 6, 30), false},
+		// 14:00 UTC is 11:00 in Brasília
+		{"UTC instant", time.Date(2026, 3, 10, 14, 0, 0, 0, time.UTC), time.Date(2026, 3, 10, 14, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, immediate := TEDSettlementTime(tt.now)
+			if !got.Equal(tt.want) || immediate != tt.wantImmediate {
+				t.Errorf("TEDSettlementTime(%v) = %v, %v, want %v, %v", tt.now, got, immediate, tt.want, tt.wantImmediate)
+			}
+		})
+	}
+}
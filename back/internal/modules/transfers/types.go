@@ -16,6 +16,7 @@ type Transfer struct {
 	RecipientName        *string    `json:"recipient_name,omitempty"`
 	RecipientDocument    *string    `json:"recipient_document,omitempty"`
 	RecipientBank        *string    `json:"recipient_bank,omitempty"`
+	RecipientBankName    *string    `json:"recipient_bank_name,omitempty"` // From the payee registry
 	RecipientBranch      *string    `json:"recipient_branch,omitempty"`
 	RecipientAccount     *string    `json:"recipient_account,omitempty"`
 	RecipientAccountType *string    `json:"recipient_account_type,omitempty"`
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/lauratech/fin/back/internal/shared/payees"
 )
 
 // ValidateAmount validates that the transfer amount is positive
@@ -230,14 +231,13 @@ func ValidateTEDData(req CreateTEDRequest) error {
 		}
 	}
 
-	// Validate bank code (3 digits)
-	matched, _ := regexp.MatchString(`^\d{3}$`, req.RecipientBank)
-	if !matched {
+	// Validate bank code (COMPE code of a bank in the payee registry)
+	if _, ok := payees.LookupBank(req.RecipientBank); !ok {
 		return ErrInvalidBankData
 	}
 
 	// Validate branch (4-5 digits, may include check digit)
-	matched, _ = regexp.MatchString(`^\d{4,5}$`, regexp.MustCompile(`\D`).ReplaceAllString(req.RecipientBranch, ""))
+	matched, _ := regexp.MatchString(`^\d{4,5}$`, regexp.MustCompile(`\D`).ReplaceAllString(req.RecipientBranch, ""))
 	if !matched {
 		return ErrInvalidBankData
 	}
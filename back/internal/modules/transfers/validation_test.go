@@ -0,0 +1,36 @@
+package transfers
+
+import "testing"
+
+// TestValidateTEDDataBank tests that the recipient bank must be in the payee registry
+func TestValidateTEDDataBank(t *testing.T) {
+	tests := []struct {
+		name     string
+		bank     string
+		expected error
+	}{
+		{"Registered bank", "341", nil},
+		{"Registered payment institution", "260", nil},
+		{"Unknown code", "999", ErrInvalidBankData},
+		{"Closed bank", "356", ErrInvalidBankData},
+		{"Short code", "34", ErrInvalidBankData},
+		{"Empty code", "", ErrInvalidBankData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := CreateTEDRequest{
+				RecipientName:        "Maria Silva",
+				RecipientDocument:    "52998224725",
+				RecipientBank:        tt.bank,
+				RecipientBranch:      "0001",
+				RecipientAccount:     "12345-6",
+				RecipientAccountType: "checking",
+				AmountCents:          10000,
+			}
+			if err := ValidateTEDData(req); err != tt.expected {
+				t.Errorf("ValidateTEDData() error = %v, expected %v", err, tt.expected)
+			}
+		})
+	}
+}
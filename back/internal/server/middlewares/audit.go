@@ -96,6 +96,7 @@ func (al *AuditLogger) AuditMiddleware() func(http.Handler) http.Handler {
 					// Try to parse as JSON
 					if len(bodyBytes) > 0 {
 						json.Unmarshal(bodyBytes, &requestBody)
+						redactSensitiveFields(requestBody)
 					}
 				}
 			}
@@ -191,6 +192,24 @@ func (al *AuditLogger) logAudit(r *http.Request, arw *auditResponseWriter, reque
 	}
 }
 
+// sensitiveFields lists request body keys that must never reach audit_logs
+var sensitiveFields = map[string]bool{
+	"pin":         true,
+	"current_pin": true,
+	"cvv":         true,
+	"card_number": true,
+	"token":       true,
+}
+
+// redactSensitiveFields masks secrets in a decoded request body (top level only)
+func redactSensitiveFields(body map[string]interface{}) {
+	for key := range body {
+		if sensitiveFields[key] {
+			body[key] = "[REDACTED]"
+		}
+	}
+}
+
 // isMutationMethod checks if HTTP method is a mutation
 func isMutationMethod(method string) bool {
 	return method == http.MethodPost ||
@@ -0,0 +1,35 @@
+package middlewares
+
+import "testing"
+
+func TestRedactSensitiveFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		value    interface{}
+		expected interface{}
+	}{
+		{name: "redacts pin", key: "pin", value: "1234", expected: "[REDACTED]"},
+		{name: "redacts current pin", key: "current_pin", value: "4321", expected: "[REDACTED]"},
+		{name: "redacts cvv", key: "cvv", value: "123", expected: "[REDACTED]"},
+		{name: "redacts card number", key: "card_number", value: "4111111111111111", expected: "[REDACTED]"},
+		{name: "redacts reveal token", key: "token", value: "abc", expected: "[REDACTED]"},
+		{name: "keeps other fields", key: "holder_name", value: "Maria Silva", expected: "Maria Silva"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := map[string]interface{}{tt.key: tt.value}
+			redactSensitiveFields(body)
+
+			if body[tt.key] != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, body[tt.key])
+			}
+		})
+	}
+}
+
+func TestRedactSensitiveFields_NilBody(t *testing.T) {
+	// Must not panic on bodies that failed to decode
+	redactSensitiveFields(nil)
+}
@@ -0,0 +1,46 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+const (
+	// HeaderInternalServiceToken carries the shared secret of internal service-to-service calls
+	HeaderInternalServiceToken = "X-Internal-Service-Token"
+
+	// HeaderInternalServiceName identifies the calling service (recorded in audit logs)
+	HeaderInternalServiceName = "X-Internal-Service-Name"
+)
+
+// InternalAuth restricts routes to internal callers holding the service token
+// Security: These routes are never exposed through APISIX; an empty token disables them
+func InternalAuth(serviceToken string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if serviceToken == "" {
+				w.Header().Set("Content-Type", "application/json")
+				http.Error(w, `{"error":{"code":"AUTH_005","message":"Forbidden: Internal API disabled"}}`, http.StatusForbidden)
+				return
+			}
+
+			token := strings.TrimSpace(r.Header.Get(HeaderInternalServiceToken))
+			if subtle.ConstantTimeCompare([]byte(token), []byte(serviceToken)) != 1 {
+				w.Header().Set("Content-Type", "application/json")
+				http.Error(w, `{"error":{"code":"AUTH_004","message":"Unauthorized: Invalid internal service token"}}`, http.StatusUnauthorized)
+				return
+			}
+
+			service := strings.TrimSpace(r.Header.Get(HeaderInternalServiceName))
+			if service == "" {
+				service = "unknown"
+			}
+
+			// Store calling service in context for downstream handlers
+			ctx := context.WithValue(r.Context(), "internal_service", service)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
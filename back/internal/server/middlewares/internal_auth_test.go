@@ -0,0 +1,54 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInternalAuth(t *testing.T) {
+	const secret = "internal-service-token-0123456789"
+
+	tests := []struct {
+		name           string
+		configured     string
+		token          string
+		service        string
+		expectedStatus int
+		expectedCaller string
+	}{
+		{name: "allows valid token", configured: secret, token: secret, service: "authorizer", expectedStatus: http.StatusOK, expectedCaller: "authorizer"},
+		{name: "defaults caller name", configured: secret, token: secret, expectedStatus: http.StatusOK, expectedCaller: "unknown"},
+		{name: "rejects missing token", configured: secret, expectedStatus: http.StatusUnauthorized},
+		{name: "rejects wrong token", configured: secret, token: "wrong-token", expectedStatus: http.StatusUnauthorized},
+		{name: "rejects everything when disabled", configured: "", token: "", expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var caller string
+			handler := InternalAuth(tt.configured)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				caller, _ = r.Context().Value("internal_service").(string)
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/internal/cards/detokenize", nil)
+			if tt.token != "" {
+				req.Header.Set(HeaderInternalServiceToken, tt.token)
+			}
+			if tt.service != "" {
+				req.Header.Set(HeaderInternalServiceName, tt.service)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+			if caller != tt.expectedCaller {
+				t.Errorf("expected caller %q, got %q", tt.expectedCaller, caller)
+			}
+		})
+	}
+}
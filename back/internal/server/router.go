@@ -38,6 +38,40 @@ func (s *Server) setupRouter() *chi.Mux {
 
 	r.Get("/health", s.handleHealth)
 
+	// ========================================
+	// Internal Routes (Service-to-Service)
+	// ========================================
+
+	r.Group(func(r chi.Router) {
+		// Internal service token (never routed through APISIX)
+		r.Use(middlewares.InternalAuth(s.Config.InternalAPIToken))
+
+		r.Route("/internal/cards", func(r chi.Router) {
+			r.Post("/tokenize", s.cardsHandler.TokenizePAN)                       // PAN -> card + token
+			r.Post("/detokenize", s.cardsHandler.Detokenize)                      // token -> PAN (audited)
+			r.Post("/shipments/{id}/shipped", s.cardsHandler.MarkShipped)         // carrier + tracking code
+			r.Post("/shipments/{id}/delivered", s.cardsHandler.MarkDelivered)     // delivery confirmation
+			r.Get("/{id}/fraud-decisions", s.cardsHandler.ListCardFraudDecisions) // fraud decision log
+		})
+
+		r.Route("/internal/fraud", func(r chi.Router) {
+			r.Get("/rules", s.cardsHandler.ListFraudRules)             // rules + thresholds
+			r.Patch("/rules/{code}", s.cardsHandler.UpdateFraudRule)   // enable/disable, score, params
+			r.Put("/thresholds", s.cardsHandler.UpdateFraudThresholds) // step-up and decline scores
+		})
+
+		r.Route("/internal/bills", func(r chi.Router) {
+			r.Get("/charge-rules", s.billsHandler.ListChargeRules)                            // payee fine/interest/discount rates
+			r.Put("/charge-rules/{payee_type}/{payee_code}", s.billsHandler.UpsertChargeRule) // bank code or segment + company ID
+			r.Put("/registry/{barcode}", s.billsHandler.RegisterBoletoCharges)                // CIP/NPC registry stand-in
+		})
+
+		r.Route("/internal/disputes", func(r chi.Router) {
+			r.Post("/{id}/provisional-credit", s.disputesHandler.GrantProvisionalCredit) // credit during investigation
+			r.Post("/{id}/resolve", s.disputesHandler.ResolveDispute)                    // chargeback outcome (won/lost)
+		})
+	})
+
 	// ========================================
 	// Protected Routes (Require Auth)
 	// ========================================
@@ -90,7 +124,14 @@ func (s *Server) setupRouter() *chi.Mux {
 				r.With(middlewares.RateLimitMiddleware(20, time.Hour)).Post("/{id}/unblock", s.cardsHandler.UnblockCard)              // 20/hour
 				r.With(middlewares.RateLimitMiddleware(20, time.Hour)).Patch("/{id}/limits", s.cardsHandler.UpdateLimits)             // 20/hour
 				r.With(middlewares.RateLimitMiddleware(20, time.Hour)).Patch("/{id}/security", s.cardsHandler.UpdateSecuritySettings) // 20/hour
+				r.Get("/{id}/categories", s.cardsHandler.GetCategoryControls)                                                         // Category rules
+				r.With(middlewares.RateLimitMiddleware(20, time.Hour)).Put("/{id}/categories", s.cardsHandler.UpdateCategoryControls) // 20/hour
 				r.With(middlewares.RateLimitMiddleware(3, time.Hour)).Post("/{id}/pin", s.cardsHandler.SetPIN)                        // 3/hour - very sensitive
+				r.With(middlewares.RateLimitMiddleware(5, time.Hour)).Post("/{id}/reveal", s.cardsHandler.RequestReveal)              // 5/hour - PIN step-up
+				r.With(middlewares.RateLimitMiddleware(5, time.Hour)).Post("/{id}/reveal/consume", s.cardsHandler.ConsumeReveal)      // 5/hour - single-use token
+				r.With(middlewares.RateLimitMiddleware(5, time.Hour)).Post("/{id}/replace", s.cardsHandler.ReplaceCard)               // 5/hour - issues a new PAN
+				r.Get("/{id}/shipment", s.cardsHandler.GetShipment)                                                                   // Physical card delivery tracking
+				r.With(middlewares.RateLimitMiddleware(5, time.Hour)).Post("/{id}/activate", s.cardsHandler.ActivateCard)             // 5/hour - last four + CVV
 				r.With(middlewares.RateLimitMiddleware(5, time.Hour)).Delete("/{id}", s.cardsHandler.CancelCard)                      // 5/hour
 			})
 
@@ -100,14 +141,37 @@ func (s *Server) setupRouter() *chi.Mux {
 				r.Post("/export", s.cardsHandler.ExportTransactions)
 			})
 
+			// Merchants (user category overrides)
+			r.Route("/merchants", func(r chi.Router) {
+				r.Get("/overrides", s.merchantsHandler.ListOverrides)
+				r.With(middlewares.RateLimitMiddleware(50, time.Hour)).Put("/overrides/{key}", s.merchantsHandler.SetOverride) // 50/hour
+				r.Delete("/overrides/{key}", s.merchantsHandler.DeleteOverride)
+			})
+
+			// Disputes (card transaction chargebacks)
+			r.Route("/disputes", func(r chi.Router) {
+				r.With(middlewares.RateLimitMiddleware(10, time.Hour)).Post("/", s.disputesHandler.OpenDispute) // 10/hour
+				r.Get("/", s.disputesHandler.ListDisputes)
+				r.Get("/{id}", s.disputesHandler.GetDispute)
+			})
+
 			// Bills
 			r.Route("/bills", func(r chi.Router) {
 				r.With(middlewares.RateLimitMiddleware(20, time.Hour)).Post("/validate", s.billsHandler.ValidateBarcode) // 20/hour
+				r.With(middlewares.RateLimitMiddleware(20, time.Hour)).Post("/parse", s.billsHandler.ParseBill)          // 20/hour
 				r.Post("/", s.billsHandler.CreateBill)
 				r.Get("/", s.billsHandler.ListBills)
+				r.Get("/stats", s.billsHandler.GetStats)
+				r.Post("/issued", s.billsHandler.IssueBoleto) // boletos the user issues to charge others
+				r.Get("/issued", s.billsHandler.ListIssuedBoletos)
+				r.Get("/issued/{id}", s.billsHandler.GetIssuedBoleto)
+				r.Get("/issued/{id}/pdf", s.billsHandler.GetIssuedBoletoPDF)
+				r.Delete("/issued/{id}", s.billsHandler.CancelIssuedBoleto)
 				r.Get("/{id}", s.billsHandler.GetBill)
 				r.With(middlewares.RateLimitMiddleware(10, time.Hour)).Post("/{id}/pay", s.billsHandler.PayBill) // 10/hour
 				r.Delete("/{id}", s.billsHandler.CancelBill)
+				r.Put("/{id}/schedule", s.billsHandler.ScheduleBill)
+				r.Delete("/{id}/schedule", s.billsHandler.UnscheduleBill)
 			})
 
 			// Budgets
@@ -135,6 +199,12 @@ func (s *Server) setupRouter() *chi.Mux {
 				r.Post("/tickets/{id}/messages", s.supportHandler.AddMessage)
 				r.Patch("/tickets/{id}/status", s.supportHandler.UpdateTicketStatus)
 			})
+
+			// Notifications
+			r.Route("/notifications", func(r chi.Router) {
+				r.Get("/", s.notificationsHandler.ListNotifications)
+				r.Post("/{id}/read", s.notificationsHandler.MarkRead)
+			})
 		})
 	})
 
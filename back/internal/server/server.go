@@ -1,48 +1,83 @@
 package server
 
 import (
+	"context"
 	"database/sql"
+	"log"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/lauratech/fin/back/internal/config"
 	"github.com/lauratech/fin/back/internal/modules/bills"
 	"github.com/lauratech/fin/back/internal/modules/budgets"
 	"github.com/lauratech/fin/back/internal/modules/cards"
+	"github.com/lauratech/fin/back/internal/modules/disputes"
+	"github.com/lauratech/fin/back/internal/modules/merchants"
+	"github.com/lauratech/fin/back/internal/modules/notifications"
 	"github.com/lauratech/fin/back/internal/modules/support"
 	"github.com/lauratech/fin/back/internal/modules/transfers"
 	"github.com/lauratech/fin/back/internal/modules/users"
+	"github.com/lauratech/fin/back/internal/shared/crypto"
+	"github.com/lauratech/fin/back/internal/shared/dda"
+	"github.com/lauratech/fin/back/internal/shared/jobs"
 )
 
 // Server holds dependencies for HTTP server
 type Server struct {
-	Config           *config.Config
-	DB               *sql.DB
-	router           *chi.Mux
-	usersHandler     *users.Handler
-	transfersHandler *transfers.Handler
-	cardsHandler     *cards.Handler
-	billsHandler     *bills.Handler
-	budgetsHandler   *budgets.Handler
-	supportHandler   *support.Handler
+	Config               *config.Config
+	DB                   *sql.DB
+	router               *chi.Mux
+	usersHandler         *users.Handler
+	transfersHandler     *transfers.Handler
+	cardsHandler         *cards.Handler
+	billsHandler         *bills.Handler
+	budgetsHandler       *budgets.Handler
+	supportHandler       *support.Handler
+	notificationsHandler *notifications.Handler
+	disputesHandler      *disputes.Handler
+	merchantsHandler     *merchants.Handler
+	jobs                 []jobs.Job
 }
 
 // New creates a new server instance
-func New(cfg *config.Config, db *sql.DB) *Server {
+func New(cfg *config.Config, db *sql.DB, keyring *crypto.Keyring) *Server {
 	// Initialize repositories
 	usersRepo := users.NewRepository(db)
 	transfersRepo := transfers.NewRepository(db)
-	cardsRepo := cards.NewRepository(db, cfg.EncryptionKey)
+	cardsRepo := cards.NewRepository(db, keyring)
 	billsRepo := bills.NewRepository(db)
 	budgetsRepo := budgets.NewRepository(db)
 	supportRepo := support.NewRepository(db)
+	notificationsRepo := notifications.NewRepository(db)
+	disputesRepo := disputes.NewRepository(db)
+	merchantsRepo := merchants.NewRepository(db)
 
 	// Initialize services
+	notificationsService := notifications.NewService(notificationsRepo)
 	usersService := users.NewService(usersRepo)
 	transfersService := transfers.NewService(transfersRepo, usersRepo, db)
-	cardsService := cards.NewService(cardsRepo, db)
-	billsService := bills.NewService(billsRepo, db)
+	cardsService := cards.NewService(cardsRepo, db, cards.Config{
+		DynamicCVVWindow:       cfg.DynamicCVVWindow,
+		DynamicCVVDriftWindows: cfg.DynamicCVVDriftWindows,
+		CVVKey:                 []byte(cfg.CardCVVKey),
+		PANFingerprintKey:      []byte(cfg.PANFingerprintKey),
+		RenewalLeadDays:        cfg.CardRenewalLeadDays,
+		ExpiryNoticeDays:       cfg.CardExpiryNoticeDays,
+		RenewSamePAN:           cfg.CardRenewalSamePAN,
+	}, notificationsService)
+	var ddaProvider dda.Provider
+	var ddaSyncInterval time.Duration // Without a provider the DDA sync is disabled
+	if cfg.DDAProviderDir != "" {
+		ddaProvider = dda.NewFileProvider(cfg.DDAProviderDir)
+		ddaSyncInterval = cfg.DDASyncInterval
+	}
+	billsService := bills.NewService(billsRepo, db, bills.Config{
+		BoletoBankCode: cfg.BoletoBankCode,
+	}, notificationsService, ddaProvider)
 	budgetsService := budgets.NewService(budgetsRepo, db)
 	supportService := support.NewService(supportRepo, db)
+	disputesService := disputes.NewService(disputesRepo, db, supportService, notificationsService)
+	merchantsService := merchants.NewService(merchantsRepo)
 
 	// Initialize handlers
 	usersHandler := users.NewHandler(usersService)
@@ -51,16 +86,106 @@ func New(cfg *config.Config, db *sql.DB) *Server {
 	billsHandler := bills.NewHandler(billsService)
 	budgetsHandler := budgets.NewHandler(budgetsService)
 	supportHandler := support.NewHandler(supportService)
+	notificationsHandler := notifications.NewHandler(notificationsService)
+	disputesHandler := disputes.NewHandler(disputesService)
+	merchantsHandler := merchants.NewHandler(merchantsService)
 
 	s := &Server{
-		Config:           cfg,
-		DB:               db,
-		usersHandler:     usersHandler,
-		transfersHandler: transfersHandler,
-		cardsHandler:     cardsHandler,
-		billsHandler:     billsHandler,
-		budgetsHandler:   budgetsHandler,
-		supportHandler:   supportHandler,
+		Config:               cfg,
+		DB:                   db,
+		usersHandler:         usersHandler,
+		transfersHandler:     transfersHandler,
+		cardsHandler:         cardsHandler,
+		billsHandler:         billsHandler,
+		budgetsHandler:       budgetsHandler,
+		supportHandler:       supportHandler,
+		notificationsHandler: notificationsHandler,
+		disputesHandler:      disputesHandler,
+		merchantsHandler:     merchantsHandler,
+		jobs: []jobs.Job{
+			{
+				Name:     "card_expiry_lifecycle",
+				Interval: cfg.CardLifecycleInterval,
+				Run: func(ctx context.Context) error {
+					result, err := cardsService.RunExpiryLifecycle(ctx, time.Now())
+					if result != nil {
+						log.Printf("Card lifecycle: %d expired, %d renewed, %d notified, %d failed",
+							result.Expired, result.Renewed, result.Notified, result.Failed)
+					}
+					return err
+				},
+			},
+			{
+				Name:     "card_dispute_deadlines",
+				Interval: cfg.DisputeDeadlinesInterval,
+				Run: func(ctx context.Context) error {
+					result, err := disputesService.RunDeadlines(ctx, time.Now())
+					if result != nil {
+						log.Printf("Dispute deadlines: %d tickets linked, %d provisionally credited, %d resolved, %d reversals collected, %d failed",
+							result.TicketsLinked, result.ProvisionalCredited, result.Resolved, result.ReversalsCollected, result.Failed)
+					}
+					return err
+				},
+			},
+			{
+				Name:     "merchant_enrichment",
+				Interval: cfg.MerchantEnrichmentInterval,
+				Run: func(ctx context.Context) error {
+					result, err := merchantsService.EnrichPendingTransactions(ctx)
+					if result != nil {
+						log.Printf("Merchant enrichment: %d enriched, %d failed", result.Enriched, result.Failed)
+					}
+					return err
+				},
+			},
+			{
+				Name:     "bill_overdue_sweep",
+				Interval: cfg.BillOverdueInterval,
+				Run: func(ctx context.Context) error {
+					result, err := billsService.RunOverdueSweep(ctx, time.Now())
+					if result != nil {
+						log.Printf("Bill overdue sweep: %d marked overdue, %d recalculated, %d notified, %d failed",
+							result.MarkedOverdue, result.Recalculated, result.Notified, result.Failed)
+					}
+					return err
+				},
+			},
+			{
+				Name:     "bill_scheduled_payments",
+				Interval: cfg.BillScheduledPaymentsInterval,
+				Run: func(ctx context.Context) error {
+					result, err := billsService.RunScheduledPayments(ctx, time.Now())
+					if result != nil {
+						log.Printf("Scheduled bill payments: %d paid, %d retried, %d gave up, %d failed",
+							result.Paid, result.Retried, result.GaveUp, result.Failed)
+					}
+					return err
+				},
+			},
+			{
+				Name:     "dda_sync",
+				Interval: ddaSyncInterval,
+				Run: func(ctx context.Context) error {
+					result, err := billsService.RunDDASync(ctx, time.Now())
+					if result != nil {
+						log.Printf("DDA sync: %d created, %d duplicates, %d invalid, %d failed",
+							result.Created, result.Duplicates, result.Invalid, result.Failed)
+					}
+					return err
+				},
+			},
+			{
+				Name:     "ted_settlement",
+				Interval: cfg.TEDSettlementInterval,
+				Run: func(ctx context.Context) error {
+					result, err := transfersService.SettleScheduledTEDs(ctx, time.Now())
+					if result != nil {
+						log.Printf("TED settlement: %d settled, %d failed", result.Settled, result.Failed)
+					}
+					return err
+				},
+			},
+		},
 	}
 
 	s.router = s.setupRouter()
@@ -68,6 +193,11 @@ func New(cfg *config.Config, db *sql.DB) *Server {
 	return s
 }
 
+// Jobs returns the background jobs to be run by the scheduler
+func (s *Server) Jobs() []jobs.Job {
+	return s.jobs
+}
+
 // Router returns the configured Chi router
 func (s *Server) Router() *chi.Mux {
 	return s.router
@@ -0,0 +1,258 @@
+// Package barcode encodes and decodes Interleaved 2 of 5 (ITF), the symbology of boleto and
+// arrecadação barcodes (FEBRABAN: 44 digits, wide elements 2 to 3 times the
+// narrow ones).
+//
+// Digits are encoded in pairs: the bars carry the first digit and the spaces
+// between them the second, five elements each, two of them wide. The symbol
+// starts with narrow bar, space, bar, space and ends with wide bar, narrow
+// space, narrow bar.
+package barcode
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"sort"
+)
+
+var (
+	// ErrNotFound is returned when no ITF barcode is found
+	ErrNotFound = errors.New("barcode: no ITF barcode found")
+
+	// ErrInvalidDigits is returned when encoding an odd number of digits or non-digits
+	ErrInvalidDigits = errors.New("barcode: ITF encodes an even number of digits")
+)
+
+// digitPatterns are the wide (true) elements of each digit
+var digitPatterns = [10][5]bool{
+	{false, false, true, true, false}, // 0: nnwwn
+	{true, false, false, false, true}, // 1: wnnnw
+	{false, true, false, false, true}, // 2: nwnnw
+	{true, true, false, false, false}, // 3: wwnnn
+	{false, false, true, false, true}, // 4: nnwnw
+	{true, false, true, false, false}, // 5: wnwnn
+	{false, true, true, false, false}, // 6: nwwnn
+	{false, false, false, true, true}, // 7: nnnww
+	{true, false, false, true, false}, // 8: wnnwn
+	{false, true, false, true, false}, // 9: nwnwn
+}
+
+const (
+	// minWideRatio is how much wider than the widest narrow element a wide one must be
+	minWideRatio = 1.4
+
+	// quietZoneRatio is the minimum light margin after the stop pattern, in narrow widths
+	quietZoneRatio = 5
+
+	// minContrast is the minimum luminance range (0-255) of a scanned line
+	minContrast = 64
+
+	// scanLines is about how many rows and columns ScanImage reads
+	scanLines = 64
+)
+
+// Encode returns the run widths of the ITF symbol of digits, alternating bar
+// and space and starting with a bar, in narrow widths (wide elements are wide
+// times the narrow ones)
+func Encode(digits string, wide int) ([]int, error) {
+	if len(digits) == 0 || len(digits)%2 != 0 {
+		return nil, ErrInvalidDigits
+	}
+
+	runs := make([]int, 0, 4+5*len(digits)+3)
+	runs = append(runs, 1, 1, 1, 1)
+	for i := 0; i < len(digits); i += 2 {
+		if digits[i] < '0' || digits[i] > '9' || digits[i+1] < '0' || digits[i+1] > '9' {
+			return nil, ErrInvalidDigits
+		}
+		bars, spaces := digitPatterns[digits[i]-'0'], digitPatterns[digits[i+1]-'0']
+		for j := 0; j < 5; j++ {
+			runs = append(runs, width(bars[j], wide), width(spaces[j], wide))
+		}
+	}
+	return append(runs, wide, 1, 1), nil
+}
+
+// width returns the width of an element
+func width(isWide bool, wide int) int {
+	if isWide {
+		return wide
+	}
+	return 1
+}
+
+// DecodeRuns decodes an ITF symbol from run widths alternating bar and space,
+// starting with a bar. The symbol may start at any bar of runs and must end
+// with the stop pattern followed by a quiet zone (or the end of runs).
+// Returns the longest symbol found.
+func DecodeRuns(runs []float64) (string, error) {
+	best := ""
+	for start := 0; start+7 <= len(runs); start += 2 {
+		if digits, ok := decodeAt(runs, start); ok && len(digits) > len(best) {
+			best = digits
+		}
+	}
+	if best == "" {
+		return "", ErrNotFound
+	}
+	return best, nil
+}
+
+// decodeAt decodes a symbol whose start pattern begins at runs[start]
+func decodeAt(runs []float64, start int) (string, bool) {
+	// 1. Start pattern: four narrow elements of about the same width
+	narrow := (runs[start] + runs[start+1] + runs[start+2] + runs[start+3]) / 4
+	for _, w := range runs[start : start+4] {
+		if w < narrow/2 || w > narrow*1.5 {
+			return "", false
+		}
+	}
+	// The start pattern needs a light margin before it
+	if start > 0 && runs[start-1] < narrow*quietZoneRatio {
+		return "", false
+	}
+
+	// 2. Digit pairs until the stop pattern
+	digits := make([]byte, 0, 44)
+	for pos := start + 4; pos+3 <= len(runs); pos += 10 {
+		if len(digits) > 0 && isStop(runs, pos, narrow) {
+			return string(digits), true
+		}
+		if pos+10 > len(runs) {
+			return "", false
+		}
+
+		var bars, spaces [5]float64
+		for i := 0; i < 5; i++ {
+			bars[i] = runs[pos+2*i]
+			spaces[i] = runs[pos+2*i+1]
+		}
+		first, ok1 := decodeDigit(bars)
+		second, ok2 := decodeDigit(spaces)
+		if !ok1 || !ok2 {
+			return "", false
+		}
+		digits = append(digits, '0'+first, '0'+second)
+	}
+	return "", false
+}
+
+// isStop reports whether runs[pos:] is the stop pattern followed by a quiet zone
+func isStop(runs []float64, pos int, narrow float64) bool {
+	if runs[pos] < narrow*minWideRatio || runs[pos+1] > narrow*1.5 || runs[pos+2] > narrow*1.5 {
+		return false
+	}
+	return pos+3 == len(runs) || runs[pos+3] >= narrow*quietZoneRatio
+}
+
+// decodeDigit decodes five element widths, taking the two widest as wide
+func decodeDigit(widths [5]float64) (byte, bool) {
+	order := []int{0, 1, 2, 3, 4}
+	sort.SliceStable(order, func(i, j int) bool { return widths[order[i]] > widths[order[j]] })
+	if widths[order[1]] < widths[order[2]]*minWideRatio {
+		return 0, false
+	}
+
+	var pattern [5]bool
+	pattern[order[0]], pattern[order[1]] = true, true
+	for digit, p := range digitPatterns {
+		if p == pattern {
+			return byte(digit), true
+		}
+	}
+	return 0, false
+}
+
+// ScanImage reads ITF barcodes along evenly spaced rows and columns of img, in
+// both directions (so rotated and upside-down barcodes are found). Returns the
+// distinct symbols, the most often read first.
+func ScanImage(img image.Image) []string {
+	bounds := img.Bounds()
+
+	counts := map[string]int{}
+	var found []string
+	read := func(line []uint8) {
+		runs := lineRuns(line)
+		for _, r := range [][]float64{runs, reverseRuns(runs)} {
+			if digits, err := DecodeRuns(r); err == nil {
+				if counts[digits] == 0 {
+					found = append(found, digits)
+				}
+				counts[digits]++
+			}
+		}
+	}
+
+	row := make([]uint8, bounds.Dx())
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += max(1, bounds.Dy()/scanLines) {
+		for i := range row {
+			row[i] = luminance(img.At(bounds.Min.X+i, y))
+		}
+		read(row)
+	}
+	column := make([]uint8, bounds.Dy())
+	for x := bounds.Min.X; x < bounds.Max.X; x += max(1, bounds.Dx()/scanLines) {
+		for i := range column {
+			column[i] = luminance(img.At(x, bounds.Min.Y+i))
+		}
+		read(column)
+	}
+
+	sort.SliceStable(found, func(i, j int) bool { return counts[found[i]] > counts[found[j]] })
+	return found
+}
+
+// luminance returns the 8-bit luminance of c over a white background
+func luminance(c color.Color) uint8 {
+	r, g, b, a := c.RGBA()
+	white := 0xffff - a
+	r, g, b = r+white, g+white, b+white
+	return uint8((19595*r + 38470*g + 7471*b + 1<<15) >> 24)
+}
+
+// lineRuns binarizes a line of luminance at the midpoint of its range and returns
+// the widths of its runs, starting with the first dark one
+func lineRuns(line []uint8) []float64 {
+	lo, hi := uint8(255), uint8(0)
+	for _, v := range line {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	if int(hi)-int(lo) < minContrast {
+		return nil
+	}
+	threshold := (int(lo) + int(hi)) / 2
+
+	var runs []float64
+	dark, length := true, 0
+	for _, v := range line {
+		isDark := int(v) < threshold
+		if len(runs) == 0 && length == 0 && !isDark {
+			continue // Leading light margin
+		}
+		if isDark != dark {
+			runs = append(runs, float64(length))
+			dark, length = isDark, 0
+		}
+		length++
+	}
+	if length > 0 {
+		runs = append(runs, float64(length))
+	}
+	return runs
+}
+
+// reverseRuns returns the runs of the line read backwards, starting with a dark one
+func reverseRuns(runs []float64) []float64 {
+	n := len(runs)
+	if n == 0 {
+		return nil
+	}
+	if n%2 == 0 {
+		n-- // Drop the trailing light run
+	}
+	reversed := make([]float64, 0, n)
+	for i := n - 1; i >= 0; i-- {
+		reversed = append(reversed, runs[i])
+	}
+	return reversed
+}
@@ -0,0 +1,155 @@
+package barcode
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+const boleto = "34191000000000150001090000000000000000000001"
+
+// encodeRuns returns the runs of an ITF symbol (even number of digits)
+func encodeRuns(digits string, narrow, wide float64) []float64 {
+	runs := []float64{narrow, narrow, narrow, narrow}
+	for i := 0; i < len(digits); i += 2 {
+		bars, spaces := digitPatterns[digits[i]-'0'], digitPatterns[digits[i+1]-'0']
+		for j := 0; j < 5; j++ {
+			for _, isWide := range []bool{bars[j], spaces[j]} {
+				if isWide {
+					runs = append(runs, wide)
+				} else {
+					runs = append(runs, narrow)
+				}
+			}
+		}
+	}
+	return append(runs, wide, narrow, narrow)
+}
+
+// renderImage draws the symbol with a quiet zone, rotated 90 degrees when vertical
+func renderImage(digits string, narrow, wide int, vertical bool) image.Image {
+	runs := encodeRuns(digits, float64(narrow), float64(wide))
+	length := 20 * narrow
+	for _, r := range runs {
+		length += int(r)
+	}
+	length += 20 * narrow
+
+	rect := image.Rect(0, 0, length, 60)
+	if vertical {
+		rect = image.Rect(0, 0, 60, length)
+	}
+	img := image.NewRGBA(rect)
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+
+	pos := 10 * narrow
+	for i, r := range runs {
+		if i%2 == 0 {
+			bar := image.Rect(pos, 5, pos+int(r), 55)
+			if vertical {
+				bar = image.Rect(5, pos, 55, pos+int(r))
+			}
+			draw.Draw(img, bar, image.NewUniform(color.Black), image.Point{}, draw.Src)
+		}
+		pos += int(r)
+	}
+	return img
+}
+
+// TestDecodeRuns tests decoding symbols with margins, print gain and damage
+func TestDecodeRuns(t *testing.T) {
+	withMargins := append([]float64{3, 40}, encodeRuns("1234", 2, 5)...)
+	withMargins = append(withMargins, 40, 2, 2)
+
+	gain := encodeRuns(boleto, 3, 7)
+	for i := range gain {
+		if i%2 == 0 {
+			gain[i]++ // Bars print wider, spaces narrower
+		} else {
+			gain[i]--
+		}
+	}
+	gain = append(gain, 50)
+
+	damaged := encodeRuns(boleto, 2, 5)
+	for i := 24; i < 34; i += 2 {
+		damaged[i] = 5 // Every bar of a digit wide
+	}
+
+	tests := []struct {
+		name    string
+		runs    []float64
+		want    string
+		wantErr error
+	}{
+		{"Boleto", encodeRuns(boleto, 2, 6), boleto, nil},
+		{"Margins and noise", withMargins, "1234", nil},
+		{"Print gain", gain, boleto, nil},
+		{"Damaged", damaged, "", ErrNotFound},
+		{"Empty", nil, "", ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeRuns(tt.runs)
+			if got != tt.want || err != tt.wantErr {
+				t.Errorf("DecodeRuns() = %q, %v, want %q, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestEncode tests encoding runs and rejecting invalid digits
+func TestEncode(t *testing.T) {
+	runs, err := Encode("12", 3)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	// Start, 1 (wnnnw) interleaved with 2 (nwnnw), stop
+	want := []int{1, 1, 1, 1, 3, 1, 1, 3, 1, 1, 1, 1, 3, 3, 3, 1, 1}
+	if fmt.Sprint(runs) != fmt.Sprint(want) {
+		t.Errorf("Encode() = %v, want %v", runs, want)
+	}
+
+	for _, digits := range []string{"", "123", "1a"} {
+		if _, err := Encode(digits, 3); err != ErrInvalidDigits {
+			t.Errorf("Encode(%q) error = %v, want %v", digits, err, ErrInvalidDigits)
+		}
+	}
+}
+
+// TestScanImage tests reading a barcode from horizontal, vertical and upside-down images
+func TestScanImage(t *testing.T) {
+	upsideDown := renderImage(boleto, 2, 5, false).(*image.RGBA)
+	flipped := image.NewRGBA(upsideDown.Bounds())
+	width := upsideDown.Bounds().Dx()
+	for y := 0; y < upsideDown.Bounds().Dy(); y++ {
+		for x := 0; x < width; x++ {
+			flipped.Set(width-1-x, y, upsideDown.At(x, y))
+		}
+	}
+
+	tests := []struct {
+		name string
+		img  image.Image
+	}{
+		{"Horizontal", renderImage(boleto, 2, 5, false)},
+		{"Vertical", renderImage(boleto, 3, 8, true)},
+		{"Upside down", flipped},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ScanImage(tt.img); len(got) == 0 || got[0] != boleto {
+				t.Errorf("ScanImage() = %v, want [%s]", got, boleto)
+			}
+		})
+	}
+
+	blank := image.NewGray(image.Rect(0, 0, 100, 100))
+	if got := ScanImage(blank); len(got) != 0 {
+		t.Errorf("ScanImage(blank) = %v, want none", got)
+	}
+}
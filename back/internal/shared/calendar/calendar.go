@@ -0,0 +1,321 @@
+// Package calendar is the Brazilian banking calendar.
+//
+// Banks close on weekends and national holidays (including Carnaval Monday
+// and Tuesday, Good Friday and Corpus Christi, which move with Easter), so
+// settlements, boleto due dates and scheduled payments roll forward to the next
+// business day. State and municipal holidays come from the data file shipped
+// with the service (holidays.csv) plus an optional file loaded at startup;
+// the package-level functions use the national calendar only.
+//
+// Dates are calendar days: only the year, month and day of a time.Time (in its
+// own location) are considered, and results keep the time of day and location.
+package calendar
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Holiday scopes
+const (
+	ScopeNational = "national"
+	ScopeState    = "state" // code: state abbreviation (UF), e.g. "SP"
+	ScopeCity     = "city"  // code: IBGE municipality code, e.g. "3550308"
+)
+
+// Location is the time zone of the banking calendar (Brasília, without daylight saving time since 2019)
+var Location = time.FixedZone("BRT", -3*60*60)
+
+// Holiday is a holiday on a given date
+type Holiday struct {
+	Date  time.Time `json:"date"`
+	Name  string    `json:"name"`
+	Scope string    `json:"scope"`
+}
+
+// rule is a yearly holiday: a fixed day, or an offset in days from Easter Sunday
+type rule struct {
+	name         string
+	month        time.Month
+	day          int
+	easter       bool
+	easterOffset int
+	fromYear     int // First year the holiday applies (0: always)
+}
+
+// dateIn returns the date of the holiday in year (UTC midnight)
+func (r rule) dateIn(year int) (time.Time, bool) {
+	if year < r.fromYear {
+		return time.Time{}, false
+	}
+	if r.easter {
+		return easterSunday(year).AddDate(0, 0, r.easterOffset), true
+	}
+	return time.Date(year, r.month, r.day, 0, 0, 0, 0, time.UTC), true
+}
+
+// nationalRules are the national banking holidays
+var nationalRules = []rule{
+	{name: "Confraternização Universal", month: time.January, day: 1},
+	{name: "Carnaval", easter: true, easterOffset: -48},
+	{name: "Carnaval", easter: true, easterOffset: -47},
+	{name: "Sexta-feira Santa", easter: true, easterOffset: -2},
+	{name: "Tiradentes", month: time.April, day: 21},
+	{name: "Dia do Trabalho", month: time.May, day: 1},
+	{name: "Corpus Christi", easter: true, easterOffset: 60},
+	{name: "Independência do Brasil", month: time.September, day: 7},
+	{name: "Nossa Senhora Aparecida", month: time.October, day: 12},
+	{name: "Finados", month: time.November, day: 2},
+	{name: "Proclamação da República", month: time.November, day: 15},
+	// National holiday since 2024 (Lei 14.759/2023)
+	{name: "Dia Nacional de Zumbi e da Consciência Negra", month: time.November, day: 20, fromYear: 2024},
+	{name: "Natal", month: time.December, day: 25},
+}
+
+//go:embed holidays.csv
+var regionalData string
+
+var (
+	mu       sync.RWMutex
+	regional = map[string][]rule{} // "state:SP" or "city:3550308" -> holidays
+)
+
+func init() {
+	rules, err := parseRegional(regionalData)
+	if err != nil {
+		// The data file is embedded at build time; a parse error is a programming error
+		panic(err)
+	}
+	addRegional(rules)
+}
+
+// LoadFile adds the state and municipal holidays of a data file (same format
+// as holidays.csv) to the embedded ones. Meant to be called at startup.
+func LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("calendar: %w", err)
+	}
+	rules, err := parseRegional(string(data))
+	if err != nil {
+		return err
+	}
+	addRegional(rules)
+	return nil
+}
+
+// addRegional registers parsed regional holidays
+func addRegional(rules map[string][]rule) {
+	mu.Lock()
+	defer mu.Unlock()
+	for key, list := range rules {
+		regional[key] = append(regional[key], list...)
+	}
+}
+
+// Calendar is the banking calendar of a place: national holidays plus the
+// holidays of a state and a municipality
+type Calendar struct {
+	state string
+	city  string
+}
+
+// National is the calendar with national holidays only
+var National = &Calendar{}
+
+// For returns the calendar of a state (UF) and municipality (IBGE code).
+// Either may be empty; unknown places only have national holidays.
+func For(state, city string) *Calendar {
+	return &Calendar{state: strings.ToUpper(strings.TrimSpace(state)), city: strings.TrimSpace(city)}
+}
+
+// HolidayOn returns the holiday on date, if any. National holidays take
+// precedence over regional holidays on the same day.
+func (c *Calendar) HolidayOn(date time.Time) (Holiday, bool) {
+	year, month, day := date.Date()
+	day0 := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+
+	for _, h := range c.Holidays(year) {
+		if h.Date.Equal(day0) {
+			return h, true
+		}
+	}
+	return Holiday{}, false
+}
+
+// IsHoliday reports whether date is a holiday
+func (c *Calendar) IsHoliday(date time.Time) bool {
+	_, ok := c.HolidayOn(date)
+	return ok
+}
+
+// IsBusinessDay reports whether banks open on date (weekdays that are not holidays)
+func (c *Calendar) IsBusinessDay(date time.Time) bool {
+	if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		return false
+	}
+	return !c.IsHoliday(date)
+}
+
+// NextBusinessDay returns date when it is a business day, or the first business day after it
+func (c *Calendar) NextBusinessDay(date time.Time) time.Time {
+	for !c.IsBusinessDay(date) {
+		date = date.AddDate(0, 0, 1)
+	}
+	return date
+}
+
+// AddBusinessDays moves date by days business days (backwards when negative).
+// Non-business days are skipped, so Friday plus one business day is Monday;
+// zero days returns date unchanged.
+func (c *Calendar) AddBusinessDays(date time.Time, days int) time.Time {
+	step := 1
+	if days < 0 {
+		step, days = -1, -days
+	}
+	for days > 0 {
+		date = date.AddDate(0, 0, step)
+		if c.IsBusinessDay(date) {
+			days--
+		}
+	}
+	return date
+}
+
+// Holidays returns the holidays of a year in date order (UTC midnight dates)
+func (c *Calendar) Holidays(year int) []Holiday {
+	holidays := make([]Holiday, 0, len(nationalRules))
+	seen := make(map[time.Time]bool)
+	add := func(rules []rule, scope string) {
+		for _, r := range rules {
+			date, ok := r.dateIn(year)
+			if !ok || seen[date] {
+				continue
+			}
+			seen[date] = true
+			holidays = append(holidays, Holiday{Date: date, Name: r.name, Scope: scope})
+		}
+	}
+
+	add(nationalRules, ScopeNational)
+	mu.RLock()
+	if c.state != "" {
+		add(regional[ScopeState+":"+c.state], ScopeState)
+	}
+	if c.city != "" {
+		add(regional[ScopeCity+":"+c.city], ScopeCity)
+	}
+	mu.RUnlock()
+
+	sort.Slice(holidays, func(i, j int) bool { return holidays[i].Date.Before(holidays[j].Date) })
+	return holidays
+}
+
+// IsHoliday reports whether date is a national holiday
+func IsHoliday(date time.Time) bool {
+	return National.IsHoliday(date)
+}
+
+// IsBusinessDay reports whether banks open on date nationwide
+func IsBusinessDay(date time.Time) bool {
+	return National.IsBusinessDay(date)
+}
+
+// NextBusinessDay returns date, or the first national business day after it
+func NextBusinessDay(date time.Time) time.Time {
+	return National.NextBusinessDay(date)
+}
+
+// AddBusinessDays moves date by days national business days
+func AddBusinessDays(date time.Time, days int) time.Time {
+	return National.AddBusinessDays(date, days)
+}
+
+// Holidays returns the national holidays of a year in date order
+func Holidays(year int) []Holiday {
+	return National.Holidays(year)
+}
+
+// DateOf returns the date of t in Brasília, as a UTC midnight
+func DateOf(t time.Time) time.Time {
+	year, month, day := t.In(Location).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// easterSunday returns the date of Easter Sunday (Gregorian computus)
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := (19*a + b - b/4 - (b-(b+8)/25+1)/3 + 15) % 30
+	e := (32 + 2*(b%4) + 2*(c/4) - d - c%4) % 7
+	f := d + e - 7*((a+11*d+22*e)/451) + 114
+	return time.Date(year, time.Month(f/31), f%31+1, 0, 0, 0, 0, time.UTC)
+}
+
+// parseRegional parses a regional holiday data file. Each line is
+// "scope,code,date,name" where date is MM-DD or an Easter offset (easter+8, easter-3).
+func parseRegional(data string) (map[string][]rule, error) {
+	rules := make(map[string][]rule)
+
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.SplitN(line, ",", 4)
+		if len(fields) != 4 || fields[3] == "" {
+			return nil, fmt.Errorf("calendar: line %d: expected 4 fields", i+1)
+		}
+		scope, code := fields[0], fields[1]
+		switch {
+		case scope == ScopeState && len(code) == 2 && strings.ToUpper(code) == code:
+		case scope == ScopeCity && len(code) == 7 && isDigits(code):
+		default:
+			return nil, fmt.Errorf("calendar: line %d: invalid scope %q or code %q", i+1, scope, code)
+		}
+
+		r, err := parseRuleDate(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("calendar: line %d: %w", i+1, err)
+		}
+		r.name = fields[3]
+		rules[scope+":"+code] = append(rules[scope+":"+code], r)
+	}
+
+	return rules, nil
+}
+
+// parseRuleDate parses MM-DD or easter±N
+func parseRuleDate(value string) (rule, error) {
+	if offset, found := strings.CutPrefix(value, "easter"); found {
+		n, err := strconv.Atoi(offset)
+		if offset == "" || err != nil || n < -100 || n > 100 {
+			return rule{}, fmt.Errorf("invalid Easter offset %q", value)
+		}
+		return rule{easter: true, easterOffset: n}, nil
+	}
+
+	date, err := time.Parse("01-02", value)
+	if err != nil || len(value) != 5 {
+		return rule{}, fmt.Errorf("invalid date %q", value)
+	}
+	return rule{month: date.Month(), day: date.Day()}, nil
+}
+
+// isDigits reports whether value only contains ASCII digits
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
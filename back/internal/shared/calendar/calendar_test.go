@@ -0,0 +1,187 @@
+package calendar
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// date returns a UTC midnight date
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// TestEasterSunday tests the Easter computus
+func TestEasterSunday(t *testing.T) {
+	for year, want := range map[int]time.Time{
+		2019: date(2019, 4, 21),
+		2024: date(2024, 3, 31),
+		2025: date(2025, 4, 20),
+		2026: date(2026, 4, 5),
+		2027: date(2027, 3, 28),
+		2038: date(2038, 4, 25),
+	} {
+		if got := easterSunday(year); !got.Equal(want) {
+			t.Errorf("easterSunday(%d) = %v, want %v", year, got, want)
+		}
+	}
+}
+
+// TestNationalHolidays tests fixed and Easter-based national holidays
+func TestNationalHolidays(t *testing.T) {
+	holidays := []time.Time{
+		date(2026, 1, 1),
+		date(2026, 2, 16), // Carnaval Monday
+		date(2026, 2, 17), // Carnaval Tuesday
+		date(2026, 4, 3),  // Good Friday
+		date(2026, 4, 21),
+		date(2026, 6, 4), // Corpus Christi
+		date(2026, 9, 7),
+		date(2026, 11, 20),
+		date(2026, 12, 25),
+		date(2025, 3, 3), // Carnaval Monday
+		date(2025, 6, 19),
+	}
+	for _, holiday := range holidays {
+		if !IsHoliday(holiday) {
+			t.Errorf("IsHoliday(%s) = false", holiday.Format("2006-01-02"))
+		}
+	}
+
+	// Ash Wednesday, a regular day, and Consciência Negra before it became national
+	for _, day := range []time.Time{date(2026, 2, 18), date(2026, 3, 10), date(2023, 11, 20)} {
+		if IsHoliday(day) {
+			t.Errorf("IsHoliday(%s) = true", day.Format("2006-01-02"))
+		}
+	}
+
+	if got := len(Holidays(2026)); got != 13 {
+		t.Errorf("len(Holidays(2026)) = %d, want 13", got)
+	}
+	if got := len(Holidays(2023)); got != 12 {
+		t.Errorf("len(Holidays(2023)) = %d, want 12", got)
+	}
+}
+
+// TestBusinessDays tests NextBusinessDay and AddBusinessDays over weekends and holidays
+func TestBusinessDays(t *testing.T) {
+	// Christmas 2026 is a Friday: the next business day is Monday
+	if got := NextBusinessDay(date(2026, 12, 25)); !got.Equal(date(2026, 12, 28)) {
+		t.Errorf("NextBusinessDay(2026-12-25) = %s", got.Format("2006-01-02"))
+	}
+	// Business days are returned unchanged, keeping the time of day
+	morning := time.Date(2026, 3, 10, 9, 30, 0, 0, Location)
+	if got := NextBusinessDay(morning); !got.Equal(morning) {
+		t.Errorf("NextBusinessDay(%v) = %v", morning, got)
+	}
+
+	tests := []struct {
+		name string
+		from time.Time
+		days int
+		want time.Time
+	}{
+		{"Zero days", date(2026, 3, 14), 0, date(2026, 3, 14)},
+		{"Friday plus one", date(2026, 3, 13), 1, date(2026, 3, 16)},
+		{"Over Carnaval", date(2026, 2, 13), 1, date(2026, 2, 18)},
+		{"Over Good Friday and weekend", date(2026, 4, 2), 2, date(2026, 4, 7)},
+		{"From a Saturday", date(2026, 3, 14), 1, date(2026, 3, 16)},
+		{"Backwards over a weekend", date(2026, 3, 16), -1, date(2026, 3, 13)},
+		{"Backwards over Christmas", date(2026, 12, 28), -2, date(2026, 12, 23)},
+		{"Ten days", date(2026, 3, 2), 10, date(2026, 3, 16)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AddBusinessDays(tt.from, tt.days); !got.Equal(tt.want) {
+				t.Errorf("AddBusinessDays(%s, %d) = %s, want %s", tt.from.Format("2006-01-02"), tt.days, got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+// TestRegionalCalendar tests state and municipal holidays from the embedded data file
+func TestRegionalCalendar(t *testing.T) {
+	saoPaulo := For("sp", "3550308")
+
+	// Revolução Constitucionalista (state) and the city anniversary
+	for _, day := range []time.Time{date(2026, 7, 9), date(2027, 1, 25)} {
+		if saoPaulo.IsBusinessDay(day) {
+			t.Errorf("São Paulo: IsBusinessDay(%s) = true", day.Format("2006-01-02"))
+		}
+		if !IsBusinessDay(day) {
+			t.Errorf("national: IsBusinessDay(%s) = false", day.Format("2006-01-02"))
+		}
+	}
+	if holiday, ok := saoPaulo.HolidayOn(date(2027, 1, 25)); !ok || holiday.Scope != ScopeCity {
+		t.Errorf("HolidayOn(2027-01-25) = %+v, %v", holiday, ok)
+	}
+	// National holidays win over a regional holiday on the same day
+	if holiday, _ := For("MT", "").HolidayOn(date(2026, 11, 20)); holiday.Scope != ScopeNational {
+		t.Errorf("HolidayOn(2026-11-20) scope = %q, want %q", holiday.Scope, ScopeNational)
+	}
+	if !For("MT", "").IsHoliday(date(2023, 11, 20)) {
+		t.Error("MT: 2023-11-20 should be a state holiday")
+	}
+
+	// Nossa Senhora da Penha is the Monday after Easter week in Espírito Santo
+	if !For("ES", "").IsHoliday(date(2026, 4, 13)) {
+		t.Error("ES: 2026-04-13 should be a holiday")
+	}
+
+	// Thursday 2026-07-09 in São Paulo: one business day later is Friday
+	if got := saoPaulo.AddBusinessDays(date(2026, 7, 8), 1); !got.Equal(date(2026, 7, 10)) {
+		t.Errorf("São Paulo: AddBusinessDays(2026-07-08, 1) = %s", got.Format("2006-01-02"))
+	}
+
+	if got := len(For("XX", "9999999").Holidays(2026)); got != len(Holidays(2026)) {
+		t.Errorf("unknown place has %d holidays, want the %d national ones", got, len(Holidays(2026)))
+	}
+}
+
+// TestLoadFile tests loading extra regional holidays and data file validation
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "holidays.csv")
+	data := "# extra\ncity,4202404,07-11,Aniversário de Blumenau\ncity,4202404,easter-3,Quinta-feira Santa\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadFile(path); err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+
+	blumenau := For("SC", "4202404")
+	for _, day := range []time.Time{date(2026, 7, 11), date(2026, 4, 2), date(2026, 8, 11)} {
+		if !blumenau.IsHoliday(day) {
+			t.Errorf("Blumenau: IsHoliday(%s) = false", day.Format("2006-01-02"))
+		}
+	}
+
+	if err := LoadFile(filepath.Join(t.TempDir(), "missing.csv")); err == nil {
+		t.Error("LoadFile() accepted a missing file")
+	}
+
+	for _, line := range []string{
+		"country,BR,01-01,Ano Novo",
+		"state,sp,01-01,Lowercase UF",
+		"city,355030,01-01,Short IBGE code",
+		"state,SP,13-01,Invalid month",
+		"state,SP,easter,Missing offset",
+		"state,SP,easter+x,Invalid offset",
+		"state,SP,7-9,Unpadded date",
+		"state,SP,07-09",
+	} {
+		if _, err := parseRegional(line); err == nil {
+			t.Errorf("parseRegional(%q) accepted an invalid line", line)
+		}
+	}
+}
+
+// TestDateOf tests the Brasília date of an instant
+func TestDateOf(t *testing.T) {
+	// 01:30 UTC is still the previous day in Brasília
+	if got := DateOf(time.Date(2026, 3, 11, 1, 30, 0, 0, time.UTC)); !got.Equal(date(2026, 3, 10)) {
+		t.Errorf("DateOf() = %v", got)
+	}
+}
@@ -367,6 +367,38 @@ func TestArgon2Parameters(t *testing.T) {
 	}
 }
 
+// TestGenerateToken tests token generation and hashing
+func TestGenerateToken(t *testing.T) {
+	token1, err := GenerateToken(32)
+	if err != nil {
+		t.Fatalf("Token generation failed: %v", err)
+	}
+	token2, err := GenerateToken(32)
+	if err != nil {
+		t.Fatalf("Token generation failed: %v", err)
+	}
+
+	if token1 == token2 {
+		t.Error("Two generated tokens should not be equal")
+	}
+
+	// Hash must be deterministic and hex-encoded SHA-256 (64 chars)
+	if HashToken(token1) != HashToken(token1) {
+		t.Error("HashToken should be deterministic")
+	}
+	if len(HashToken(token1)) != 64 {
+		t.Errorf("Expected 64-char hash, got %d", len(HashToken(token1)))
+	}
+	if HashToken(token1) == HashToken(token2) {
+		t.Error("Different tokens should produce different hashes")
+	}
+
+	// Too-short tokens are rejected
+	if _, err := GenerateToken(8); err == nil {
+		t.Error("Expected error for token size below 16 bytes")
+	}
+}
+
 // BenchmarkEncrypt benchmarks encryption performance
 func BenchmarkEncrypt(b *testing.B) {
 	key := []byte("12345678901234567890123456789012")
@@ -410,3 +442,126 @@ func BenchmarkVerifyPIN(b *testing.B) {
 		_, _ = VerifyPIN(pin, hash)
 	}
 }
+
+// TestKeyringEncryptDecrypt tests envelope encryption with a versioned keyring
+func TestKeyringEncryptDecrypt(t *testing.T) {
+	keyring, err := NewKeyring(map[uint32][]byte{
+		1: []byte("12345678901234567890123456789012"),
+		2: []byte("abcdefghijklmnopqrstuvwxyz012345"),
+	}, 2, 1)
+	if err != nil {
+		t.Fatalf("NewKeyring failed: %v", err)
+	}
+
+	plaintext := []byte("4532123456789012")
+	ciphertext, err := keyring.Encrypt(plaintext)
+	if err != nil {
+		t.Fatalf("Encryption failed: %v", err)
+	}
+
+	if KeyVersion(ciphertext) != 2 {
+		t.Errorf("Expected key version 2 in header, got %d", KeyVersion(ciphertext))
+	}
+	if keyring.NeedsReencrypt(ciphertext) {
+		t.Error("Ciphertext under the current key should not need re-encryption")
+	}
+
+	decrypted, err := keyring.Decrypt(ciphertext)
+	if err != nil {
+		t.Fatalf("Decryption failed: %v", err)
+	}
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Errorf("Expected %s, got %s", plaintext, decrypted)
+	}
+
+	// Tampering with the body is detected
+	ciphertext[len(ciphertext)-1] ^= 0xFF
+	if _, err := keyring.Decrypt(ciphertext); err == nil {
+		t.Error("Expected tampered ciphertext to fail decryption")
+	}
+}
+
+// TestKeyringRotation tests legacy decryption and re-encryption to a new key version
+func TestKeyringRotation(t *testing.T) {
+	oldKey := []byte("12345678901234567890123456789012")
+	newKey := []byte("abcdefghijklmnopqrstuvwxyz012345")
+	plaintext := []byte("123")
+
+	// Legacy ciphertext: plain Encrypt output without header
+	legacy, err := Encrypt(plaintext, oldKey)
+	if err != nil {
+		t.Fatalf("Encryption failed: %v", err)
+	}
+
+	v1, err := NewKeyring(map[uint32][]byte{1: oldKey}, 1, 1)
+	if err != nil {
+		t.Fatalf("NewKeyring failed: %v", err)
+	}
+	envelopeV1, err := v1.Encrypt(plaintext)
+	if err != nil {
+		t.Fatalf("Encryption failed: %v", err)
+	}
+
+	v2, err := NewKeyring(map[uint32][]byte{1: oldKey, 2: newKey}, 2, 1)
+	if err != nil {
+		t.Fatalf("NewKeyring failed: %v", err)
+	}
+
+	for name, ciphertext := range map[string][]byte{"legacy": legacy, "version 1": envelopeV1} {
+		t.Run(name, func(t *testing.T) {
+			if !v2.NeedsReencrypt(ciphertext) {
+				t.Fatal("Expected ciphertext to need re-encryption")
+			}
+
+			rekeyed, err := v2.Reencrypt(ciphertext)
+			if err != nil {
+				t.Fatalf("Re-encryption failed: %v", err)
+			}
+			if KeyVersion(rekeyed) != 2 {
+				t.Errorf("Expected key version 2, got %d", KeyVersion(rekeyed))
+			}
+
+			decrypted, err := v2.Decrypt(rekeyed)
+			if err != nil || !bytes.Equal(decrypted, plaintext) {
+				t.Errorf("Expected %s after re-encryption, got %s (err: %v)", plaintext, decrypted, err)
+			}
+
+			// Once the old key is retired, only re-encrypted data stays readable
+			v2Only, _ := NewKeyring(map[uint32][]byte{2: newKey}, 2, 0)
+			if _, err := v2Only.Decrypt(rekeyed); err != nil {
+				t.Errorf("Re-encrypted data should not need the old key: %v", err)
+			}
+			if _, err := v2Only.Decrypt(ciphertext); err == nil {
+				t.Error("Old ciphertext should not decrypt without the old key")
+			}
+		})
+	}
+}
+
+// TestParseKeys tests parsing of "version:key" lists
+func TestParseKeys(t *testing.T) {
+	keys, err := ParseKeys("2:abcdefghijklmnopqrstuvwxyz012345, 3:12345678901234567890123456789012")
+	if err != nil {
+		t.Fatalf("ParseKeys failed: %v", err)
+	}
+	if len(keys) != 2 || string(keys[3]) != "12345678901234567890123456789012" {
+		t.Errorf("Unexpected keys: %v", keys)
+	}
+
+	invalid := []string{
+		"abcdefghijklmnopqrstuvwxyz012345",   // missing version
+		"0:abcdefghijklmnopqrstuvwxyz012345", // version 0
+		"x:abcdefghijklmnopqrstuvwxyz012345", // non-numeric version
+		"2:short",                            // wrong key size
+		"2:abcdefghijklmnopqrstuvwxyz012345,2:abcdefghijklmnopqrstuvwxyz012345", // duplicate
+	}
+	for _, spec := range invalid {
+		if _, err := ParseKeys(spec); err == nil {
+			t.Errorf("Expected error for %q", spec)
+		}
+	}
+
+	if _, err := NewKeyring(map[uint32][]byte{1: []byte("12345678901234567890123456789012")}, 2, 1); err == nil {
+		t.Error("Expected error when the current version is missing")
+	}
+}
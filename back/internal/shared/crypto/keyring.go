@@ -0,0 +1,313 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrUnknownKeyVersion is returned when a ciphertext references a key missing from the keyring
+	ErrUnknownKeyVersion = errors.New("ciphertext key version not found in keyring")
+
+	// ErrInvalidKeyring is returned when a keyring has no keys or no usable current key
+	ErrInvalidKeyring = errors.New("keyring must contain the current key version")
+)
+
+// envelopeMagic marks envelope ciphertexts.
+// Legacy ciphertexts (plain Encrypt output) start directly with a random nonce.
+var envelopeMagic = []byte("ENV1")
+
+const (
+	// Header: [magic (4 bytes)][key version (4 bytes, big-endian)][wrapped data key]
+	versionSize = 4
+	headerSize  = 4 + versionSize
+
+	// Wrapped data key: [nonce (12 bytes)][encrypted 32-byte key + auth tag (16 bytes)]
+	wrappedKeySize = nonceSize + keySize + 16
+)
+
+// Keyring holds versioned key-encryption keys (KEKs) for envelope encryption.
+//
+// Every value is encrypted with a fresh random data key (DEK); the DEK is
+// encrypted ("wrapped") with the current KEK and stored in the ciphertext
+// header together with the KEK version:
+//
+//	[magic "ENV1"][KEK version][wrapped DEK][nonce][ciphertext + auth tag]
+//
+// Rotating the KEK only requires re-wrapping the DEK (see Reencrypt), and old
+// versions stay decryptable for as long as they remain in the keyring.
+type Keyring struct {
+	keys    map[uint32][]byte
+	current uint32
+	legacy  uint32
+}
+
+// NewKeyring creates a keyring from versioned 32-byte keys.
+//
+// Parameters:
+//   - keys: KEKs by version (versions must be > 0)
+//   - current: Version used for new encryptions
+//   - legacy: Version that decrypts legacy ciphertexts without a header (0 disables)
+func NewKeyring(keys map[uint32][]byte, current, legacy uint32) (*Keyring, error) {
+	if _, ok := keys[current]; !ok || current == 0 {
+		return nil, ErrInvalidKeyring
+	}
+	if _, ok := keys[legacy]; legacy != 0 && !ok {
+		return nil, fmt.Errorf("legacy key version %d not found in keyring", legacy)
+	}
+
+	copied := make(map[uint32][]byte, len(keys))
+	for version, key := range keys {
+		if version == 0 {
+			return nil, fmt.Errorf("key version must be greater than 0")
+		}
+		if len(key) != keySize {
+			return nil, fmt.Errorf("key version %d: %w", version, ErrInvalidKeySize)
+		}
+		copied[version] = append([]byte(nil), key...)
+	}
+
+	return &Keyring{keys: copied, current: current, legacy: legacy}, nil
+}
+
+// ParseKeys parses a "version:key,version:key" list of raw 32-byte keys.
+// Keys must not contain commas.
+func ParseKeys(spec string) (map[uint32][]byte, error) {
+	keys := make(map[uint32][]byte)
+	if strings.TrimSpace(spec) == "" {
+		return keys, nil
+	}
+
+	for _, entry := range strings.Split(spec, ",") {
+		versionStr, key, found := strings.Cut(strings.TrimSpace(entry), ":")
+		if !found {
+			return nil, fmt.Errorf("invalid key entry %q (expected version:key)", entry)
+		}
+
+		version, err := strconv.ParseUint(versionStr, 10, 32)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("invalid key version %q", versionStr)
+		}
+		if _, exists := keys[uint32(version)]; exists {
+			return nil, fmt.Errorf("duplicate key version %d", version)
+		}
+		if len(key) != keySize {
+			return nil, fmt.Errorf("key version %d: %w", version, ErrInvalidKeySize)
+		}
+
+		keys[uint32(version)] = []byte(key)
+	}
+
+	return keys, nil
+}
+
+// keyringFile is the JSON layout of a local KMS stand-in file
+type keyringFile struct {
+	CurrentVersion uint32 `json:"current_version"`
+	LegacyVersion  uint32 `json:"legacy_version"`
+	Keys           []struct {
+		Version uint32 `json:"version"`
+		Key     string `json:"key"` // base64 (standard encoding) of 32 random bytes
+	} `json:"keys"`
+}
+
+// LoadKeyringFile loads a keyring from a JSON file acting as a local KMS stand-in.
+//
+// Example:
+//
+//	{"current_version": 2, "legacy_version": 1, "keys": [
+//	  {"version": 1, "key": "<base64 32 bytes>"},
+//	  {"version": 2, "key": "<base64 32 bytes>"}
+//	]}
+func LoadKeyringFile(path string) (*Keyring, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keyring file: %w", err)
+	}
+
+	var file keyringFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse keyring file: %w", err)
+	}
+
+	keys := make(map[uint32][]byte, len(file.Keys))
+	for _, entry := range file.Keys {
+		key, err := base64.StdEncoding.DecodeString(entry.Key)
+		if err != nil {
+			return nil, fmt.Errorf("key version %d: invalid base64: %w", entry.Version, err)
+		}
+		if _, exists := keys[entry.Version]; exists {
+			return nil, fmt.Errorf("duplicate key version %d", entry.Version)
+		}
+		keys[entry.Version] = key
+	}
+
+	return NewKeyring(keys, file.CurrentVersion, file.LegacyVersion)
+}
+
+// CurrentVersion returns the key version used for new encryptions
+func (k *Keyring) CurrentVersion() uint32 {
+	return k.current
+}
+
+// Versions returns all key versions in the keyring in ascending order
+func (k *Keyring) Versions() []uint32 {
+	versions := make([]uint32, 0, len(k.keys))
+	for version := range k.keys {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions
+}
+
+// Encrypt encrypts plaintext under a fresh data key wrapped with the current KEK
+func (k *Keyring) Encrypt(plaintext []byte) ([]byte, error) {
+	dek := make([]byte, keySize)
+	if _, err := io.ReadFull(rand.Reader, dek); err != nil {
+		return nil, err
+	}
+
+	body, err := Encrypt(plaintext, dek)
+	if err != nil {
+		return nil, err
+	}
+
+	return k.seal(dek, body)
+}
+
+// EncryptString is a convenience wrapper for encrypting strings
+func (k *Keyring) EncryptString(plaintext string) ([]byte, error) {
+	return k.Encrypt([]byte(plaintext))
+}
+
+// Decrypt decrypts an envelope or legacy ciphertext.
+// Legacy ciphertexts are decrypted with the legacy key version.
+func (k *Keyring) Decrypt(ciphertext []byte) ([]byte, error) {
+	version, wrapped, body, ok := parseEnvelope(ciphertext)
+	if !ok {
+		return k.decryptLegacy(ciphertext)
+	}
+
+	dek, err := k.unwrap(version, wrapped)
+	if err == nil {
+		plaintext, err := Decrypt(body, dek)
+		if err == nil {
+			return plaintext, nil
+		}
+	}
+
+	// A legacy nonce may start with the magic bytes by chance (2^-32)
+	if plaintext, legacyErr := k.decryptLegacy(ciphertext); legacyErr == nil {
+		return plaintext, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, ErrDecryptionFailed
+}
+
+// DecryptString is a convenience wrapper for decrypting to strings
+func (k *Keyring) DecryptString(ciphertext []byte) (string, error) {
+	plaintext, err := k.Decrypt(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// KeyVersion returns the KEK version of a ciphertext (0 for legacy ciphertexts)
+func KeyVersion(ciphertext []byte) uint32 {
+	version, _, _, ok := parseEnvelope(ciphertext)
+	if !ok {
+		return 0
+	}
+	return version
+}
+
+// NeedsReencrypt reports whether a ciphertext is not under the current KEK
+func (k *Keyring) NeedsReencrypt(ciphertext []byte) bool {
+	return KeyVersion(ciphertext) != k.current
+}
+
+// Reencrypt moves a ciphertext to the current KEK.
+//
+// Envelope ciphertexts only have their data key re-wrapped (the encrypted body
+// is kept as is); legacy ciphertexts are fully re-encrypted. Ciphertexts
+// already under the current KEK are returned unchanged.
+func (k *Keyring) Reencrypt(ciphertext []byte) ([]byte, error) {
+	version, wrapped, body, ok := parseEnvelope(ciphertext)
+	if !ok {
+		plaintext, err := k.decryptLegacy(ciphertext)
+		if err != nil {
+			return nil, err
+		}
+		return k.Encrypt(plaintext)
+	}
+
+	if version == k.current {
+		return ciphertext, nil
+	}
+
+	dek, err := k.unwrap(version, wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	return k.seal(dek, body)
+}
+
+// seal wraps a data key with the current KEK and prepends the envelope header
+func (k *Keyring) seal(dek, body []byte) ([]byte, error) {
+	wrapped, err := Encrypt(dek, k.keys[k.current])
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, headerSize+len(wrapped)+len(body))
+	out = append(out, envelopeMagic...)
+	out = binary.BigEndian.AppendUint32(out, k.current)
+	out = append(out, wrapped...)
+	out = append(out, body...)
+	return out, nil
+}
+
+// unwrap decrypts a data key with the KEK of the given version
+func (k *Keyring) unwrap(version uint32, wrapped []byte) ([]byte, error) {
+	kek, ok := k.keys[version]
+	if !ok {
+		return nil, ErrUnknownKeyVersion
+	}
+	return Decrypt(wrapped, kek)
+}
+
+// decryptLegacy decrypts a header-less ciphertext with the legacy key
+func (k *Keyring) decryptLegacy(ciphertext []byte) ([]byte, error) {
+	kek, ok := k.keys[k.legacy]
+	if !ok {
+		return nil, ErrUnknownKeyVersion
+	}
+	return Decrypt(ciphertext, kek)
+}
+
+// parseEnvelope splits an envelope ciphertext into its parts.
+// ok is false for legacy ciphertexts.
+func parseEnvelope(ciphertext []byte) (version uint32, wrapped, body []byte, ok bool) {
+	if len(ciphertext) < headerSize+wrappedKeySize || !bytes.HasPrefix(ciphertext, envelopeMagic) {
+		return 0, nil, nil, false
+	}
+
+	version = binary.BigEndian.Uint32(ciphertext[len(envelopeMagic):headerSize])
+	wrapped = ciphertext[headerSize : headerSize+wrappedKeySize]
+	body = ciphertext[headerSize+wrappedKeySize:]
+	return version, wrapped, body, true
+}
@@ -0,0 +1,35 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// GenerateToken returns a URL-safe random token built from size random bytes.
+//
+// Tokens are bearer credentials: store only their hash (see HashToken)
+// and hand the plaintext to the client exactly once.
+func GenerateToken(size int) (string, error) {
+	if size < 16 {
+		return "", fmt.Errorf("token size must be at least 16 bytes, got %d", size)
+	}
+
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken returns the hex-encoded SHA-256 hash of a token.
+//
+// A fast hash is sufficient here because tokens carry at least 128 bits of
+// entropy, unlike PINs which must use Argon2id (see HashPIN).
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
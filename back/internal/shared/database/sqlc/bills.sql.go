@@ -35,23 +35,40 @@ INSERT INTO bills (
     fee_cents,
     final_amount_cents,
     recipient_name,
-    due_date
+    due_date,
+    effective_due_date,
+    fine_cents,
+    interest_cents,
+    discount_cents,
+    charges_source,
+    scheduled_for,
+    schedule_status,
+    source,
+    charges_calculated_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, NOW()
 )
-RETURNING id, user_id, type, status, barcode, amount_cents, fee_cents, final_amount_cents, recipient_name, due_date, payment_date, created_at
+RETURNING id, user_id, type, status, barcode, amount_cents, fee_cents, final_amount_cents, recipient_name, due_date, payment_date, created_at, effective_due_date, fine_cents, interest_cents, discount_cents, charges_source, charges_calculated_at, scheduled_for, schedule_status, payment_attempts, last_payment_attempt_at, failure_reason, source
 `
 
 type CreateBillParams struct {
-	UserID           uuid.UUID     `json:"user_id"`
-	Type             string        `json:"type"`
-	Status           string        `json:"status"`
-	Barcode          string        `json:"barcode"`
-	AmountCents      int64         `json:"amount_cents"`
-	FeeCents         sql.NullInt64 `json:"fee_cents"`
-	FinalAmountCents int64         `json:"final_amount_cents"`
-	RecipientName    string        `json:"recipient_name"`
-	DueDate          time.Time     `json:"due_date"`
+	UserID           uuid.UUID      `json:"user_id"`
+	Type             string         `json:"type"`
+	Status           string         `json:"status"`
+	Barcode          string         `json:"barcode"`
+	AmountCents      int64          `json:"amount_cents"`
+	FeeCents         sql.NullInt64  `json:"fee_cents"`
+	FinalAmountCents int64          `json:"final_amount_cents"`
+	RecipientName    string         `json:"recipient_name"`
+	DueDate          sql.NullTime   `json:"due_date"`
+	EffectiveDueDate sql.NullTime   `json:"effective_due_date"`
+	FineCents        int64          `json:"fine_cents"`
+	InterestCents    int64          `json:"interest_cents"`
+	DiscountCents    int64          `json:"discount_cents"`
+	ChargesSource    string         `json:"charges_source"`
+	ScheduledFor     sql.NullTime   `json:"scheduled_for"`
+	ScheduleStatus   sql.NullString `json:"schedule_status"`
+	Source           string         `json:"source"`
 }
 
 func (q *Queries) CreateBill(ctx context.Context, arg CreateBillParams) (Bill, error) {
@@ -65,6 +82,14 @@ func (q *Queries) CreateBill(ctx context.Context, arg CreateBillParams) (Bill, e
 		arg.FinalAmountCents,
 		arg.RecipientName,
 		arg.DueDate,
+		arg.EffectiveDueDate,
+		arg.FineCents,
+		arg.InterestCents,
+		arg.DiscountCents,
+		arg.ChargesSource,
+		arg.ScheduledFor,
+		arg.ScheduleStatus,
+		arg.Source,
 	)
 	var i Bill
 	err := row.Scan(
@@ -80,6 +105,18 @@ func (q *Queries) CreateBill(ctx context.Context, arg CreateBillParams) (Bill, e
 		&i.DueDate,
 		&i.PaymentDate,
 		&i.CreatedAt,
+		&i.EffectiveDueDate,
+		&i.FineCents,
+		&i.InterestCents,
+		&i.DiscountCents,
+		&i.ChargesSource,
+		&i.ChargesCalculatedAt,
+		&i.ScheduledFor,
+		&i.ScheduleStatus,
+		&i.PaymentAttempts,
+		&i.LastPaymentAttemptAt,
+		&i.FailureReason,
+		&i.Source,
 	)
 	return i, err
 }
@@ -96,7 +133,7 @@ func (q *Queries) DeleteBill(ctx context.Context, id uuid.UUID) error {
 }
 
 const getBillByBarcode = `-- name: GetBillByBarcode :one
-SELECT id, user_id, type, status, barcode, amount_cents, fee_cents, final_amount_cents, recipient_name, due_date, payment_date, created_at FROM bills
+SELECT id, user_id, type, status, barcode, amount_cents, fee_cents, final_amount_cents, recipient_name, due_date, payment_date, created_at, effective_due_date, fine_cents, interest_cents, discount_cents, charges_source, charges_calculated_at, scheduled_for, schedule_status, payment_attempts, last_payment_attempt_at, failure_reason, source FROM bills
 WHERE barcode = $1
 LIMIT 1
 `
@@ -117,12 +154,24 @@ func (q *Queries) GetBillByBarcode(ctx context.Context, barcode string) (Bill, e
 		&i.DueDate,
 		&i.PaymentDate,
 		&i.CreatedAt,
+		&i.EffectiveDueDate,
+		&i.FineCents,
+		&i.InterestCents,
+		&i.DiscountCents,
+		&i.ChargesSource,
+		&i.ChargesCalculatedAt,
+		&i.ScheduledFor,
+		&i.ScheduleStatus,
+		&i.PaymentAttempts,
+		&i.LastPaymentAttemptAt,
+		&i.FailureReason,
+		&i.Source,
 	)
 	return i, err
 }
 
 const getBillByID = `-- name: GetBillByID :one
-SELECT id, user_id, type, status, barcode, amount_cents, fee_cents, final_amount_cents, recipient_name, due_date, payment_date, created_at FROM bills
+SELECT id, user_id, type, status, barcode, amount_cents, fee_cents, final_amount_cents, recipient_name, due_date, payment_date, created_at, effective_due_date, fine_cents, interest_cents, discount_cents, charges_source, charges_calculated_at, scheduled_for, schedule_status, payment_attempts, last_payment_attempt_at, failure_reason, source FROM bills
 WHERE id = $1
 LIMIT 1
 `
@@ -143,12 +192,24 @@ func (q *Queries) GetBillByID(ctx context.Context, id uuid.UUID) (Bill, error) {
 		&i.DueDate,
 		&i.PaymentDate,
 		&i.CreatedAt,
+		&i.EffectiveDueDate,
+		&i.FineCents,
+		&i.InterestCents,
+		&i.DiscountCents,
+		&i.ChargesSource,
+		&i.ChargesCalculatedAt,
+		&i.ScheduledFor,
+		&i.ScheduleStatus,
+		&i.PaymentAttempts,
+		&i.LastPaymentAttemptAt,
+		&i.FailureReason,
+		&i.Source,
 	)
 	return i, err
 }
 
 const getBillForUpdate = `-- name: GetBillForUpdate :one
-SELECT id, user_id, type, status, barcode, amount_cents, fee_cents, final_amount_cents, recipient_name, due_date, payment_date, created_at FROM bills
+SELECT id, user_id, type, status, barcode, amount_cents, fee_cents, final_amount_cents, recipient_name, due_date, payment_date, created_at, effective_due_date, fine_cents, interest_cents, discount_cents, charges_source, charges_calculated_at, scheduled_for, schedule_status, payment_attempts, last_payment_attempt_at, failure_reason, source FROM bills
 WHERE id = $1
 FOR UPDATE
 `
@@ -169,6 +230,18 @@ func (q *Queries) GetBillForUpdate(ctx context.Context, id uuid.UUID) (Bill, err
 		&i.DueDate,
 		&i.PaymentDate,
 		&i.CreatedAt,
+		&i.EffectiveDueDate,
+		&i.FineCents,
+		&i.InterestCents,
+		&i.DiscountCents,
+		&i.ChargesSource,
+		&i.ChargesCalculatedAt,
+		&i.ScheduledFor,
+		&i.ScheduleStatus,
+		&i.PaymentAttempts,
+		&i.LastPaymentAttemptAt,
+		&i.FailureReason,
+		&i.Source,
 	)
 	return i, err
 }
@@ -177,8 +250,10 @@ const getUserBillsStats = `-- name: GetUserBillsStats :one
 SELECT
     COUNT(*) as total_bills,
     COUNT(*) FILTER (WHERE status = 'pending') as pending_bills,
+    COUNT(*) FILTER (WHERE status = 'overdue') as overdue_bills,
     COUNT(*) FILTER (WHERE status = 'paid') as paid_bills,
     COALESCE(SUM(final_amount_cents) FILTER (WHERE status = 'pending'), 0)::bigint as pending_amount_cents,
+    COALESCE(SUM(final_amount_cents) FILTER (WHERE status = 'overdue'), 0)::bigint as overdue_amount_cents,
     COALESCE(SUM(final_amount_cents) FILTER (WHERE status = 'paid'), 0)::bigint as paid_amount_cents
 FROM bills
 WHERE user_id = $1
@@ -187,8 +262,10 @@ WHERE user_id = $1
 type GetUserBillsStatsRow struct {
 	TotalBills         int64 `json:"total_bills"`
 	PendingBills       int64 `json:"pending_bills"`
+	OverdueBills       int64 `json:"overdue_bills"`
 	PaidBills          int64 `json:"paid_bills"`
 	PendingAmountCents int64 `json:"pending_amount_cents"`
+	OverdueAmountCents int64 `json:"overdue_amount_cents"`
 	PaidAmountCents    int64 `json:"paid_amount_cents"`
 }
 
@@ -198,28 +275,118 @@ func (q *Queries) GetUserBillsStats(ctx context.Context, userID uuid.UUID) (GetU
 	err := row.Scan(
 		&i.TotalBills,
 		&i.PendingBills,
+		&i.OverdueBills,
 		&i.PaidBills,
 		&i.PendingAmountCents,
+		&i.OverdueAmountCents,
 		&i.PaidAmountCents,
 	)
 	return i, err
 }
 
+const listDueScheduledBills = `-- name: ListDueScheduledBills :many
+
+SELECT id, user_id, type, status, barcode, amount_cents, fee_cents, final_amount_cents, recipient_name, due_date, payment_date, created_at, effective_due_date, fine_cents, interest_cents, discount_cents, charges_source, charges_calculated_at, scheduled_for, schedule_status, payment_attempts, last_payment_attempt_at, failure_reason, source FROM bills
+WHERE schedule_status = 'scheduled'
+  AND status IN ('pending', 'overdue')
+  AND scheduled_for <= $1::DATE
+  AND (scheduled_for, id) > ($2::DATE, $3::UUID)
+ORDER BY scheduled_for, id
+LIMIT $4
+`
+
+type ListDueScheduledBillsParams struct {
+	ScheduledOnOrBefore time.Time `json:"scheduled_on_or_before"`
+	AfterScheduledFor   time.Time `json:"after_scheduled_for"`
+	AfterID             uuid.UUID `json:"after_id"`
+	BatchSize           int32     `json:"batch_size"`
+}
+
+// ListDueScheduledBills lists unpaid bills scheduled up to a date, after a (scheduled_for, id) cursor
+func (q *Queries) ListDueScheduledBills(ctx context.Context, arg ListDueScheduledBillsParams) ([]Bill, error) {
+	rows, err := q.db.QueryContext(ctx, listDueScheduledBills,
+		arg.ScheduledOnOrBefore,
+		arg.AfterScheduledFor,
+		arg.AfterID,
+		arg.BatchSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Bill{}
+	for rows.Next() {
+		var i Bill
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Type,
+			&i.Status,
+			&i.Barcode,
+			&i.AmountCents,
+			&i.FeeCents,
+			&i.FinalAmountCents,
+			&i.RecipientName,
+			&i.DueDate,
+			&i.PaymentDate,
+			&i.CreatedAt,
+			&i.EffectiveDueDate,
+			&i.FineCents,
+			&i.InterestCents,
+			&i.DiscountCents,
+			&i.ChargesSource,
+			&i.ChargesCalculatedAt,
+			&i.ScheduledFor,
+			&i.ScheduleStatus,
+			&i.PaymentAttempts,
+			&i.LastPaymentAttemptAt,
+			&i.FailureReason,
+			&i.Source,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listOverdueBills = `-- name: ListOverdueBills :many
-SELECT id, user_id, type, status, barcode, amount_cents, fee_cents, final_amount_cents, recipient_name, due_date, payment_date, created_at FROM bills
-WHERE status = 'pending'
-  AND due_date < CURRENT_DATE
-ORDER BY due_date ASC
-LIMIT $1 OFFSET $2
+
+SELECT id, user_id, type, status, barcode, amount_cents, fee_cents, final_amount_cents, recipient_name, due_date, payment_date, created_at, effective_due_date, fine_cents, interest_cents, discount_cents, charges_source, charges_calculated_at, scheduled_for, schedule_status, payment_attempts, last_payment_attempt_at, failure_reason, source FROM bills
+WHERE due_date <= $1::DATE
+  AND (
+    status = 'pending'
+    OR (status = 'overdue' AND charges_calculated_at < $2::TIMESTAMPTZ)
+  )
+  AND (due_date, id) > ($3::DATE, $4::UUID)
+ORDER BY due_date, id
+LIMIT $5
 `
 
 type ListOverdueBillsParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	DueOnOrBefore    time.Time `json:"due_on_or_before"`
+	CalculatedBefore time.Time `json:"calculated_before"`
+	AfterDueDate     time.Time `json:"after_due_date"`
+	AfterID          uuid.UUID `json:"after_id"`
+	BatchSize        int32     `json:"batch_size"`
 }
 
+// ListOverdueBills lists bills past their due date that are still pending, or
+// overdue with charges last calculated before calculated_before, after a (due_date, id) cursor
 func (q *Queries) ListOverdueBills(ctx context.Context, arg ListOverdueBillsParams) ([]Bill, error) {
-	rows, err := q.db.QueryContext(ctx, listOverdueBills, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listOverdueBills,
+		arg.DueOnOrBefore,
+		arg.CalculatedBefore,
+		arg.AfterDueDate,
+		arg.AfterID,
+		arg.BatchSize,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -240,6 +407,18 @@ func (q *Queries) ListOverdueBills(ctx context.Context, arg ListOverdueBillsPara
 			&i.DueDate,
 			&i.PaymentDate,
 			&i.CreatedAt,
+			&i.EffectiveDueDate,
+			&i.FineCents,
+			&i.InterestCents,
+			&i.DiscountCents,
+			&i.ChargesSource,
+			&i.ChargesCalculatedAt,
+			&i.ScheduledFor,
+			&i.ScheduleStatus,
+			&i.PaymentAttempts,
+			&i.LastPaymentAttemptAt,
+			&i.FailureReason,
+			&i.Source,
 		); err != nil {
 			return nil, err
 		}
@@ -255,7 +434,7 @@ func (q *Queries) ListOverdueBills(ctx context.Context, arg ListOverdueBillsPara
 }
 
 const listUserBills = `-- name: ListUserBills :many
-SELECT id, user_id, type, status, barcode, amount_cents, fee_cents, final_amount_cents, recipient_name, due_date, payment_date, created_at FROM bills
+SELECT id, user_id, type, status, barcode, amount_cents, fee_cents, final_amount_cents, recipient_name, due_date, payment_date, created_at, effective_due_date, fine_cents, interest_cents, discount_cents, charges_source, charges_calculated_at, scheduled_for, schedule_status, payment_attempts, last_payment_attempt_at, failure_reason, source FROM bills
 WHERE user_id = $1
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3
@@ -289,6 +468,18 @@ func (q *Queries) ListUserBills(ctx context.Context, arg ListUserBillsParams) ([
 			&i.DueDate,
 			&i.PaymentDate,
 			&i.CreatedAt,
+			&i.EffectiveDueDate,
+			&i.FineCents,
+			&i.InterestCents,
+			&i.DiscountCents,
+			&i.ChargesSource,
+			&i.ChargesCalculatedAt,
+			&i.ScheduledFor,
+			&i.ScheduleStatus,
+			&i.PaymentAttempts,
+			&i.LastPaymentAttemptAt,
+			&i.FailureReason,
+			&i.Source,
 		); err != nil {
 			return nil, err
 		}
@@ -304,7 +495,7 @@ func (q *Queries) ListUserBills(ctx context.Context, arg ListUserBillsParams) ([
 }
 
 const listUserBillsByStatus = `-- name: ListUserBillsByStatus :many
-SELECT id, user_id, type, status, barcode, amount_cents, fee_cents, final_amount_cents, recipient_name, due_date, payment_date, created_at FROM bills
+SELECT id, user_id, type, status, barcode, amount_cents, fee_cents, final_amount_cents, recipient_name, due_date, payment_date, created_at, effective_due_date, fine_cents, interest_cents, discount_cents, charges_source, charges_calculated_at, scheduled_for, schedule_status, payment_attempts, last_payment_attempt_at, failure_reason, source FROM bills
 WHERE user_id = $1 AND status = $2
 ORDER BY created_at DESC
 LIMIT $3 OFFSET $4
@@ -344,6 +535,18 @@ func (q *Queries) ListUserBillsByStatus(ctx context.Context, arg ListUserBillsBy
 			&i.DueDate,
 			&i.PaymentDate,
 			&i.CreatedAt,
+			&i.EffectiveDueDate,
+			&i.FineCents,
+			&i.InterestCents,
+			&i.DiscountCents,
+			&i.ChargesSource,
+			&i.ChargesCalculatedAt,
+			&i.ScheduledFor,
+			&i.ScheduleStatus,
+			&i.PaymentAttempts,
+			&i.LastPaymentAttemptAt,
+			&i.FailureReason,
+			&i.Source,
 		); err != nil {
 			return nil, err
 		}
@@ -359,14 +562,18 @@ func (q *Queries) ListUserBillsByStatus(ctx context.Context, arg ListUserBillsBy
 }
 
 const markBillAsPaid = `-- name: MarkBillAsPaid :one
+
 UPDATE bills
 SET
     status = 'paid',
-    payment_date = NOW()
+    payment_date = NOW(),
+    schedule_status = CASE WHEN schedule_status = 'scheduled' THEN 'paid' ELSE schedule_status END,
+    failure_reason = NULL
 WHERE id = $1
-RETURNING id, user_id, type, status, barcode, amount_cents, fee_cents, final_amount_cents, recipient_name, due_date, payment_date, created_at
+RETURNING id, user_id, type, status, barcode, amount_cents, fee_cents, final_amount_cents, recipient_name, due_date, payment_date, created_at, effective_due_date, fine_cents, interest_cents, discount_cents, charges_source, charges_calculated_at, scheduled_for, schedule_status, payment_attempts, last_payment_attempt_at, failure_reason, source
 `
 
+// MarkBillAsPaid pays a bill; a pending schedule is closed as paid
 func (q *Queries) MarkBillAsPaid(ctx context.Context, id uuid.UUID) (Bill, error) {
 	row := q.db.QueryRowContext(ctx, markBillAsPaid, id)
 	var i Bill
@@ -383,6 +590,231 @@ func (q *Queries) MarkBillAsPaid(ctx context.Context, id uuid.UUID) (Bill, error
 		&i.DueDate,
 		&i.PaymentDate,
 		&i.CreatedAt,
+		&i.EffectiveDueDate,
+		&i.FineCents,
+		&i.InterestCents,
+		&i.DiscountCents,
+		&i.ChargesSource,
+		&i.ChargesCalculatedAt,
+		&i.ScheduledFor,
+		&i.ScheduleStatus,
+		&i.PaymentAttempts,
+		&i.LastPaymentAttemptAt,
+		&i.FailureReason,
+		&i.Source,
+	)
+	return i, err
+}
+
+const recordBillPaymentFailure = `-- name: RecordBillPaymentFailure :one
+
+UPDATE bills
+SET
+    payment_attempts = payment_attempts + 1,
+    last_payment_attempt_at = NOW(),
+    failure_reason = $1,
+    schedule_status = CASE WHEN $2::BOOLEAN THEN 'failed' ELSE schedule_status END
+WHERE id = $3 AND schedule_status = 'scheduled'
+RETURNING id, user_id, type, status, barcode, amount_cents, fee_cents, final_amount_cents, recipient_name, due_date, payment_date, created_at, effective_due_date, fine_cents, interest_cents, discount_cents, charges_source, charges_calculated_at, scheduled_for, schedule_status, payment_attempts, last_payment_attempt_at, failure_reason, source
+`
+
+type RecordBillPaymentFailureParams struct {
+	FailureReason sql.NullString `json:"failure_reason"`
+	GiveUp        bool           `json:"give_up"`
+	ID            uuid.UUID      `json:"id"`
+}
+
+// RecordBillPaymentFailure records a failed scheduled payment attempt; the
+// schedule fails when no retry is left
+func (q *Queries) RecordBillPaymentFailure(ctx context.Context, arg RecordBillPaymentFailureParams) (Bill, error) {
+	row := q.db.QueryRowContext(ctx, recordBillPaymentFailure, arg.FailureReason, arg.GiveUp, arg.ID)
+	var i Bill
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Type,
+		&i.Status,
+		&i.Barcode,
+		&i.AmountCents,
+		&i.FeeCents,
+		&i.FinalAmountCents,
+		&i.RecipientName,
+		&i.DueDate,
+		&i.PaymentDate,
+		&i.CreatedAt,
+		&i.EffectiveDueDate,
+		&i.FineCents,
+		&i.InterestCents,
+		&i.DiscountCents,
+		&i.ChargesSource,
+		&i.ChargesCalculatedAt,
+		&i.ScheduledFor,
+		&i.ScheduleStatus,
+		&i.PaymentAttempts,
+		&i.LastPaymentAttemptAt,
+		&i.FailureReason,
+		&i.Source,
+	)
+	return i, err
+}
+
+const scheduleBillPayment = `-- name: ScheduleBillPayment :one
+
+UPDATE bills
+SET
+    scheduled_for = $1::DATE,
+    schedule_status = 'scheduled',
+    payment_attempts = 0,
+    last_payment_attempt_at = NULL,
+    failure_reason = NULL
+WHERE id = $2
+RETURNING id, user_id, type, status, barcode, amount_cents, fee_cents, final_amount_cents, recipient_name, due_date, payment_date, created_at, effective_due_date, fine_cents, interest_cents, discount_cents, charges_source, charges_calculated_at, scheduled_for, schedule_status, payment_attempts, last_payment_attempt_at, failure_reason, source
+`
+
+type ScheduleBillPaymentParams struct {
+	ScheduledFor time.Time `json:"scheduled_for"`
+	ID           uuid.UUID `json:"id"`
+}
+
+// ScheduleBillPayment schedules the payment of a bill, resetting previous attempts
+func (q *Queries) ScheduleBillPayment(ctx context.Context, arg ScheduleBillPaymentParams) (Bill, error) {
+	row := q.db.QueryRowContext(ctx, scheduleBillPayment, arg.ScheduledFor, arg.ID)
+	var i Bill
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Type,
+		&i.Status,
+		&i.Barcode,
+		&i.AmountCents,
+		&i.FeeCents,
+		&i.FinalAmountCents,
+		&i.RecipientName,
+		&i.DueDate,
+		&i.PaymentDate,
+		&i.CreatedAt,
+		&i.EffectiveDueDate,
+		&i.FineCents,
+		&i.InterestCents,
+		&i.DiscountCents,
+		&i.ChargesSource,
+		&i.ChargesCalculatedAt,
+		&i.ScheduledFor,
+		&i.ScheduleStatus,
+		&i.PaymentAttempts,
+		&i.LastPaymentAttemptAt,
+		&i.FailureReason,
+		&i.Source,
+	)
+	return i, err
+}
+
+const unscheduleBillPayment = `-- name: UnscheduleBillPayment :one
+UPDATE bills
+SET
+    scheduled_for = NULL,
+    schedule_status = NULL
+WHERE id = $1 AND schedule_status = 'scheduled'
+RETURNING id, user_id, type, status, barcode, amount_cents, fee_cents, final_amount_cents, recipient_name, due_date, payment_date, created_at, effective_due_date, fine_cents, interest_cents, discount_cents, charges_source, charges_calculated_at, scheduled_for, schedule_status, payment_attempts, last_payment_attempt_at, failure_reason, source
+`
+
+func (q *Queries) UnscheduleBillPayment(ctx context.Context, id uuid.UUID) (Bill, error) {
+	row := q.db.QueryRowContext(ctx, unscheduleBillPayment, id)
+	var i Bill
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Type,
+		&i.Status,
+		&i.Barcode,
+		&i.AmountCents,
+		&i.FeeCents,
+		&i.FinalAmountCents,
+		&i.RecipientName,
+		&i.DueDate,
+		&i.PaymentDate,
+		&i.CreatedAt,
+		&i.EffectiveDueDate,
+		&i.FineCents,
+		&i.InterestCents,
+		&i.DiscountCents,
+		&i.ChargesSource,
+		&i.ChargesCalculatedAt,
+		&i.ScheduledFor,
+		&i.ScheduleStatus,
+		&i.PaymentAttempts,
+		&i.LastPaymentAttemptAt,
+		&i.FailureReason,
+		&i.Source,
+	)
+	return i, err
+}
+
+const updateBillCharges = `-- name: UpdateBillCharges :one
+
+UPDATE bills
+SET
+    effective_due_date = $1::DATE,
+    fine_cents = $2,
+    interest_cents = $3,
+    discount_cents = $4,
+    fee_cents = $5,
+    final_amount_cents = $6,
+    charges_source = $7,
+    charges_calculated_at = NOW()
+WHERE id = $8
+RETURNING id, user_id, type, status, barcode, amount_cents, fee_cents, final_amount_cents, recipient_name, due_date, payment_date, created_at, effective_due_date, fine_cents, interest_cents, discount_cents, charges_source, charges_calculated_at, scheduled_for, schedule_status, payment_attempts, last_payment_attempt_at, failure_reason, source
+`
+
+type UpdateBillChargesParams struct {
+	EffectiveDueDate sql.NullTime  `json:"effective_due_date"`
+	FineCents        int64         `json:"fine_cents"`
+	InterestCents    int64         `json:"interest_cents"`
+	DiscountCents    int64         `json:"discount_cents"`
+	FeeCents         sql.NullInt64 `json:"fee_cents"`
+	FinalAmountCents int64         `json:"final_amount_cents"`
+	ChargesSource    string        `json:"charges_source"`
+	ID               uuid.UUID     `json:"id"`
+}
+
+// UpdateBillCharges stores the charges breakdown recalculated for a payment date
+func (q *Queries) UpdateBillCharges(ctx context.Context, arg UpdateBillChargesParams) (Bill, error) {
+	row := q.db.QueryRowContext(ctx, updateBillCharges,
+		arg.EffectiveDueDate,
+		arg.FineCents,
+		arg.InterestCents,
+		arg.DiscountCents,
+		arg.FeeCents,
+		arg.FinalAmountCents,
+		arg.ChargesSource,
+		arg.ID,
+	)
+	var i Bill
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Type,
+		&i.Status,
+		&i.Barcode,
+		&i.AmountCents,
+		&i.FeeCents,
+		&i.FinalAmountCents,
+		&i.RecipientName,
+		&i.DueDate,
+		&i.PaymentDate,
+		&i.CreatedAt,
+		&i.EffectiveDueDate,
+		&i.FineCents,
+		&i.InterestCents,
+		&i.DiscountCents,
+		&i.ChargesSource,
+		&i.ChargesCalculatedAt,
+		&i.ScheduledFor,
+		&i.ScheduleStatus,
+		&i.PaymentAttempts,
+		&i.LastPaymentAttemptAt,
+		&i.FailureReason,
+		&i.Source,
 	)
 	return i, err
 }
@@ -391,7 +823,7 @@ const updateBillStatus = `-- name: UpdateBillStatus :one
 UPDATE bills
 SET status = $2
 WHERE id = $1
-RETURNING id, user_id, type, status, barcode, amount_cents, fee_cents, final_amount_cents, recipient_name, due_date, payment_date, created_at
+RETURNING id, user_id, type, status, barcode, amount_cents, fee_cents, final_amount_cents, recipient_name, due_date, payment_date, created_at, effective_due_date, fine_cents, interest_cents, discount_cents, charges_source, charges_calculated_at, scheduled_for, schedule_status, payment_attempts, last_payment_attempt_at, failure_reason, source
 `
 
 type UpdateBillStatusParams struct {
@@ -415,6 +847,18 @@ func (q *Queries) UpdateBillStatus(ctx context.Context, arg UpdateBillStatusPara
 		&i.DueDate,
 		&i.PaymentDate,
 		&i.CreatedAt,
+		&i.EffectiveDueDate,
+		&i.FineCents,
+		&i.InterestCents,
+		&i.DiscountCents,
+		&i.ChargesSource,
+		&i.ChargesCalculatedAt,
+		&i.ScheduledFor,
+		&i.ScheduleStatus,
+		&i.PaymentAttempts,
+		&i.LastPaymentAttemptAt,
+		&i.FailureReason,
+		&i.Source,
 	)
 	return i, err
 }
@@ -34,22 +34,36 @@ INSERT INTO card_transactions (
     merchant_category,
     status,
     is_international,
-    transaction_date
+    transaction_date,
+    mcc,
+    channel,
+    merchant_country,
+    merchant_key,
+    merchant_display_name,
+    merchant_logo_key,
+    merchant_city
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
 )
-RETURNING id, card_id, user_id, amount_cents, merchant_name, merchant_category, status, is_international, transaction_date, created_at
+RETURNING id, card_id, user_id, amount_cents, merchant_name, merchant_category, status, is_international, transaction_date, created_at, mcc, channel, merchant_country, merchant_key, merchant_display_name, merchant_logo_key, merchant_city
 `
 
 type CreateCardTransactionParams struct {
-	CardID           uuid.UUID      `json:"card_id"`
-	UserID           uuid.UUID      `json:"user_id"`
-	AmountCents      int64          `json:"amount_cents"`
-	MerchantName     string         `json:"merchant_name"`
-	MerchantCategory sql.NullString `json:"merchant_category"`
-	Status           string         `json:"status"`
-	IsInternational  sql.NullBool   `json:"is_international"`
-	TransactionDate  time.Time      `json:"transaction_date"`
+	CardID              uuid.UUID      `json:"card_id"`
+	UserID              uuid.UUID      `json:"user_id"`
+	AmountCents         int64          `json:"amount_cents"`
+	MerchantName        string         `json:"merchant_name"`
+	MerchantCategory    sql.NullString `json:"merchant_category"`
+	Status              string         `json:"status"`
+	IsInternational     sql.NullBool   `json:"is_international"`
+	TransactionDate     time.Time      `json:"transaction_date"`
+	Mcc                 sql.NullString `json:"mcc"`
+	Channel             sql.NullString `json:"channel"`
+	MerchantCountry     sql.NullString `json:"merchant_country"`
+	MerchantKey         sql.NullString `json:"merchant_key"`
+	MerchantDisplayName sql.NullString `json:"merchant_display_name"`
+	MerchantLogoKey     sql.NullString `json:"merchant_logo_key"`
+	MerchantCity        sql.NullString `json:"merchant_city"`
 }
 
 func (q *Queries) CreateCardTransaction(ctx context.Context, arg CreateCardTransactionParams) (CardTransaction, error) {
@@ -62,6 +76,13 @@ func (q *Queries) CreateCardTransaction(ctx context.Context, arg CreateCardTrans
 		arg.Status,
 		arg.IsInternational,
 		arg.TransactionDate,
+		arg.Mcc,
+		arg.Channel,
+		arg.MerchantCountry,
+		arg.MerchantKey,
+		arg.MerchantDisplayName,
+		arg.MerchantLogoKey,
+		arg.MerchantCity,
 	)
 	var i CardTransaction
 	err := row.Scan(
@@ -75,12 +96,19 @@ func (q *Queries) CreateCardTransaction(ctx context.Context, arg CreateCardTrans
 		&i.IsInternational,
 		&i.TransactionDate,
 		&i.CreatedAt,
+		&i.Mcc,
+		&i.Channel,
+		&i.MerchantCountry,
+		&i.MerchantKey,
+		&i.MerchantDisplayName,
+		&i.MerchantLogoKey,
+		&i.MerchantCity,
 	)
 	return i, err
 }
 
 const getCardTransactionByID = `-- name: GetCardTransactionByID :one
-SELECT id, card_id, user_id, amount_cents, merchant_name, merchant_category, status, is_international, transaction_date, created_at FROM card_transactions
+SELECT id, card_id, user_id, amount_cents, merchant_name, merchant_category, status, is_international, transaction_date, created_at, mcc, channel, merchant_country, merchant_key, merchant_display_name, merchant_logo_key, merchant_city FROM card_transactions
 WHERE id = $1
 LIMIT 1
 `
@@ -99,20 +127,61 @@ func (q *Queries) GetCardTransactionByID(ctx context.Context, id uuid.UUID) (Car
 		&i.IsInternational,
 		&i.TransactionDate,
 		&i.CreatedAt,
+		&i.Mcc,
+		&i.Channel,
+		&i.MerchantCountry,
+		&i.MerchantKey,
+		&i.MerchantDisplayName,
+		&i.MerchantLogoKey,
+		&i.MerchantCity,
+	)
+	return i, err
+}
+
+const getCardTransactionForUpdate = `-- name: GetCardTransactionForUpdate :one
+SELECT id, card_id, user_id, amount_cents, merchant_name, merchant_category, status, is_international, transaction_date, created_at, mcc, channel, merchant_country, merchant_key, merchant_display_name, merchant_logo_key, merchant_city FROM card_transactions
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetCardTransactionForUpdate(ctx context.Context, id uuid.UUID) (CardTransaction, error) {
+	row := q.db.QueryRowContext(ctx, getCardTransactionForUpdate, id)
+	var i CardTransaction
+	err := row.Scan(
+		&i.ID,
+		&i.CardID,
+		&i.UserID,
+		&i.AmountCents,
+		&i.MerchantName,
+		&i.MerchantCategory,
+		&i.Status,
+		&i.IsInternational,
+		&i.TransactionDate,
+		&i.CreatedAt,
+		&i.Mcc,
+		&i.Channel,
+		&i.MerchantCountry,
+		&i.MerchantKey,
+		&i.MerchantDisplayName,
+		&i.MerchantLogoKey,
+		&i.MerchantCity,
 	)
 	return i, err
 }
 
 const getCardTransactionsByCategory = `-- name: GetCardTransactionsByCategory :many
+
 SELECT
-    merchant_category,
+    COALESCE(o.category, t.merchant_category, '')::VARCHAR AS merchant_category,
     COUNT(*) as transaction_count,
-    SUM(amount_cents) as total_amount_cents
-FROM card_transactions
-WHERE user_id = $1
-  AND transaction_date >= $2
-  AND transaction_date <= $3
-GROUP BY merchant_category
+    SUM(t.amount_cents) as total_amount_cents
+FROM card_transactions t
+LEFT JOIN merchant_category_overrides o
+    ON o.user_id = t.user_id AND o.merchant_key = t.merchant_key
+WHERE t.user_id = $1
+  AND t.transaction_date >= $2
+  AND t.transaction_date <= $3
+GROUP BY 1
 ORDER BY total_amount_cents DESC
 `
 
@@ -123,11 +192,12 @@ type GetCardTransactionsByCategoryParams struct {
 }
 
 type GetCardTransactionsByCategoryRow struct {
-	MerchantCategory sql.NullString `json:"merchant_category"`
-	TransactionCount int64          `json:"transaction_count"`
-	TotalAmountCents int64          `json:"total_amount_cents"`
+	MerchantCategory string `json:"merchant_category"`
+	TransactionCount int64  `json:"transaction_count"`
+	TotalAmountCents int64  `json:"total_amount_cents"`
 }
 
+// Spending by category with the user's merchant category overrides applied
 func (q *Queries) GetCardTransactionsByCategory(ctx context.Context, arg GetCardTransactionsByCategoryParams) ([]GetCardTransactionsByCategoryRow, error) {
 	rows, err := q.db.QueryContext(ctx, getCardTransactionsByCategory, arg.UserID, arg.StartDate, arg.EndDate)
 	if err != nil {
@@ -151,22 +221,21 @@ func (q *Queries) GetCardTransactionsByCategory(ctx context.Context, arg GetCard
 	return items, nil
 }
 
-const getCardTransactionsByDateRange = `-- name: GetCardTransactionsByDateRange :many
-SELECT id, card_id, user_id, amount_cents, merchant_name, merchant_category, status, is_international, transaction_date, created_at FROM card_transactions
-WHERE user_id = $1
-  AND transaction_date >= $2
-  AND transaction_date <= $3
+const listCardTransactions = `-- name: ListCardTransactions :many
+SELECT id, card_id, user_id, amount_cents, merchant_name, merchant_category, status, is_international, transaction_date, created_at, mcc, channel, merchant_country, merchant_key, merchant_display_name, merchant_logo_key, merchant_city FROM card_transactions
+WHERE card_id = $1
 ORDER BY transaction_date DESC
+LIMIT $2 OFFSET $3
 `
 
-type GetCardTransactionsByDateRangeParams struct {
-	UserID    uuid.UUID `json:"user_id"`
-	StartDate time.Time `json:"start_date"`
-	EndDate   time.Time `json:"end_date"`
+type ListCardTransactionsParams struct {
+	CardID uuid.UUID `json:"card_id"`
+	Limit  int32     `json:"limit"`
+	Offset int32     `json:"offset"`
 }
 
-func (q *Queries) GetCardTransactionsByDateRange(ctx context.Context, arg GetCardTransactionsByDateRangeParams) ([]CardTransaction, error) {
-	rows, err := q.db.QueryContext(ctx, getCardTransactionsByDateRange, arg.UserID, arg.StartDate, arg.EndDate)
+func (q *Queries) ListCardTransactions(ctx context.Context, arg ListCardTransactionsParams) ([]CardTransaction, error) {
+	rows, err := q.db.QueryContext(ctx, listCardTransactions, arg.CardID, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -185,6 +254,13 @@ func (q *Queries) GetCardTransactionsByDateRange(ctx context.Context, arg GetCar
 			&i.IsInternational,
 			&i.TransactionDate,
 			&i.CreatedAt,
+			&i.Mcc,
+			&i.Channel,
+			&i.MerchantCountry,
+			&i.MerchantKey,
+			&i.MerchantDisplayName,
+			&i.MerchantLogoKey,
+			&i.MerchantCity,
 		); err != nil {
 			return nil, err
 		}
@@ -199,21 +275,63 @@ func (q *Queries) GetCardTransactionsByDateRange(ctx context.Context, arg GetCar
 	return items, nil
 }
 
-const listCardTransactions = `-- name: ListCardTransactions :many
-SELECT id, card_id, user_id, amount_cents, merchant_name, merchant_category, status, is_international, transaction_date, created_at FROM card_transactions
-WHERE card_id = $1
-ORDER BY transaction_date DESC
-LIMIT $2 OFFSET $3
+const listFilteredCardTransactions = `-- name: ListFilteredCardTransactions :many
+
+SELECT id, card_id, user_id, amount_cents, merchant_name, merchant_category, status, is_international, transaction_date, created_at, mcc, channel, merchant_country, merchant_key, merchant_display_name, merchant_logo_key, merchant_city FROM card_transactions
+WHERE user_id = $1
+  AND ($2::UUID IS NULL OR card_id = $2::UUID)
+  AND ($3::TIMESTAMPTZ IS NULL OR transaction_date >= $3::TIMESTAMPTZ)
+  AND ($4::TIMESTAMPTZ IS NULL OR transaction_date < $4::TIMESTAMPTZ)
+  AND ($5::VARCHAR IS NULL OR status = $5::VARCHAR)
+  AND ($6::VARCHAR IS NULL OR COALESCE(
+        (SELECT o.category FROM merchant_category_overrides o
+         WHERE o.user_id = card_transactions.user_id AND o.merchant_key = card_transactions.merchant_key),
+        merchant_category) = $6::VARCHAR)
+  AND ($7::TEXT IS NULL
+       OR merchant_name ILIKE '%' || $7::TEXT || '%'
+       OR merchant_display_name ILIKE '%' || $7::TEXT || '%')
+  AND ($8::BIGINT IS NULL OR amount_cents >= $8::BIGINT)
+  AND ($9::BIGINT IS NULL OR amount_cents <= $9::BIGINT)
+  AND ($10::BOOLEAN IS NULL OR COALESCE(is_international, FALSE) = $10::BOOLEAN)
+  AND ($11::TIMESTAMPTZ IS NULL OR (transaction_date, id) < ($11::TIMESTAMPTZ, $12::UUID))
+ORDER BY transaction_date DESC, id DESC
+LIMIT $13
 `
 
-type ListCardTransactionsParams struct {
-	CardID uuid.UUID `json:"card_id"`
-	Limit  int32     `json:"limit"`
-	Offset int32     `json:"offset"`
+type ListFilteredCardTransactionsParams struct {
+	UserID          uuid.UUID      `json:"user_id"`
+	CardID          uuid.NullUUID  `json:"card_id"`
+	StartDate       sql.NullTime   `json:"start_date"`
+	EndDate         sql.NullTime   `json:"end_date"`
+	Status          sql.NullString `json:"status"`
+	Category        sql.NullString `json:"category"`
+	Search          sql.NullString `json:"search"`
+	MinAmountCents  sql.NullInt64  `json:"min_amount_cents"`
+	MaxAmountCents  sql.NullInt64  `json:"max_amount_cents"`
+	IsInternational sql.NullBool   `json:"is_international"`
+	CursorDate      sql.NullTime   `json:"cursor_date"`
+	CursorID        uuid.NullUUID  `json:"cursor_id"`
+	RowLimit        int32          `json:"row_limit"`
 }
 
-func (q *Queries) ListCardTransactions(ctx context.Context, arg ListCardTransactionsParams) ([]CardTransaction, error) {
-	rows, err := q.db.QueryContext(ctx, listCardTransactions, arg.CardID, arg.Limit, arg.Offset)
+// Filters are optional (NULL = any). end_date is exclusive, search is an ILIKE
+// pattern fragment and category honors the user's merchant category overrides. Keyset pagination: pass the last row's (transaction_date, id) as cursor.
+func (q *Queries) ListFilteredCardTransactions(ctx context.Context, arg ListFilteredCardTransactionsParams) ([]CardTransaction, error) {
+	rows, err := q.db.QueryContext(ctx, listFilteredCardTransactions,
+		arg.UserID,
+		arg.CardID,
+		arg.StartDate,
+		arg.EndDate,
+		arg.Status,
+		arg.Category,
+		arg.Search,
+		arg.MinAmountCents,
+		arg.MaxAmountCents,
+		arg.IsInternational,
+		arg.CursorDate,
+		arg.CursorID,
+		arg.RowLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -232,6 +350,61 @@ func (q *Queries) ListCardTransactions(ctx context.Context, arg ListCardTransact
 			&i.IsInternational,
 			&i.TransactionDate,
 			&i.CreatedAt,
+			&i.Mcc,
+			&i.Channel,
+			&i.MerchantCountry,
+			&i.MerchantKey,
+			&i.MerchantDisplayName,
+			&i.MerchantLogoKey,
+			&i.MerchantCity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnenrichedCardTransactions = `-- name: ListUnenrichedCardTransactions :many
+SELECT id, card_id, user_id, amount_cents, merchant_name, merchant_category, status, is_international, transaction_date, created_at, mcc, channel, merchant_country, merchant_key, merchant_display_name, merchant_logo_key, merchant_city FROM card_transactions
+WHERE merchant_key IS NULL
+ORDER BY created_at
+LIMIT $1
+`
+
+func (q *Queries) ListUnenrichedCardTransactions(ctx context.Context, limit int32) ([]CardTransaction, error) {
+	rows, err := q.db.QueryContext(ctx, listUnenrichedCardTransactions, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CardTransaction{}
+	for rows.Next() {
+		var i CardTransaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.CardID,
+			&i.UserID,
+			&i.AmountCents,
+			&i.MerchantName,
+			&i.MerchantCategory,
+			&i.Status,
+			&i.IsInternational,
+			&i.TransactionDate,
+			&i.CreatedAt,
+			&i.Mcc,
+			&i.Channel,
+			&i.MerchantCountry,
+			&i.MerchantKey,
+			&i.MerchantDisplayName,
+			&i.MerchantLogoKey,
+			&i.MerchantCity,
 		); err != nil {
 			return nil, err
 		}
@@ -247,7 +420,7 @@ func (q *Queries) ListCardTransactions(ctx context.Context, arg ListCardTransact
 }
 
 const listUserCardTransactions = `-- name: ListUserCardTransactions :many
-SELECT id, card_id, user_id, amount_cents, merchant_name, merchant_category, status, is_international, transaction_date, created_at FROM card_transactions
+SELECT id, card_id, user_id, amount_cents, merchant_name, merchant_category, status, is_international, transaction_date, created_at, mcc, channel, merchant_country, merchant_key, merchant_display_name, merchant_logo_key, merchant_city FROM card_transactions
 WHERE user_id = $1
 ORDER BY transaction_date DESC
 LIMIT $2 OFFSET $3
@@ -279,6 +452,13 @@ func (q *Queries) ListUserCardTransactions(ctx context.Context, arg ListUserCard
 			&i.IsInternational,
 			&i.TransactionDate,
 			&i.CreatedAt,
+			&i.Mcc,
+			&i.Channel,
+			&i.MerchantCountry,
+			&i.MerchantKey,
+			&i.MerchantDisplayName,
+			&i.MerchantLogoKey,
+			&i.MerchantCity,
 		); err != nil {
 			return nil, err
 		}
@@ -292,3 +472,111 @@ func (q *Queries) ListUserCardTransactions(ctx context.Context, arg ListUserCard
 	}
 	return items, nil
 }
+
+const setCardTransactionMerchant = `-- name: SetCardTransactionMerchant :exec
+UPDATE card_transactions
+SET
+    merchant_key = $2,
+    merchant_display_name = $3,
+    merchant_logo_key = $4,
+    merchant_city = $5,
+    merchant_category = $6
+WHERE id = $1
+`
+
+type SetCardTransactionMerchantParams struct {
+	ID                  uuid.UUID      `json:"id"`
+	MerchantKey         sql.NullString `json:"merchant_key"`
+	MerchantDisplayName sql.NullString `json:"merchant_display_name"`
+	MerchantLogoKey     sql.NullString `json:"merchant_logo_key"`
+	MerchantCity        sql.NullString `json:"merchant_city"`
+	MerchantCategory    sql.NullString `json:"merchant_category"`
+}
+
+func (q *Queries) SetCardTransactionMerchant(ctx context.Context, arg SetCardTransactionMerchantParams) error {
+	_, err := q.db.ExecContext(ctx, setCardTransactionMerchant,
+		arg.ID,
+		arg.MerchantKey,
+		arg.MerchantDisplayName,
+		arg.MerchantLogoKey,
+		arg.MerchantCity,
+		arg.MerchantCategory,
+	)
+	return err
+}
+
+const summarizeFilteredCardTransactions = `-- name: SummarizeFilteredCardTransactions :one
+
+SELECT
+    COUNT(*) AS transaction_count,
+    COALESCE(SUM(amount_cents), 0)::BIGINT AS total_amount_cents
+FROM card_transactions
+WHERE user_id = $1
+  AND ($2::UUID IS NULL OR card_id = $2::UUID)
+  AND ($3::TIMESTAMPTZ IS NULL OR transaction_date >= $3::TIMESTAMPTZ)
+  AND ($4::TIMESTAMPTZ IS NULL OR transaction_date < $4::TIMESTAMPTZ)
+  AND ($5::VARCHAR IS NULL OR status = $5::VARCHAR)
+  AND ($6::VARCHAR IS NULL OR COALESCE(
+        (SELECT o.category FROM merchant_category_overrides o
+         WHERE o.user_id = card_transactions.user_id AND o.merchant_key = card_transactions.merchant_key),
+        merchant_category) = $6::VARCHAR)
+  AND ($7::TEXT IS NULL
+       OR merchant_name ILIKE '%' || $7::TEXT || '%'
+       OR merchant_display_name ILIKE '%' || $7::TEXT || '%')
+  AND ($8::BIGINT IS NULL OR amount_cents >= $8::BIGINT)
+  AND ($9::BIGINT IS NULL OR amount_cents <= $9::BIGINT)
+  AND ($10::BOOLEAN IS NULL OR COALESCE(is_international, FALSE) = $10::BOOLEAN)
+`
+
+type SummarizeFilteredCardTransactionsParams struct {
+	UserID          uuid.UUID      `json:"user_id"`
+	CardID          uuid.NullUUID  `json:"card_id"`
+	StartDate       sql.NullTime   `json:"start_date"`
+	EndDate         sql.NullTime   `json:"end_date"`
+	Status          sql.NullString `json:"status"`
+	Category        sql.NullString `json:"category"`
+	Search          sql.NullString `json:"search"`
+	MinAmountCents  sql.NullInt64  `json:"min_amount_cents"`
+	MaxAmountCents  sql.NullInt64  `json:"max_amount_cents"`
+	IsInternational sql.NullBool   `json:"is_international"`
+}
+
+type SummarizeFilteredCardTransactionsRow struct {
+	TransactionCount int64 `json:"transaction_count"`
+	TotalAmountCents int64 `json:"total_amount_cents"`
+}
+
+// Totals of ListFilteredCardTransactions over every page (same filters, no cursor)
+func (q *Queries) SummarizeFilteredCardTransactions(ctx context.Context, arg SummarizeFilteredCardTransactionsParams) (SummarizeFilteredCardTransactionsRow, error) {
+	row := q.db.QueryRowContext(ctx, summarizeFilteredCardTransactions,
+		arg.UserID,
+		arg.CardID,
+		arg.StartDate,
+		arg.EndDate,
+		arg.Status,
+		arg.Category,
+		arg.Search,
+		arg.MinAmountCents,
+		arg.MaxAmountCents,
+		arg.IsInternational,
+	)
+	var i SummarizeFilteredCardTransactionsRow
+	err := row.Scan(&i.TransactionCount, &i.TotalAmountCents)
+	return i, err
+}
+
+const updateCardTransactionStatus = `-- name: UpdateCardTransactionStatus :exec
+UPDATE card_transactions
+SET status = $2
+WHERE id = $1
+`
+
+type UpdateCardTransactionStatusParams struct {
+	ID     uuid.UUID `json:"id"`
+	Status string    `json:"status"`
+}
+
+func (q *Queries) UpdateCardTransactionStatus(ctx context.Context, arg UpdateCardTransactionStatusParams) error {
+	_, err := q.db.ExecContext(ctx, updateCardTransactionStatus, arg.ID, arg.Status)
+	return err
+}
@@ -12,6 +12,193 @@ import (
 	"github.com/google/uuid"
 )
 
+const activateCard = `-- name: ActivateCard :exec
+
+UPDATE cards
+SET
+    activated_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND activated_at IS NULL
+`
+
+// ========================================
+// PHYSICAL CARD ACTIVATION
+// ========================================
+func (q *Queries) ActivateCard(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, activateCard, id)
+	return err
+}
+
+const applyCardRenewal = `-- name: ApplyCardRenewal :exec
+
+UPDATE cards
+SET
+    cvv_encrypted = renewal_cvv_encrypted,
+    expiry_month = renewal_expiry_month,
+    expiry_year = renewal_expiry_year,
+    expires_at = renewal_expires_at,
+    renewal_cvv_encrypted = NULL,
+    renewal_expiry_month = NULL,
+    renewal_expiry_year = NULL,
+    renewal_expires_at = NULL,
+    updated_at = NOW()
+WHERE id = $1 AND renewal_expires_at IS NOT NULL
+`
+
+// ApplyCardRenewal moves a pending same-PAN renewal into effect
+func (q *Queries) ApplyCardRenewal(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, applyCardRenewal, id)
+	return err
+}
+
+const applyOverdueCardRenewals = `-- name: ApplyOverdueCardRenewals :many
+
+UPDATE cards
+SET
+    cvv_encrypted = renewal_cvv_encrypted,
+    expiry_month = renewal_expiry_month,
+    expiry_year = renewal_expiry_year,
+    expires_at = renewal_expires_at,
+    renewal_cvv_encrypted = NULL,
+    renewal_expiry_month = NULL,
+    renewal_expiry_year = NULL,
+    renewal_expires_at = NULL,
+    updated_at = NOW()
+WHERE renewal_expires_at IS NOT NULL
+  AND status IN ('active', 'blocked')
+  AND expires_at <= $1
+RETURNING id, user_id, type, brand, status, card_number_encrypted, cvv_encrypted, pin_hash, last_four_digits, holder_name, expiry_month, expiry_year, daily_limit_cents, monthly_limit_cents, current_daily_spent_cents, current_monthly_spent_cents, is_contactless, is_international, block_international, block_online, created_at, updated_at, expires_at, blocked_at, cvv_mode, dcvv_secret_encrypted, profile, locked_merchant_name, locked_merchant_category, amount_cap_cents, total_spent_cents, pan_fingerprint, pan_token, encryption_key_version, cancellation_reason, cancelled_at, replaced_by_card_id, renewed_at, activated_at, renewal_cvv_encrypted, renewal_expiry_month, renewal_expiry_year, renewal_expires_at
+`
+
+// ========================================
+// EXPIRY LIFECYCLE
+// ========================================
+// ApplyOverdueCardRenewals moves the pending same-PAN renewal of cards past
+// their current expiry into effect: the old plastic no longer works anyway.
+func (q *Queries) ApplyOverdueCardRenewals(ctx context.Context, expiresAt sql.NullTime) ([]Card, error) {
+	rows, err := q.db.QueryContext(ctx, applyOverdueCardRenewals, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Card{}
+	for rows.Next() {
+		var i Card
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Type,
+			&i.Brand,
+			&i.Status,
+			&i.CardNumberEncrypted,
+			&i.CvvEncrypted,
+			&i.PinHash,
+			&i.LastFourDigits,
+			&i.HolderName,
+			&i.ExpiryMonth,
+			&i.ExpiryYear,
+			&i.DailyLimitCents,
+			&i.MonthlyLimitCents,
+			&i.CurrentDailySpentCents,
+			&i.CurrentMonthlySpentCents,
+			&i.IsContactless,
+			&i.IsInternational,
+			&i.BlockInternational,
+			&i.BlockOnline,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ExpiresAt,
+			&i.BlockedAt,
+			&i.CvvMode,
+			&i.DcvvSecretEncrypted,
+			&i.Profile,
+			&i.LockedMerchantName,
+			&i.LockedMerchantCategory,
+			&i.AmountCapCents,
+			&i.TotalSpentCents,
+			&i.PanFingerprint,
+			&i.PanToken,
+			&i.EncryptionKeyVersion,
+			&i.CancellationReason,
+			&i.CancelledAt,
+			&i.ReplacedByCardID,
+			&i.RenewedAt,
+			&i.ActivatedAt,
+			&i.RenewalCvvEncrypted,
+			&i.RenewalExpiryMonth,
+			&i.RenewalExpiryYear,
+			&i.RenewalExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const cancelCardWithReason = `-- name: CancelCardWithReason :exec
+UPDATE cards
+SET
+    status = $2,
+    cancellation_reason = $3,
+    cancelled_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1
+`
+
+type CancelCardWithReasonParams struct {
+	ID                 uuid.UUID      `json:"id"`
+	Status             string         `json:"status"`
+	CancellationReason sql.NullString `json:"cancellation_reason"`
+}
+
+func (q *Queries) CancelCardWithReason(ctx context.Context, arg CancelCardWithReasonParams) error {
+	_, err := q.db.ExecContext(ctx, cancelCardWithReason, arg.ID, arg.Status, arg.CancellationReason)
+	return err
+}
+
+const countCardsByPANFingerprint = `-- name: CountCardsByPANFingerprint :one
+SELECT COUNT(*) FROM cards
+WHERE pan_fingerprint = $1
+`
+
+func (q *Queries) CountCardsByPANFingerprint(ctx context.Context, panFingerprint sql.NullString) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countCardsByPANFingerprint, panFingerprint)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countCardsByPANToken = `-- name: CountCardsByPANToken :one
+SELECT COUNT(*) FROM cards
+WHERE pan_token = $1
+`
+
+func (q *Queries) CountCardsByPANToken(ctx context.Context, panToken sql.NullString) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countCardsByPANToken, panToken)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countCardsForRekey = `-- name: CountCardsForRekey :one
+SELECT COUNT(*) FROM cards
+WHERE encryption_key_version <> $1 OR pan_fingerprint IS NULL
+`
+
+func (q *Queries) CountCardsForRekey(ctx context.Context, encryptionKeyVersion int32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countCardsForRekey, encryptionKeyVersion)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countUserActiveCards = `-- name: CountUserActiveCards :one
 SELECT COUNT(*) FROM cards
 WHERE user_id = $1 AND status = 'active'
@@ -58,12 +245,21 @@ INSERT INTO cards (
     is_international,
     block_international,
     block_online,
-    expires_at
+    expires_at,
+    cvv_mode,
+    dcvv_secret_encrypted,
+    profile,
+    amount_cap_cents,
+    pan_fingerprint,
+    pan_token,
+    encryption_key_version,
+    activated_at
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
-    $11, $12, $13, $14, $15, $16, $17, $18, $19, $20
+    $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+    $21, $22, $23, $24, $25, $26, $27, $28
 )
-RETURNING id, user_id, type, brand, status, card_number_encrypted, cvv_encrypted, pin_hash, last_four_digits, holder_name, expiry_month, expiry_year, daily_limit_cents, monthly_limit_cents, current_daily_spent_cents, current_monthly_spent_cents, is_contactless, is_international, block_international, block_online, created_at, updated_at, expires_at, blocked_at
+RETURNING id, user_id, type, brand, status, card_number_encrypted, cvv_encrypted, pin_hash, last_four_digits, holder_name, expiry_month, expiry_year, daily_limit_cents, monthly_limit_cents, current_daily_spent_cents, current_monthly_spent_cents, is_contactless, is_international, block_international, block_online, created_at, updated_at, expires_at, blocked_at, cvv_mode, dcvv_secret_encrypted, profile, locked_merchant_name, locked_merchant_category, amount_cap_cents, total_spent_cents, pan_fingerprint, pan_token, encryption_key_version, cancellation_reason, cancelled_at, replaced_by_card_id, renewed_at, activated_at, renewal_cvv_encrypted, renewal_expiry_month, renewal_expiry_year, renewal_expires_at
 `
 
 type CreateCardParams struct {
@@ -87,6 +283,14 @@ type CreateCardParams struct {
 	BlockInternational       sql.NullBool   `json:"block_international"`
 	BlockOnline              sql.NullBool   `json:"block_online"`
 	ExpiresAt                sql.NullTime   `json:"expires_at"`
+	CvvMode                  string         `json:"cvv_mode"`
+	DcvvSecretEncrypted      []byte         `json:"dcvv_secret_encrypted"`
+	Profile                  string         `json:"profile"`
+	AmountCapCents           sql.NullInt64  `json:"amount_cap_cents"`
+	PanFingerprint           sql.NullString `json:"pan_fingerprint"`
+	PanToken                 sql.NullString `json:"pan_token"`
+	EncryptionKeyVersion     int32          `json:"encryption_key_version"`
+	ActivatedAt              sql.NullTime   `json:"activated_at"`
 }
 
 // ========================================
@@ -114,7 +318,390 @@ func (q *Queries) CreateCard(ctx context.Context, arg CreateCardParams) (Card, e
 		arg.BlockInternational,
 		arg.BlockOnline,
 		arg.ExpiresAt,
+		arg.CvvMode,
+		arg.DcvvSecretEncrypted,
+		arg.Profile,
+		arg.AmountCapCents,
+		arg.PanFingerprint,
+		arg.PanToken,
+		arg.EncryptionKeyVersion,
+		arg.ActivatedAt,
+	)
+	var i Card
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Type,
+		&i.Brand,
+		&i.Status,
+		&i.CardNumberEncrypted,
+		&i.CvvEncrypted,
+		&i.PinHash,
+		&i.LastFourDigits,
+		&i.HolderName,
+		&i.ExpiryMonth,
+		&i.ExpiryYear,
+		&i.DailyLimitCents,
+		&i.MonthlyLimitCents,
+		&i.CurrentDailySpentCents,
+		&i.CurrentMonthlySpentCents,
+		&i.IsContactless,
+		&i.IsInternational,
+		&i.BlockInternational,
+		&i.BlockOnline,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ExpiresAt,
+		&i.BlockedAt,
+		&i.CvvMode,
+		&i.DcvvSecretEncrypted,
+		&i.Profile,
+		&i.LockedMerchantName,
+		&i.LockedMerchantCategory,
+		&i.AmountCapCents,
+		&i.TotalSpentCents,
+		&i.PanFingerprint,
+		&i.PanToken,
+		&i.EncryptionKeyVersion,
+		&i.CancellationReason,
+		&i.CancelledAt,
+		&i.ReplacedByCardID,
+		&i.RenewedAt,
+		&i.ActivatedAt,
+		&i.RenewalCvvEncrypted,
+		&i.RenewalExpiryMonth,
+		&i.RenewalExpiryYear,
+		&i.RenewalExpiresAt,
+	)
+	return i, err
+}
+
+const deleteCard = `-- name: DeleteCard :exec
+UPDATE cards
+SET
+    status = 'cancelled',
+    updated_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) DeleteCard(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteCard, id)
+	return err
+}
+
+const expireCards = `-- name: ExpireCards :many
+UPDATE cards
+SET
+    status = 'expired',
+    updated_at = NOW()
+WHERE status IN ('active', 'blocked')
+  AND expires_at <= $1
+RETURNING id, user_id, type, brand, status, card_number_encrypted, cvv_encrypted, pin_hash, last_four_digits, holder_name, expiry_month, expiry_year, daily_limit_cents, monthly_limit_cents, current_daily_spent_cents, current_monthly_spent_cents, is_contactless, is_international, block_international, block_online, created_at, updated_at, expires_at, blocked_at, cvv_mode, dcvv_secret_encrypted, profile, locked_merchant_name, locked_merchant_category, amount_cap_cents, total_spent_cents, pan_fingerprint, pan_token, encryption_key_version, cancellation_reason, cancelled_at, replaced_by_card_id, renewed_at, activated_at, renewal_cvv_encrypted, renewal_expiry_month, renewal_expiry_year, renewal_expires_at
+`
+
+func (q *Queries) ExpireCards(ctx context.Context, expiresAt sql.NullTime) ([]Card, error) {
+	rows, err := q.db.QueryContext(ctx, expireCards, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Card{}
+	for rows.Next() {
+		var i Card
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Type,
+			&i.Brand,
+			&i.Status,
+			&i.CardNumberEncrypted,
+			&i.CvvEncrypted,
+			&i.PinHash,
+			&i.LastFourDigits,
+			&i.HolderName,
+			&i.ExpiryMonth,
+			&i.ExpiryYear,
+			&i.DailyLimitCents,
+			&i.MonthlyLimitCents,
+			&i.CurrentDailySpentCents,
+			&i.CurrentMonthlySpentCents,
+			&i.IsContactless,
+			&i.IsInternational,
+			&i.BlockInternational,
+			&i.BlockOnline,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ExpiresAt,
+			&i.BlockedAt,
+			&i.CvvMode,
+			&i.DcvvSecretEncrypted,
+			&i.Profile,
+			&i.LockedMerchantName,
+			&i.LockedMerchantCategory,
+			&i.AmountCapCents,
+			&i.TotalSpentCents,
+			&i.PanFingerprint,
+			&i.PanToken,
+			&i.EncryptionKeyVersion,
+			&i.CancellationReason,
+			&i.CancelledAt,
+			&i.ReplacedByCardID,
+			&i.RenewedAt,
+			&i.ActivatedAt,
+			&i.RenewalCvvEncrypted,
+			&i.RenewalExpiryMonth,
+			&i.RenewalExpiryYear,
+			&i.RenewalExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCardByID = `-- name: GetCardByID :one
+SELECT id, user_id, type, brand, status, card_number_encrypted, cvv_encrypted, pin_hash, last_four_digits, holder_name, expiry_month, expiry_year, daily_limit_cents, monthly_limit_cents, current_daily_spent_cents, current_monthly_spent_cents, is_contactless, is_international, block_international, block_online, created_at, updated_at, expires_at, blocked_at, cvv_mode, dcvv_secret_encrypted, profile, locked_merchant_name, locked_merchant_category, amount_cap_cents, total_spent_cents, pan_fingerprint, pan_token, encryption_key_version, cancellation_reason, cancelled_at, replaced_by_card_id, renewed_at, activated_at, renewal_cvv_encrypted, renewal_expiry_month, renewal_expiry_year, renewal_expires_at FROM cards
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetCardByID(ctx context.Context, id uuid.UUID) (Card, error) {
+	row := q.db.QueryRowContext(ctx, getCardByID, id)
+	var i Card
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Type,
+		&i.Brand,
+		&i.Status,
+		&i.CardNumberEncrypted,
+		&i.CvvEncrypted,
+		&i.PinHash,
+		&i.LastFourDigits,
+		&i.HolderName,
+		&i.ExpiryMonth,
+		&i.ExpiryYear,
+		&i.DailyLimitCents,
+		&i.MonthlyLimitCents,
+		&i.CurrentDailySpentCents,
+		&i.CurrentMonthlySpentCents,
+		&i.IsContactless,
+		&i.IsInternational,
+		&i.BlockInternational,
+		&i.BlockOnline,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ExpiresAt,
+		&i.BlockedAt,
+		&i.CvvMode,
+		&i.DcvvSecretEncrypted,
+		&i.Profile,
+		&i.LockedMerchantName,
+		&i.LockedMerchantCategory,
+		&i.AmountCapCents,
+		&i.TotalSpentCents,
+		&i.PanFingerprint,
+		&i.PanToken,
+		&i.EncryptionKeyVersion,
+		&i.CancellationReason,
+		&i.CancelledAt,
+		&i.ReplacedByCardID,
+		&i.RenewedAt,
+		&i.ActivatedAt,
+		&i.RenewalCvvEncrypted,
+		&i.RenewalExpiryMonth,
+		&i.RenewalExpiryYear,
+		&i.RenewalExpiresAt,
+	)
+	return i, err
+}
+
+const getCardByPANFingerprint = `-- name: GetCardByPANFingerprint :one
+SELECT id, user_id, type, brand, status, card_number_encrypted, cvv_encrypted, pin_hash, last_four_digits, holder_name, expiry_month, expiry_year, daily_limit_cents, monthly_limit_cents, current_daily_spent_cents, current_monthly_spent_cents, is_contactless, is_international, block_international, block_online, created_at, updated_at, expires_at, blocked_at, cvv_mode, dcvv_secret_encrypted, profile, locked_merchant_name, locked_merchant_category, amount_cap_cents, total_spent_cents, pan_fingerprint, pan_token, encryption_key_version, cancellation_reason, cancelled_at, replaced_by_card_id, renewed_at, activated_at, renewal_cvv_encrypted, renewal_expiry_month, renewal_expiry_year, renewal_expires_at FROM cards
+WHERE pan_fingerprint = $1
+LIMIT 1
+`
+
+func (q *Queries) GetCardByPANFingerprint(ctx context.Context, panFingerprint sql.NullString) (Card, error) {
+	row := q.db.QueryRowContext(ctx, getCardByPANFingerprint, panFingerprint)
+	var i Card
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Type,
+		&i.Brand,
+		&i.Status,
+		&i.CardNumberEncrypted,
+		&i.CvvEncrypted,
+		&i.PinHash,
+		&i.LastFourDigits,
+		&i.HolderName,
+		&i.ExpiryMonth,
+		&i.ExpiryYear,
+		&i.DailyLimitCents,
+		&i.MonthlyLimitCents,
+		&i.CurrentDailySpentCents,
+		&i.CurrentMonthlySpentCents,
+		&i.IsContactless,
+		&i.IsInternational,
+		&i.BlockInternational,
+		&i.BlockOnline,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ExpiresAt,
+		&i.BlockedAt,
+		&i.CvvMode,
+		&i.DcvvSecretEncrypted,
+		&i.Profile,
+		&i.LockedMerchantName,
+		&i.LockedMerchantCategory,
+		&i.AmountCapCents,
+		&i.TotalSpentCents,
+		&i.PanFingerprint,
+		&i.PanToken,
+		&i.EncryptionKeyVersion,
+		&i.CancellationReason,
+		&i.CancelledAt,
+		&i.ReplacedByCardID,
+		&i.RenewedAt,
+		&i.ActivatedAt,
+		&i.RenewalCvvEncrypted,
+		&i.RenewalExpiryMonth,
+		&i.RenewalExpiryYear,
+		&i.RenewalExpiresAt,
+	)
+	return i, err
+}
+
+const getCardByPANToken = `-- name: GetCardByPANToken :one
+SELECT id, user_id, type, brand, status, card_number_encrypted, cvv_encrypted, pin_hash, last_four_digits, holder_name, expiry_month, expiry_year, daily_limit_cents, monthly_limit_cents, current_daily_spent_cents, current_monthly_spent_cents, is_contactless, is_international, block_international, block_online, created_at, updated_at, expires_at, blocked_at, cvv_mode, dcvv_secret_encrypted, profile, locked_merchant_name, locked_merchant_category, amount_cap_cents, total_spent_cents, pan_fingerprint, pan_token, encryption_key_version, cancellation_reason, cancelled_at, replaced_by_card_id, renewed_at, activated_at, renewal_cvv_encrypted, renewal_expiry_month, renewal_expiry_year, renewal_expires_at FROM cards
+WHERE pan_token = $1
+LIMIT 1
+`
+
+func (q *Queries) GetCardByPANToken(ctx context.Context, panToken sql.NullString) (Card, error) {
+	row := q.db.QueryRowContext(ctx, getCardByPANToken, panToken)
+	var i Card
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Type,
+		&i.Brand,
+		&i.Status,
+		&i.CardNumberEncrypted,
+		&i.CvvEncrypted,
+		&i.PinHash,
+		&i.LastFourDigits,
+		&i.HolderName,
+		&i.ExpiryMonth,
+		&i.ExpiryYear,
+		&i.DailyLimitCents,
+		&i.MonthlyLimitCents,
+		&i.CurrentDailySpentCents,
+		&i.CurrentMonthlySpentCents,
+		&i.IsContactless,
+		&i.IsInternational,
+		&i.BlockInternational,
+		&i.BlockOnline,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ExpiresAt,
+		&i.BlockedAt,
+		&i.CvvMode,
+		&i.DcvvSecretEncrypted,
+		&i.Profile,
+		&i.LockedMerchantName,
+		&i.LockedMerchantCategory,
+		&i.AmountCapCents,
+		&i.TotalSpentCents,
+		&i.PanFingerprint,
+		&i.PanToken,
+		&i.EncryptionKeyVersion,
+		&i.CancellationReason,
+		&i.CancelledAt,
+		&i.ReplacedByCardID,
+		&i.RenewedAt,
+		&i.ActivatedAt,
+		&i.RenewalCvvEncrypted,
+		&i.RenewalExpiryMonth,
+		&i.RenewalExpiryYear,
+		&i.RenewalExpiresAt,
 	)
+	return i, err
+}
+
+const getCardForUpdate = `-- name: GetCardForUpdate :one
+SELECT id, user_id, type, brand, status, card_number_encrypted, cvv_encrypted, pin_hash, last_four_digits, holder_name, expiry_month, expiry_year, daily_limit_cents, monthly_limit_cents, current_daily_spent_cents, current_monthly_spent_cents, is_contactless, is_international, block_international, block_online, created_at, updated_at, expires_at, blocked_at, cvv_mode, dcvv_secret_encrypted, profile, locked_merchant_name, locked_merchant_category, amount_cap_cents, total_spent_cents, pan_fingerprint, pan_token, encryption_key_version, cancellation_reason, cancelled_at, replaced_by_card_id, renewed_at, activated_at, renewal_cvv_encrypted, renewal_expiry_month, renewal_expiry_year, renewal_expires_at FROM cards
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetCardForUpdate(ctx context.Context, id uuid.UUID) (Card, error) {
+	row := q.db.QueryRowContext(ctx, getCardForUpdate, id)
+	var i Card
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Type,
+		&i.Brand,
+		&i.Status,
+		&i.CardNumberEncrypted,
+		&i.CvvEncrypted,
+		&i.PinHash,
+		&i.LastFourDigits,
+		&i.HolderName,
+		&i.ExpiryMonth,
+		&i.ExpiryYear,
+		&i.DailyLimitCents,
+		&i.MonthlyLimitCents,
+		&i.CurrentDailySpentCents,
+		&i.CurrentMonthlySpentCents,
+		&i.IsContactless,
+		&i.IsInternational,
+		&i.BlockInternational,
+		&i.BlockOnline,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ExpiresAt,
+		&i.BlockedAt,
+		&i.CvvMode,
+		&i.DcvvSecretEncrypted,
+		&i.Profile,
+		&i.LockedMerchantName,
+		&i.LockedMerchantCategory,
+		&i.AmountCapCents,
+		&i.TotalSpentCents,
+		&i.PanFingerprint,
+		&i.PanToken,
+		&i.EncryptionKeyVersion,
+		&i.CancellationReason,
+		&i.CancelledAt,
+		&i.ReplacedByCardID,
+		&i.RenewedAt,
+		&i.ActivatedAt,
+		&i.RenewalCvvEncrypted,
+		&i.RenewalExpiryMonth,
+		&i.RenewalExpiryYear,
+		&i.RenewalExpiresAt,
+	)
+	return i, err
+}
+
+const getReplacedCard = `-- name: GetReplacedCard :one
+SELECT id, user_id, type, brand, status, card_number_encrypted, cvv_encrypted, pin_hash, last_four_digits, holder_name, expiry_month, expiry_year, daily_limit_cents, monthly_limit_cents, current_daily_spent_cents, current_monthly_spent_cents, is_contactless, is_international, block_international, block_online, created_at, updated_at, expires_at, blocked_at, cvv_mode, dcvv_secret_encrypted, profile, locked_merchant_name, locked_merchant_category, amount_cap_cents, total_spent_cents, pan_fingerprint, pan_token, encryption_key_version, cancellation_reason, cancelled_at, replaced_by_card_id, renewed_at, activated_at, renewal_cvv_encrypted, renewal_expiry_month, renewal_expiry_year, renewal_expires_at FROM cards
+WHERE replaced_by_card_id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetReplacedCard(ctx context.Context, replacedByCardID uuid.NullUUID) (Card, error) {
+	row := q.db.QueryRowContext(ctx, getReplacedCard, replacedByCardID)
 	var i Card
 	err := row.Scan(
 		&i.ID,
@@ -141,112 +728,284 @@ func (q *Queries) CreateCard(ctx context.Context, arg CreateCardParams) (Card, e
 		&i.UpdatedAt,
 		&i.ExpiresAt,
 		&i.BlockedAt,
+		&i.CvvMode,
+		&i.DcvvSecretEncrypted,
+		&i.Profile,
+		&i.LockedMerchantName,
+		&i.LockedMerchantCategory,
+		&i.AmountCapCents,
+		&i.TotalSpentCents,
+		&i.PanFingerprint,
+		&i.PanToken,
+		&i.EncryptionKeyVersion,
+		&i.CancellationReason,
+		&i.CancelledAt,
+		&i.ReplacedByCardID,
+		&i.RenewedAt,
+		&i.ActivatedAt,
+		&i.RenewalCvvEncrypted,
+		&i.RenewalExpiryMonth,
+		&i.RenewalExpiryYear,
+		&i.RenewalExpiresAt,
 	)
 	return i, err
 }
 
-const deleteCard = `-- name: DeleteCard :exec
-UPDATE cards
-SET
-    status = 'cancelled',
-    updated_at = NOW()
-WHERE id = $1
-`
-
-func (q *Queries) DeleteCard(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteCard, id)
-	return err
-}
+const getUserCardsByStatus = `-- name: GetUserCardsByStatus :many
+SELECT id, user_id, type, brand, status, card_number_encrypted, cvv_encrypted, pin_hash, last_four_digits, holder_name, expiry_month, expiry_year, daily_limit_cents, monthly_limit_cents, current_daily_spent_cents, current_monthly_spent_cents, is_contactless, is_international, block_international, block_online, created_at, updated_at, expires_at, blocked_at, cvv_mode, dcvv_secret_encrypted, profile, locked_merchant_name, locked_merchant_category, amount_cap_cents, total_spent_cents, pan_fingerprint, pan_token, encryption_key_version, cancellation_reason, cancelled_at, replaced_by_card_id, renewed_at, activated_at, renewal_cvv_encrypted, renewal_expiry_month, renewal_expiry_year, renewal_expires_at FROM cards
+WHERE user_id = $1 AND status = $2
+ORDER BY created_at DESC
+`
+
+type GetUserCardsByStatusParams struct {
+	UserID uuid.UUID `json:"user_id"`
+	Status string    `json:"status"`
+}
+
+func (q *Queries) GetUserCardsByStatus(ctx context.Context, arg GetUserCardsByStatusParams) ([]Card, error) {
+	rows, err := q.db.QueryContext(ctx, getUserCardsByStatus, arg.UserID, arg.Status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Card{}
+	for rows.Next() {
+		var i Card
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Type,
+			&i.Brand,
+			&i.Status,
+			&i.CardNumberEncrypted,
+			&i.CvvEncrypted,
+			&i.PinHash,
+			&i.LastFourDigits,
+			&i.HolderName,
+			&i.ExpiryMonth,
+			&i.ExpiryYear,
+			&i.DailyLimitCents,
+			&i.MonthlyLimitCents,
+			&i.CurrentDailySpentCents,
+			&i.CurrentMonthlySpentCents,
+			&i.IsContactless,
+			&i.IsInternational,
+			&i.BlockInternational,
+			&i.BlockOnline,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ExpiresAt,
+			&i.BlockedAt,
+			&i.CvvMode,
+			&i.DcvvSecretEncrypted,
+			&i.Profile,
+			&i.LockedMerchantName,
+			&i.LockedMerchantCategory,
+			&i.AmountCapCents,
+			&i.TotalSpentCents,
+			&i.PanFingerprint,
+			&i.PanToken,
+			&i.EncryptionKeyVersion,
+			&i.CancellationReason,
+			&i.CancelledAt,
+			&i.ReplacedByCardID,
+			&i.RenewedAt,
+			&i.ActivatedAt,
+			&i.RenewalCvvEncrypted,
+			&i.RenewalExpiryMonth,
+			&i.RenewalExpiryYear,
+			&i.RenewalExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listActiveUserCards = `-- name: ListActiveUserCards :many
+SELECT id, user_id, type, brand, status, card_number_encrypted, cvv_encrypted, pin_hash, last_four_digits, holder_name, expiry_month, expiry_year, daily_limit_cents, monthly_limit_cents, current_daily_spent_cents, current_monthly_spent_cents, is_contactless, is_international, block_international, block_online, created_at, updated_at, expires_at, blocked_at, cvv_mode, dcvv_secret_encrypted, profile, locked_merchant_name, locked_merchant_category, amount_cap_cents, total_spent_cents, pan_fingerprint, pan_token, encryption_key_version, cancellation_reason, cancelled_at, replaced_by_card_id, renewed_at, activated_at, renewal_cvv_encrypted, renewal_expiry_month, renewal_expiry_year, renewal_expires_at FROM cards
+WHERE user_id = $1 AND status = 'active'
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListActiveUserCards(ctx context.Context, userID uuid.UUID) ([]Card, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveUserCards, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Card{}
+	for rows.Next() {
+		var i Card
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Type,
+			&i.Brand,
+			&i.Status,
+			&i.CardNumberEncrypted,
+			&i.CvvEncrypted,
+			&i.PinHash,
+			&i.LastFourDigits,
+			&i.HolderName,
+			&i.ExpiryMonth,
+			&i.ExpiryYear,
+			&i.DailyLimitCents,
+			&i.MonthlyLimitCents,
+			&i.CurrentDailySpentCents,
+			&i.CurrentMonthlySpentCents,
+			&i.IsContactless,
+			&i.IsInternational,
+			&i.BlockInternational,
+			&i.BlockOnline,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ExpiresAt,
+			&i.BlockedAt,
+			&i.CvvMode,
+			&i.DcvvSecretEncrypted,
+			&i.Profile,
+			&i.LockedMerchantName,
+			&i.LockedMerchantCategory,
+			&i.AmountCapCents,
+			&i.TotalSpentCents,
+			&i.PanFingerprint,
+			&i.PanToken,
+			&i.EncryptionKeyVersion,
+			&i.CancellationReason,
+			&i.CancelledAt,
+			&i.ReplacedByCardID,
+			&i.RenewedAt,
+			&i.ActivatedAt,
+			&i.RenewalCvvEncrypted,
+			&i.RenewalExpiryMonth,
+			&i.RenewalExpiryYear,
+			&i.RenewalExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCardsDueForRenewal = `-- name: ListCardsDueForRenewal :many
 
-const getCardByID = `-- name: GetCardByID :one
-SELECT id, user_id, type, brand, status, card_number_encrypted, cvv_encrypted, pin_hash, last_four_digits, holder_name, expiry_month, expiry_year, daily_limit_cents, monthly_limit_cents, current_daily_spent_cents, current_monthly_spent_cents, is_contactless, is_international, block_international, block_online, created_at, updated_at, expires_at, blocked_at FROM cards
-WHERE id = $1
-LIMIT 1
+SELECT id, user_id, type, brand, status, card_number_encrypted, cvv_encrypted, pin_hash, last_four_digits, holder_name, expiry_month, expiry_year, daily_limit_cents, monthly_limit_cents, current_daily_spent_cents, current_monthly_spent_cents, is_contactless, is_international, block_international, block_online, created_at, updated_at, expires_at, blocked_at, cvv_mode, dcvv_secret_encrypted, profile, locked_merchant_name, locked_merchant_category, amount_cap_cents, total_spent_cents, pan_fingerprint, pan_token, encryption_key_version, cancellation_reason, cancelled_at, replaced_by_card_id, renewed_at, activated_at, renewal_cvv_encrypted, renewal_expiry_month, renewal_expiry_year, renewal_expires_at FROM cards
+WHERE type = 'physical'
+  AND profile = 'standard'
+  AND status = 'active'
+  AND replaced_by_card_id IS NULL
+  AND renewal_expires_at IS NULL
+  AND expires_at > $1
+  AND expires_at <= $2
+ORDER BY expires_at
 `
 
-func (q *Queries) GetCardByID(ctx context.Context, id uuid.UUID) (Card, error) {
-	row := q.db.QueryRowContext(ctx, getCardByID, id)
-	var i Card
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.Type,
-		&i.Brand,
-		&i.Status,
-		&i.CardNumberEncrypted,
-		&i.CvvEncrypted,
-		&i.PinHash,
-		&i.LastFourDigits,
-		&i.HolderName,
-		&i.ExpiryMonth,
-		&i.ExpiryYear,
-		&i.DailyLimitCents,
-		&i.MonthlyLimitCents,
-		&i.CurrentDailySpentCents,
-		&i.CurrentMonthlySpentCents,
-		&i.IsContactless,
-		&i.IsInternational,
-		&i.BlockInternational,
-		&i.BlockOnline,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.ExpiresAt,
-		&i.BlockedAt,
-	)
-	return i, err
+type ListCardsDueForRenewalParams struct {
+	Now         sql.NullTime `json:"now"`
+	RenewBefore sql.NullTime `json:"renew_before"`
 }
 
-const getCardForUpdate = `-- name: GetCardForUpdate :one
-SELECT id, user_id, type, brand, status, card_number_encrypted, cvv_encrypted, pin_hash, last_four_digits, holder_name, expiry_month, expiry_year, daily_limit_cents, monthly_limit_cents, current_daily_spent_cents, current_monthly_spent_cents, is_contactless, is_international, block_international, block_online, created_at, updated_at, expires_at, blocked_at FROM cards
-WHERE id = $1
-FOR UPDATE
-`
-
-func (q *Queries) GetCardForUpdate(ctx context.Context, id uuid.UUID) (Card, error) {
-	row := q.db.QueryRowContext(ctx, getCardForUpdate, id)
-	var i Card
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.Type,
-		&i.Brand,
-		&i.Status,
-		&i.CardNumberEncrypted,
-		&i.CvvEncrypted,
-		&i.PinHash,
-		&i.LastFourDigits,
-		&i.HolderName,
-		&i.ExpiryMonth,
-		&i.ExpiryYear,
-		&i.DailyLimitCents,
-		&i.MonthlyLimitCents,
-		&i.CurrentDailySpentCents,
-		&i.CurrentMonthlySpentCents,
-		&i.IsContactless,
-		&i.IsInternational,
-		&i.BlockInternational,
-		&i.BlockOnline,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.ExpiresAt,
-		&i.BlockedAt,
-	)
-	return i, err
+// ListCardsDueForRenewal returns active physical cards expiring inside the
+// renewal window that were not renewed or replaced yet.
+func (q *Queries) ListCardsDueForRenewal(ctx context.Context, arg ListCardsDueForRenewalParams) ([]Card, error) {
+	rows, err := q.db.QueryContext(ctx, listCardsDueForRenewal, arg.Now, arg.RenewBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Card{}
+	for rows.Next() {
+		var i Card
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Type,
+			&i.Brand,
+			&i.Status,
+			&i.CardNumberEncrypted,
+			&i.CvvEncrypted,
+			&i.PinHash,
+			&i.LastFourDigits,
+			&i.HolderName,
+			&i.ExpiryMonth,
+			&i.ExpiryYear,
+			&i.DailyLimitCents,
+			&i.MonthlyLimitCents,
+			&i.CurrentDailySpentCents,
+			&i.CurrentMonthlySpentCents,
+			&i.IsContactless,
+			&i.IsInternational,
+			&i.BlockInternational,
+			&i.BlockOnline,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ExpiresAt,
+			&i.BlockedAt,
+			&i.CvvMode,
+			&i.DcvvSecretEncrypted,
+			&i.Profile,
+			&i.LockedMerchantName,
+			&i.LockedMerchantCategory,
+			&i.AmountCapCents,
+			&i.TotalSpentCents,
+			&i.PanFingerprint,
+			&i.PanToken,
+			&i.EncryptionKeyVersion,
+			&i.CancellationReason,
+			&i.CancelledAt,
+			&i.ReplacedByCardID,
+			&i.RenewedAt,
+			&i.ActivatedAt,
+			&i.RenewalCvvEncrypted,
+			&i.RenewalExpiryMonth,
+			&i.RenewalExpiryYear,
+			&i.RenewalExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const getUserCardsByStatus = `-- name: GetUserCardsByStatus :many
-SELECT id, user_id, type, brand, status, card_number_encrypted, cvv_encrypted, pin_hash, last_four_digits, holder_name, expiry_month, expiry_year, daily_limit_cents, monthly_limit_cents, current_daily_spent_cents, current_monthly_spent_cents, is_contactless, is_international, block_international, block_online, created_at, updated_at, expires_at, blocked_at FROM cards
-WHERE user_id = $1 AND status = $2
-ORDER BY created_at DESC
+const listCardsExpiringBetween = `-- name: ListCardsExpiringBetween :many
+SELECT id, user_id, type, brand, status, card_number_encrypted, cvv_encrypted, pin_hash, last_four_digits, holder_name, expiry_month, expiry_year, daily_limit_cents, monthly_limit_cents, current_daily_spent_cents, current_monthly_spent_cents, is_contactless, is_international, block_international, block_online, created_at, updated_at, expires_at, blocked_at, cvv_mode, dcvv_secret_encrypted, profile, locked_merchant_name, locked_merchant_category, amount_cap_cents, total_spent_cents, pan_fingerprint, pan_token, encryption_key_version, cancellation_reason, cancelled_at, replaced_by_card_id, renewed_at, activated_at, renewal_cvv_encrypted, renewal_expiry_month, renewal_expiry_year, renewal_expires_at FROM cards
+WHERE status IN ('active', 'blocked')
+  AND replaced_by_card_id IS NULL
+  AND renewal_expires_at IS NULL
+  AND expires_at > $1
+  AND expires_at <= $2
+ORDER BY expires_at
 `
 
-type GetUserCardsByStatusParams struct {
-	UserID uuid.UUID `json:"user_id"`
-	Status string    `json:"status"`
+type ListCardsExpiringBetweenParams struct {
+	FromTime sql.NullTime `json:"from_time"`
+	ToTime   sql.NullTime `json:"to_time"`
 }
 
-func (q *Queries) GetUserCardsByStatus(ctx context.Context, arg GetUserCardsByStatusParams) ([]Card, error) {
-	rows, err := q.db.QueryContext(ctx, getUserCardsByStatus, arg.UserID, arg.Status)
+func (q *Queries) ListCardsExpiringBetween(ctx context.Context, arg ListCardsExpiringBetweenParams) ([]Card, error) {
+	rows, err := q.db.QueryContext(ctx, listCardsExpiringBetween, arg.FromTime, arg.ToTime)
 	if err != nil {
 		return nil, err
 	}
@@ -279,6 +1038,25 @@ func (q *Queries) GetUserCardsByStatus(ctx context.Context, arg GetUserCardsBySt
 			&i.UpdatedAt,
 			&i.ExpiresAt,
 			&i.BlockedAt,
+			&i.CvvMode,
+			&i.DcvvSecretEncrypted,
+			&i.Profile,
+			&i.LockedMerchantName,
+			&i.LockedMerchantCategory,
+			&i.AmountCapCents,
+			&i.TotalSpentCents,
+			&i.PanFingerprint,
+			&i.PanToken,
+			&i.EncryptionKeyVersion,
+			&i.CancellationReason,
+			&i.CancelledAt,
+			&i.ReplacedByCardID,
+			&i.RenewedAt,
+			&i.ActivatedAt,
+			&i.RenewalCvvEncrypted,
+			&i.RenewalExpiryMonth,
+			&i.RenewalExpiryYear,
+			&i.RenewalExpiresAt,
 		); err != nil {
 			return nil, err
 		}
@@ -293,14 +1071,27 @@ func (q *Queries) GetUserCardsByStatus(ctx context.Context, arg GetUserCardsBySt
 	return items, nil
 }
 
-const listActiveUserCards = `-- name: ListActiveUserCards :many
-SELECT id, user_id, type, brand, status, card_number_encrypted, cvv_encrypted, pin_hash, last_four_digits, holder_name, expiry_month, expiry_year, daily_limit_cents, monthly_limit_cents, current_daily_spent_cents, current_monthly_spent_cents, is_contactless, is_international, block_international, block_online, created_at, updated_at, expires_at, blocked_at FROM cards
-WHERE user_id = $1 AND status = 'active'
-ORDER BY created_at DESC
+const listCardsForRekey = `-- name: ListCardsForRekey :many
+
+SELECT id, user_id, type, brand, status, card_number_encrypted, cvv_encrypted, pin_hash, last_four_digits, holder_name, expiry_month, expiry_year, daily_limit_cents, monthly_limit_cents, current_daily_spent_cents, current_monthly_spent_cents, is_contactless, is_international, block_international, block_online, created_at, updated_at, expires_at, blocked_at, cvv_mode, dcvv_secret_encrypted, profile, locked_merchant_name, locked_merchant_category, amount_cap_cents, total_spent_cents, pan_fingerprint, pan_token, encryption_key_version, cancellation_reason, cancelled_at, replaced_by_card_id, renewed_at, activated_at, renewal_cvv_encrypted, renewal_expiry_month, renewal_expiry_year, renewal_expires_at FROM cards
+WHERE id > $1 AND (encryption_key_version <> $2 OR pan_fingerprint IS NULL)
+ORDER BY id
+LIMIT $3
 `
 
-func (q *Queries) ListActiveUserCards(ctx context.Context, userID uuid.UUID) ([]Card, error) {
-	rows, err := q.db.QueryContext(ctx, listActiveUserCards, userID)
+type ListCardsForRekeyParams struct {
+	ID                   uuid.UUID `json:"id"`
+	EncryptionKeyVersion int32     `json:"encryption_key_version"`
+	Limit                int32     `json:"limit"`
+}
+
+// ========================================
+// KEY ROTATION
+// ========================================
+// Cards not under the current key, or still missing their PAN fingerprint
+// (including cards already re-encrypted on read by the API).
+func (q *Queries) ListCardsForRekey(ctx context.Context, arg ListCardsForRekeyParams) ([]Card, error) {
+	rows, err := q.db.QueryContext(ctx, listCardsForRekey, arg.ID, arg.EncryptionKeyVersion, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -333,6 +1124,25 @@ func (q *Queries) ListActiveUserCards(ctx context.Context, userID uuid.UUID) ([]
 			&i.UpdatedAt,
 			&i.ExpiresAt,
 			&i.BlockedAt,
+			&i.CvvMode,
+			&i.DcvvSecretEncrypted,
+			&i.Profile,
+			&i.LockedMerchantName,
+			&i.LockedMerchantCategory,
+			&i.AmountCapCents,
+			&i.TotalSpentCents,
+			&i.PanFingerprint,
+			&i.PanToken,
+			&i.EncryptionKeyVersion,
+			&i.CancellationReason,
+			&i.CancelledAt,
+			&i.ReplacedByCardID,
+			&i.RenewedAt,
+			&i.ActivatedAt,
+			&i.RenewalCvvEncrypted,
+			&i.RenewalExpiryMonth,
+			&i.RenewalExpiryYear,
+			&i.RenewalExpiresAt,
 		); err != nil {
 			return nil, err
 		}
@@ -348,7 +1158,7 @@ func (q *Queries) ListActiveUserCards(ctx context.Context, userID uuid.UUID) ([]
 }
 
 const listUserCards = `-- name: ListUserCards :many
-SELECT id, user_id, type, brand, status, card_number_encrypted, cvv_encrypted, pin_hash, last_four_digits, holder_name, expiry_month, expiry_year, daily_limit_cents, monthly_limit_cents, current_daily_spent_cents, current_monthly_spent_cents, is_contactless, is_international, block_international, block_online, created_at, updated_at, expires_at, blocked_at FROM cards
+SELECT id, user_id, type, brand, status, card_number_encrypted, cvv_encrypted, pin_hash, last_four_digits, holder_name, expiry_month, expiry_year, daily_limit_cents, monthly_limit_cents, current_daily_spent_cents, current_monthly_spent_cents, is_contactless, is_international, block_international, block_online, created_at, updated_at, expires_at, blocked_at, cvv_mode, dcvv_secret_encrypted, profile, locked_merchant_name, locked_merchant_category, amount_cap_cents, total_spent_cents, pan_fingerprint, pan_token, encryption_key_version, cancellation_reason, cancelled_at, replaced_by_card_id, renewed_at, activated_at, renewal_cvv_encrypted, renewal_expiry_month, renewal_expiry_year, renewal_expires_at FROM cards
 WHERE user_id = $1
 ORDER BY created_at DESC
 `
@@ -387,6 +1197,25 @@ func (q *Queries) ListUserCards(ctx context.Context, userID uuid.UUID) ([]Card,
 			&i.UpdatedAt,
 			&i.ExpiresAt,
 			&i.BlockedAt,
+			&i.CvvMode,
+			&i.DcvvSecretEncrypted,
+			&i.Profile,
+			&i.LockedMerchantName,
+			&i.LockedMerchantCategory,
+			&i.AmountCapCents,
+			&i.TotalSpentCents,
+			&i.PanFingerprint,
+			&i.PanToken,
+			&i.EncryptionKeyVersion,
+			&i.CancellationReason,
+			&i.CancelledAt,
+			&i.ReplacedByCardID,
+			&i.RenewedAt,
+			&i.ActivatedAt,
+			&i.RenewalCvvEncrypted,
+			&i.RenewalExpiryMonth,
+			&i.RenewalExpiryYear,
+			&i.RenewalExpiresAt,
 		); err != nil {
 			return nil, err
 		}
@@ -401,6 +1230,80 @@ func (q *Queries) ListUserCards(ctx context.Context, userID uuid.UUID) ([]Card,
 	return items, nil
 }
 
+const lockCardMerchant = `-- name: LockCardMerchant :exec
+UPDATE cards
+SET
+    locked_merchant_name = $2,
+    locked_merchant_category = $3,
+    updated_at = NOW()
+WHERE id = $1
+`
+
+type LockCardMerchantParams struct {
+	ID                     uuid.UUID      `json:"id"`
+	LockedMerchantName     sql.NullString `json:"locked_merchant_name"`
+	LockedMerchantCategory sql.NullString `json:"locked_merchant_category"`
+}
+
+func (q *Queries) LockCardMerchant(ctx context.Context, arg LockCardMerchantParams) error {
+	_, err := q.db.ExecContext(ctx, lockCardMerchant, arg.ID, arg.LockedMerchantName, arg.LockedMerchantCategory)
+	return err
+}
+
+const renewCardInPlace = `-- name: RenewCardInPlace :execrows
+
+UPDATE cards
+SET
+    card_number_encrypted = $2,
+    cvv_encrypted = $3,
+    dcvv_secret_encrypted = $4,
+    encryption_key_version = $5,
+    renewal_cvv_encrypted = $6,
+    renewal_expiry_month = $7,
+    renewal_expiry_year = $8,
+    renewal_expires_at = $9,
+    renewed_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1
+  AND expires_at = $10
+  AND renewal_expires_at IS NULL
+`
+
+type RenewCardInPlaceParams struct {
+	ID                   uuid.UUID     `json:"id"`
+	CardNumberEncrypted  []byte        `json:"card_number_encrypted"`
+	CvvEncrypted         []byte        `json:"cvv_encrypted"`
+	DcvvSecretEncrypted  []byte        `json:"dcvv_secret_encrypted"`
+	EncryptionKeyVersion int32         `json:"encryption_key_version"`
+	RenewalCvvEncrypted  []byte        `json:"renewal_cvv_encrypted"`
+	RenewalExpiryMonth   sql.NullInt16 `json:"renewal_expiry_month"`
+	RenewalExpiryYear    sql.NullInt16 `json:"renewal_expiry_year"`
+	RenewalExpiresAt     sql.NullTime  `json:"renewal_expires_at"`
+	PreviousExpiresAt    sql.NullTime  `json:"previous_expires_at"`
+}
+
+// RenewCardInPlace keeps the PAN and stores the new expiry and CVV as pending
+// until the new plastic is activated (see ApplyCardRenewal).
+// Affects no rows if the card expiry changed or it was renewed concurrently.
+func (q *Queries) RenewCardInPlace(ctx context.Context, arg RenewCardInPlaceParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, renewCardInPlace,
+		arg.ID,
+		arg.CardNumberEncrypted,
+		arg.CvvEncrypted,
+		arg.DcvvSecretEncrypted,
+		arg.EncryptionKeyVersion,
+		arg.RenewalCvvEncrypted,
+		arg.RenewalExpiryMonth,
+		arg.RenewalExpiryYear,
+		arg.RenewalExpiresAt,
+		arg.PreviousExpiresAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const resetAllDailySpent = `-- name: ResetAllDailySpent :exec
 UPDATE cards
 SET
@@ -453,6 +1356,159 @@ func (q *Queries) ResetMonthlySpent(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const setCardPANFingerprint = `-- name: SetCardPANFingerprint :exec
+UPDATE cards
+SET pan_fingerprint = $2,
+    updated_at = NOW()
+WHERE id = $1 AND pan_fingerprint IS NULL
+`
+
+type SetCardPANFingerprintParams struct {
+	ID             uuid.UUID      `json:"id"`
+	PanFingerprint sql.NullString `json:"pan_fingerprint"`
+}
+
+func (q *Queries) SetCardPANFingerprint(ctx context.Context, arg SetCardPANFingerprintParams) error {
+	_, err := q.db.ExecContext(ctx, setCardPANFingerprint, arg.ID, arg.PanFingerprint)
+	return err
+}
+
+const setCardPANToken = `-- name: SetCardPANToken :one
+
+UPDATE cards
+SET pan_token = $2,
+    updated_at = NOW()
+WHERE id = $1 AND pan_token IS NULL
+RETURNING id, user_id, type, brand, status, card_number_encrypted, cvv_encrypted, pin_hash, last_four_digits, holder_name, expiry_month, expiry_year, daily_limit_cents, monthly_limit_cents, current_daily_spent_cents, current_monthly_spent_cents, is_contactless, is_international, block_international, block_online, created_at, updated_at, expires_at, blocked_at, cvv_mode, dcvv_secret_encrypted, profile, locked_merchant_name, locked_merchant_category, amount_cap_cents, total_spent_cents, pan_fingerprint, pan_token, encryption_key_version, cancellation_reason, cancelled_at, replaced_by_card_id, renewed_at, activated_at, renewal_cvv_encrypted, renewal_expiry_month, renewal_expiry_year, renewal_expires_at
+`
+
+type SetCardPANTokenParams struct {
+	ID       uuid.UUID      `json:"id"`
+	PanToken sql.NullString `json:"pan_token"`
+}
+
+// SetCardPANToken assigns a token only once.
+// Returns no rows if the card already has one.
+func (q *Queries) SetCardPANToken(ctx context.Context, arg SetCardPANTokenParams) (Card, error) {
+	row := q.db.QueryRowContext(ctx, setCardPANToken, arg.ID, arg.PanToken)
+	var i Card
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Type,
+		&i.Brand,
+		&i.Status,
+		&i.CardNumberEncrypted,
+		&i.CvvEncrypted,
+		&i.PinHash,
+		&i.LastFourDigits,
+		&i.HolderName,
+		&i.ExpiryMonth,
+		&i.ExpiryYear,
+		&i.DailyLimitCents,
+		&i.MonthlyLimitCents,
+		&i.CurrentDailySpentCents,
+		&i.CurrentMonthlySpentCents,
+		&i.IsContactless,
+		&i.IsInternational,
+		&i.BlockInternational,
+		&i.BlockOnline,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ExpiresAt,
+		&i.BlockedAt,
+		&i.CvvMode,
+		&i.DcvvSecretEncrypted,
+		&i.Profile,
+		&i.LockedMerchantName,
+		&i.LockedMerchantCategory,
+		&i.AmountCapCents,
+		&i.TotalSpentCents,
+		&i.PanFingerprint,
+		&i.PanToken,
+		&i.EncryptionKeyVersion,
+		&i.CancellationReason,
+		&i.CancelledAt,
+		&i.ReplacedByCardID,
+		&i.RenewedAt,
+		&i.ActivatedAt,
+		&i.RenewalCvvEncrypted,
+		&i.RenewalExpiryMonth,
+		&i.RenewalExpiryYear,
+		&i.RenewalExpiresAt,
+	)
+	return i, err
+}
+
+const setCardReplacement = `-- name: SetCardReplacement :exec
+UPDATE cards
+SET
+    replaced_by_card_id = $2,
+    updated_at = NOW()
+WHERE id = $1
+`
+
+type SetCardReplacementParams struct {
+	ID               uuid.UUID     `json:"id"`
+	ReplacedByCardID uuid.NullUUID `json:"replaced_by_card_id"`
+}
+
+func (q *Queries) SetCardReplacement(ctx context.Context, arg SetCardReplacementParams) error {
+	_, err := q.db.ExecContext(ctx, setCardReplacement, arg.ID, arg.ReplacedByCardID)
+	return err
+}
+
+const updateCardEncryption = `-- name: UpdateCardEncryption :execrows
+
+UPDATE cards
+SET card_number_encrypted = $2,
+    cvv_encrypted = $3,
+    dcvv_secret_encrypted = $4,
+    encryption_key_version = $5,
+    renewal_cvv_encrypted = $6,
+    updated_at = NOW()
+WHERE id = $1
+  AND encryption_key_version = $7
+  AND cvv_encrypted = $8
+  AND dcvv_secret_encrypted IS NOT DISTINCT FROM $9::BYTEA
+  AND renewal_cvv_encrypted IS NOT DISTINCT FROM $10::BYTEA
+`
+
+type UpdateCardEncryptionParams struct {
+	ID                          uuid.UUID `json:"id"`
+	CardNumberEncrypted         []byte    `json:"card_number_encrypted"`
+	CvvEncrypted                []byte    `json:"cvv_encrypted"`
+	DcvvSecretEncrypted         []byte    `json:"dcvv_secret_encrypted"`
+	EncryptionKeyVersion        int32     `json:"encryption_key_version"`
+	RenewalCvvEncrypted         []byte    `json:"renewal_cvv_encrypted"`
+	PreviousKeyVersion          int32     `json:"previous_key_version"`
+	PreviousCvvEncrypted        []byte    `json:"previous_cvv_encrypted"`
+	PreviousDcvvSecretEncrypted []byte    `json:"previous_dcvv_secret_encrypted"`
+	PreviousRenewalCvvEncrypted []byte    `json:"previous_renewal_cvv_encrypted"`
+}
+
+// UpdateCardEncryption stores re-encrypted ciphertexts.
+// Affects no rows if the card was re-encrypted or its CVVs changed concurrently
+// (renewal stored or applied), so stale ciphertexts are never written back.
+func (q *Queries) UpdateCardEncryption(ctx context.Context, arg UpdateCardEncryptionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateCardEncryption,
+		arg.ID,
+		arg.CardNumberEncrypted,
+		arg.CvvEncrypted,
+		arg.DcvvSecretEncrypted,
+		arg.EncryptionKeyVersion,
+		arg.RenewalCvvEncrypted,
+		arg.PreviousKeyVersion,
+		arg.PreviousCvvEncrypted,
+		arg.PreviousDcvvSecretEncrypted,
+		arg.PreviousRenewalCvvEncrypted,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateCardLimits = `-- name: UpdateCardLimits :exec
 UPDATE cards
 SET
@@ -563,3 +1619,21 @@ func (q *Queries) UpdateCardStatus(ctx context.Context, arg UpdateCardStatusPara
 	_, err := q.db.ExecContext(ctx, updateCardStatus, arg.ID, arg.Status)
 	return err
 }
+
+const updateCardTotalSpent = `-- name: UpdateCardTotalSpent :exec
+UPDATE cards
+SET
+    total_spent_cents = $2,
+    updated_at = NOW()
+WHERE id = $1
+`
+
+type UpdateCardTotalSpentParams struct {
+	ID              uuid.UUID `json:"id"`
+	TotalSpentCents int64     `json:"total_spent_cents"`
+}
+
+func (q *Queries) UpdateCardTotalSpent(ctx context.Context, arg UpdateCardTotalSpentParams) error {
+	_, err := q.db.ExecContext(ctx, updateCardTotalSpent, arg.ID, arg.TotalSpentCents)
+	return err
+}
@@ -6,6 +6,7 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -28,18 +29,52 @@ type AuditLog struct {
 }
 
 type Bill struct {
-	ID               uuid.UUID     `json:"id"`
-	UserID           uuid.UUID     `json:"user_id"`
-	Type             string        `json:"type"`
-	Status           string        `json:"status"`
-	Barcode          string        `json:"barcode"`
-	AmountCents      int64         `json:"amount_cents"`
-	FeeCents         sql.NullInt64 `json:"fee_cents"`
-	FinalAmountCents int64         `json:"final_amount_cents"`
-	RecipientName    string        `json:"recipient_name"`
-	DueDate          time.Time     `json:"due_date"`
-	PaymentDate      sql.NullTime  `json:"payment_date"`
-	CreatedAt        sql.NullTime  `json:"created_at"`
+	ID                   uuid.UUID      `json:"id"`
+	UserID               uuid.UUID      `json:"user_id"`
+	Type                 string         `json:"type"`
+	Status               string         `json:"status"`
+	Barcode              string         `json:"barcode"`
+	AmountCents          int64          `json:"amount_cents"`
+	FeeCents             sql.NullInt64  `json:"fee_cents"`
+	FinalAmountCents     int64          `json:"final_amount_cents"`
+	RecipientName        string         `json:"recipient_name"`
+	DueDate              sql.NullTime   `json:"due_date"`
+	PaymentDate          sql.NullTime   `json:"payment_date"`
+	CreatedAt            sql.NullTime   `json:"created_at"`
+	EffectiveDueDate     sql.NullTime   `json:"effective_due_date"`
+	FineCents            int64          `json:"fine_cents"`
+	InterestCents        int64          `json:"interest_cents"`
+	DiscountCents        int64          `json:"discount_cents"`
+	ChargesSource        string         `json:"charges_source"`
+	ChargesCalculatedAt  sql.NullTime   `json:"charges_calculated_at"`
+	ScheduledFor         sql.NullTime   `json:"scheduled_for"`
+	ScheduleStatus       sql.NullString `json:"schedule_status"`
+	PaymentAttempts      int32          `json:"payment_attempts"`
+	LastPaymentAttemptAt sql.NullTime   `json:"last_payment_attempt_at"`
+	FailureReason        sql.NullString `json:"failure_reason"`
+	Source               string         `json:"source"`
+}
+
+type BillChargeRule struct {
+	ID                 uuid.UUID    `json:"id"`
+	PayeeType          string       `json:"payee_type"`
+	PayeeCode          string       `json:"payee_code"`
+	FineBps            int32        `json:"fine_bps"`
+	InterestMonthlyBps int32        `json:"interest_monthly_bps"`
+	DiscountBps        int32        `json:"discount_bps"`
+	DiscountDays       int32        `json:"discount_days"`
+	CreatedAt          sql.NullTime `json:"created_at"`
+	UpdatedAt          sql.NullTime `json:"updated_at"`
+}
+
+type BillRegistryEntry struct {
+	Barcode            string       `json:"barcode"`
+	FineBps            int32        `json:"fine_bps"`
+	InterestMonthlyBps int32        `json:"interest_monthly_bps"`
+	DiscountBps        int32        `json:"discount_bps"`
+	DiscountUntil      sql.NullTime `json:"discount_until"`
+	CreatedAt          sql.NullTime `json:"created_at"`
+	UpdatedAt          sql.NullTime `json:"updated_at"`
 }
 
 type Budget struct {
@@ -81,19 +116,213 @@ type Card struct {
 	UpdatedAt                sql.NullTime   `json:"updated_at"`
 	ExpiresAt                sql.NullTime   `json:"expires_at"`
 	BlockedAt                sql.NullTime   `json:"blocked_at"`
+	CvvMode                  string         `json:"cvv_mode"`
+	DcvvSecretEncrypted      []byte         `json:"dcvv_secret_encrypted"`
+	Profile                  string         `json:"profile"`
+	LockedMerchantName       sql.NullString `json:"locked_merchant_name"`
+	LockedMerchantCategory   sql.NullString `json:"locked_merchant_category"`
+	AmountCapCents           sql.NullInt64  `json:"amount_cap_cents"`
+	TotalSpentCents          int64          `json:"total_spent_cents"`
+	PanFingerprint           sql.NullString `json:"pan_fingerprint"`
+	PanToken                 sql.NullString `json:"pan_token"`
+	EncryptionKeyVersion     int32          `json:"encryption_key_version"`
+	CancellationReason       sql.NullString `json:"cancellation_reason"`
+	CancelledAt              sql.NullTime   `json:"cancelled_at"`
+	ReplacedByCardID         uuid.NullUUID  `json:"replaced_by_card_id"`
+	RenewedAt                sql.NullTime   `json:"renewed_at"`
+	ActivatedAt              sql.NullTime   `json:"activated_at"`
+	RenewalCvvEncrypted      []byte         `json:"renewal_cvv_encrypted"`
+	RenewalExpiryMonth       sql.NullInt16  `json:"renewal_expiry_month"`
+	RenewalExpiryYear        sql.NullInt16  `json:"renewal_expiry_year"`
+	RenewalExpiresAt         sql.NullTime   `json:"renewal_expires_at"`
 }
 
-type CardTransaction struct {
+type CardAccountUpdate struct {
 	ID               uuid.UUID      `json:"id"`
-	CardID           uuid.UUID      `json:"card_id"`
-	UserID           uuid.UUID      `json:"user_id"`
-	AmountCents      int64          `json:"amount_cents"`
+	OldCardID        uuid.UUID      `json:"old_card_id"`
+	NewCardID        uuid.UUID      `json:"new_card_id"`
 	MerchantName     string         `json:"merchant_name"`
 	MerchantCategory sql.NullString `json:"merchant_category"`
 	Status           string         `json:"status"`
-	IsInternational  sql.NullBool   `json:"is_international"`
-	TransactionDate  time.Time      `json:"transaction_date"`
 	CreatedAt        sql.NullTime   `json:"created_at"`
+	UpdatedAt        sql.NullTime   `json:"updated_at"`
+}
+
+type CardBinRange struct {
+	ID        uuid.UUID    `json:"id"`
+	Brand     string       `json:"brand"`
+	Product   string       `json:"product"`
+	Prefix    string       `json:"prefix"`
+	PanLength int16        `json:"pan_length"`
+	IsActive  bool         `json:"is_active"`
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+type CardCategoryControl struct {
+	ID                uuid.UUID     `json:"id"`
+	CardID            uuid.UUID     `json:"card_id"`
+	Category          string        `json:"category"`
+	IsBlocked         bool          `json:"is_blocked"`
+	MonthlyLimitCents sql.NullInt64 `json:"monthly_limit_cents"`
+	CreatedAt         sql.NullTime  `json:"created_at"`
+	UpdatedAt         sql.NullTime  `json:"updated_at"`
+}
+
+type CardDispute struct {
+	ID                     uuid.UUID      `json:"id"`
+	UserID                 uuid.UUID      `json:"user_id"`
+	CardID                 uuid.UUID      `json:"card_id"`
+	CardTransactionID      uuid.UUID      `json:"card_transaction_id"`
+	SupportTicketID        uuid.NullUUID  `json:"support_ticket_id"`
+	Reason                 string         `json:"reason"`
+	Evidence               string         `json:"evidence"`
+	AmountCents            int64          `json:"amount_cents"`
+	Status                 string         `json:"status"`
+	ProvisionalCreditCents int64          `json:"provisional_credit_cents"`
+	ResolutionNote         sql.NullString `json:"resolution_note"`
+	ProvisionalCreditDueAt time.Time      `json:"provisional_credit_due_at"`
+	ResolutionDueAt        time.Time      `json:"resolution_due_at"`
+	ProvisionalCreditedAt  sql.NullTime   `json:"provisional_credited_at"`
+	ResolvedAt             sql.NullTime   `json:"resolved_at"`
+	CreatedAt              sql.NullTime   `json:"created_at"`
+	UpdatedAt              sql.NullTime   `json:"updated_at"`
+	ReversalPendingCents   int64          `json:"reversal_pending_cents"`
+}
+
+type CardFraudDecision struct {
+	ID                uuid.UUID       `json:"id"`
+	CardID            uuid.UUID       `json:"card_id"`
+	UserID            uuid.UUID       `json:"user_id"`
+	CardTransactionID uuid.NullUUID   `json:"card_transaction_id"`
+	AmountCents       int64           `json:"amount_cents"`
+	MerchantName      string          `json:"merchant_name"`
+	Mcc               sql.NullString  `json:"mcc"`
+	Channel           string          `json:"channel"`
+	MerchantCountry   sql.NullString  `json:"merchant_country"`
+	Decision          string          `json:"decision"`
+	Score             int32           `json:"score"`
+	Explanation       json.RawMessage `json:"explanation"`
+	CreatedAt         sql.NullTime    `json:"created_at"`
+}
+
+type CardRevealToken struct {
+	ID         uuid.UUID    `json:"id"`
+	CardID     uuid.UUID    `json:"card_id"`
+	UserID     uuid.UUID    `json:"user_id"`
+	TokenHash  string       `json:"token_hash"`
+	ExpiresAt  time.Time    `json:"expires_at"`
+	ConsumedAt sql.NullTime `json:"consumed_at"`
+	CreatedAt  sql.NullTime `json:"created_at"`
+}
+
+type CardShipment struct {
+	ID              uuid.UUID      `json:"id"`
+	CardID          uuid.UUID      `json:"card_id"`
+	Status          string         `json:"status"`
+	RecipientName   string         `json:"recipient_name"`
+	Street          string         `json:"street"`
+	Number          string         `json:"number"`
+	Complement      sql.NullString `json:"complement"`
+	Neighborhood    string         `json:"neighborhood"`
+	City            string         `json:"city"`
+	State           string         `json:"state"`
+	PostalCode      string         `json:"postal_code"`
+	EmbosserBatchID uuid.NullUUID  `json:"embosser_batch_id"`
+	Carrier         sql.NullString `json:"carrier"`
+	TrackingCode    sql.NullString `json:"tracking_code"`
+	RequestedAt     sql.NullTime   `json:"requested_at"`
+	ProducedAt      sql.NullTime   `json:"produced_at"`
+	ShippedAt       sql.NullTime   `json:"shipped_at"`
+	DeliveredAt     sql.NullTime   `json:"delivered_at"`
+	ActivatedAt     sql.NullTime   `json:"activated_at"`
+	CreatedAt       sql.NullTime   `json:"created_at"`
+	UpdatedAt       sql.NullTime   `json:"updated_at"`
+}
+
+type CardTransaction struct {
+	ID                  uuid.UUID      `json:"id"`
+	CardID              uuid.UUID      `json:"card_id"`
+	UserID              uuid.UUID      `json:"user_id"`
+	AmountCents         int64          `json:"amount_cents"`
+	MerchantName        string         `json:"merchant_name"`
+	MerchantCategory    sql.NullString `json:"merchant_category"`
+	Status              string         `json:"status"`
+	IsInternational     sql.NullBool   `json:"is_international"`
+	TransactionDate     time.Time      `json:"transaction_date"`
+	CreatedAt           sql.NullTime   `json:"created_at"`
+	Mcc                 sql.NullString `json:"mcc"`
+	Channel             sql.NullString `json:"channel"`
+	MerchantCountry     sql.NullString `json:"merchant_country"`
+	MerchantKey         sql.NullString `json:"merchant_key"`
+	MerchantDisplayName sql.NullString `json:"merchant_display_name"`
+	MerchantLogoKey     sql.NullString `json:"merchant_logo_key"`
+	MerchantCity        sql.NullString `json:"merchant_city"`
+}
+
+type FraudRule struct {
+	ID          uuid.UUID       `json:"id"`
+	Code        string          `json:"code"`
+	Description string          `json:"description"`
+	IsEnabled   bool            `json:"is_enabled"`
+	Score       int32           `json:"score"`
+	Params      json.RawMessage `json:"params"`
+	CreatedAt   sql.NullTime    `json:"created_at"`
+	UpdatedAt   sql.NullTime    `json:"updated_at"`
+}
+
+type FraudSetting struct {
+	ID           bool         `json:"id"`
+	StepUpScore  int32        `json:"step_up_score"`
+	DeclineScore int32        `json:"decline_score"`
+	UpdatedAt    sql.NullTime `json:"updated_at"`
+}
+
+type IssuedBoleto struct {
+	ID                 uuid.UUID      `json:"id"`
+	IssuerUserID       uuid.UUID      `json:"issuer_user_id"`
+	IssuerName         string         `json:"issuer_name"`
+	NossoNumero        int64          `json:"nosso_numero"`
+	Barcode            string         `json:"barcode"`
+	LinhaDigitavel     string         `json:"linha_digitavel"`
+	AmountCents        int64          `json:"amount_cents"`
+	DueDate            time.Time      `json:"due_date"`
+	PayerName          string         `json:"payer_name"`
+	PayerDocument      string         `json:"payer_document"`
+	Description        sql.NullString `json:"description"`
+	FineBps            int32          `json:"fine_bps"`
+	InterestMonthlyBps int32          `json:"interest_monthly_bps"`
+	DiscountBps        int32          `json:"discount_bps"`
+	DiscountUntil      sql.NullTime   `json:"discount_until"`
+	Status             string         `json:"status"`
+	PaidAmountCents    sql.NullInt64  `json:"paid_amount_cents"`
+	PaidAt             sql.NullTime   `json:"paid_at"`
+	PayerUserID        uuid.NullUUID  `json:"payer_user_id"`
+	BillID             uuid.NullUUID  `json:"bill_id"`
+	CreatedAt          sql.NullTime   `json:"created_at"`
+	UpdatedAt          sql.NullTime   `json:"updated_at"`
+}
+
+type MerchantCategoryOverride struct {
+	ID           uuid.UUID    `json:"id"`
+	UserID       uuid.UUID    `json:"user_id"`
+	MerchantKey  string       `json:"merchant_key"`
+	MerchantName string       `json:"merchant_name"`
+	Category     string       `json:"category"`
+	CreatedAt    sql.NullTime `json:"created_at"`
+	UpdatedAt    sql.NullTime `json:"updated_at"`
+}
+
+type Notification struct {
+	ID           uuid.UUID      `json:"id"`
+	UserID       uuid.UUID      `json:"user_id"`
+	Type         string         `json:"type"`
+	Title        string         `json:"title"`
+	Message      string         `json:"message"`
+	ResourceType sql.NullString `json:"resource_type"`
+	ResourceID   uuid.NullUUID  `json:"resource_id"`
+	DedupKey     string         `json:"dedup_key"`
+	ReadAt       sql.NullTime   `json:"read_at"`
+	CreatedAt    sql.NullTime   `json:"created_at"`
 }
 
 type SupportTicket struct {
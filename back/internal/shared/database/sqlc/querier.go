@@ -12,16 +12,50 @@ import (
 )
 
 type Querier interface {
+	// ========================================
+	// PHYSICAL CARD ACTIVATION
+	// ========================================
+	ActivateCard(ctx context.Context, id uuid.UUID) error
+	// ApplyCardRenewal moves a pending same-PAN renewal into effect
+	ApplyCardRenewal(ctx context.Context, id uuid.UUID) error
+	// ========================================
+	// EXPIRY LIFECYCLE
+	// ========================================
+	// ApplyOverdueCardRenewals moves the pending same-PAN renewal of cards past
+	// their current expiry into effect: the old plastic no longer works anyway.
+	ApplyOverdueCardRenewals(ctx context.Context, expiresAt sql.NullTime) ([]Card, error)
+	CancelCardWithReason(ctx context.Context, arg CancelCardWithReasonParams) error
+	CancelIssuedBoleto(ctx context.Context, id uuid.UUID) (IssuedBoleto, error)
 	CancelTransfer(ctx context.Context, id uuid.UUID) (Transfer, error)
+	// ConsumeCardRevealToken atomically marks a token as used.
+	// Returns no rows if the token is unknown, expired or already consumed.
+	ConsumeCardRevealToken(ctx context.Context, tokenHash string) (CardRevealToken, error)
+	CountActiveCardDisputesForTransaction(ctx context.Context, cardTransactionID uuid.UUID) (int64, error)
 	CountAllTickets(ctx context.Context) (int64, error)
 	CountAuditLogsByUser(ctx context.Context, userID uuid.NullUUID) (int64, error)
+	CountCardFraudDecisions(ctx context.Context, cardID uuid.UUID) (int64, error)
+	// CountCardFraudDecisionsSince counts scored authorizations on a card since a time
+	// (declined and stepped-up attempts included)
+	CountCardFraudDecisionsSince(ctx context.Context, arg CountCardFraudDecisionsSinceParams) (int64, error)
+	// CountCardSmallFraudDecisionsSince counts scored authorizations up to a small amount on a card since a time
+	CountCardSmallFraudDecisionsSince(ctx context.Context, arg CountCardSmallFraudDecisionsSinceParams) (int64, error)
 	CountCardTransactions(ctx context.Context, cardID uuid.UUID) (int64, error)
+	CountCardsByPANFingerprint(ctx context.Context, panFingerprint sql.NullString) (int64, error)
+	CountCardsByPANToken(ctx context.Context, panToken sql.NullString) (int64, error)
+	CountCardsForRekey(ctx context.Context, encryptionKeyVersion int32) (int64, error)
 	CountTicketMessages(ctx context.Context, ticketID uuid.UUID) (int64, error)
 	CountTicketsByStatus(ctx context.Context, status string) (int64, error)
+	CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int64, error)
 	CountUserActiveCards(ctx context.Context, userID uuid.UUID) (int64, error)
 	CountUserBills(ctx context.Context, userID uuid.UUID) (int64, error)
 	CountUserBudgets(ctx context.Context, userID uuid.UUID) (int64, error)
+	CountUserCardDisputes(ctx context.Context, userID uuid.UUID) (int64, error)
+	CountUserCardTransactionsByMCC(ctx context.Context, arg CountUserCardTransactionsByMCCParams) (int64, error)
 	CountUserCards(ctx context.Context, userID uuid.UUID) (int64, error)
+	CountUserCompletedCardTransactions(ctx context.Context, userID uuid.UUID) (int64, error)
+	CountUserInternationalCardTransactions(ctx context.Context, userID uuid.UUID) (int64, error)
+	CountUserIssuedBoletos(ctx context.Context, arg CountUserIssuedBoletosParams) (int64, error)
+	CountUserNotifications(ctx context.Context, userID uuid.UUID) (int64, error)
 	CountUserTickets(ctx context.Context, userID uuid.UUID) (int64, error)
 	CountUserTicketsByStatus(ctx context.Context, arg CountUserTicketsByStatusParams) (int64, error)
 	CountUserTransfers(ctx context.Context, userID uuid.UUID) (int64, error)
@@ -32,7 +66,32 @@ type Querier interface {
 	// CARDS QUERIES
 	// ========================================
 	CreateCard(ctx context.Context, arg CreateCardParams) (Card, error)
+	CreateCardAccountUpdate(ctx context.Context, arg CreateCardAccountUpdateParams) (CardAccountUpdate, error)
+	// ========================================
+	// CARD CATEGORY CONTROLS QUERIES
+	// ========================================
+	CreateCardCategoryControl(ctx context.Context, arg CreateCardCategoryControlParams) (CardCategoryControl, error)
+	// ========================================
+	// CARD DISPUTES QUERIES
+	// ========================================
+	CreateCardDispute(ctx context.Context, arg CreateCardDisputeParams) (CardDispute, error)
+	CreateCardFraudDecision(ctx context.Context, arg CreateCardFraudDecisionParams) (CardFraudDecision, error)
+	// ========================================
+	// CARD REVEAL TOKENS QUERIES
+	// ========================================
+	CreateCardRevealToken(ctx context.Context, arg CreateCardRevealTokenParams) (CardRevealToken, error)
+	// ========================================
+	// CARD SHIPMENTS QUERIES
+	// ========================================
+	CreateCardShipment(ctx context.Context, arg CreateCardShipmentParams) (CardShipment, error)
 	CreateCardTransaction(ctx context.Context, arg CreateCardTransactionParams) (CardTransaction, error)
+	CreateIssuedBoleto(ctx context.Context, arg CreateIssuedBoletoParams) (IssuedBoleto, error)
+	// ========================================
+	// NOTIFICATIONS QUERIES
+	// ========================================
+	// CreateNotification inserts a notification once per dedup key.
+	// Returns no rows if a notification with the same key already exists.
+	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
 	// Support Tickets Queries
 	CreateTicket(ctx context.Context, arg CreateTicketParams) (SupportTicket, error)
 	// Ticket Messages Queries
@@ -42,27 +101,47 @@ type Querier interface {
 	DeleteBill(ctx context.Context, id uuid.UUID) error
 	DeleteBudget(ctx context.Context, id uuid.UUID) error
 	DeleteCard(ctx context.Context, id uuid.UUID) error
+	DeleteCardCategoryControls(ctx context.Context, cardID uuid.UUID) error
+	DeleteExpiredCardRevealTokens(ctx context.Context) error
+	DeleteMerchantCategoryOverride(ctx context.Context, arg DeleteMerchantCategoryOverrideParams) (int64, error)
 	DeleteTicket(ctx context.Context, id uuid.UUID) error
 	DeleteTicketMessage(ctx context.Context, id uuid.UUID) error
+	ExpireCards(ctx context.Context, expiresAt sql.NullTime) ([]Card, error)
 	GetAuditLogsByRequestID(ctx context.Context, requestID sql.NullString) (AuditLog, error)
 	GetAuditLogsByResource(ctx context.Context, arg GetAuditLogsByResourceParams) ([]AuditLog, error)
 	GetAuditLogsByUserID(ctx context.Context, arg GetAuditLogsByUserIDParams) ([]AuditLog, error)
 	GetBillByBarcode(ctx context.Context, barcode string) (Bill, error)
 	GetBillByID(ctx context.Context, id uuid.UUID) (Bill, error)
+	GetBillChargeRule(ctx context.Context, arg GetBillChargeRuleParams) (BillChargeRule, error)
 	GetBillForUpdate(ctx context.Context, id uuid.UUID) (Bill, error)
+	GetBillRegistryEntry(ctx context.Context, barcode string) (BillRegistryEntry, error)
 	GetBudgetByCategoryAndPeriod(ctx context.Context, arg GetBudgetByCategoryAndPeriodParams) (Budget, error)
 	GetBudgetByID(ctx context.Context, id uuid.UUID) (Budget, error)
 	GetBudgetForUpdate(ctx context.Context, id uuid.UUID) (Budget, error)
 	GetBudgetsNearLimit(ctx context.Context, userID uuid.UUID) ([]Budget, error)
 	GetCardByID(ctx context.Context, id uuid.UUID) (Card, error)
+	GetCardByPANFingerprint(ctx context.Context, panFingerprint sql.NullString) (Card, error)
+	GetCardByPANToken(ctx context.Context, panToken sql.NullString) (Card, error)
+	GetCardCategoryControl(ctx context.Context, arg GetCardCategoryControlParams) (CardCategoryControl, error)
+	GetCardDisputeByID(ctx context.Context, id uuid.UUID) (CardDispute, error)
+	GetCardDisputeForUpdate(ctx context.Context, id uuid.UUID) (CardDispute, error)
 	GetCardForUpdate(ctx context.Context, id uuid.UUID) (Card, error)
+	GetCardShipmentForUpdate(ctx context.Context, id uuid.UUID) (CardShipment, error)
 	GetCardTransactionByID(ctx context.Context, id uuid.UUID) (CardTransaction, error)
+	GetCardTransactionForUpdate(ctx context.Context, id uuid.UUID) (CardTransaction, error)
+	// Spending by category with the user's merchant category overrides applied
 	GetCardTransactionsByCategory(ctx context.Context, arg GetCardTransactionsByCategoryParams) ([]GetCardTransactionsByCategoryRow, error)
-	GetCardTransactionsByDateRange(ctx context.Context, arg GetCardTransactionsByDateRangeParams) ([]CardTransaction, error)
 	GetDailyTransferSum(ctx context.Context, userID uuid.UUID) (int64, error)
+	GetFraudRuleByCode(ctx context.Context, code string) (FraudRule, error)
+	GetFraudSettings(ctx context.Context) (FraudSetting, error)
+	GetIssuedBoletoByBarcode(ctx context.Context, barcode string) (IssuedBoleto, error)
+	GetIssuedBoletoByBarcodeForUpdate(ctx context.Context, barcode string) (IssuedBoleto, error)
+	GetIssuedBoletoByID(ctx context.Context, id uuid.UUID) (IssuedBoleto, error)
+	GetLatestCardShipment(ctx context.Context, cardID uuid.UUID) (CardShipment, error)
 	GetLatestTicketMessage(ctx context.Context, ticketID uuid.UUID) (TicketMessage, error)
 	GetMonthlyTransferSum(ctx context.Context, userID uuid.UUID) (int64, error)
 	GetOverBudgets(ctx context.Context, userID uuid.UUID) ([]Budget, error)
+	GetReplacedCard(ctx context.Context, replacedByCardID uuid.NullUUID) (Card, error)
 	GetTicketByID(ctx context.Context, id uuid.UUID) (SupportTicket, error)
 	GetTicketByNumber(ctx context.Context, ticketNumber string) (SupportTicket, error)
 	GetTicketForUpdate(ctx context.Context, id uuid.UUID) (SupportTicket, error)
@@ -75,47 +154,156 @@ type Querier interface {
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (User, error)
 	GetUserByKratosID(ctx context.Context, kratosIdentityID string) (User, error)
+	// GetUserCardSpendingProfile returns the median amount and count of the user's completed card transactions since a time
+	GetUserCardSpendingProfile(ctx context.Context, arg GetUserCardSpendingProfileParams) (GetUserCardSpendingProfileRow, error)
+	GetUserCardTransactionByMerchantKey(ctx context.Context, arg GetUserCardTransactionByMerchantKeyParams) (CardTransaction, error)
 	GetUserCardsByStatus(ctx context.Context, arg GetUserCardsByStatusParams) ([]Card, error)
 	GetUserForUpdate(ctx context.Context, id uuid.UUID) (User, error)
 	IncrementBudgetSpent(ctx context.Context, arg IncrementBudgetSpentParams) (Budget, error)
+	// ========================================
+	// CARD BIN RANGES QUERIES
+	// ========================================
+	ListActiveCardBINRanges(ctx context.Context, arg ListActiveCardBINRangesParams) ([]CardBinRange, error)
 	ListActiveUserCards(ctx context.Context, userID uuid.UUID) ([]Card, error)
+	// ListActiveUsersWithCPF lists active users with a CPF after an id cursor
+	ListActiveUsersWithCPF(ctx context.Context, arg ListActiveUsersWithCPFParams) ([]User, error)
 	// Admin/Staff Queries
 	ListAllTickets(ctx context.Context, arg ListAllTicketsParams) ([]SupportTicket, error)
+	ListBillChargeRules(ctx context.Context) ([]BillChargeRule, error)
+	ListCardAccountUpdates(ctx context.Context, newCardID uuid.UUID) ([]CardAccountUpdate, error)
+	ListCardCategoryControls(ctx context.Context, cardID uuid.UUID) ([]CardCategoryControl, error)
+	// ListCardDisputesPastProvisionalDeadline returns opened disputes whose provisional credit is overdue
+	ListCardDisputesPastProvisionalDeadline(ctx context.Context, arg ListCardDisputesPastProvisionalDeadlineParams) ([]uuid.UUID, error)
+	// ListCardDisputesPastResolutionDeadline returns unresolved disputes whose resolution is overdue
+	ListCardDisputesPastResolutionDeadline(ctx context.Context, arg ListCardDisputesPastResolutionDeadlineParams) ([]uuid.UUID, error)
+	// ListCardDisputesWithReversalPending returns lost disputes whose provisional credit is not fully reversed
+	ListCardDisputesWithReversalPending(ctx context.Context, limit int32) ([]uuid.UUID, error)
+	// ListCardDisputesWithoutTicket returns disputes whose support ticket could not be opened
+	ListCardDisputesWithoutTicket(ctx context.Context, limit int32) ([]CardDispute, error)
+	ListCardFraudDecisions(ctx context.Context, arg ListCardFraudDecisionsParams) ([]CardFraudDecision, error)
+	// ========================================
+	// CARD ACCOUNT UPDATES QUERIES
+	// ========================================
+	// ListCardRecurringMerchants returns merchants with completed charges in at
+	// least two distinct months since the given date (card-on-file subscriptions).
+	ListCardRecurringMerchants(ctx context.Context, arg ListCardRecurringMerchantsParams) ([]ListCardRecurringMerchantsRow, error)
 	ListCardTransactions(ctx context.Context, arg ListCardTransactionsParams) ([]CardTransaction, error)
+	// ListCardsDueForRenewal returns active physical cards expiring inside the
+	// renewal window that were not renewed or replaced yet.
+	ListCardsDueForRenewal(ctx context.Context, arg ListCardsDueForRenewalParams) ([]Card, error)
+	ListCardsExpiringBetween(ctx context.Context, arg ListCardsExpiringBetweenParams) ([]Card, error)
+	// ========================================
+	// KEY ROTATION
+	// ========================================
+	// Cards not under the current key, or still missing their PAN fingerprint
+	// (including cards already re-encrypted on read by the API).
+	ListCardsForRekey(ctx context.Context, arg ListCardsForRekeyParams) ([]Card, error)
+	// ListDueScheduledBills lists unpaid bills scheduled up to a date, after a (scheduled_for, id) cursor
+	ListDueScheduledBills(ctx context.Context, arg ListDueScheduledBillsParams) ([]Bill, error)
+	// ListDueScheduledTEDs lists TEDs waiting for their settlement date (requested outside the TED window)
+	ListDueScheduledTEDs(ctx context.Context, arg ListDueScheduledTEDsParams) ([]Transfer, error)
+	ListEnabledFraudRules(ctx context.Context) ([]FraudRule, error)
+	// Filters are optional (NULL = any). end_date is exclusive, search is an ILIKE
+	// pattern fragment and category honors the user's merchant category overrides. Keyset pagination: pass the last row's (transaction_date, id) as cursor.
+	ListFilteredCardTransactions(ctx context.Context, arg ListFilteredCardTransactionsParams) ([]CardTransaction, error)
+	// ========================================
+	// FRAUD RULES QUERIES
+	// ========================================
+	ListFraudRules(ctx context.Context) ([]FraudRule, error)
+	// ListOverdueBills lists bills past their due date that are still pending, or
+	// overdue with charges last calculated before calculated_before, after a (due_date, id) cursor
 	ListOverdueBills(ctx context.Context, arg ListOverdueBillsParams) ([]Bill, error)
+	// ListShipmentsForEmbossing locks requested shipments of usable cards.
+	// Rows locked by a concurrent export are skipped.
+	ListShipmentsForEmbossing(ctx context.Context, limit int32) ([]CardShipment, error)
 	ListTicketMessages(ctx context.Context, arg ListTicketMessagesParams) ([]TicketMessage, error)
 	ListTicketsByStatus(ctx context.Context, arg ListTicketsByStatusParams) ([]SupportTicket, error)
+	ListUnenrichedCardTransactions(ctx context.Context, limit int32) ([]CardTransaction, error)
 	ListUserBills(ctx context.Context, arg ListUserBillsParams) ([]Bill, error)
 	ListUserBillsByStatus(ctx context.Context, arg ListUserBillsByStatusParams) ([]Bill, error)
 	ListUserBudgets(ctx context.Context, arg ListUserBudgetsParams) ([]Budget, error)
 	ListUserBudgetsByCategory(ctx context.Context, arg ListUserBudgetsByCategoryParams) ([]Budget, error)
 	ListUserBudgetsByPeriod(ctx context.Context, arg ListUserBudgetsByPeriodParams) ([]Budget, error)
+	ListUserCardDisputes(ctx context.Context, arg ListUserCardDisputesParams) ([]CardDispute, error)
 	ListUserCardTransactions(ctx context.Context, arg ListUserCardTransactionsParams) ([]CardTransaction, error)
 	ListUserCards(ctx context.Context, userID uuid.UUID) ([]Card, error)
+	// ListUserIssuedBoletos lists the boletos issued by a user, optionally by status
+	ListUserIssuedBoletos(ctx context.Context, arg ListUserIssuedBoletosParams) ([]IssuedBoleto, error)
+	ListUserMerchantCategoryOverrides(ctx context.Context, userID uuid.UUID) ([]MerchantCategoryOverride, error)
+	ListUserNotifications(ctx context.Context, arg ListUserNotificationsParams) ([]Notification, error)
 	ListUserTickets(ctx context.Context, arg ListUserTicketsParams) ([]SupportTicket, error)
 	ListUserTicketsByStatus(ctx context.Context, arg ListUserTicketsByStatusParams) ([]SupportTicket, error)
 	ListUserTransfers(ctx context.Context, arg ListUserTransfersParams) ([]Transfer, error)
 	ListUserTransfersByStatus(ctx context.Context, arg ListUserTransfersByStatusParams) ([]Transfer, error)
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	LockCardMerchant(ctx context.Context, arg LockCardMerchantParams) error
+	// MarkBillAsPaid pays a bill; a pending schedule is closed as paid
 	MarkBillAsPaid(ctx context.Context, id uuid.UUID) (Bill, error)
+	MarkCardDisputeProvisionalCredit(ctx context.Context, arg MarkCardDisputeProvisionalCreditParams) error
+	// MarkIssuedBoletoPaid records the payment of an open boleto by a user of the platform
+	MarkIssuedBoletoPaid(ctx context.Context, arg MarkIssuedBoletoPaidParams) (IssuedBoleto, error)
+	MarkNotificationRead(ctx context.Context, arg MarkNotificationReadParams) (int64, error)
+	MarkShipmentActivated(ctx context.Context, id uuid.UUID) error
+	MarkShipmentDelivered(ctx context.Context, id uuid.UUID) error
+	MarkShipmentProduced(ctx context.Context, arg MarkShipmentProducedParams) error
+	MarkShipmentShipped(ctx context.Context, arg MarkShipmentShippedParams) error
+	// NextIssuedBoletoNossoNumero reserves the nosso número of a new boleto
+	NextIssuedBoletoNossoNumero(ctx context.Context) (int64, error)
+	// RecordBillPaymentFailure records a failed scheduled payment attempt; the
+	// schedule fails when no retry is left
+	RecordBillPaymentFailure(ctx context.Context, arg RecordBillPaymentFailureParams) (Bill, error)
+	// RenewCardInPlace keeps the PAN and stores the new expiry and CVV as pending
+	// until the new plastic is activated (see ApplyCardRenewal).
+	// Affects no rows if the card expiry changed or it was renewed concurrently.
+	RenewCardInPlace(ctx context.Context, arg RenewCardInPlaceParams) (int64, error)
 	ResetAllDailySpent(ctx context.Context) error
 	ResetAllMonthlySpent(ctx context.Context) error
 	ResetBudgetSpent(ctx context.Context, userID uuid.UUID) error
 	ResetDailySpent(ctx context.Context, id uuid.UUID) error
 	ResetMonthlySpent(ctx context.Context, id uuid.UUID) error
+	ResolveCardDispute(ctx context.Context, arg ResolveCardDisputeParams) error
+	// ScheduleBillPayment schedules the payment of a bill, resetting previous attempts
+	ScheduleBillPayment(ctx context.Context, arg ScheduleBillPaymentParams) (Bill, error)
+	SetCardDisputeReversalPending(ctx context.Context, arg SetCardDisputeReversalPendingParams) error
+	SetCardDisputeTicket(ctx context.Context, arg SetCardDisputeTicketParams) error
+	SetCardPANFingerprint(ctx context.Context, arg SetCardPANFingerprintParams) error
+	// SetCardPANToken assigns a token only once.
+	// Returns no rows if the card already has one.
+	SetCardPANToken(ctx context.Context, arg SetCardPANTokenParams) (Card, error)
+	SetCardReplacement(ctx context.Context, arg SetCardReplacementParams) error
+	SetCardTransactionMerchant(ctx context.Context, arg SetCardTransactionMerchantParams) error
+	SumCardCategorySpent(ctx context.Context, arg SumCardCategorySpentParams) (int64, error)
+	// Totals of ListFilteredCardTransactions over every page (same filters, no cursor)
+	SummarizeFilteredCardTransactions(ctx context.Context, arg SummarizeFilteredCardTransactionsParams) (SummarizeFilteredCardTransactionsRow, error)
+	UnscheduleBillPayment(ctx context.Context, id uuid.UUID) (Bill, error)
+	// UpdateBillCharges stores the charges breakdown recalculated for a payment date
+	UpdateBillCharges(ctx context.Context, arg UpdateBillChargesParams) (Bill, error)
 	UpdateBillStatus(ctx context.Context, arg UpdateBillStatusParams) (Bill, error)
 	UpdateBudget(ctx context.Context, arg UpdateBudgetParams) (Budget, error)
 	UpdateBudgetSpent(ctx context.Context, arg UpdateBudgetSpentParams) (Budget, error)
+	// UpdateCardEncryption stores re-encrypted ciphertexts.
+	// Affects no rows if the card was re-encrypted or its CVVs changed concurrently
+	// (renewal stored or applied), so stale ciphertexts are never written back.
+	UpdateCardEncryption(ctx context.Context, arg UpdateCardEncryptionParams) (int64, error)
 	UpdateCardLimits(ctx context.Context, arg UpdateCardLimitsParams) error
 	UpdateCardPIN(ctx context.Context, arg UpdateCardPINParams) error
 	UpdateCardSecuritySettings(ctx context.Context, arg UpdateCardSecuritySettingsParams) error
 	UpdateCardSpentAmounts(ctx context.Context, arg UpdateCardSpentAmountsParams) error
 	UpdateCardStatus(ctx context.Context, arg UpdateCardStatusParams) error
+	UpdateCardTotalSpent(ctx context.Context, arg UpdateCardTotalSpentParams) error
+	UpdateCardTransactionStatus(ctx context.Context, arg UpdateCardTransactionStatusParams) error
+	UpdateFraudRule(ctx context.Context, arg UpdateFraudRuleParams) (FraudRule, error)
+	UpdateFraudSettings(ctx context.Context, arg UpdateFraudSettingsParams) (FraudSetting, error)
 	UpdateTicket(ctx context.Context, arg UpdateTicketParams) (SupportTicket, error)
 	UpdateTicketStatus(ctx context.Context, arg UpdateTicketStatusParams) (SupportTicket, error)
 	UpdateTransferStatus(ctx context.Context, arg UpdateTransferStatusParams) (Transfer, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
 	UpdateUserBalance(ctx context.Context, arg UpdateUserBalanceParams) error
+	// UpsertBillChargeRule creates or replaces the charge rule of a payee
+	UpsertBillChargeRule(ctx context.Context, arg UpsertBillChargeRuleParams) (BillChargeRule, error)
+	// UpsertBillRegistryEntry registers the charges of a boleto (CIP/NPC stand-in)
+	UpsertBillRegistryEntry(ctx context.Context, arg UpsertBillRegistryEntryParams) (BillRegistryEntry, error)
+	UpsertMerchantCategoryOverride(ctx context.Context, arg UpsertMerchantCategoryOverrideParams) (MerchantCategoryOverride, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -268,6 +268,68 @@ func (q *Queries) GetTransferForUpdate(ctx context.Context, id uuid.UUID) (Trans
 	return i, err
 }
 
+const listDueScheduledTEDs = `-- name: ListDueScheduledTEDs :many
+
+SELECT id, user_id, type, status, amount_cents, fee_cents, currency, pix_key, pix_key_type, recipient_name, recipient_document, recipient_bank, recipient_branch, recipient_account, recipient_account_type, recipient_user_id, scheduled_for, completed_at, failure_reason, authentication_code, created_at, updated_at FROM transfers
+WHERE type = 'ted'
+  AND status = 'processing'
+  AND scheduled_for <= $1
+ORDER BY scheduled_for, id
+LIMIT $2
+`
+
+type ListDueScheduledTEDsParams struct {
+	ScheduledFor sql.NullTime `json:"scheduled_for"`
+	Limit        int32        `json:"limit"`
+}
+
+// ListDueScheduledTEDs lists TEDs waiting for their settlement date (requested outside the TED window)
+func (q *Queries) ListDueScheduledTEDs(ctx context.Context, arg ListDueScheduledTEDsParams) ([]Transfer, error) {
+	rows, err := q.db.QueryContext(ctx, listDueScheduledTEDs, arg.ScheduledFor, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Transfer{}
+	for rows.Next() {
+		var i Transfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Type,
+			&i.Status,
+			&i.AmountCents,
+			&i.FeeCents,
+			&i.Currency,
+			&i.PixKey,
+			&i.PixKeyType,
+			&i.RecipientName,
+			&i.RecipientDocument,
+			&i.RecipientBank,
+			&i.RecipientBranch,
+			&i.RecipientAccount,
+			&i.RecipientAccountType,
+			&i.RecipientUserID,
+			&i.ScheduledFor,
+			&i.CompletedAt,
+			&i.FailureReason,
+			&i.AuthenticationCode,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUserTransfers = `-- name: ListUserTransfers :many
 SELECT id, user_id, type, status, amount_cents, fee_cents, currency, pix_key, pix_key_type, recipient_name, recipient_document, recipient_bank, recipient_branch, recipient_account, recipient_account_type, recipient_user_id, scheduled_for, completed_at, failure_reason, authentication_code, created_at, updated_at FROM transfers
 WHERE user_id = $1
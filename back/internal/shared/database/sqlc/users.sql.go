@@ -150,6 +150,58 @@ func (q *Queries) GetUserForUpdate(ctx context.Context, id uuid.UUID) (User, err
 	return i, err
 }
 
+const listActiveUsersWithCPF = `-- name: ListActiveUsersWithCPF :many
+
+SELECT id, kratos_identity_id, email, full_name, cpf, balance_cents, daily_transfer_limit_cents, monthly_transfer_limit_cents, status, kyc_status, created_at, updated_at FROM users
+WHERE status = 'active'
+  AND cpf IS NOT NULL
+  AND id > $1::UUID
+ORDER BY id
+LIMIT $2
+`
+
+type ListActiveUsersWithCPFParams struct {
+	AfterID   uuid.UUID `json:"after_id"`
+	BatchSize int32     `json:"batch_size"`
+}
+
+// ListActiveUsersWithCPF lists active users with a CPF after an id cursor
+func (q *Queries) ListActiveUsersWithCPF(ctx context.Context, arg ListActiveUsersWithCPFParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveUsersWithCPF, arg.AfterID, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.KratosIdentityID,
+			&i.Email,
+			&i.FullName,
+			&i.Cpf,
+			&i.BalanceCents,
+			&i.DailyTransferLimitCents,
+			&i.MonthlyTransferLimitCents,
+			&i.Status,
+			&i.KycStatus,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUsers = `-- name: ListUsers :many
 SELECT id, kratos_identity_id, email, full_name, cpf, balance_cents, daily_transfer_limit_cents, monthly_transfer_limit_cents, status, kyc_status, created_at, updated_at FROM users
 ORDER BY created_at DESC
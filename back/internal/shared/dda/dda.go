@@ -0,0 +1,78 @@
+// Package dda fetches the boletos issued against a payer through DDA (Débito
+// Direto Autorizado).
+//
+// Banks register every boleto with the payer's CPF or CNPJ, and DDA lets the
+// payer's bank list them. Providers implement the integration; FileProvider is
+// a local stand-in that reads boletos from JSON files.
+package dda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrInvalidDocument is returned when the payer document is not 11 or 14 digits
+var ErrInvalidDocument = errors.New("dda: invalid payer document")
+
+// Boleto is a boleto registered against a payer
+type Boleto struct {
+	ID                string `json:"id"`                 // Provider identifier of the boleto
+	Barcode           string `json:"barcode"`            // Barcode (44 digits) or linha digitável (47 digits)
+	PayerDocument     string `json:"payer_document"`     // CPF or CNPJ of the payer (digits)
+	RecipientName     string `json:"recipient_name"`     // Beneficiary (cedente)
+	RecipientDocument string `json:"recipient_document"` // CPF or CNPJ of the beneficiary
+	AmountCents       int64  `json:"amount_cents"`       // Registered amount
+}
+
+// Provider lists the boletos registered against a payer
+type Provider interface {
+	FetchBoletos(ctx context.Context, payerDocument string) ([]Boleto, error)
+}
+
+// FileProvider is a DDA stand-in reading <dir>/<document>.json, a JSON array of
+// boletos. A payer without a file has no boletos.
+type FileProvider struct {
+	dir string
+}
+
+// NewFileProvider creates a file provider reading from dir
+func NewFileProvider(dir string) *FileProvider {
+	return &FileProvider{dir: dir}
+}
+
+// FetchBoletos returns the boletos of the payer's file
+func (p *FileProvider) FetchBoletos(ctx context.Context, payerDocument string) ([]Boleto, error) {
+	if !isDocument(payerDocument) {
+		return nil, ErrInvalidDocument
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(p.dir, payerDocument+".json"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("dda: %w", err)
+	}
+
+	var boletos []Boleto
+	if err := json.Unmarshal(data, &boletos); err != nil {
+		return nil, fmt.Errorf("dda: parse %s.json: %w", payerDocument, err)
+	}
+	return boletos, nil
+}
+
+// isDocument reports whether value is a CPF (11 digits) or CNPJ (14 digits)
+func isDocument(value string) bool {
+	if len(value) != 11 && len(value) != 14 {
+		return false
+	}
+	return strings.Trim(value, "0123456789") == ""
+}
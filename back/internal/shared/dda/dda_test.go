@@ -0,0 +1,52 @@
+package dda
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestFileProvider tests reading a payer's boletos, payers without a file and invalid input
+func TestFileProvider(t *testing.T) {
+	dir := t.TempDir()
+	data := `[{"id": "dda-1", "barcode": "00191234500000100000000000000000000000000017", "payer_document": "12345678909",
+		"recipient_name": "Escola Exemplo", "recipient_document": "11222333000181", "amount_cents": 10000}]`
+	if err := os.WriteFile(filepath.Join(dir, "12345678909.json"), []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "98765432100.json"), []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	provider := NewFileProvider(dir)
+	ctx := context.Background()
+
+	boletos, err := provider.FetchBoletos(ctx, "12345678909")
+	if err != nil {
+		t.Fatalf("FetchBoletos() error = %v", err)
+	}
+	want := Boleto{
+		ID:                "dda-1",
+		Barcode:           "00191234500000100000000000000000000000000017",
+		PayerDocument:     "12345678909",
+		RecipientName:     "Escola Exemplo",
+		RecipientDocument: "11222333000181",
+		AmountCents:       10000,
+	}
+	if len(boletos) != 1 || boletos[0] != want {
+		t.Errorf("FetchBoletos() = %+v, want [%+v]", boletos, want)
+	}
+
+	if boletos, err := provider.FetchBoletos(ctx, "11144477735"); err != nil || len(boletos) != 0 {
+		t.Errorf("payer without file: FetchBoletos() = %v, %v, want no boletos", boletos, err)
+	}
+	if _, err := provider.FetchBoletos(ctx, "98765432100"); err == nil {
+		t.Error("FetchBoletos() accepted an invalid file")
+	}
+	for _, document := range []string{"", "123", "../../../etc/passwd", "1234567890a"} {
+		if _, err := provider.FetchBoletos(ctx, document); !errors.Is(err, ErrInvalidDocument) {
+			t.Errorf("FetchBoletos(%q) error = %v, expected %v", document, err, ErrInvalidDocument)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Package jobs runs periodic background jobs.
+//
+// Every run takes a PostgreSQL advisory lock named after the job, so with
+// several API instances running only one of them executes a given job at a
+// time; the others skip that tick.
+//
+// Jobs work through their items one by one: an item that fails does not stop
+// the run, and its error is joined into the error the job returns, which the
+// scheduler logs. The item stays pending and is picked up again on a later tick.
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"hash/fnv"
+	"log"
+	"sync"
+	"time"
+)
+
+// Job is a unit of periodic background work
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs jobs at fixed intervals until its context is cancelled
+type Scheduler struct {
+	db   *sql.DB
+	jobs []Job
+}
+
+// NewScheduler creates a scheduler for the given jobs
+func NewScheduler(database *sql.DB, jobs ...Job) *Scheduler {
+	return &Scheduler{
+		db:   database,
+		jobs: jobs,
+	}
+}
+
+// Start runs every job once immediately and then on its interval.
+// It blocks until ctx is cancelled and all running jobs have returned.
+func (s *Scheduler) Start(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, job := range s.jobs {
+		if job.Interval <= 0 || job.Run == nil {
+			log.Printf("Job %s: disabled (no interval or run function)", job.Name)
+			continue
+		}
+
+		wg.Add(1)
+		go func(job Job) {
+			defer wg.Done()
+			s.loop(ctx, job)
+		}(job)
+	}
+	wg.Wait()
+}
+
+// loop runs a job on its interval until ctx is cancelled
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce runs a job under its advisory lock, logging (not propagating) failures
+func (s *Scheduler) runOnce(ctx context.Context, job Job) {
+	start := time.Now()
+
+	ran, err := s.withLock(ctx, job.Name, job.Run)
+	switch {
+	case err != nil && ctx.Err() == nil:
+		log.Printf("Job %s: failed after %s: %v", job.Name, time.Since(start).Round(time.Millisecond), err)
+	case err == nil && ran:
+		log.Printf("Job %s: completed in %s", job.Name, time.Since(start).Round(time.Millisecond))
+	}
+}
+
+// withLock runs fn while holding the job's session-level advisory lock.
+// ran is false if another instance holds the lock.
+func (s *Scheduler) withLock(ctx context.Context, name string, fn func(ctx context.Context) error) (ran bool, err error) {
+	// Advisory locks belong to a session, so lock and unlock on one connection
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	key := LockKey(name)
+
+	var locked bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&locked); err != nil {
+		return false, err
+	}
+	if !locked {
+		return false, nil
+	}
+	defer func() {
+		// Unlock even if the job context was cancelled
+		if _, unlockErr := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", key); unlockErr != nil {
+			log.Printf("Job %s: failed to release lock: %v", name, unlockErr)
+		}
+	}()
+
+	return true, fn(ctx)
+}
+
+// LockKey derives the advisory lock key of a job from its name
+func LockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("jobs:" + name))
+	return int64(h.Sum64())
+}
@@ -0,0 +1,24 @@
+package jobs
+
+import "testing"
+
+// TestLockKey tests that lock keys are stable and distinct per job
+func TestLockKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  string
+		equal bool
+	}{
+		{name: "same job", a: "card_expiry", b: "card_expiry", equal: true},
+		{name: "different jobs", a: "card_expiry", b: "bills_overdue", equal: false},
+		{name: "case sensitive", a: "card_expiry", b: "CARD_EXPIRY", equal: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LockKey(tt.a) == LockKey(tt.b); got != tt.equal {
+				t.Errorf("LockKey(%q) == LockKey(%q) = %v, want %v", tt.a, tt.b, got, tt.equal)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// Package mcc maps ISO 18245 merchant category codes (MCC) to spending categories.
+//
+// The mapping table is shipped with the service (mcc_categories.csv) and is
+// shared by card authorization (category blocking and limits), budgets and
+// analytics so that every module categorizes merchant_category the same way.
+package mcc
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Spending categories
+const (
+	CategoryGroceries      = "groceries"
+	CategoryDining         = "dining"
+	CategoryTransportation = "transportation"
+	CategoryTravel         = "travel"
+	CategoryEntertainment  = "entertainment"
+	CategoryShopping       = "shopping"
+	CategoryBills          = "bills"
+	CategoryHealth         = "health"
+	CategoryEducation      = "education"
+	CategoryGambling       = "gambling"
+	CategoryAdult          = "adult"
+	CategoryCrypto         = "crypto"
+	CategoryCash           = "cash"
+	CategoryFinancial      = "financial"
+	CategoryOther          = "other"
+)
+
+// categories lists every valid category
+var categories = map[string]bool{
+	CategoryGroceries:      true,
+	CategoryDining:         true,
+	CategoryTransportation: true,
+	CategoryTravel:         true,
+	CategoryEntertainment:  true,
+	CategoryShopping:       true,
+	CategoryBills:          true,
+	CategoryHealth:         true,
+	CategoryEducation:      true,
+	CategoryGambling:       true,
+	CategoryAdult:          true,
+	CategoryCrypto:         true,
+	CategoryCash:           true,
+	CategoryFinancial:      true,
+	CategoryOther:          true,
+}
+
+// Entry is a single MCC mapping
+type Entry struct {
+	MCC         string `json:"mcc"`
+	Category    string `json:"category"`
+	Description string `json:"description"`
+}
+
+// codeRange maps a contiguous MCC range (e.g. airlines 3000-3350)
+type codeRange struct {
+	start, end  int
+	category    string
+	description string
+}
+
+//go:embed mcc_categories.csv
+var mappingData string
+
+var (
+	exact  map[int]Entry
+	ranges []codeRange
+)
+
+func init() {
+	var err error
+	exact, ranges, err = parseMapping(mappingData)
+	if err != nil {
+		// The table is embedded at build time; a parse error is a programming error
+		panic(err)
+	}
+}
+
+// Lookup returns the mapping for a 4-digit MCC
+func Lookup(code string) (Entry, bool) {
+	n, ok := parseCode(code)
+	if !ok {
+		return Entry{}, false
+	}
+
+	if entry, ok := exact[n]; ok {
+		return entry, true
+	}
+
+	for _, r := range ranges {
+		if n >= r.start && n <= r.end {
+			return Entry{MCC: code, Category: r.category, Description: r.description}, true
+		}
+	}
+
+	return Entry{}, false
+}
+
+// Categorize returns the spending category for a merchant_category value.
+// Accepts either a 4-digit MCC or a category name; unknown values map to "other".
+func Categorize(merchantCategory string) string {
+	value := strings.ToLower(strings.TrimSpace(merchantCategory))
+
+	if categories[value] {
+		return value
+	}
+	if entry, ok := Lookup(value); ok {
+		return entry.Category
+	}
+	return CategoryOther
+}
+
+// IsMCC reports whether the value is a syntactically valid 4-digit MCC
+func IsMCC(value string) bool {
+	_, ok := parseCode(value)
+	return ok
+}
+
+// IsCategory reports whether the value is a known category
+func IsCategory(category string) bool {
+	return categories[category]
+}
+
+// Categories returns all known categories in alphabetical order
+func Categories() []string {
+	list := make([]string, 0, len(categories))
+	for category := range categories {
+		list = append(list, category)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// parseCode parses a 4-digit MCC
+func parseCode(code string) (int, bool) {
+	if len(code) != 4 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(code)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+// parseMapping parses the embedded CSV mapping table
+func parseMapping(data string) (map[int]Entry, []codeRange, error) {
+	exactCodes := make(map[int]Entry)
+	var codeRanges []codeRange
+
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.SplitN(line, ",", 3)
+		if len(fields) != 3 {
+			return nil, nil, fmt.Errorf("mcc: line %d: expected 3 fields", i+1)
+		}
+
+		code, category, description := fields[0], fields[1], fields[2]
+		if !categories[category] {
+			return nil, nil, fmt.Errorf("mcc: line %d: unknown category %q", i+1, category)
+		}
+
+		if start, end, found := strings.Cut(code, "-"); found {
+			s, okStart := parseCode(start)
+			e, okEnd := parseCode(end)
+			if !okStart || !okEnd || s > e {
+				return nil, nil, fmt.Errorf("mcc: line %d: invalid range %q", i+1, code)
+			}
+			codeRanges = append(codeRanges, codeRange{start: s, end: e, category: category, description: description})
+			continue
+		}
+
+		n, ok := parseCode(code)
+		if !ok {
+			return nil, nil, fmt.Errorf("mcc: line %d: invalid code %q", i+1, code)
+		}
+		if _, dup := exactCodes[n]; dup {
+			return nil, nil, fmt.Errorf("mcc: line %d: duplicate code %q", i+1, code)
+		}
+		exactCodes[n] = Entry{MCC: code, Category: category, Description: description}
+	}
+
+	return exactCodes, codeRanges, nil
+}
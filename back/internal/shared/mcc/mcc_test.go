@@ -0,0 +1,81 @@
+package mcc
+
+import "testing"
+
+// TestLookup tests exact and range MCC lookups
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		found    bool
+		category string
+	}{
+		{"Supermarket", "5411", true, CategoryGroceries},
+		{"Restaurant", "5812", true, CategoryDining},
+		{"Casino", "7995", true, CategoryGambling},
+		{"Crypto", "6051", true, CategoryCrypto},
+		{"ATM", "6011", true, CategoryCash},
+		{"Airline range start", "3000", true, CategoryTravel},
+		{"Hotel range", "3750", true, CategoryTravel},
+		{"Unmapped", "1234", false, ""},
+		{"Too short", "541", false, ""},
+		{"Non-numeric", "54a1", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, ok := Lookup(tt.code)
+			if ok != tt.found {
+				t.Fatalf("Lookup(%s) found = %v, want %v", tt.code, ok, tt.found)
+			}
+			if ok && entry.Category != tt.category {
+				t.Errorf("Lookup(%s) category = %s, want %s", tt.code, entry.Category, tt.category)
+			}
+		})
+	}
+}
+
+// TestCategorize tests categorization of merchant_category values
+func TestCategorize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"5411", CategoryGroceries},
+		{"groceries", CategoryGroceries},
+		{" Dining ", CategoryDining},
+		{"7995", CategoryGambling},
+		{"1234", CategoryOther},
+		{"", CategoryOther},
+		{"unknown category", CategoryOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Categorize(tt.input); got != tt.expected {
+				t.Errorf("Categorize(%q) = %s, want %s", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestParseMapping tests rejection of malformed mapping tables
+func TestParseMapping(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"Unknown category", "5411,food,Grocery"},
+		{"Missing field", "5411,groceries"},
+		{"Invalid range", "3350-3000,travel,Airlines"},
+		{"Duplicate code", "5411,groceries,A\n5411,groceries,B"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := parseMapping(tt.data); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,284 @@
+// Package merchant turns raw card descriptors into enriched merchants.
+//
+// Card networks deliver free-form descriptors ("UBER *TRIP 0923",
+// "MP *LOJA DO ZE  SAO PAULO BR"). Enrich normalizes them into a canonical
+// name, a stable key (used by user category overrides), a logo key, a spending
+// category and the city. Known merchants come from the catalog shipped with the
+// service (merchants.csv); other descriptors are cleaned up and categorized
+// through the MCC mapping, so every module enriches merchants the same way.
+package merchant
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/lauratech/fin/back/internal/shared/mcc"
+)
+
+// MaxKeyLength is the maximum length of a merchant key (card_transactions.merchant_key)
+const MaxKeyLength = 100
+
+// Info is an enriched merchant
+type Info struct {
+	Key      string `json:"key"`                // Catalog key, or a slug of the cleaned name
+	Name     string `json:"name"`               // Display name
+	Category string `json:"category"`           // Spending category (see package mcc)
+	LogoKey  string `json:"logo_key,omitempty"` // Asset key of the merchant logo (catalog merchants only)
+	City     string `json:"city,omitempty"`
+	Known    bool   `json:"known"` // Found in the merchant catalog
+}
+
+// catalogEntry is a known merchant of the catalog
+type catalogEntry struct {
+	key      string
+	name     string
+	category string
+	logoKey  string
+}
+
+var (
+	//go:embed merchants.csv
+	catalogData string
+
+	//go:embed aggregators.csv
+	aggregatorData string
+
+	//go:embed cities.csv
+	cityData string
+)
+
+var (
+	patterns    map[string]catalogEntry // normalized pattern -> merchant
+	maxPattern  int                     // longest pattern, in words
+	aggregators map[string]bool
+	cities      map[string]string // normalized name -> display name
+	maxCity     int               // longest city, in words
+)
+
+func init() {
+	var err error
+	if patterns, maxPattern, err = parseCatalog(catalogData); err != nil {
+		// The catalog is embedded at build time; a parse error is a programming error
+		panic(err)
+	}
+	aggregators = parseList(aggregatorData)
+	if cities, maxCity, err = parseCities(cityData); err != nil {
+		panic(err)
+	}
+}
+
+// countryCodes are the merchant country suffixes stripped from descriptors
+var countryCodes = map[string]bool{
+	"BR": true, "BRA": true, "US": true, "USA": true, "GB": true, "GBR": true,
+	"IE": true, "PT": true, "ES": true, "FR": true, "NL": true, "LU": true,
+	"DE": true, "AR": true, "CL": true, "UY": true, "SE": true,
+}
+
+// legalSuffixes are company type suffixes dropped from unknown merchant names
+var legalSuffixes = map[string]bool{
+	"LTDA": true, "ME": true, "EPP": true, "EIRELI": true, "SA": true, "MEI": true,
+}
+
+// lowercaseWords stay lowercase in display names (unless first)
+var lowercaseWords = map[string]bool{
+	"de": true, "da": true, "do": true, "das": true, "dos": true, "e": true,
+}
+
+// accentFolder maps accented uppercase letters to ASCII
+var accentFolder = strings.NewReplacer(
+	"Á", "A", "À", "A", "Â", "A", "Ã", "A", "Ä", "A",
+	"É", "E", "È", "E", "Ê", "E", "Ë", "E",
+	"Í", "I", "Ì", "I", "Î", "I", "Ï", "I",
+	"Ó", "O", "Ò", "O", "Ô", "O", "Õ", "O", "Ö", "O",
+	"Ú", "U", "Ù", "U", "Û", "U", "Ü", "U",
+	"Ç", "C", "Ñ", "N",
+)
+
+// Enrich normalizes a raw descriptor. merchantCategory (MCC or category name)
+// categorizes merchants missing from the catalog.
+func Enrich(descriptor, merchantCategory string) Info {
+	// Fixed-width descriptors pad the name before the city with spaces
+	fixedWidth := strings.Contains(strings.TrimSpace(descriptor), "  ")
+
+	// 1. Uppercase, fold accents and drop payment facilitator prefixes
+	value := accentFolder.Replace(strings.ToUpper(descriptor))
+	value = strings.ReplaceAll(value, "'", "")
+	if prefix, rest, found := strings.Cut(value, "*"); found {
+		if aggregators[strings.Join(tokenize(prefix), " ")] && len(tokenize(rest)) > 0 {
+			value = rest
+		}
+	}
+	tokens := tokenize(value)
+
+	info := Info{Category: mcc.Categorize(merchantCategory)}
+	if len(tokens) == 0 {
+		return info
+	}
+
+	// 2. Known merchant: longest catalog pattern matching the leading words
+	var known *catalogEntry
+	for n := min(maxPattern, len(tokens)); n > 0; n-- {
+		if entry, ok := patterns[strings.Join(tokens[:n], " ")]; ok {
+			known = &entry
+			break
+		}
+	}
+
+	// 3. Strip the country and the city from the end
+	hasCountry := false
+	if len(tokens) > 1 && countryCodes[tokens[len(tokens)-1]] {
+		tokens = tokens[:len(tokens)-1]
+		hasCountry = true
+	}
+	if hasCountry || fixedWidth {
+		for n := min(maxCity, len(tokens)-1); n > 0; n-- {
+			if city, ok := cities[strings.Join(tokens[len(tokens)-n:], " ")]; ok {
+				info.City = city
+				tokens = tokens[:len(tokens)-n]
+				break
+			}
+		}
+	}
+
+	if known != nil {
+		info.Key = known.key
+		info.Name = known.name
+		info.Category = known.category
+		info.LogoKey = known.logoKey
+		info.Known = true
+		return info
+	}
+
+	// 4. Unknown merchant: drop trailing references (e.g. "0923") and company suffixes
+	for len(tokens) > 1 {
+		last := tokens[len(tokens)-1]
+		if !hasDigit(last) && !legalSuffixes[last] {
+			break
+		}
+		tokens = tokens[:len(tokens)-1]
+	}
+
+	info.Name = displayName(tokens)
+	info.Key = slug(tokens)
+	return info
+}
+
+// Normalize returns the normalized form of a merchant name: uppercase,
+// without accents, with punctuation as single spaces
+func Normalize(value string) string {
+	value = accentFolder.Replace(strings.ToUpper(value))
+	return strings.Join(tokenize(strings.ReplaceAll(value, "'", "")), " ")
+}
+
+// tokenize splits an uppercase value into words of letters, digits and "&"
+func tokenize(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return !(r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '&')
+	})
+}
+
+// hasDigit reports whether a word contains a digit
+func hasDigit(word string) bool {
+	return strings.ContainsAny(word, "0123456789")
+}
+
+// displayName title-cases words ("PADARIA DO ZE" -> "Padaria do Ze")
+func displayName(tokens []string) string {
+	words := make([]string, len(tokens))
+	for i, token := range tokens {
+		word := strings.ToLower(token)
+		if i == 0 || !lowercaseWords[word] {
+			word = strings.ToUpper(word[:1]) + word[1:]
+		}
+		words[i] = word
+	}
+	return strings.Join(words, " ")
+}
+
+// slug builds the key of an unknown merchant ("PADARIA DO ZE" -> "padaria-do-ze")
+func slug(tokens []string) string {
+	key := strings.ToLower(strings.Join(tokens, "-"))
+	key = strings.ReplaceAll(key, "&", "and")
+	if len(key) > MaxKeyLength {
+		key = strings.TrimRight(key[:MaxKeyLength], "-")
+	}
+	return key
+}
+
+// parseCatalog parses the embedded merchant catalog
+func parseCatalog(data string) (map[string]catalogEntry, int, error) {
+	byPattern := make(map[string]catalogEntry)
+	keys := make(map[string]bool)
+	longest := 0
+
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, ",")
+		if len(fields) != 5 {
+			return nil, 0, fmt.Errorf("merchant: catalog line %d: expected 5 fields", i+1)
+		}
+
+		entry := catalogEntry{key: fields[0], name: fields[1], category: fields[2], logoKey: fields[3]}
+		if entry.key == "" || keys[entry.key] {
+			return nil, 0, fmt.Errorf("merchant: catalog line %d: missing or duplicate key %q", i+1, entry.key)
+		}
+		if !mcc.IsCategory(entry.category) {
+			return nil, 0, fmt.Errorf("merchant: catalog line %d: unknown category %q", i+1, entry.category)
+		}
+		keys[entry.key] = true
+
+		for _, pattern := range strings.Split(fields[4], "|") {
+			words := tokenize(pattern)
+			normalized := strings.Join(words, " ")
+			if normalized != pattern {
+				return nil, 0, fmt.Errorf("merchant: catalog line %d: pattern %q is not normalized", i+1, pattern)
+			}
+			if _, dup := byPattern[normalized]; dup {
+				return nil, 0, fmt.Errorf("merchant: catalog line %d: duplicate pattern %q", i+1, pattern)
+			}
+			byPattern[normalized] = entry
+			longest = max(longest, len(words))
+		}
+	}
+
+	return byPattern, longest, nil
+}
+
+// parseCities parses the embedded city list
+func parseCities(data string) (map[string]string, int, error) {
+	byName := make(map[string]string)
+	longest := 0
+
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, display, found := strings.Cut(line, ",")
+		if !found || name == "" || display == "" {
+			return nil, 0, fmt.Errorf("merchant: cities line %d: expected 2 fields", i+1)
+		}
+		byName[name] = display
+		longest = max(longest, len(tokenize(name)))
+	}
+
+	return byName, longest, nil
+}
+
+// parseList parses an embedded one-value-per-line list
+func parseList(data string) map[string]bool {
+	values := make(map[string]bool)
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			values[line] = true
+		}
+	}
+	return values
+}
@@ -0,0 +1,156 @@
+package merchant
+
+import (
+	"testing"
+
+	"github.com/lauratech/fin/back/internal/shared/mcc"
+)
+
+// TestEnrich tests descriptor normalization for known and unknown merchants
+func TestEnrich(t *testing.T) {
+	tests := []struct {
+		name       string
+		descriptor string
+		category   string
+		want       Info
+	}{
+		{
+			name:       "Uber trip with reference",
+			descriptor: "UBER *TRIP 0923",
+			want:       Info{Key: "uber", Name: "Uber", Category: mcc.CategoryTransportation, LogoKey: "uber", Known: true},
+		},
+		{
+			name:       "Uber Eats wins over Uber",
+			descriptor: "UBER* EATS",
+			category:   "5814",
+			want:       Info{Key: "uber_eats", Name: "Uber Eats", Category: mcc.CategoryDining, LogoKey: "uber-eats", Known: true},
+		},
+		{
+			name:       "Lowercase with domain",
+			descriptor: "netflix.com",
+			want:       Info{Key: "netflix", Name: "Netflix", Category: mcc.CategoryEntertainment, LogoKey: "netflix", Known: true},
+		},
+		{
+			name:       "Accents and city",
+			descriptor: "PÃO DE AÇÚCAR 1234  SÃO PAULO BR",
+			want:       Info{Key: "pao_de_acucar", Name: "Pão de Açúcar", Category: mcc.CategoryGroceries, LogoKey: "pao-de-acucar", City: "São Paulo", Known: true},
+		},
+		{
+			name:       "Catalog category wins over MCC",
+			descriptor: "AMAZON PRIME*2K4L",
+			category:   "5942",
+			want:       Info{Key: "amazon_prime", Name: "Amazon Prime", Category: mcc.CategoryEntertainment, LogoKey: "amazon-prime", Known: true},
+		},
+		{
+			name:       "Apostrophe",
+			descriptor: "MCDONALD'S 0412 CURITIBA BR",
+			want:       Info{Key: "mcdonalds", Name: "McDonald's", Category: mcc.CategoryDining, LogoKey: "mcdonalds", City: "Curitiba", Known: true},
+		},
+		{
+			name:       "Facilitator prefix with unknown merchant",
+			descriptor: "MP *PADARIAPAOQUENTE",
+			category:   "5462",
+			want:       Info{Key: "padariapaoquente", Name: "Padariapaoquente", Category: mcc.CategoryGroceries},
+		},
+		{
+			name:       "Facilitator prefix with known merchant",
+			descriptor: "PAYPAL *SPOTIFY",
+			want:       Info{Key: "spotify", Name: "Spotify", Category: mcc.CategoryEntertainment, LogoKey: "spotify", Known: true},
+		},
+		{
+			name:       "Unknown merchant with city, reference and company suffix",
+			descriptor: "PADARIA DO ZE LTDA 0042   RIO DE JANEIRO BR",
+			category:   "groceries",
+			want:       Info{Key: "padaria-do-ze", Name: "Padaria do Ze", Category: mcc.CategoryGroceries, City: "Rio de Janeiro"},
+		},
+		{
+			name:       "City word without country is part of the name",
+			descriptor: "LOJA SALVADOR",
+			category:   "5999",
+			want:       Info{Key: "loja-salvador", Name: "Loja Salvador", Category: mcc.CategoryShopping},
+		},
+		{
+			name:       "Only a city keeps the name",
+			descriptor: "CURITIBA BR",
+			want:       Info{Key: "curitiba", Name: "Curitiba", Category: mcc.CategoryOther},
+		},
+		{
+			name:       "Pattern must match whole words",
+			descriptor: "TIMBAUBA MODAS",
+			category:   "5651",
+			want:       Info{Key: "timbauba-modas", Name: "Timbauba Modas", Category: mcc.CategoryShopping},
+		},
+		{
+			name:       "Numeric name kept",
+			descriptor: "1001 NOITES",
+			want:       Info{Key: "1001-noites", Name: "1001 Noites", Category: mcc.CategoryOther},
+		},
+		{
+			name:       "Ampersand",
+			descriptor: "SAL & PIMENTA",
+			category:   "5812",
+			want:       Info{Key: "sal-and-pimenta", Name: "Sal & Pimenta", Category: mcc.CategoryDining},
+		},
+		{
+			name:       "Empty descriptor",
+			descriptor: "  *** ",
+			category:   "5411",
+			want:       Info{Category: mcc.CategoryGroceries},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Enrich(tt.descriptor, tt.category); got != tt.want {
+				t.Errorf("Enrich(%q) = %+v, want %+v", tt.descriptor, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestEnrichKeyStable tests that descriptor variants of a merchant share a key
+func TestEnrichKeyStable(t *testing.T) {
+	variants := []string{
+		"PADARIA DO ZE 0001",
+		"Padaria do Zé",
+		"PADARIA DO ZE LTDA  SAO PAULO BR",
+		"PAG*PADARIA DO ZE",
+	}
+
+	for _, v := range variants {
+		if got := Enrich(v, "").Key; got != "padaria-do-ze" {
+			t.Errorf("Enrich(%q).Key = %q, want %q", v, got, "padaria-do-ze")
+		}
+	}
+}
+
+// TestNormalize tests normalized merchant names
+func TestNormalize(t *testing.T) {
+	if got := Normalize("  Pão-de-Açúcar.com.br "); got != "PAO DE ACUCAR COM BR" {
+		t.Errorf("Normalize() = %q", got)
+	}
+}
+
+// TestCatalog tests that the embedded data files are consistent
+func TestCatalog(t *testing.T) {
+	if len(patterns) == 0 || len(cities) == 0 || len(aggregators) == 0 {
+		t.Fatalf("patterns = %d, cities = %d, aggregators = %d", len(patterns), len(cities), len(aggregators))
+	}
+	for pattern, entry := range patterns {
+		if got := Enrich(pattern, ""); got.Key != entry.key {
+			t.Errorf("pattern %q enriches to %q, want %q", pattern, got.Key, entry.key)
+		}
+	}
+	for name := range cities {
+		if Normalize(name) != name {
+			t.Errorf("city %q is not normalized", name)
+		}
+	}
+
+	if _, _, err := parseCatalog("x,X,unknown,x,X"); err == nil {
+		t.Error("parseCatalog() accepted an unknown category")
+	}
+	if _, _, err := parseCatalog("x,X,other,x,Not Normalized"); err == nil {
+		t.Error("parseCatalog() accepted a pattern that is not normalized")
+	}
+}
@@ -0,0 +1,203 @@
+// Package payees is the registry of the payees of bills and transfers: banks,
+// by COMPE code and ISPB, and arrecadação collectors (utilities, city halls,
+// government bodies), by concessionária segment and FEBRABAN company ID.
+//
+// Entries come from the data file shipped with the service (payees.csv) plus
+// an optional file loaded at startup, whose entries add to or replace the
+// embedded ones. Each file carries a "# version:" line; Version reports the
+// version of the last file loaded.
+package payees
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Payee kinds in the data file
+const (
+	KindBank      = "bank"
+	KindCollector = "collector"
+)
+
+// Bank is a financial institution
+type Bank struct {
+	Code string `json:"code"` // COMPE code (3 digits)
+	ISPB string `json:"ispb"` // Identifier in the payment system (8 digits)
+	Name string `json:"name"`
+}
+
+// Collector is a company or body collecting bills through concessionária barcodes
+type Collector struct {
+	Segment   string `json:"segment"`    // Segment of the barcode (position 2)
+	CompanyID string `json:"company_id"` // FEBRABAN company ID, or CNPJ root for segment 6
+	Name      string `json:"name"`
+}
+
+// registry holds the entries of the loaded data files
+type registry struct {
+	version    string
+	banks      map[string]Bank // by COMPE code
+	ispbs      map[string]Bank // by ISPB
+	collectors map[string]Collector
+}
+
+//go:embed payees.csv
+var embeddedData string
+
+var (
+	mu      sync.RWMutex
+	current = &registry{
+		banks:      map[string]Bank{},
+		ispbs:      map[string]Bank{},
+		collectors: map[string]Collector{},
+	}
+)
+
+func init() {
+	parsed, err := parse(embeddedData)
+	if err != nil {
+		// The data file is embedded at build time; a parse error is a programming error
+		panic(err)
+	}
+	add(parsed)
+}
+
+// LoadFile adds the payees of a data file (same format as payees.csv) to the
+// loaded ones, replacing entries with the same code. Meant to be called at startup.
+func LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("payees: %w", err)
+	}
+	parsed, err := parse(string(data))
+	if err != nil {
+		return err
+	}
+	add(parsed)
+	return nil
+}
+
+// add registers parsed payees
+func add(parsed *registry) {
+	mu.Lock()
+	defer mu.Unlock()
+	if parsed.version != "" {
+		current.version = parsed.version
+	}
+	for code, bank := range parsed.banks {
+		if previous, ok := current.banks[code]; ok {
+			delete(current.ispbs, previous.ISPB)
+		}
+		current.banks[code] = bank
+		current.ispbs[bank.ISPB] = bank
+	}
+	for key, collector := range parsed.collectors {
+		current.collectors[key] = collector
+	}
+}
+
+// Version returns the version of the last data file loaded
+func Version() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current.version
+}
+
+// LookupBank returns the bank with a COMPE code
+func LookupBank(code string) (Bank, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	bank, ok := current.banks[code]
+	return bank, ok
+}
+
+// LookupBankByISPB returns the bank with an ISPB
+func LookupBankByISPB(ispb string) (Bank, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	bank, ok := current.ispbs[ispb]
+	return bank, ok
+}
+
+// Banks returns every bank, by COMPE code
+func Banks() []Bank {
+	mu.RLock()
+	banks := make([]Bank, 0, len(current.banks))
+	for _, bank := range current.banks {
+		banks = append(banks, bank)
+	}
+	mu.RUnlock()
+
+	sort.Slice(banks, func(i, j int) bool { return banks[i].Code < banks[j].Code })
+	return banks
+}
+
+// LookupCollector returns the collector of a concessionária segment and company ID
+func LookupCollector(segment, companyID string) (Collector, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	collector, ok := current.collectors[segment+companyID]
+	return collector, ok
+}
+
+// parse parses a data file
+func parse(data string) (*registry, error) {
+	parsed := &registry{
+		banks:      map[string]Bank{},
+		collectors: map[string]Collector{},
+	}
+
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if version, ok := strings.CutPrefix(line, "# version:"); ok {
+			parsed.version = strings.TrimSpace(version)
+			continue
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.SplitN(line, ",", 4)
+		if len(fields) != 4 || strings.TrimSpace(fields[3]) == "" {
+			return nil, fmt.Errorf("payees: line %d: expected 4 fields", i+1)
+		}
+		kind, code, ispb, name := fields[0], fields[1], fields[2], strings.TrimSpace(fields[3])
+
+		switch {
+		case kind == KindBank && len(code) == 3 && isDigits(code) && len(ispb) == 8 && isDigits(ispb):
+			parsed.banks[code] = Bank{Code: code, ISPB: ispb, Name: name}
+		case kind == KindCollector && isCollectorCode(code) && ispb == "":
+			parsed.collectors[code] = Collector{Segment: code[:1], CompanyID: code[1:], Name: name}
+		default:
+			return nil, fmt.Errorf("payees: line %d: invalid kind %q, code %q or ISPB %q", i+1, kind, code, ispb)
+		}
+	}
+
+	return parsed, nil
+}
+
+// isCollectorCode reports whether code is a segment (1-9) followed by a 4-digit
+// company ID, or by an 8-digit CNPJ root for segment 6
+func isCollectorCode(code string) bool {
+	if !isDigits(code) || code[0] == '0' {
+		return false
+	}
+	if code[0] == '6' {
+		return len(code) == 9
+	}
+	return len(code) == 5
+}
+
+// isDigits reports whether value only contains ASCII digits
+func isDigits(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
@@ -0,0 +1,109 @@
+package payees
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestEmbeddedData tests lookups in the data file shipped with the service
+func TestEmbeddedData(t *testing.T) {
+	if Version() == "" {
+		t.Error("Version() is empty")
+	}
+
+	for _, tt := range []struct{ code, ispb, name string }{
+		{"001", "00000000", "Banco do Brasil S.A."},
+		{"104", "00360305", "Caixa Econômica Federal"},
+		{"260", "18236120", "Nu Pagamentos S.A. - Instituição de Pagamento"},
+		{"341", "60701190", "Itaú Unibanco S.A."},
+		{"488", "46518205", "JPMorgan Chase Bank, National Association"},
+	} {
+		bank, ok := LookupBank(tt.code)
+		if !ok || bank.ISPB != tt.ispb || bank.Name != tt.name {
+			t.Errorf("LookupBank(%q) = %+v, %v, want %s %s", tt.code, bank, ok, tt.ispb, tt.name)
+		}
+		if byISPB, ok := LookupBankByISPB(tt.ispb); !ok || byISPB != bank {
+			t.Errorf("LookupBankByISPB(%q) = %+v, %v, want %+v", tt.ispb, byISPB, ok, bank)
+		}
+	}
+
+	// Closed institutions are not payees
+	for _, code := range []string{"356", "399", "999", "34"} {
+		if bank, ok := LookupBank(code); ok {
+			t.Errorf("LookupBank(%q) = %+v, want not found", code, bank)
+		}
+	}
+
+	banks := Banks()
+	if len(banks) < 300 {
+		t.Errorf("Banks() returned %d banks, want the full list", len(banks))
+	}
+	for i := 1; i < len(banks); i++ {
+		if banks[i-1].Code >= banks[i].Code {
+			t.Fatalf("Banks() not ordered by code: %s before %s", banks[i-1].Code, banks[i].Code)
+		}
+	}
+
+	for _, tt := range []struct{ segment, companyID, name string }{
+		{"2", "0097", "Sabesp - Companhia de Saneamento Básico do Estado de São Paulo"},
+		{"3", "0048", "Light Serviços de Eletricidade"},
+		{"4", "0058", "Telefônica Brasil - Vivo"},
+	} {
+		if collector, ok := LookupCollector(tt.segment, tt.companyID); !ok || collector.Name != tt.name {
+			t.Errorf("LookupCollector(%q, %q) = %+v, %v, want %s", tt.segment, tt.companyID, collector, ok, tt.name)
+		}
+	}
+}
+
+// TestLoadFile tests loading extra payees and data file validation
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "payees.csv")
+	data := "# version: 2026-11-01\n" +
+		"bank,654,92874270,Banco Digimais S.A. (renamed)\n" +
+		"bank,998,12345678,Banco Exemplo S.A.\n" +
+		"collector,30048,,Light Serviços de Eletricidade\n" +
+		"collector,612345678,,Concessionária Exemplo\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadFile(path); err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+
+	if got := Version(); got != "2026-11-01" {
+		t.Errorf("Version() = %q, want 2026-11-01", got)
+	}
+	if bank, _ := LookupBank("654"); bank.Name != "Banco Digimais S.A. (renamed)" {
+		t.Errorf("LookupBank(654) = %+v, want the loaded name", bank)
+	}
+	if bank, ok := LookupBankByISPB("12345678"); !ok || bank.Code != "998" {
+		t.Errorf("LookupBankByISPB(12345678) = %+v, %v, want bank 998", bank, ok)
+	}
+	if collector, ok := LookupCollector("3", "0048"); !ok || collector.Name != "Light Serviços de Eletricidade" {
+		t.Errorf("LookupCollector(3, 0048) = %+v, %v", collector, ok)
+	}
+	if collector, ok := LookupCollector("6", "12345678"); !ok || collector.Segment != "6" {
+		t.Errorf("LookupCollector(6, 12345678) = %+v, %v", collector, ok)
+	}
+
+	if err := LoadFile(filepath.Join(t.TempDir(), "missing.csv")); err == nil {
+		t.Error("LoadFile() accepted a missing file")
+	}
+
+	for _, line := range []string{
+		"agency,001,00000000,Unknown kind",
+		"bank,01,00000000,Short code",
+		"bank,001,0000000,Short ISPB",
+		"bank,001,,Missing ISPB",
+		"bank,001,00000000,",
+		"collector,3004,,Short company ID",
+		"collector,30048,12345678,Collector with ISPB",
+		"collector,60048,,Segment 6 without CNPJ root",
+		"collector,00048,,Segment 0",
+	} {
+		if _, err := parse(line); err == nil {
+			t.Errorf("parse(%q) accepted an invalid line", line)
+		}
+	}
+}
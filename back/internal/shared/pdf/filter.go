@@ -0,0 +1,205 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/zlib"
+	"encoding/ascii85"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// maxDecodedSize limits the decoded size of a stream (decompression bombs)
+const maxDecodedSize = 64 << 20
+
+// ErrUnsupportedFilter is returned for streams encoded with an unsupported filter
+var ErrUnsupportedFilter = errors.New("pdf: unsupported stream filter")
+
+// Decode returns the data of a stream with its filters applied. DCTDecode
+// (JPEG) is left in place: it ends the chain and the data is returned as JPEG.
+func (d *Document) Decode(stream *Stream) ([]byte, error) {
+	filters := d.filters(stream.Dict)
+	params := d.decodeParms(stream.Dict, len(filters))
+
+	data := stream.Raw
+	for i, filter := range filters {
+		var err error
+		switch filter {
+		case "FlateDecode", "Fl":
+			data, err = inflate(data)
+			if err == nil {
+				data, err = d.unpredict(data, params[i])
+			}
+		case "ASCIIHexDecode", "AHx":
+			data, err = asciiHex(data)
+		case "ASCII85Decode", "A85":
+			data, err = ascii85Decode(data)
+		case "DCTDecode", "DCT":
+			if i != len(filters)-1 {
+				return nil, ErrUnsupportedFilter
+			}
+			return data, nil
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedFilter, filter)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// filters returns the filter names of a stream
+func (d *Document) filters(dict Dict) []Name {
+	switch f := d.Resolve(dict["Filter"]).(type) {
+	case Name:
+		return []Name{f}
+	case []any:
+		names := make([]Name, 0, len(f))
+		for _, v := range f {
+			if name, ok := d.Resolve(v).(Name); ok {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+	return nil
+}
+
+// decodeParms returns the decode parameters of each filter (nil when absent)
+func (d *Document) decodeParms(dict Dict, n int) []Dict {
+	params := make([]Dict, n)
+	switch p := d.Resolve(dict["DecodeParms"]).(type) {
+	case Dict:
+		if n > 0 {
+			params[0] = p
+		}
+	case []any:
+		for i := 0; i < len(p) && i < n; i++ {
+			params[i], _ = d.Resolve(p[i]).(Dict)
+		}
+	}
+	return params
+}
+
+// inflate decompresses zlib data (or raw deflate, which some writers emit)
+func inflate(data []byte) ([]byte, error) {
+	var r io.Reader
+	if zr, err := zlib.NewReader(bytes.NewReader(data)); err == nil {
+		r = zr
+	} else {
+		r = flate.NewReader(bytes.NewReader(data))
+	}
+
+	out, err := io.ReadAll(io.LimitReader(r, maxDecodedSize+1))
+	if len(out) > maxDecodedSize {
+		return nil, fmt.Errorf("pdf: stream larger than %d bytes", maxDecodedSize)
+	}
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	return out, nil // Truncated streams are common: keep what was read
+}
+
+// unpredict reverses the PNG predictors of Flate data (TIFF predictor 2 is not supported)
+func (d *Document) unpredict(data []byte, params Dict) ([]byte, error) {
+	predictor, _ := d.int(params, "Predictor")
+	if predictor < 10 {
+		if predictor == 2 {
+			return nil, ErrUnsupportedFilter
+		}
+		return data, nil
+	}
+
+	colors, bpc, columns := 1, 8, 1
+	if v, ok := d.int(params, "Colors"); ok && v > 0 {
+		colors = v
+	}
+	if v, ok := d.int(params, "BitsPerComponent"); ok && v > 0 {
+		bpc = v
+	}
+	if v, ok := d.int(params, "Columns"); ok && v > 0 {
+		columns = v
+	}
+	bpp := max(1, colors*bpc/8)
+	rowSize := (colors*bpc*columns + 7) / 8
+
+	out := make([]byte, 0, len(data))
+	prev := make([]byte, rowSize)
+	for len(data) >= rowSize+1 {
+		filter, row := data[0], append([]byte(nil), data[1:rowSize+1]...)
+		data = data[rowSize+1:]
+		for i := range row {
+			var left, upLeft byte
+			if i >= bpp {
+				left, upLeft = row[i-bpp], prev[i-bpp]
+			}
+			up := prev[i]
+			switch filter {
+			case 1: // Sub
+				row[i] += left
+			case 2: // Up
+				row[i] += up
+			case 3: // Average
+				row[i] += byte((int(left) + int(up)) / 2)
+			case 4: // Paeth
+				row[i] += paeth(left, up, upLeft)
+			}
+		}
+		out = append(out, row...)
+		prev = row
+	}
+	return out, nil
+}
+
+// paeth is the PNG Paeth predictor
+func paeth(a, b, c byte) byte {
+	p := int(a) + int(b) - int(c)
+	pa, pb, pc := abs(p-int(a)), abs(p-int(b)), abs(p-int(c))
+	switch {
+	case pa <= pb && pa <= pc:
+		return a
+	case pb <= pc:
+		return b
+	}
+	return c
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// asciiHex decodes ASCIIHexDecode data (ends at ">")
+func asciiHex(data []byte) ([]byte, error) {
+	digits := make([]byte, 0, len(data))
+	for _, c := range data {
+		if c == '>' {
+			break
+		}
+		if !isSpace(c) {
+			digits = append(digits, c)
+		}
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	out := make([]byte, len(digits)/2)
+	_, err := hex.Decode(out, digits)
+	return out, err
+}
+
+// ascii85Decode decodes ASCII85Decode data (optional "<~", ends at "~>")
+func ascii85Decode(data []byte) ([]byte, error) {
+	data = bytes.TrimPrefix(bytes.TrimLeft(data, " \t\r\n"), []byte("<~"))
+	if end := bytes.Index(data, []byte("~>")); end >= 0 {
+		data = data[:end]
+	}
+	out := make([]byte, 4*len(data)+4) // "z" expands to four bytes
+	n, _, err := ascii85.Decode(out, data, true)
+	return out[:n], err
+}
@@ -0,0 +1,141 @@
+package pdf
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+)
+
+// MaxImagePixels limits the size of the images decoded by Images. Callers
+// decoding other untrusted images reuse it to bound their allocations.
+const MaxImagePixels = 40_000_000
+
+// Images returns the image XObjects of the document that can be decoded: JPEG
+// (DCTDecode), and 1- or 8-bit gray, RGB and CMYK samples, as grayscale.
+// Images with other encodings (JBIG2, CCITT, indexed colors) are skipped.
+func (d *Document) Images() []image.Image {
+	var images []image.Image
+	for _, stream := range d.streams {
+		if d.name(stream.Dict, "Subtype") != "Image" {
+			continue
+		}
+		if img := d.decodeImage(stream); img != nil {
+			images = append(images, img)
+		}
+	}
+	return images
+}
+
+// decodeImage decodes an image XObject, or returns nil
+func (d *Document) decodeImage(stream *Stream) image.Image {
+	width, _ := d.int(stream.Dict, "Width")
+	height, _ := d.int(stream.Dict, "Height")
+	if width <= 0 || height <= 0 || width*height > MaxImagePixels {
+		return nil
+	}
+
+	data, err := d.Decode(stream)
+	if err != nil {
+		return nil
+	}
+	filters := d.filters(stream.Dict)
+	if n := len(filters); n > 0 && (filters[n-1] == "DCTDecode" || filters[n-1] == "DCT") {
+		img, err := jpeg.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil
+		}
+		return img
+	}
+
+	// Raw samples
+	bpc, ok := d.int(stream.Dict, "BitsPerComponent")
+	mask, _ := d.Resolve(stream.Dict["ImageMask"]).(bool)
+	if mask || !ok {
+		bpc = 1
+	}
+	components := 1
+	if !mask {
+		components = d.components(stream.Dict["ColorSpace"])
+	}
+	if components == 0 || (bpc != 1 && bpc != 8) || (bpc == 1 && components != 1) {
+		return nil
+	}
+
+	rowSize := (width*components*bpc + 7) / 8
+	if len(data) < rowSize*height {
+		return nil
+	}
+
+	// Decode [1 0] inverts the samples (image masks paint the 0 samples)
+	invert := false
+	if decode, ok := d.Resolve(stream.Dict["Decode"]).([]any); ok && len(decode) > 0 {
+		invert = decode[0] == 1 || decode[0] == 1.0
+	}
+
+	gray := image.NewGray(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		row := data[y*rowSize : (y+1)*rowSize]
+		for x := 0; x < width; x++ {
+			var v byte
+			if bpc == 1 {
+				if row[x/8]&(0x80>>(x%8)) != 0 {
+					v = 255
+				}
+			} else {
+				v = sampleLuminance(row[x*components : (x+1)*components])
+			}
+			if invert {
+				v = 255 - v
+			}
+			gray.Pix[y*gray.Stride+x] = v
+		}
+	}
+	return gray
+}
+
+// components returns the number of color components of a color space, or 0
+// when it is not supported
+func (d *Document) components(colorSpace any) int {
+	switch cs := d.Resolve(colorSpace).(type) {
+	case Name:
+		switch cs {
+		case "DeviceGray", "CalGray", "G":
+			return 1
+		case "DeviceRGB", "CalRGB", "RGB":
+			return 3
+		case "DeviceCMYK", "CMYK":
+			return 4
+		}
+	case []any:
+		if len(cs) < 2 {
+			return 0
+		}
+		family, _ := d.Resolve(cs[0]).(Name)
+		switch family {
+		case "CalGray":
+			return 1
+		case "CalRGB":
+			return 3
+		case "ICCBased":
+			if profile, ok := d.Resolve(cs[1]).(*Stream); ok {
+				n, _ := d.int(profile.Dict, "N")
+				if n == 1 || n == 3 || n == 4 {
+					return n
+				}
+			}
+		}
+	}
+	return 0
+}
+
+// sampleLuminance converts a gray, RGB or CMYK sample to luminance
+func sampleLuminance(sample []byte) byte {
+	switch len(sample) {
+	case 3:
+		return byte((19595*int(sample[0]) + 38470*int(sample[1]) + 7471*int(sample[2]) + 1<<15) >> 16)
+	case 4:
+		ink := (int(sample[0])+int(sample[1])+int(sample[2]))/3 + int(sample[3])
+		return byte(255 - min(255, ink))
+	}
+	return sample[0]
+}
@@ -0,0 +1,312 @@
+package pdf
+
+import (
+	"errors"
+	"strconv"
+)
+
+var errSyntax = errors.New("pdf: syntax error")
+
+// maxDepth limits the nesting of arrays and dictionaries
+const maxDepth = 64
+
+// operator is a bare keyword: a content stream operator, or "stream"/"endobj"
+type operator string
+
+// lexer parses PDF objects from data starting at pos
+type lexer struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+// isSpace reports whether c is PDF whitespace
+func isSpace(c byte) bool {
+	return c == 0 || c == '\t' || c == '\n' || c == '\f' || c == '\r' || c == ' '
+}
+
+// isDelimiter reports whether c ends a name, number or keyword
+func isDelimiter(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return isSpace(c)
+}
+
+// skipSpace skips whitespace and comments
+func (l *lexer) skipSpace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		if c == '%' {
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+			continue
+		}
+		if !isSpace(c) {
+			return
+		}
+		l.pos++
+	}
+}
+
+// keyword consumes kw if it is the next token
+func (l *lexer) keyword(kw string) bool {
+	l.skipSpace()
+	end := l.pos + len(kw)
+	if end > len(l.data) || string(l.data[l.pos:end]) != kw {
+		return false
+	}
+	if end < len(l.data) && !isDelimiter(l.data[end]) {
+		return false
+	}
+	l.pos = end
+	return true
+}
+
+// parseObject parses the next object. Bare keywords other than true, false and
+// null are returned as operators.
+func (l *lexer) parseObject() (any, error) {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return nil, errSyntax
+	}
+
+	switch c := l.data[l.pos]; {
+	case c == '<' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '<':
+		l.pos += 2
+		return l.parseDict()
+	case c == '<':
+		l.pos++
+		return l.parseHexString(), nil
+	case c == '(':
+		l.pos++
+		return l.parseLiteralString(), nil
+	case c == '/':
+		l.pos++
+		return l.parseName(), nil
+	case c == '[':
+		l.pos++
+		return l.parseArray()
+	case c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9'):
+		return l.parseNumber()
+	case isDelimiter(c):
+		l.pos++
+		return nil, errSyntax
+	}
+
+	start := l.pos
+	for l.pos < len(l.data) && !isDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+	switch word := string(l.data[start:l.pos]); word {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	default:
+		return operator(word), nil
+	}
+}
+
+// parseDict parses a dictionary after "<<"
+func (l *lexer) parseDict() (any, error) {
+	if l.depth++; l.depth > maxDepth {
+		return nil, errSyntax
+	}
+	defer func() { l.depth-- }()
+
+	dict := Dict{}
+	for {
+		l.skipSpace()
+		if l.pos+1 < len(l.data) && l.data[l.pos] == '>' && l.data[l.pos+1] == '>' {
+			l.pos += 2
+			return dict, nil
+		}
+		key, err := l.parseObject()
+		if err != nil {
+			return nil, err
+		}
+		name, ok := key.(Name)
+		if !ok {
+			return nil, errSyntax
+		}
+		value, err := l.parseObject()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := value.(operator); ok {
+			return nil, errSyntax
+		}
+		dict[name] = value
+	}
+}
+
+// parseArray parses an array after "["
+func (l *lexer) parseArray() (any, error) {
+	if l.depth++; l.depth > maxDepth {
+		return nil, errSyntax
+	}
+	defer func() { l.depth-- }()
+
+	var array []any
+	for {
+		l.skipSpace()
+		if l.pos < len(l.data) && l.data[l.pos] == ']' {
+			l.pos++
+			return array, nil
+		}
+		value, err := l.parseObject()
+		if err != nil {
+			return nil, err
+		}
+		array = append(array, value)
+	}
+}
+
+// parseNumber parses an integer, a real or an indirect reference ("n g R")
+func (l *lexer) parseNumber() (any, error) {
+	start := l.pos
+	l.pos++
+	for l.pos < len(l.data) && !isDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+	token := string(l.data[start:l.pos])
+
+	n, err := strconv.Atoi(token)
+	if err != nil {
+		f, err := strconv.ParseFloat(token, 64)
+		if err != nil {
+			return nil, errSyntax
+		}
+		return f, nil
+	}
+
+	// Indirect reference
+	save := l.pos
+	l.skipSpace()
+	genStart := l.pos
+	for l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '9' {
+		l.pos++
+	}
+	if l.pos > genStart && l.pos < len(l.data) && isSpace(l.data[l.pos]) {
+		gen, _ := strconv.Atoi(string(l.data[genStart:l.pos]))
+		if l.keyword("R") {
+			return Ref{Num: n, Gen: gen}, nil
+		}
+	}
+	l.pos = save
+	return n, nil
+}
+
+// parseName parses a name after "/", decoding #xx escapes
+func (l *lexer) parseName() Name {
+	var name []byte
+	for l.pos < len(l.data) && !isDelimiter(l.data[l.pos]) {
+		c := l.data[l.pos]
+		if c == '#' && l.pos+2 < len(l.data) {
+			if v, err := strconv.ParseUint(string(l.data[l.pos+1:l.pos+3]), 16, 8); err == nil {
+				name = append(name, byte(v))
+				l.pos += 3
+				continue
+			}
+		}
+		name = append(name, c)
+		l.pos++
+	}
+	return Name(name)
+}
+
+// parseLiteralString parses a string after "(", with balanced parentheses and escapes
+func (l *lexer) parseLiteralString() []byte {
+	var s []byte
+	nesting := 0
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			nesting++
+		case ')':
+			if nesting == 0 {
+				return s
+			}
+			nesting--
+		case '\\':
+			if l.pos >= len(l.data) {
+				return s
+			}
+			e := l.data[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r':
+				if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+				continue // Line continuation
+			case '\n':
+				continue
+			default:
+				if e >= '0' && e <= '7' {
+					v := int(e - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						v = v*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					c = byte(v)
+				} else {
+					c = e
+				}
+			}
+		}
+		s = append(s, c)
+	}
+	return s
+}
+
+// parseHexString parses a string after "<" (an odd last digit is followed by 0)
+func (l *lexer) parseHexString() []byte {
+	var s []byte
+	var high byte
+	odd := false
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		var v byte
+		switch {
+		case c == '>':
+			if odd {
+				s = append(s, high<<4)
+			}
+			return s
+		case c >= '0' && c <= '9':
+			v = c - '0'
+		case c >= 'a' && c <= 'f':
+			v = c - 'a' + 10
+		case c >= 'A' && c <= 'F':
+			v = c - 'A' + 10
+		default:
+			continue
+		}
+		if odd {
+			s = append(s, high<<4|v)
+		} else {
+			high = v
+		}
+		odd = !odd
+	}
+	return s
+}
@@ -0,0 +1,152 @@
+// Package pdf reads the text layer and the images of PDF documents, enough to
+// find the linha digitável and the barcode of a boleto, and writes simple
+// one-page documents (Writer) to print them.
+//
+// It is a minimal reader: objects are located by scanning for "n g obj"
+// (without the cross-reference table, so damaged files are read too), object
+// streams are not expanded, and text is only decoded for simple font encodings.
+package pdf
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"strconv"
+)
+
+var (
+	// ErrNotPDF is returned when the data is not a PDF document
+	ErrNotPDF = errors.New("pdf: not a PDF document")
+
+	// ErrEncrypted is returned for encrypted documents, whose streams cannot be read
+	ErrEncrypted = errors.New("pdf: document is encrypted")
+)
+
+// Name is a PDF name (without the leading slash)
+type Name string
+
+// Ref is an indirect object reference
+type Ref struct {
+	Num int
+	Gen int
+}
+
+// Dict is a PDF dictionary, keyed by name
+type Dict map[Name]any
+
+// Stream is a stream object: its dictionary and raw (encoded) data
+type Stream struct {
+	Dict Dict
+	Raw  []byte
+}
+
+// Document is a parsed PDF document
+type Document struct {
+	objects map[int]any // Object number → value (later definitions win)
+	streams []*Stream   // Streams in file order
+}
+
+var objectRegex = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b`)
+
+// Parse reads the objects of a PDF document
+func Parse(data []byte) (*Document, error) {
+	header := data[:min(len(data), 1024)]
+	if !bytes.Contains(header, []byte("%PDF-")) {
+		return nil, ErrNotPDF
+	}
+
+	doc := &Document{objects: map[int]any{}}
+	encrypted := false
+
+	for _, loc := range objectRegex.FindAllSubmatchIndex(data, -1) {
+		num, _ := strconv.Atoi(string(data[loc[2]:loc[3]]))
+		lex := &lexer{data: data, pos: loc[1]}
+		value, err := lex.parseObject()
+		if err != nil {
+			continue
+		}
+
+		if dict, ok := value.(Dict); ok {
+			if dict["Type"] == Name("XRef") && dict["Encrypt"] != nil {
+				encrypted = true
+			}
+			if lex.keyword("stream") {
+				stream := &Stream{Dict: dict, Raw: streamData(data, lex.pos, dict)}
+				doc.streams = append(doc.streams, stream)
+				value = stream
+			}
+		}
+		doc.objects[num] = value
+	}
+
+	for _, loc := range regexp.MustCompile(`trailer\s*<<`).FindAllIndex(data, -1) {
+		lex := &lexer{data: data, pos: loc[0] + len("trailer")}
+		if trailer, err := lex.parseObject(); err == nil {
+			if dict, ok := trailer.(Dict); ok && dict["Encrypt"] != nil {
+				encrypted = true
+			}
+		}
+	}
+	if encrypted {
+		return nil, ErrEncrypted
+	}
+
+	return doc, nil
+}
+
+// streamData returns the raw data of a stream whose "stream" keyword ends at pos
+func streamData(data []byte, pos int, dict Dict) []byte {
+	// The keyword is followed by CRLF or LF
+	if bytes.HasPrefix(data[pos:], []byte("\r\n")) {
+		pos += 2
+	} else if pos < len(data) && (data[pos] == '\n' || data[pos] == '\r') {
+		pos++
+	}
+
+	// Trust a direct /Length that lands on endstream, else search for it
+	if length, ok := dict["Length"].(int); ok && length >= 0 && pos+length <= len(data) {
+		rest := bytes.TrimLeft(data[pos+length:], "\r\n \t")
+		if bytes.HasPrefix(rest, []byte("endstream")) {
+			return data[pos : pos+length]
+		}
+	}
+	end := bytes.Index(data[pos:], []byte("endstream"))
+	if end < 0 {
+		return data[pos:]
+	}
+	return bytes.TrimRight(data[pos:pos+end], "\r\n")
+}
+
+// Resolve follows indirect references to their object
+func (d *Document) Resolve(value any) any {
+	for i := 0; i < 32; i++ {
+		ref, ok := value.(Ref)
+		if !ok {
+			return value
+		}
+		value = d.objects[ref.Num]
+	}
+	return nil
+}
+
+// Streams returns the streams of the document in file order
+func (d *Document) Streams() []*Stream {
+	return d.streams
+}
+
+// int resolves an integer value of a dictionary
+func (d *Document) int(dict Dict, key Name) (int, bool) {
+	switch v := d.Resolve(dict[key]).(type) {
+	case int:
+		return v, true
+	case float64:
+		return int(v), true
+	}
+	return 0, false
+}
+
+// name resolves a name value of a dictionary
+func (d *Document) name(dict Dict, key Name) Name {
+	name, _ := d.Resolve(dict[key]).(Name)
+	return name
+}
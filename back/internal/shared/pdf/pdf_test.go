@@ -0,0 +1,148 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"strings"
+	"testing"
+)
+
+// buildPDF assembles a document from object bodies (object n is objects[n-1]);
+// a body may be a []byte stream data with its dictionary prefixed by "dict|"
+func buildPDF(objects ...string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	for i, body := range objects {
+		fmt.Fprintf(&buf, "%d 0 obj\n", i+1)
+		if dict, data, ok := strings.Cut(body, "|"); ok {
+			fmt.Fprintf(&buf, "%s\nstream\n%s\nendstream", strings.Replace(dict, ">>", fmt.Sprintf("/Length %d>>", len(data)), 1), data)
+		} else {
+			buf.WriteString(body)
+		}
+		buf.WriteString("\nendobj\n")
+	}
+	buf.WriteString("trailer\n<</Root 1 0 R>>\n%%EOF\n")
+	return buf.Bytes()
+}
+
+func deflate(data []byte) string {
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	w.Write(data)
+	w.Close()
+	return buf.String()
+}
+
+// TestText tests extracting the text layer of content streams
+func TestText(t *testing.T) {
+	content := `BT /F1 10 Tf 50 700 Td (Benefici\341rio: Escola \(Centro\)) Tj ET
+BT 50 680 Td [(34191.09008 )-50(00000.000000 )]TJ [(00000.000000)-300(1 00000000015000)] TJ
+<3132333435> Tj T* (linha\
+continua) ' ET
+BI /W 2 /H 1 /BPC 8 /CS /G ID ab EI`
+	doc, err := Parse(buildPDF(
+		"<</Type /Catalog /Pages 2 0 R>>",
+		"<</Type /Pages /Kids [3 0 R] /Count 1>>",
+		"<</Type /Page /Parent 2 0 R /Contents 4 0 R>>",
+		"<</Filter /FlateDecode>>|"+deflate([]byte(content)),
+		"<</Subtype /Type1C>>|(Not text) Tj",
+	))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	text := doc.Text()
+	for _, want := range []string{
+		"Benefici\xe1rio: Escola (Centro)",
+		"34191.09008 00000.000000 00000.000000 1 00000000015000",
+		"12345",
+		"\nlinhacontinua",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Text() = %q, expected to contain %q", text, want)
+		}
+	}
+	if strings.Contains(text, "Not text") {
+		t.Errorf("Text() = %q, read a font program", text)
+	}
+}
+
+// TestImages tests decoding Flate (with PNG predictor) and JPEG image XObjects
+func TestImages(t *testing.T) {
+	// 4x2 gray image, rows encoded with the Sub and Up predictors
+	rows := []byte{1, 10, 10, 10, 10, 2, 0, 245, 0, 245}
+	var jpg bytes.Buffer
+	src := image.NewGray(image.Rect(0, 0, 8, 8))
+	for i := range src.Pix {
+		src.Pix[i] = 200
+	}
+	jpeg.Encode(&jpg, src, nil)
+
+	doc, err := Parse(buildPDF(
+		"<</Type /XObject /Subtype /Image /Width 4 /Height 2 /BitsPerComponent 8 /ColorSpace /DeviceGray"+
+			" /Filter /FlateDecode /DecodeParms <</Predictor 15 /Columns 4>>>>|"+deflate(rows),
+		"<</Type /XObject /Subtype /Image /Width 8 /Height 8 /BitsPerComponent 8 /ColorSpace /DeviceGray /Filter /DCTDecode>>|"+jpg.String(),
+		"<</Type /XObject /Subtype /Image /Width 8 /Height 1 /ImageMask true>>|\x0f",
+		"<</Type /XObject /Subtype /Image /Width 1 /Height 1 /BitsPerComponent 8 /ColorSpace [/Indexed /DeviceRGB 1 <000000ffffff>]>>|\x00",
+	))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	images := doc.Images()
+	if len(images) != 3 {
+		t.Fatalf("Images() returned %d images, want 3", len(images))
+	}
+
+	gray := images[0].(*image.Gray)
+	if want := []byte{10, 20, 30, 40, 10, 9, 30, 29}; !bytes.Equal(gray.Pix, want) {
+		t.Errorf("Flate image = %v, want %v", gray.Pix, want)
+	}
+	if c := color.GrayModel.Convert(images[1].At(4, 4)).(color.Gray); c.Y < 190 || c.Y > 210 {
+		t.Errorf("JPEG image pixel = %d, want about 200", c.Y)
+	}
+	if mask := images[2].(*image.Gray).Pix; mask[0] != 0 || mask[7] != 255 {
+		t.Errorf("image mask = %v, want painted (0) then unpainted (255)", mask)
+	}
+}
+
+// TestParseErrors tests rejecting non-PDF and encrypted documents
+func TestParseErrors(t *testing.T) {
+	if _, err := Parse([]byte("\x89PNG\r\n")); !errors.Is(err, ErrNotPDF) {
+		t.Errorf("Parse(PNG) error = %v, want %v", err, ErrNotPDF)
+	}
+
+	encrypted := bytes.Replace(buildPDF("<</Type /Catalog>>", "<</Filter /Standard /V 2>>"),
+		[]byte("<</Root 1 0 R>>"), []byte("<</Root 1 0 R /Encrypt 2 0 R>>"), 1)
+	if _, err := Parse(encrypted); !errors.Is(err, ErrEncrypted) {
+		t.Errorf("Parse(encrypted) error = %v, want %v", err, ErrEncrypted)
+	}
+}
+
+// TestWriter tests that written documents read back
+func TestWriter(t *testing.T) {
+	w := NewWriter(A4Width, A4Height)
+	w.Text(40, 800, 12, true, "Beneficiário (Loja) 100%")
+	w.Text(40, 780, 10, false, "Valor: R$ 150,00 – à vista")
+	w.Rect(40, 700, 1.5, 40)
+	w.Line(40, 690, 550, 690, 0.5)
+
+	doc, err := Parse(w.Bytes())
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	text := doc.Text()
+	for _, want := range []string{"Benefici\xe1rio (Loja) 100%", "Valor: R$ 150,00 \x96 \xe0 vista"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Text() = %q, expected to contain %q", text, want)
+		}
+	}
+	if !bytes.Contains(w.Bytes(), []byte("/MediaBox [0 0 595.28 841.89]")) {
+		t.Error("Bytes() has no A4 media box")
+	}
+}
@@ -0,0 +1,110 @@
+package pdf
+
+import (
+	"bytes"
+	"strings"
+)
+
+// tjSpace is the TJ adjustment (thousandths of text space) read as a word break
+const tjSpace = -200
+
+// Text returns the text shown by the content streams of the document (pages
+// and form XObjects), one line per positioning operator. String bytes are
+// taken as characters, which holds for the standard encodings of simple fonts;
+// text of composite (CID) fonts comes out as noise.
+func (d *Document) Text() string {
+	var text strings.Builder
+	for _, stream := range d.streams {
+		if !d.isContent(stream.Dict) {
+			continue
+		}
+		data, err := d.Decode(stream)
+		if err != nil {
+			continue
+		}
+		contentText(&text, data)
+	}
+	return text.String()
+}
+
+// isContent reports whether a stream may be a content stream
+func (d *Document) isContent(dict Dict) bool {
+	switch d.name(dict, "Type") {
+	case "XRef", "ObjStm", "Metadata", "EmbeddedFile":
+		return false
+	}
+	switch d.name(dict, "Subtype") {
+	case "", "Form":
+	default:
+		return false // Images, font programs, XML
+	}
+	for _, key := range []Name{"Length1", "Length2", "Length3", "N"} {
+		if dict[key] != nil {
+			return false // Font programs and ICC profiles
+		}
+	}
+	return true
+}
+
+// contentText appends the text of a content stream to text
+func contentText(text *strings.Builder, data []byte) {
+	lex := &lexer{data: data}
+	var operands []any
+
+	for {
+		value, err := lex.parseObject()
+		if err != nil {
+			if lex.pos >= len(data) {
+				return
+			}
+			operands = operands[:0]
+			continue
+		}
+
+		op, ok := value.(operator)
+		if !ok {
+			operands = append(operands, value)
+			continue
+		}
+
+		switch op {
+		case "Tj", "'", "\"":
+			if len(operands) > 0 {
+				if s, ok := operands[len(operands)-1].([]byte); ok {
+					if op != "Tj" {
+						text.WriteByte('\n')
+					}
+					text.Write(s)
+				}
+			}
+		case "TJ":
+			if len(operands) > 0 {
+				array, _ := operands[len(operands)-1].([]any)
+				for _, v := range array {
+					switch v := v.(type) {
+					case []byte:
+						text.Write(v)
+					case int:
+						if v <= tjSpace {
+							text.WriteByte(' ')
+						}
+					case float64:
+						if v <= tjSpace {
+							text.WriteByte(' ')
+						}
+					}
+				}
+			}
+		case "Td", "TD", "T*", "Tm", "ET":
+			text.WriteByte('\n')
+		case "ID":
+			// Inline image data runs until "EI"
+			end := bytes.Index(data[lex.pos:], []byte("EI"))
+			if end < 0 {
+				return
+			}
+			lex.pos += end + 2
+		}
+		operands = operands[:0]
+	}
+}
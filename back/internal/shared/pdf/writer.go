@@ -0,0 +1,119 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A4 page size in points
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+)
+
+// Writer builds a one-page document with text in the standard Helvetica fonts
+// (WinAnsi encoding), filled rectangles and lines. Coordinates are in points
+// from the bottom left corner of the page.
+type Writer struct {
+	width   float64
+	height  float64
+	content bytes.Buffer
+}
+
+// NewWriter creates a writer for a page of the given size
+func NewWriter(width, height float64) *Writer {
+	return &Writer{width: width, height: height}
+}
+
+// Text writes a line of text with its baseline starting at (x, y)
+func (w *Writer) Text(x, y, size float64, bold bool, text string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(&w.content, "BT /%s %s Tf %s %s Td (%s) Tj ET\n", font, num(size), num(x), num(y), escapeText(text))
+}
+
+// Rect fills a black rectangle with its bottom left corner at (x, y)
+func (w *Writer) Rect(x, y, width, height float64) {
+	fmt.Fprintf(&w.content, "%s %s %s %s re f\n", num(x), num(y), num(width), num(height))
+}
+
+// Line strokes a black line
+func (w *Writer) Line(x1, y1, x2, y2, lineWidth float64) {
+	fmt.Fprintf(&w.content, "%s w %s %s m %s %s l S\n", num(lineWidth), num(x1), num(y1), num(x2), num(y2))
+}
+
+// Bytes returns the document
+func (w *Writer) Bytes() []byte {
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	zw.Write(w.content.Bytes())
+	zw.Close()
+
+	objects := []string{
+		"<</Type /Catalog /Pages 2 0 R>>",
+		"<</Type /Pages /Kids [3 0 R] /Count 1>>",
+		fmt.Sprintf("<</Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources <</Font <</F1 5 0 R /F2 6 0 R>>>> /Contents 4 0 R>>",
+			num(w.width), num(w.height)),
+		fmt.Sprintf("<</Length %d /Filter /FlateDecode>>\nstream\n%s\nendstream", compressed.Len(), compressed.String()),
+		"<</Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding>>",
+		"<</Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding>>",
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<</Size %d /Root 1 0 R>>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return out.Bytes()
+}
+
+// num formats a coordinate with up to two decimals
+func num(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// escapeText encodes text in WinAnsi as the body of a literal string.
+// Characters outside the encoding are replaced by "?".
+func escapeText(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		var c byte
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			c = byte(r)
+		case r >= 0x20 && r < 0x7f, r >= 0xa0 && r <= 0xff:
+			c = byte(r) // ASCII and Latin-1 match WinAnsi
+		case r == '–':
+			c = 0x96
+		case r == '—':
+			c = 0x97
+		case r == '€':
+			c = 0x80
+		default:
+			c = '?'
+		}
+		if c >= 0x80 {
+			fmt.Fprintf(&b, "\\%03o", c)
+		} else {
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
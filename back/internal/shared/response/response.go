@@ -104,3 +104,41 @@ func Paginated(w http.ResponseWriter, status int, data interface{}, pagination P
 	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(response)
 }
+
+// CursorPaginatedResponse represents a cursor-paginated API response
+type CursorPaginatedResponse struct {
+	Data       interface{}      `json:"data"`
+	Pagination CursorPagination `json:"pagination"`
+	Totals     interface{}      `json:"totals,omitempty"`
+	Meta       Meta             `json:"meta"`
+}
+
+// CursorPagination contains cursor pagination metadata
+type CursorPagination struct {
+	Limit      int    `json:"limit"`
+	Total      int    `json:"total"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// CursorPaginated writes a cursor-paginated JSON response.
+// totals carries aggregates over every page (omitted when nil).
+func CursorPaginated(w http.ResponseWriter, status int, data interface{}, pagination CursorPagination, totals interface{}, ctx context.Context) {
+	response := CursorPaginatedResponse{
+		Data:       data,
+		Pagination: pagination,
+		Totals:     totals,
+		Meta: Meta{
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+		},
+	}
+
+	// Extract request ID from context
+	if requestID, ok := ctx.Value("request_id").(string); ok {
+		response.Meta.RequestID = requestID
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}